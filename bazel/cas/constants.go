@@ -15,11 +15,89 @@ const (
 
 	// ActionCache constants
 	ResultAddressKey = "ActionCacheResult"
+
+	// Data of the tombstone marking an ActionResult as purged, as Stores don't support deletion
+	ResultTombstone = "ActionCacheResultPurged"
+
+	// Background output validation limits, see ValidateOutputsAsync
+	DefaultAsyncValidationWorkers = 8
+	DefaultAsyncValidationQueue   = 1000
+
+	// Batch API size limits, on the combined size of blob data in a request or response.
+	// BatchUpdateBlobs accepts up to 10MiB per the Bazel API, and BatchReadBlobs responses
+	// must fit in the default 4MiB grpc message size of clients.
+	MaxBatchUpdateSize = 10 * 1024 * 1024
+	MaxBatchReadSize   = 4*1024*1024 - MaxBatchMessageOverhead
+
+	// Room reserved for non-data fields when sizing batch and tree messages
+	MaxBatchMessageOverhead = 64 * 1024
+
+	// GetTree page sizes, in number of Directories
+	DefaultGetTreePageSize = 1000
+	MaxGetTreePageSize     = 10000
+
+	// Maximum number of queued Directories listed in a GetTree page token, wider frontiers are
+	// kept server-side as cursors that are removed once unused for DefaultTreeCursorTimeout
+	MaxGetTreeTokenDigests      = 100
+	DefaultTreeCursorTimeout    = 10 * time.Minute
+	DefaultTreeCursorMaxDigests = 1000 * 1000
+	TreeCursorGCInterval        = time.Minute
+
+	// Spooled data of interrupted Writes is kept this long for resumption before being removed
+	DefaultWriteSpoolTimeout = 30 * time.Minute
+	WriteSpoolGCInterval     = time.Minute
+
+	// Maximum size of the spooled data of all Writes, Writes that don't fit are rejected with ResourceExhausted
+	DefaultWriteSpoolMaxSize = 16 * 1024 * 1024 * 1024
 )
 
 // Resource naming format guidelines
-var ResourceReadFormatStr string = fmt.Sprintf("[<instance-name>/]%s/<hash>/<size>[/filename]", ResourceNameType)
-var ResourceWriteFormatStr string = fmt.Sprintf("[<instance-name>/]%s/<uuid>/%s/<hash>/<size>[/filename]", ResourceNameAction, ResourceNameType)
+var ResourceReadFormatStr string = fmt.Sprintf("[<instance-name>/]%s/[<digest-function>/]<hash>/<size>[/filename]", ResourceNameType)
+var ResourceWriteFormatStr string = fmt.Sprintf("[<instance-name>/]%s/<uuid>/%s/[<digest-function>/]<hash>/<size>[/filename]", ResourceNameAction, ResourceNameType)
 
 // Default TTL for CAS-based operations
 var DefaultTTL time.Duration = time.Hour * 24 * 7
+
+// Controls how GetActionResult verifies that the outputs referenced by a cached ActionResult
+// still exist in the Store, as they may expire independently of the result itself.
+type OutputValidation int
+
+const (
+	// Check outputs before returning a result, results with missing outputs are purged and treated as misses
+	ValidateOutputsSync OutputValidation = iota
+	// Return results immediately and check outputs in the background, results with missing outputs
+	// are purged so that subsequent lookups miss
+	ValidateOutputsAsync
+	// Return results without checking outputs
+	ValidateOutputsNone
+)
+
+// ActionCache configuration of a CAS server
+type ActionCacheConfig struct {
+	OutputValidation OutputValidation
+	// Number of goroutines validating outputs with ValidateOutputsAsync
+	AsyncValidationWorkers int
+	// Number of results waiting for validation beyond which more hits aren't validated
+	AsyncValidationQueue int
+}
+
+func DefaultActionCacheConfig() *ActionCacheConfig {
+	return &ActionCacheConfig{
+		OutputValidation:       ValidateOutputsSync,
+		AsyncValidationWorkers: DefaultAsyncValidationWorkers,
+		AsyncValidationQueue:   DefaultAsyncValidationQueue,
+	}
+}
+
+// Parses an OutputValidation from its name: "sync", "async" or "none"
+func ParseOutputValidation(s string) (OutputValidation, error) {
+	switch s {
+	case "sync":
+		return ValidateOutputsSync, nil
+	case "async":
+		return ValidateOutputsAsync, nil
+	case "none":
+		return ValidateOutputsNone, nil
+	}
+	return ValidateOutputsNone, fmt.Errorf("Invalid output validation %q, expected one of sync, async, none", s)
+}
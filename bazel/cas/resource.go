@@ -16,16 +16,18 @@ import (
 // Keep track of a Resource specified by a client.
 // Instance - optional parameter identifying a server instance
 // Digest - Bazel Digest identifier
+// DigestFunction - function that computed the Digest, as named in the resource or SHA256 if unnamed
 // UUID - client identifier attached to write requests
-//	Unused by Scoot currently except for tracking/logging
+//	Used by Scoot to identify resumable writes
 type Resource struct {
-	Instance string
-	Digest   *remoteexecution.Digest
-	UUID     uuid.UUID
+	Instance       string
+	Digest         *remoteexecution.Digest
+	DigestFunction *bazel.DigestFunction
+	UUID           uuid.UUID
 }
 
 func (r *Resource) String() string {
-	return fmt.Sprintf("Instance: %s, Digest: %s, UUID: %s", r.Instance, r.Digest, r.UUID)
+	return fmt.Sprintf("Instance: %s, Digest: %s, DigestFunction: %s, UUID: %s", r.Instance, r.Digest, r.DigestFunction.Name, r.UUID)
 }
 
 // Return a valid read resource string based on individual components. Errors on invalid inputs.
@@ -49,7 +51,7 @@ func GetDefaultReadResourceName(hash string, size int64) (string, error) {
 }
 
 // Parses a name string from the Read API into a Resource for bazel artifacts.
-// Valid read format: "[<instance>/]blobs/<hash>/<size>[/<filename>]"
+// Valid read format: "[<instance>/]blobs/[<digest function>/]<hash>/<size>[/<filename>]"
 // Scoot does not currently use/track the filename portion of resource names
 func ParseReadResource(name string) (*Resource, error) {
 	elems := strings.Split(name, "/")
@@ -57,20 +59,24 @@ func ParseReadResource(name string) (*Resource, error) {
 		return nil, resourceError("len elems '/' mismatch", name, ResourceReadFormatStr)
 	}
 
-	var instance, hash, sizeStr string
+	var instance string
+	var rest []string
 	if elems[0] == ResourceNameType {
 		instance = bazel.DefaultInstanceName
-		hash = elems[1]
-		sizeStr = elems[2]
+		rest = elems[1:]
 	} else if elems[1] == ResourceNameType && len(elems) > 3 {
 		instance = elems[0]
-		hash = elems[2]
-		sizeStr = elems[3]
+		rest = elems[2:]
 	} else {
 		return nil, resourceError("resource type not found", name, ResourceReadFormatStr)
 	}
 
-	return ParseResource(instance, "", hash, sizeStr, name, ResourceReadFormatStr)
+	fnName, rest := splitDigestFunction(rest)
+	if len(rest) < 2 {
+		return nil, resourceError("len elems '/' mismatch", name, ResourceReadFormatStr)
+	}
+
+	return parseResource(instance, "", fnName, rest[0], rest[1], name, ResourceReadFormatStr)
 }
 
 // Return a valid write resource string based on individual components. Errors on invalid inputs
@@ -94,7 +100,7 @@ func GetDefaultWriteResourceName(uuid, hash string, size int64) (string, error)
 }
 
 // Parses a name string from the Write API into a Resource for bazel artifacts.
-// Valid read format: "[<instance>/]uploads/<uuid>/blobs/<hash>/<size>[/<filename>]"
+// Valid write format: "[<instance>/]uploads/<uuid>/blobs/[<digest function>/]<hash>/<size>[/<filename>]"
 // Scoot does not currently use/track the filename portion of resource names
 func ParseWriteResource(name string) (*Resource, error) {
 	elems := strings.Split(name, "/")
@@ -102,7 +108,7 @@ func ParseWriteResource(name string) (*Resource, error) {
 		return nil, resourceError("len elems '/' mismatch", name, ResourceWriteFormatStr)
 	}
 
-	var id, instance, hash, sizeStr string
+	var id, instance string
 	var rest []string
 
 	if elems[0] == ResourceNameAction {
@@ -120,14 +126,22 @@ func ParseWriteResource(name string) (*Resource, error) {
 	}
 
 	id = rest[0]
-	hash = rest[2]
-	sizeStr = rest[3]
+	fnName, rest := splitDigestFunction(rest[2:])
+	if len(rest) < 2 {
+		return nil, resourceError("len elems '/' mismatch", name, ResourceWriteFormatStr)
+	}
 
-	return ParseResource(instance, id, hash, sizeStr, name, ResourceWriteFormatStr)
+	return parseResource(instance, id, fnName, rest[0], rest[1], name, ResourceWriteFormatStr)
 }
 
-// Underlying Resource parser from separated URI components
+// Underlying Resource parser from separated URI components.
+// The digest function is SHA256, see bazel.DigestFunctionFromProto
 func ParseResource(instance, id, hash, sizeStr, name, format string) (*Resource, error) {
+	return parseResource(instance, id, "", hash, sizeStr, name, format)
+}
+
+// Resource parser with an optional digest function name. If unset, the function is SHA256
+func parseResource(instance, id, fnName, hash, sizeStr, name, format string) (*Resource, error) {
 	var uid uuid.UUID
 	if id != "" {
 		u, err := uuid.ParseHex(id)
@@ -142,11 +156,31 @@ func ParseResource(instance, id, hash, sizeStr, name, format string) (*Resource,
 		return nil, resourceError("size value could not be parsed as int64", name, format)
 	}
 
-	if !bazel.IsValidDigest(hash, size) {
+	fn := bazel.SHA256
+	if fnName != "" {
+		fn, _ = bazel.DigestFunctionFromName(fnName)
+	}
+	if !fn.IsValidDigest(hash, size) {
 		return nil, resourceError("digest hash/size invalid", name, format)
 	}
 
-	return &Resource{Instance: instance, Digest: &remoteexecution.Digest{Hash: hash, SizeBytes: size}, UUID: uid}, nil
+	return &Resource{
+		Instance:       instance,
+		Digest:         &remoteexecution.Digest{Hash: hash, SizeBytes: size},
+		DigestFunction: fn,
+		UUID:           uid,
+	}, nil
+}
+
+// Splits an optional digest function name from the elements of a resource name following the resource type.
+// Function names can't be mistaken for hashes, which are hex encoded.
+func splitDigestFunction(elems []string) (string, []string) {
+	if len(elems) > 0 {
+		if _, ok := bazel.DigestFunctionFromName(elems[0]); ok {
+			return elems[0], elems[1:]
+		}
+	}
+	return "", elems
 }
 
 // helper for descriptive resource error messages
@@ -5,6 +5,8 @@ import (
 
 	uuid "github.com/nu7hatch/gouuid"
 	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+
+	"github.com/twitter/scoot/bazel"
 )
 
 func TestResourceComponents(t *testing.T) {
@@ -74,6 +76,37 @@ func TestParseReadResource(t *testing.T) {
 	if !resourceEq(r, expected) {
 		t.Fatalf("Parsed resource not as expected: %s, got: %s", expected, r)
 	}
+	if r.DigestFunction != bazel.SHA256 {
+		t.Fatalf("Expected default digest function sha256, got: %s", r.DigestFunction.Name)
+	}
+
+	name = "instance/blobs/blake3/01ba4719c80b6fe911b091a7c05124b64eeece964e09c058ef8f9805daca546b/5"
+	r, err = ParseReadResource(name)
+	if err != nil {
+		t.Fatalf("Failed to parse valid resource name: %s: %v", name, err)
+	}
+	if !resourceEq(r, expected) || r.DigestFunction != bazel.BLAKE3 {
+		t.Fatalf("Parsed resource not as expected: %s with blake3, got: %s", expected, r)
+	}
+
+	name = "blobs/sha1/da39a3ee5e6b4b0d3255bfef95601890afd80709/0"
+	r, err = ParseReadResource(name)
+	if err != nil {
+		t.Fatalf("Failed to parse valid resource name: %s: %v", name, err)
+	}
+	if r.DigestFunction != bazel.SHA1 {
+		t.Fatalf("Expected digest function sha1, got: %s", r.DigestFunction.Name)
+	}
+
+	name = "blobs/da39a3ee5e6b4b0d3255bfef95601890afd80709/0"
+	if r, err := ParseReadResource(name); err == nil {
+		t.Fatalf("Expected failure to parse sha1 hash without its digest function: %s, got resource: %s", name, r)
+	}
+
+	name = "blobs/sha1/01ba4719c80b6fe911b091a7c05124b64eeece964e09c058ef8f9805daca546b/5"
+	if r, err := ParseReadResource(name); err == nil {
+		t.Fatalf("Expected failure to parse hash of wrong length for digest function: %s, got resource: %s", name, r)
+	}
 }
 
 func TestParseWriteResource(t *testing.T) {
@@ -9,6 +9,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -27,26 +29,40 @@ import (
 )
 
 // Implements GRPCServer, remoteexecution.ContentAddressableStoreServer,
-// remoteexecution.ActionCacheServer, bytestream.ByteStreamServer interfaces
+// remoteexecution.ActionCacheServer, bytestream.ByteStreamServer,
+// remoteexecution.CapabilitiesServer interfaces
 type casServer struct {
 	listener    net.Listener
 	server      *grpc.Server
 	storeConfig *store.StoreConfig
 	stat        stats.StatsReceiver
+	writes      *writeSpools
+	treeCursors *treeCursors
+	actionCache ActionCacheConfig
+	validateCh  chan outputValidation // ActionResults waiting for background validation, see ValidateOutputsAsync
 }
 
-// Creates a new GRPCServer (CASServer/ByteStreamServer/ActionCacheServer)
+// Creates a new GRPCServer (CASServer/ByteStreamServer/ActionCacheServer/CapabilitiesServer)
 // based on a listener, and preregisters the service
-func MakeCASServer(l net.Listener, cfg *store.StoreConfig, stat stats.StatsReceiver) *casServer {
+// The ActionCacheConfig may be nil, in which case DefaultActionCacheConfig is used.
+func MakeCASServer(l net.Listener, cfg *store.StoreConfig, ac *ActionCacheConfig, stat stats.StatsReceiver) *casServer {
+	if ac == nil {
+		ac = DefaultActionCacheConfig()
+	}
 	g := casServer{
 		listener:    l,
-		server:      grpchelpers.NewServer(),
+		server:      grpchelpers.NewServer(grpc.MaxRecvMsgSize(MaxBatchUpdateSize + MaxBatchMessageOverhead)),
 		storeConfig: cfg,
 		stat:        stat,
+		writes:      newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize),
+		treeCursors: newTreeCursors(DefaultTreeCursorTimeout, DefaultTreeCursorMaxDigests),
+		actionCache: *ac,
 	}
+	g.startValidationWorkers()
 	remoteexecution.RegisterContentAddressableStorageServer(g.server, &g)
 	remoteexecution.RegisterActionCacheServer(g.server, &g)
 	bytestream.RegisterByteStreamServer(g.server, &g)
+	remoteexecution.RegisterCapabilitiesServer(g.server, &g)
 	return &g
 }
 
@@ -59,7 +75,13 @@ func (s *casServer) IsInitialized() bool {
 	return true
 }
 
+// Serves until the listener is closed, removing abandoned Write spools and GetTree cursors in the meantime.
 func (s *casServer) Serve() error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go s.writes.gcLoop(WriteSpoolGCInterval, stop)
+	go s.treeCursors.gcLoop(TreeCursorGCInterval, stop)
+
 	log.Info("Serving GRPC CAS API on: ", s.listener.Addr())
 	return s.server.Serve(s.listener)
 }
@@ -81,13 +103,18 @@ func (s *casServer) FindMissingBlobs(
 	res := remoteexecution.FindMissingBlobsResponse{}
 
 	for _, digest := range req.GetBlobDigests() {
+		fn, err := bazel.DigestFunctionFromProto(req.GetDigestFunction())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%v", err))
+		}
+
 		// We hardcode support for empty data in snapshot/filer/checkouter.go, so never report it as missing
 		// Empty SHA can be used to represent working with a plain, empty directory, but can cause problems in Stores
-		if digest.GetHash() == bazel.EmptySha {
+		if fn.IsEmpty(digest) {
 			continue
 		}
 
-		storeName := bazel.DigestStoreName(digest)
+		storeName := fn.StoreName(digest)
 		if exists, err := s.storeConfig.Store.Exists(storeName); err != nil {
 			log.Errorf("Error checking existence: %v", err)
 			return nil, status.Error(codes.Internal, fmt.Sprintf("Store failed checking existence of %s: %v", storeName, err))
@@ -100,17 +127,227 @@ func (s *casServer) FindMissingBlobs(
 	return &res, nil
 }
 
-// BatchUpdate not supported in Scoot for V1
+// BatchUpdateBlobs writes many small blobs to the Store with a single request.
+// Each blob succeeds or fails independently, with its result reported in the corresponding response.
+// The combined size of the blobs must not exceed MaxBatchUpdateSize.
 func (s *casServer) BatchUpdateBlobs(
 	ctx context.Context,
 	req *remoteexecution.BatchUpdateBlobsRequest) (*remoteexecution.BatchUpdateBlobsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Currently unsupported in Scoot - update blobs independently")
+	log.Debugf("Received CAS BatchUpdateBlobs request with %d blobs", len(req.GetRequests()))
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzBatchUpdateBlobsRequestCounter).Inc(1)
+	defer s.stat.Latency(stats.BzBatchUpdateBlobsRequestLatency_ms).Time().Stop()
+
+	total := 0
+	for _, r := range req.GetRequests() {
+		total += len(r.GetData())
+	}
+	if total > MaxBatchUpdateSize {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("Combined blob size %d exceeds max batch size %d, use ByteStream Write instead", total, MaxBatchUpdateSize))
+	}
+
+	res := &remoteexecution.BatchUpdateBlobsResponse{}
+	for _, r := range req.GetRequests() {
+		res.Responses = append(res.Responses, &remoteexecution.BatchUpdateBlobsResponse_Response{
+			BlobDigest: r.GetContentDigest(),
+			Status:     s.updateBlob(req.GetDigestFunction(), r.GetContentDigest(), r.GetData()).Proto(),
+		})
+	}
+
+	log.Infof("Finished handling BatchUpdateBlobs request for %d blobs, %d bytes", len(res.Responses), total)
+	return res, nil
 }
 
-// GetTree not supported in Scoot for V1
+// BatchReadBlobs reads many small blobs from the Store with a single request.
+// Each blob succeeds or fails independently, with its result reported in the corresponding response.
+// The combined size of the requested digests must not exceed MaxBatchReadSize.
+func (s *casServer) BatchReadBlobs(
+	ctx context.Context,
+	req *remoteexecution.BatchReadBlobsRequest) (*remoteexecution.BatchReadBlobsResponse, error) {
+	log.Debugf("Received CAS BatchReadBlobs request: %s", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzBatchReadBlobsRequestCounter).Inc(1)
+	defer s.stat.Latency(stats.BzBatchReadBlobsRequestLatency_ms).Time().Stop()
+
+	total := int64(0)
+	for _, digest := range req.GetDigests() {
+		total += digest.GetSizeBytes()
+	}
+	if total > MaxBatchReadSize {
+		return nil, status.Error(codes.InvalidArgument,
+			fmt.Sprintf("Combined blob size %d exceeds max batch size %d, use ByteStream Read instead", total, MaxBatchReadSize))
+	}
+
+	res := &remoteexecution.BatchReadBlobsResponse{}
+	for _, digest := range req.GetDigests() {
+		data, st := s.readBlob(req.GetDigestFunction(), digest)
+		res.Responses = append(res.Responses, &remoteexecution.BatchReadBlobsResponse_Response{
+			Digest: digest,
+			Data:   data,
+			Status: st.Proto(),
+		})
+	}
+
+	log.Infof("Finished handling BatchReadBlobs request for %d blobs", len(res.Responses))
+	return res, nil
+}
+
+// GetTree streams every Directory descended from the root Directory, root first, in breadth-first order.
+// Directories that appear more than once in the tree are only returned once per request.
+// Each response holds at most the requested page size of Directories, and its NextPageToken can be
+// used to resume the traversal from the following Directory, though tokens of wide trees expire
+// once unused for DefaultTreeCursorTimeout. Parts of the tree missing from the
+// Store are omitted, but a missing root is reported as NotFound.
 func (s *casServer) GetTree(
 	req *remoteexecution.GetTreeRequest, gtServer remoteexecution.ContentAddressableStorage_GetTreeServer) error {
-	return status.Error(codes.Unimplemented, "Currently unsupported in Scoot")
+	log.Debugf("Received CAS GetTree request: %s", req)
+
+	if !s.IsInitialized() {
+		return status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzGetTreeRequestCounter).Inc(1)
+	defer s.stat.Latency(stats.BzGetTreeRequestLatency_ms).Time().Stop()
+
+	rootDigest := req.GetRootDigest()
+	fn, err := bazel.DigestFunctionFromProto(req.GetDigestFunction())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	} else if !fn.IsValidDigest(rootDigest.GetHash(), rootDigest.GetSizeBytes()) {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid RootDigest %s", rootDigest))
+	}
+	cursor := []*remoteexecution.Digest{rootDigest}
+	if token := req.GetPageToken(); strings.HasPrefix(token, treeCursorTokenPrefix) {
+		var ok bool
+		if cursor, ok = s.treeCursors.get(token); !ok {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("PageToken %q has expired, GetTree must be restarted", token))
+		}
+	} else if token != "" {
+		if cursor, err = decodeTreeCursor(fn, token); err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid PageToken %q: %v", token, err))
+		}
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = DefaultGetTreePageSize
+	} else if pageSize > MaxGetTreePageSize {
+		pageSize = MaxGetTreePageSize
+	}
+
+	// The page token is a cursor listing the Directories that are queued but not yet returned,
+	// so resuming only reads the rest of the tree. Directories returned on earlier pages aren't
+	// remembered though, and are returned again if they also appear below the cursor.
+	// Cursors of more than MaxGetTreeTokenDigests Directories are kept server-side, see treeCursors.
+	queue := []treeNode{}
+	visited := map[string]bool{}
+	for _, digest := range cursor {
+		key := bazel.DigestToStr(digest)
+		if visited[key] {
+			continue
+		}
+		visited[key] = true
+		dir, err := s.readDirectory(req.GetDigestFunction(), digest)
+		if err != nil {
+			if digest == rootDigest {
+				return err
+			}
+			log.Infof("Omitting Directory %s from tree %s: %s", key, bazel.DigestToStr(rootDigest), err)
+			continue
+		}
+		queue = append(queue, treeNode{digest, dir})
+	}
+
+	res := &remoteexecution.GetTreeResponse{}
+	resSize, sent := 0, false
+	for len(queue) > 0 {
+		dir := queue[0].dir
+		queue = queue[1:]
+		for _, node := range dir.GetDirectories() {
+			key := bazel.DigestToStr(node.GetDigest())
+			if visited[key] {
+				continue
+			}
+			visited[key] = true
+			child, err := s.readDirectory(req.GetDigestFunction(), node.GetDigest())
+			if err != nil {
+				log.Infof("Omitting Directory %s from tree %s: %s", key, bazel.DigestToStr(rootDigest), err)
+				continue
+			}
+			queue = append(queue, treeNode{node.GetDigest(), child})
+		}
+
+		res.Directories = append(res.Directories, dir)
+		resSize += proto.Size(dir)
+		if len(queue) > 0 && (len(res.Directories) >= pageSize || resSize >= MaxBatchReadSize) {
+			if res.NextPageToken, err = s.encodeTreeCursor(queue); err != nil {
+				log.Errorf("Failed to store GetTree cursor of %d Directories: %v", len(queue), err)
+				return status.Error(codes.ResourceExhausted, fmt.Sprintf("Failed to store GetTree cursor: %v", err))
+			}
+			if err := gtServer.Send(res); err != nil {
+				log.Errorf("Failed to Send(): %v", err)
+				return status.Error(codes.Internal, fmt.Sprintf("Failed to send GetTreeResponse: %v", err))
+			}
+			res = &remoteexecution.GetTreeResponse{}
+			resSize, sent = 0, true
+		}
+	}
+
+	// Always send a final page without a NextPageToken, even if it's empty
+	if len(res.Directories) > 0 || !sent {
+		if err := gtServer.Send(res); err != nil {
+			log.Errorf("Failed to Send(): %v", err)
+			return status.Error(codes.Internal, fmt.Sprintf("Failed to send GetTreeResponse: %v", err))
+		}
+	}
+
+	log.Infof("Finished handling GetTree request for %s", bazel.DigestToStr(rootDigest))
+	return nil
+}
+
+// A Directory queued by GetTree, along with the digest it was read from.
+type treeNode struct {
+	digest *remoteexecution.Digest
+	dir    *remoteexecution.Directory
+}
+
+// Encodes the digests of the queued Directories as a GetTree page token, ex: "<hash>/<size>,<hash>/<size>".
+// If there are more than MaxGetTreeTokenDigests of them, they're stored as a server-side cursor instead.
+func (s *casServer) encodeTreeCursor(queue []treeNode) (string, error) {
+	if len(queue) > MaxGetTreeTokenDigests {
+		digests := make([]*remoteexecution.Digest, 0, len(queue))
+		for _, node := range queue {
+			digests = append(digests, node.digest)
+		}
+		return s.treeCursors.put(digests)
+	}
+	keys := make([]string, 0, len(queue))
+	for _, node := range queue {
+		keys = append(keys, bazel.DigestToStr(node.digest))
+	}
+	return strings.Join(keys, ","), nil
+}
+
+// Decodes a GetTree page token into the digests of the Directories to resume from.
+func decodeTreeCursor(fn *bazel.DigestFunction, token string) ([]*remoteexecution.Digest, error) {
+	digests := []*remoteexecution.Digest{}
+	for _, key := range strings.Split(token, ",") {
+		parts := strings.Split(key, "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected <hash>/<size>, got %q", key)
+		}
+		size, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil || !fn.IsValidDigest(parts[0], size) {
+			return nil, fmt.Errorf("invalid digest %q", key)
+		}
+		digests = append(digests, &remoteexecution.Digest{Hash: parts[0], SizeBytes: size})
+	}
+	return digests, nil
 }
 
 // ByteStream APIs
@@ -144,11 +381,11 @@ func (s *casServer) Read(req *bytestream.ReadRequest, ser bytestream.ByteStream_
 	}
 
 	// Map digest to underlying store name
-	storeName := bazel.DigestStoreName(resource.Digest)
+	storeName := resource.DigestFunction.StoreName(resource.Digest)
 
 	var r io.ReadCloser
 	// If client requested to read Empty data, fulfil the request with a blank interface to bypass the Store
-	if resource.Digest.GetHash() == bazel.EmptySha {
+	if resource.DigestFunction.IsEmpty(resource.Digest) {
 		r = &nilReader{}
 	} else {
 		log.Infof("Opening store resource for reading: %s", storeName)
@@ -209,10 +446,13 @@ func (s *casServer) Read(req *bytestream.ReadRequest, ser bytestream.ByteStream_
 
 // Writes data into bundlestore from a client via grpc streaming.
 // Implements googleapis bytestream Write
-// store.Stores do not support partial Writes, and neither does our implementation.
-// We can support partial Write by keeping buffers for inflight requests in the casServer.
-// When the entire Write is buffered, we can Write to the Store and return a response with the result.
-// NOTE We also no not currently attempt any resolution between multiple client UUIDs writing the same resource
+// store.Stores do not support partial Writes, so data is spooled to a temp file keyed by the
+// UUID of the resource name until the client finishes the Write, and is then written to the Store.
+// If the stream is interrupted, the spool is kept and the client can resume the Write from the
+// committed size reported by QueryWriteStatus. Spools of abandoned Writes are removed after a timeout.
+// Data is verified against the size and hash of the Digest as it streams in, and rejected if it doesn't match.
+// Concurrent Writes of the same digest are deduplicated - only one is written to the Store at a time,
+// and the others complete as soon as it's written.
 func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 	log.Debug("Received CAS Write request")
 
@@ -222,89 +462,135 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 	s.stat.Counter(stats.BzWriteRequestCounter).Inc(1)
 	defer s.stat.Latency(stats.BzWriteRequestLatency_ms).Time().Stop()
 
-	var p []byte
-	var buffer *bytes.Buffer
-	var committed int64 = 0
-	var resource *Resource = nil
-	resourceName, storeName := "", ""
-	var err error
+	wr, err := ser.Recv()
+	if err != nil {
+		log.Errorf("Failed to Recv(): %v", err)
+		return status.Error(codes.Internal, fmt.Sprintf("Failed to Recv: %v", err))
+	}
 
-	// As indicated above, not supporting partial/resumable Writes for now.
-	// Reads in a stream of data from the client, and proceeds when we've gotten it all.
-	for {
-		wr, err := ser.Recv()
+	// Set up resource on initial WriteRequest
+	resourceName := wr.GetResourceName()
+	resource, err := ParseWriteResource(resourceName)
+	if err != nil {
+		log.Errorf("Error parsing resource: %v", err)
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+	log.Debugf("Using resource name: %s", resourceName)
+
+	// If the client is attempting to write empty/nil/size-0 data, just return as if we succeeded
+	if resource.DigestFunction.IsEmpty(resource.Digest) {
+		log.Infof("Request to write empty sha - bypassing Store write and Closing")
+		res := &bytestream.WriteResponse{CommittedSize: bazel.EmptySize}
+		err := ser.SendAndClose(res)
 		if err != nil {
-			log.Errorf("Failed to Recv(): %v", err)
-			return status.Error(codes.Internal, fmt.Sprintf("Failed to Recv: %v", err))
+			log.Errorf("Error during SendAndClose() for EmptySha: %s", err)
+			return status.Error(codes.Internal, fmt.Sprintf("Failed to SendAndClose: %v", err))
 		}
+		return nil
+	}
 
-		// Set up resource on initial WriteRequest. Initialize read buffer based on Digest size
-		if resource == nil {
-			resourceName = wr.GetResourceName()
-
-			resource, err = ParseWriteResource(resourceName)
+	// Claim the digest, waiting for any other Write of it to end. If data Exists, terminate immediately
+	// with size of existing data (Store is immutable). Note that Store does not support `stat`,
+	// so we trust client-provided size to avoid reading the data
+	storeName := resource.DigestFunction.StoreName(resource.Digest)
+	for {
+		if exists, err := s.storeConfig.Store.Exists(storeName); err != nil {
+			log.Errorf("Error checking existence: %v", err)
+			return status.Error(codes.Internal, fmt.Sprintf("Store failed checking existence of %s: %v", storeName, err))
+		} else if exists {
+			log.Infof("Resource exists in store: %s. Using client digest size: %d", storeName, resource.Digest.GetSizeBytes())
+			res := &bytestream.WriteResponse{CommittedSize: resource.Digest.GetSizeBytes()}
+			err = ser.SendAndClose(res)
 			if err != nil {
-				log.Errorf("Error parsing resource: %v", err)
-				return status.Error(codes.InvalidArgument, fmt.Sprintf("%v", err))
-			}
-			log.Debugf("Using resource name: %s", resourceName)
-
-			// If the client is attempting to write empty/nil/size-0 data, just return as if we succeeded
-			if resource.Digest.GetHash() == bazel.EmptySha {
-				log.Infof("Request to write empty sha - bypassing Store write and Closing")
-				res := &bytestream.WriteResponse{CommittedSize: bazel.EmptySize}
-				err := ser.SendAndClose(res)
-				if err != nil {
-					log.Errorf("Error during SendAndClose() for EmptySha: %s", err)
-					return status.Error(codes.Internal, fmt.Sprintf("Failed to SendAndClose: %v", err))
-				}
-				return nil
+				log.Errorf("Error during SendAndClose() for Existing: %v", err)
+				return status.Error(codes.Internal, fmt.Sprintf("Failed to SendAndClose WriteResponse: %v", err))
 			}
+			return nil
+		}
 
-			p = make([]byte, 0, resource.Digest.GetSizeBytes())
-			buffer = bytes.NewBuffer(p)
-
-			// If data Exists, terminate immediately with size of existing data (Store is immutable)
-			// Note that Store does not support `stat`, so we trust client-provided size to avoid reading the data
-			storeName = bazel.DigestStoreName(resource.Digest)
-			if exists, err := s.storeConfig.Store.Exists(storeName); err != nil {
-				log.Errorf("Error checking existence: %v", err)
-				return status.Error(codes.Internal, fmt.Sprintf("Store failed checking existence of %s: %v", storeName, err))
-			} else if exists {
-				log.Infof("Resource exists in store: %s. Using client digest size: %d", storeName, resource.Digest.GetSizeBytes())
-				res := &bytestream.WriteResponse{CommittedSize: resource.Digest.GetSizeBytes()}
-				err = ser.SendAndClose(res)
-				if err != nil {
-					log.Errorf("Error during SendAndClose() for Existing: %v", err)
-					return status.Error(codes.Internal, fmt.Sprintf("Failed to SendAndClose WriteResponse: %v", err))
-				}
-				return nil
-			}
+		wait, release := s.writes.claimDigest(storeName)
+		if wait == nil {
+			defer release()
+			break
+		}
+		log.Infof("Waiting for in progress Write of %s", storeName)
+		select {
+		case <-wait:
+		case <-ser.Context().Done():
+			return status.Error(codes.Canceled, fmt.Sprintf("Canceled waiting for Write of %s", storeName))
 		}
+	}
 
-		// Validate subsequent WriteRequest fields
+	spool, err := s.writes.acquire(resource.UUID.String(), resource.DigestFunction, resource.Digest)
+	if err == errSpoolInUse {
+		log.Errorf("Failed to acquire spool: %v", err)
+		return status.Error(codes.Aborted, fmt.Sprintf("%v", err))
+	} else if err != nil {
+		log.Errorf("Failed to acquire spool: %v", err)
+		return status.Error(codes.Internal, fmt.Sprintf("Failed to spool Write: %v", err))
+	}
+	done := false
+	defer func() { s.writes.release(spool, done) }()
+	if spool.committed > 0 {
+		log.Infof("Resuming Write %s of %s from %d bytes", spool.uuid, storeName, spool.committed)
+		s.stat.Counter(stats.BzWriteResumedCounter).Inc(1)
+	}
+
+	// Reads in a stream of data from the client, and proceeds when we've gotten it all.
+	// If the stream fails, the spool is kept so the Write can be resumed.
+	for {
+		// Validate WriteRequest fields
 		if wr.GetResourceName() != "" && resourceName != wr.GetResourceName() {
 			log.Errorf("Invalid resource name in subsequent request: %s", wr.GetResourceName())
 			return status.Error(codes.InvalidArgument, fmt.Sprintf("ResourceName %s mismatch with previous %s", wr.GetResourceName(), resourceName))
 		}
-		if wr.GetWriteOffset() != committed {
+		if wr.GetWriteOffset() != spool.committed {
 			log.Error("Invalid write offset")
-			return status.Error(codes.InvalidArgument, fmt.Sprintf("WriteOffset invalid: got %d after committing %d bytes", wr.GetWriteOffset(), committed))
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("WriteOffset invalid: got %d after committing %d bytes", wr.GetWriteOffset(), spool.committed))
 		}
 
-		buffer.Write(wr.GetData())
-		committed += int64(len(wr.GetData()))
+		// Verify size as data streams in, rejecting the Write as soon as it exceeds the Digest
+		if spool.committed+int64(len(wr.GetData())) > resource.Digest.GetSizeBytes() {
+			log.Errorf("Data exceeds digest size: %d/%d", spool.committed+int64(len(wr.GetData())), resource.Digest.GetSizeBytes())
+			s.stat.Counter(stats.BzWriteRejectedCounter).Inc(1)
+			done = true
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("Data to be written exceeds request Digest size: %d", resource.Digest.GetSizeBytes()))
+		}
+		if err := s.writes.append(spool, wr.GetData()); err == errSpoolFull {
+			log.Errorf("Failed to spool data: %v", err)
+			s.stat.Counter(stats.BzWriteSpoolFullCounter).Inc(1)
+			return status.Error(codes.ResourceExhausted, fmt.Sprintf("Failed to spool data: %v", err))
+		} else if err != nil {
+			log.Errorf("Failed to spool data: %v", err)
+			return status.Error(codes.Internal, fmt.Sprintf("Failed to spool data: %v", err))
+		}
 
 		// Per API, client indicates all data has been sent
 		if wr.GetFinishWrite() {
 			break
 		}
+
+		wr, err = ser.Recv()
+		if err != nil {
+			log.Errorf("Failed to Recv(): %v", err)
+			return status.Error(codes.Internal, fmt.Sprintf("Failed to Recv: %v", err))
+		}
 	}
 
-	// Get committed length and verify - Digest size can be arbitrarily set by the client, but is a trusted value after insertion
+	// Verify committed length and hash - the Digest is trusted after insertion, so data that doesn't
+	// match it is rejected rather than poisoning the cache. The hash was computed as data was spooled.
+	committed := spool.committed
 	if committed != resource.Digest.GetSizeBytes() {
 		log.Errorf("Data length/digest mismatch: %d/%d", committed, resource.Digest.GetSizeBytes())
-		return status.Error(codes.Internal, fmt.Sprintf("Data to be written len: %d mismatch with request Digest size: %d", committed, resource.Digest.GetSizeBytes()))
+		s.stat.Counter(stats.BzWriteRejectedCounter).Inc(1)
+		done = true
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Data to be written len: %d mismatch with request Digest size: %d", committed, resource.Digest.GetSizeBytes()))
+	}
+	if sum := spool.sum(); sum != resource.Digest.GetHash() {
+		log.Errorf("Data hash/digest mismatch: %s/%s", sum, resource.Digest.GetHash())
+		s.stat.Counter(stats.BzWriteRejectedCounter).Inc(1)
+		done = true
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("Data to be written hash: %s mismatch with request Digest hash: %s", sum, resource.Digest.GetHash()))
 	}
 
 	// Write to underlying Store
@@ -314,11 +600,12 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 		ttl.TTL = time.Now().Add(DefaultTTL)
 	}
 
-	err = s.storeConfig.Store.Write(storeName, buffer, ttl)
+	err = s.storeConfig.Store.Write(storeName, io.NewSectionReader(spool.file, 0, committed), ttl)
 	if err != nil {
 		log.Errorf("Store failed to Write: %v", err)
 		return status.Error(codes.Internal, fmt.Sprintf("Store failed writing to %s: %v", storeName, err))
 	}
+	done = true
 
 	res := &bytestream.WriteResponse{CommittedSize: committed}
 	err = ser.SendAndClose(res)
@@ -331,11 +618,42 @@ func (s *casServer) Write(ser bytestream.ByteStream_WriteServer) error {
 	return nil
 }
 
-// QueryWriteStatus gives status information about a Write operation in progress
-// Unsupported, may be added later for V1
+// QueryWriteStatus gives status information about a Write operation in progress,
+// so that clients can resume an interrupted Write from its committed size
 func (s *casServer) QueryWriteStatus(
-	context.Context, *bytestream.QueryWriteStatusRequest) (*bytestream.QueryWriteStatusResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "Currently unsupported in Scoot - Writes are not resumable")
+	ctx context.Context,
+	req *bytestream.QueryWriteStatusRequest) (*bytestream.QueryWriteStatusResponse, error) {
+	log.Debugf("Received CAS QueryWriteStatus request: %s", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzQueryWriteStatusRequestCounter).Inc(1)
+
+	resource, err := ParseWriteResource(req.GetResourceName())
+	if err != nil {
+		log.Errorf("Error parsing resource: %v", err)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+
+	if resource.DigestFunction.IsEmpty(resource.Digest) {
+		return &bytestream.QueryWriteStatusResponse{CommittedSize: bazel.EmptySize, Complete: true}, nil
+	}
+
+	// A Write is complete once its data is in the Store, regardless of which client wrote it
+	storeName := resource.DigestFunction.StoreName(resource.Digest)
+	if exists, err := s.storeConfig.Store.Exists(storeName); err != nil {
+		log.Errorf("Error checking existence: %v", err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Store failed checking existence of %s: %v", storeName, err))
+	} else if exists {
+		return &bytestream.QueryWriteStatusResponse{CommittedSize: resource.Digest.GetSizeBytes(), Complete: true}, nil
+	}
+
+	committed, ok := s.writes.status(resource.UUID.String(), resource.DigestFunction, resource.Digest)
+	if !ok {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("No Write in progress for %s", req.GetResourceName()))
+	}
+	return &bytestream.QueryWriteStatusResponse{CommittedSize: committed, Complete: false}, nil
 }
 
 // ActionCache APIs
@@ -343,13 +661,14 @@ func (s *casServer) QueryWriteStatus(
 // V2 API requires explicit uploading of Actions prior to Execution. Because of this,
 // cached ActionResults cannot be directly addressed by the ActionDigest (collision).
 // We address the ActionResult by serializing the ActionDigest with a constant string
-// and getting its hash to use for a unique and predictable blobstore address.
+// and getting its hash to use for a unique and predictable blobstore address,
+// namespaced by the digest function of the ActionDigest.
 type cacheResultAddress struct {
 	actionDigest *remoteexecution.Digest
 	storeName    string
 }
 
-func makeCacheResultAddress(ad *remoteexecution.Digest) (*cacheResultAddress, error) {
+func makeCacheResultAddress(fn *bazel.DigestFunction, ad *remoteexecution.Digest) (*cacheResultAddress, error) {
 	if ad == nil {
 		return nil, fmt.Errorf("Nil ActionDigest provided to NewResultAddress")
 	}
@@ -367,10 +686,21 @@ func makeCacheResultAddress(ad *remoteexecution.Digest) (*cacheResultAddress, er
 
 	return &cacheResultAddress{
 		actionDigest: ad,
-		storeName:    bazel.DigestStoreName(addressDigest),
+		storeName:    fn.StoreName(addressDigest),
 	}, nil
 }
 
+// Name of the tombstone marking the cached ActionResult with data arBytes as purged, see PurgeActionResult.
+// Caching Stores, ex: groupcache, keep serving data after it's overwritten, so the result can't be replaced.
+// Keying the tombstone by the result's data means a result cached again after a purge isn't purged with it.
+func (a *cacheResultAddress) tombstoneName(fn *bazel.DigestFunction, arBytes []byte) string {
+	tombstoneKey := fmt.Sprintf("%s-%x-%s", a.storeName, sha256.Sum256(arBytes), ResultTombstone)
+	return fn.StoreName(&remoteexecution.Digest{
+		Hash:      fmt.Sprintf("%x", sha256.Sum256([]byte(tombstoneKey))),
+		SizeBytes: int64(len(tombstoneKey)),
+	})
+}
+
 // Retrieve a cached execution result. Results are keyed on ActionDigests of run commands.
 func (s *casServer) GetActionResult(ctx context.Context,
 	req *remoteexecution.GetActionResultRequest) (*remoteexecution.ActionResult, error) {
@@ -383,17 +713,21 @@ func (s *casServer) GetActionResult(ctx context.Context,
 	defer s.stat.Latency(stats.BzGetActionRequestLatency_ms).Time().Stop()
 
 	// Validate input digest
-	if !bazel.IsValidDigest(req.GetActionDigest().GetHash(), req.GetActionDigest().GetSizeBytes()) {
+	fn, err := bazel.DigestFunctionFromProto(req.GetDigestFunction())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+	if !fn.IsValidDigest(req.GetActionDigest().GetHash(), req.GetActionDigest().GetSizeBytes()) {
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid ActionDigest %s", req.GetActionDigest()))
 	}
 
 	// If nil digest was requested, that's odd - return nil action result
-	if req.GetActionDigest().GetHash() == bazel.EmptySha {
+	if fn.IsEmpty(req.GetActionDigest()) {
 		log.Debug("GetActionResult - returning empty ActionResult from request for EmptySha ActionDigest")
 		return &remoteexecution.ActionResult{}, nil
 	}
 
-	address, err := makeCacheResultAddress(req.GetActionDigest())
+	address, err := makeCacheResultAddress(fn, req.GetActionDigest())
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to create cache result address: %v", err))
 	}
@@ -413,6 +747,14 @@ func (s *casServer) GetActionResult(ctx context.Context,
 		log.Errorf("Failed reading ActionResult from Store (resource %s): %s", address.storeName, err)
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Error reading from %s: %s", address.storeName, err))
 	}
+	tombstone := address.tombstoneName(fn, arAsBytes)
+	if purged, err := s.storeConfig.Store.Exists(tombstone); err != nil {
+		log.Errorf("Failed checking existence of tombstone %s: %v", tombstone, err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("Store failed checking existence of %s: %v", tombstone, err))
+	} else if purged {
+		log.Infof("ActionResult %s was purged, returning NotFound", address.storeName)
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("ActionResult %s was purged", address.storeName))
+	}
 
 	// Deserialize store data as AR
 	ar := &remoteexecution.ActionResult{}
@@ -421,6 +763,24 @@ func (s *casServer) GetActionResult(ctx context.Context,
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Error deserializing ActionResult: %s", err))
 	}
 
+	// Outputs can expire from the Store before the result does, and clients fail to download them.
+	// Purge results with missing outputs so they're treated as misses and the action is rerun.
+	switch s.actionCache.OutputValidation {
+	case ValidateOutputsSync:
+		if purged, err := s.purgeIfOutputsMissing(fn, address, arAsBytes, ar); err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("Failed validating ActionResult outputs: %v", err))
+		} else if purged {
+			return nil, status.Error(codes.NotFound, fmt.Sprintf("ActionResult %s has missing outputs", address.storeName))
+		}
+	case ValidateOutputsAsync:
+		// Validations are dropped rather than queued without bound, the result is validated again on its next hit.
+		select {
+		case s.validateCh <- outputValidation{fn: fn, address: address, arBytes: arAsBytes, ar: ar}:
+		default:
+			s.stat.Counter(stats.BzGetActionValidationDroppedCounter).Inc(1)
+		}
+	}
+
 	log.Infof("GetActionResult returning cached result: %s", ar)
 	return ar, nil
 }
@@ -438,7 +798,11 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 	defer s.stat.Latency(stats.BzUpdateActionRequestLatency_ms).Time().Stop()
 
 	// Validate input digest, ActionResult
-	if !bazel.IsValidDigest(req.GetActionDigest().GetHash(), req.GetActionDigest().GetSizeBytes()) {
+	fn, err := bazel.DigestFunctionFromProto(req.GetDigestFunction())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+	if !fn.IsValidDigest(req.GetActionDigest().GetHash(), req.GetActionDigest().GetSizeBytes()) {
 		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Invalid ActionDigest %s", req.GetActionDigest()))
 	}
 	if req.GetActionResult() == nil {
@@ -446,7 +810,7 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 	}
 
 	// No-op if user requested to store nil action digest
-	if req.GetActionDigest().GetHash() == bazel.EmptySha {
+	if fn.IsEmpty(req.GetActionDigest()) {
 		log.Debug("UpdateActionResult - returning empty ActionResult from request to store with EmptySha digest")
 		return &remoteexecution.ActionResult{}, nil
 	}
@@ -458,7 +822,7 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Error serializing ActionResult: %s", err))
 	}
 
-	address, err := makeCacheResultAddress(req.GetActionDigest())
+	address, err := makeCacheResultAddress(fn, req.GetActionDigest())
 	if err != nil {
 		return nil, status.Error(codes.Internal, fmt.Sprintf("Failed to create cache result address: %v", err))
 	}
@@ -480,8 +844,256 @@ func (s *casServer) UpdateActionResult(ctx context.Context,
 	return req.GetActionResult(), nil
 }
 
+// Purges the cached ActionResult of an ActionDigest, so that subsequent lookups are cache misses.
+// Stores don't support deletion and caching Stores never invalidate what they've read, see tombstoneName,
+// so a tombstone naming the current result is written instead. Results cached after the purge aren't affected.
+func PurgeActionResult(cfg *store.StoreConfig, fn *bazel.DigestFunction, actionDigest *remoteexecution.Digest) error {
+	if !fn.IsValidDigest(actionDigest.GetHash(), actionDigest.GetSizeBytes()) {
+		return fmt.Errorf("Invalid ActionDigest %s", actionDigest)
+	}
+	address, err := makeCacheResultAddress(fn, actionDigest)
+	if err != nil {
+		return fmt.Errorf("Failed to create cache result address: %v", err)
+	}
+
+	// Don't create entries for results that were never cached
+	r, err := cfg.Store.OpenForRead(address.storeName)
+	if err != nil {
+		if exists, existsErr := cfg.Store.Exists(address.storeName); existsErr != nil {
+			return fmt.Errorf("Store failed checking existence of %s: %v", address.storeName, existsErr)
+		} else if exists {
+			return fmt.Errorf("Store failed opening %s for read: %v", address.storeName, err)
+		}
+		return nil
+	}
+	defer r.Close()
+	arBytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("Error reading from %s: %v", address.storeName, err)
+	}
+	return address.purge(cfg, fn, arBytes)
+}
+
+// Writes the tombstone of the cached ActionResult with data arBytes.
+func (a *cacheResultAddress) purge(cfg *store.StoreConfig, fn *bazel.DigestFunction, arBytes []byte) error {
+	tombstone := a.tombstoneName(fn, arBytes)
+	ttl := store.GetTTLValue(cfg.TTLCfg)
+	if ttl != nil {
+		ttl.TTL = time.Now().Add(DefaultTTL)
+	}
+	if err := cfg.Store.Write(tombstone, bytes.NewReader([]byte(ResultTombstone)), ttl); err != nil {
+		return fmt.Errorf("Store failed writing to %s: %v", tombstone, err)
+	}
+	log.Infof("Purged ActionResult %s for ActionDigest %s", a.storeName, bazel.DigestToStr(a.actionDigest))
+	return nil
+}
+
+// Capabilities APIs
+
+// GetCapabilities reports the digest functions, batch size limit and ActionCache update policy
+// supported by this server. Execution capabilities are reported by the execution server.
+func (s *casServer) GetCapabilities(
+	ctx context.Context,
+	req *remoteexecution.GetCapabilitiesRequest) (*remoteexecution.ServerCapabilities, error) {
+	log.Debugf("Received CAS GetCapabilities request: %s", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzCASGetCapabilitiesRequestCounter).Inc(1)
+
+	return &remoteexecution.ServerCapabilities{
+		CacheCapabilities: &remoteexecution.CacheCapabilities{
+			DigestFunction: bazel.SupportedDigestFunctions(),
+			ActionCacheUpdateCapabilities: &remoteexecution.ActionCacheUpdateCapabilities{
+				UpdateEnabled: true,
+			},
+			// Clients use a single limit for both batch APIs, so report the smaller
+			MaxBatchTotalSizeBytes: MaxBatchReadSize,
+		},
+	}, nil
+}
+
 // Internal functions
 
+// Checks that the output files, output directory trees, stdout and stderr referenced by an ActionResult
+// exist in the Store, and purges the ActionResult if any are missing. Returns true if it was purged.
+// The outputs are checked concurrently, so a hit waits for one round of Exists calls rather than one per output.
+func (s *casServer) purgeIfOutputsMissing(
+	fn *bazel.DigestFunction, address *cacheResultAddress, arBytes []byte, ar *remoteexecution.ActionResult) (bool, error) {
+	digests := []*remoteexecution.Digest{ar.GetStdoutDigest(), ar.GetStderrDigest()}
+	for _, f := range ar.GetOutputFiles() {
+		digests = append(digests, f.GetDigest())
+	}
+	for _, d := range ar.GetOutputDirectories() {
+		digests = append(digests, d.GetTreeDigest())
+	}
+
+	names := []string{}
+	for _, d := range digests {
+		// Unset digests aren't outputs, and empty data is never written to the Store, see FindMissingBlobs
+		if d.GetHash() != "" && !fn.IsEmpty(d) {
+			names = append(names, fn.StoreName(d))
+		}
+	}
+	missing, err := s.findMissing(names)
+	if err != nil || missing == "" {
+		return false, err
+	}
+
+	log.Infof("Output %s of ActionResult for %s is missing, purging result", missing, bazel.DigestToStr(address.actionDigest))
+	s.stat.Counter(stats.BzGetActionMissingOutputsCounter).Inc(1)
+	if err := address.purge(s.storeConfig, fn, arBytes); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Checks that the named data exists in the Store with concurrent Exists calls.
+// Returns the name of one that's missing, or "" if all exist.
+func (s *casServer) findMissing(names []string) (string, error) {
+	type result struct {
+		name   string
+		exists bool
+		err    error
+	}
+	resultCh := make(chan result, len(names))
+	for _, name := range names {
+		go func(name string) {
+			exists, err := s.storeConfig.Store.Exists(name)
+			resultCh <- result{name, exists, err}
+		}(name)
+	}
+
+	missing := ""
+	var err error
+	for range names {
+		r := <-resultCh
+		if r.err != nil {
+			err = fmt.Errorf("Store failed checking existence of %s: %v", r.name, r.err)
+		} else if !r.exists {
+			missing = r.name
+		}
+	}
+	if missing != "" {
+		return missing, nil
+	}
+	return "", err
+}
+
+// An ActionResult waiting for background validation.
+type outputValidation struct {
+	fn      *bazel.DigestFunction
+	address *cacheResultAddress
+	arBytes []byte
+	ar      *remoteexecution.ActionResult
+}
+
+// Starts the workers that validate the outputs of ActionResults in the background, see ValidateOutputsAsync.
+func (s *casServer) startValidationWorkers() {
+	if s.actionCache.OutputValidation != ValidateOutputsAsync {
+		return
+	}
+	s.validateCh = make(chan outputValidation, s.actionCache.AsyncValidationQueue)
+	for i := 0; i < s.actionCache.AsyncValidationWorkers; i++ {
+		go func() {
+			for v := range s.validateCh {
+				if _, err := s.purgeIfOutputsMissing(v.fn, v.address, v.arBytes, v.ar); err != nil {
+					log.Errorf("Failed validating ActionResult outputs: %v", err)
+				}
+			}
+		}()
+	}
+}
+
+// Validates and writes a single blob from a batch to the Store, returning the result as a grpc Status
+func (s *casServer) updateBlob(
+	fnProto remoteexecution.DigestFunction, digest *remoteexecution.Digest, data []byte) *status.Status {
+	fn, err := bazel.DigestFunctionFromProto(fnProto)
+	if err != nil {
+		return status.New(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+	if !fn.IsValidDigest(digest.GetHash(), digest.GetSizeBytes()) {
+		return status.New(codes.InvalidArgument, fmt.Sprintf("Invalid Digest %s", digest))
+	}
+	if int64(len(data)) != digest.GetSizeBytes() || fn.Hash(data) != digest.GetHash() {
+		s.stat.Counter(stats.BzWriteRejectedCounter).Inc(1)
+		return status.New(codes.InvalidArgument, fmt.Sprintf("Digest %s does not match data", bazel.DigestToStr(digest)))
+	}
+
+	// Empty data is never written to the Store, see FindMissingBlobs
+	if fn.IsEmpty(digest) {
+		return status.New(codes.OK, "")
+	}
+
+	// If data Exists, don't write it again (Store is immutable)
+	storeName := fn.StoreName(digest)
+	if exists, err := s.storeConfig.Store.Exists(storeName); err != nil {
+		log.Errorf("Error checking existence: %v", err)
+		return status.New(codes.Internal, fmt.Sprintf("Store failed checking existence of %s: %v", storeName, err))
+	} else if exists {
+		return status.New(codes.OK, "")
+	}
+
+	// TODO use CAS Default TTL setting until API supports cache priority settings
+	ttl := store.GetTTLValue(s.storeConfig.TTLCfg)
+	if ttl != nil {
+		ttl.TTL = time.Now().Add(DefaultTTL)
+	}
+
+	if err := s.storeConfig.Store.Write(storeName, bytes.NewReader(data), ttl); err != nil {
+		log.Errorf("Store failed to Write: %v", err)
+		return status.New(codes.Internal, fmt.Sprintf("Store failed writing to %s: %v", storeName, err))
+	}
+	return status.New(codes.OK, "")
+}
+
+// Reads a single blob of a batch from the Store, returning the data and the result as a grpc Status
+func (s *casServer) readBlob(
+	fnProto remoteexecution.DigestFunction, digest *remoteexecution.Digest) ([]byte, *status.Status) {
+	fn, err := bazel.DigestFunctionFromProto(fnProto)
+	if err != nil {
+		return nil, status.New(codes.InvalidArgument, fmt.Sprintf("%v", err))
+	}
+	if !fn.IsValidDigest(digest.GetHash(), digest.GetSizeBytes()) {
+		return nil, status.New(codes.InvalidArgument, fmt.Sprintf("Invalid Digest %s", digest))
+	}
+	if fn.IsEmpty(digest) {
+		return []byte{}, status.New(codes.OK, "")
+	}
+
+	storeName := fn.StoreName(digest)
+	r, err := s.storeConfig.Store.OpenForRead(storeName)
+	if err != nil {
+		log.Errorf("Failed to OpenForRead: %v", err)
+		return nil, status.New(codes.NotFound, fmt.Sprintf("Failed opening resource %s for read, returning NotFound. Err: %v", storeName, err))
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		log.Errorf("Failed to read from Store: %v", err)
+		return nil, status.New(codes.Internal, fmt.Sprintf("Failed to read %s from Store: %v", storeName, err))
+	}
+	return data, status.New(codes.OK, "")
+}
+
+// Reads and deserializes a Directory from the Store, returning a grpc status error if it fails
+func (s *casServer) readDirectory(
+	fn remoteexecution.DigestFunction, digest *remoteexecution.Digest) (*remoteexecution.Directory, error) {
+	data, st := s.readBlob(fn, digest)
+	if st.Code() != codes.OK {
+		return nil, st.Err()
+	}
+
+	dir := &remoteexecution.Directory{}
+	if err := proto.Unmarshal(data, dir); err != nil {
+		log.Errorf("Failed to deserialize bytes from %s as Directory: %s", bazel.DigestToStr(digest), err)
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("Error deserializing Directory %s: %s", bazel.DigestToStr(digest), err))
+	}
+	return dir, nil
+}
+
 // Interface for reading Empty data in a normal way while bypassing the underlying store
 type nilReader struct{}
 
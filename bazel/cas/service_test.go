@@ -2,9 +2,14 @@ package cas
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	uuid "github.com/nu7hatch/gouuid"
@@ -24,6 +29,9 @@ var testHash1 string = "ce58a4479be1d32816ee82e57eae04415dc2bda173fa7b0f11d18aa6
 var testSize1 int64 = 7
 var testData1 []byte = []byte("abc1234")
 
+// sha256 of testData1, for tests where data is verified against its Digest
+var testDataHash1 string = "36f583dd16f4e1e201eb1e6f6d8e35a2ccb3bbe2658de46b4ffae7b0e9ed872e"
+
 func TestFindMissingBlobs(t *testing.T) {
 	f := &store.FakeStore{}
 	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
@@ -147,9 +155,9 @@ func TestReadMissing(t *testing.T) {
 
 func TestWrite(t *testing.T) {
 	f := &store.FakeStore{}
-	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
 
-	w := makeFakeWriteServer(testHash1, testSize1, testData1, 3)
+	w := makeFakeWriteServer(testDataHash1, testSize1, testData1, 3)
 
 	// Make Write request with test data
 	err := s.Write(w)
@@ -166,7 +174,7 @@ func TestWrite(t *testing.T) {
 	}
 
 	// Verify Write by reading directly from underlying Store
-	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	d := &remoteexecution.Digest{Hash: testDataHash1, SizeBytes: testSize1}
 	resourceName := bazel.DigestStoreName(d)
 	r, err := f.OpenForRead(resourceName)
 	if err != nil {
@@ -181,9 +189,62 @@ func TestWrite(t *testing.T) {
 	}
 }
 
+func TestWriteDigestFunction(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
+
+	hash := bazel.BLAKE3.Hash(testData1)
+	w := makeFakeWriteServer(hash, testSize1, testData1, 3)
+	w.resourceName = strings.Replace(w.resourceName, "/blobs/", "/blobs/blake3/", 1)
+	if err := s.Write(w); err != nil {
+		t.Fatalf("Error response from Write: %v", err)
+	}
+
+	// Data is stored under a name namespaced by digest function
+	d := &remoteexecution.Digest{Hash: hash, SizeBytes: testSize1}
+	if exists, _ := f.Exists(bazel.DigestStoreName(d)); exists {
+		t.Fatalf("Expected blake3 blob not to be stored under sha256 name: %s", bazel.DigestStoreName(d))
+	}
+	if exists, _ := f.Exists(bazel.BLAKE3.StoreName(d)); !exists {
+		t.Fatalf("Expected blake3 blob to be stored as: %s", bazel.BLAKE3.StoreName(d))
+	}
+
+	req := &remoteexecution.BatchReadBlobsRequest{
+		Digests:        []*remoteexecution.Digest{d},
+		DigestFunction: remoteexecution.DigestFunction_BLAKE3,
+	}
+	res, err := s.BatchReadBlobs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from BatchReadBlobs: %v", err)
+	}
+	if !bytes.Equal(res.GetResponses()[0].GetData(), testData1) {
+		t.Fatalf("Expected data %s, got: %s", testData1, res.GetResponses()[0].GetData())
+	}
+
+	// The same hash isn't found by the default digest function
+	req.DigestFunction = remoteexecution.DigestFunction_SHA256
+	res, err = s.BatchReadBlobs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from BatchReadBlobs: %v", err)
+	}
+	if c := codes.Code(res.GetResponses()[0].GetStatus().GetCode()); c != codes.NotFound {
+		t.Fatalf("Expected status %s, got: %s", codes.NotFound, c)
+	}
+
+	// Unsupported digest functions are rejected
+	req.DigestFunction = remoteexecution.DigestFunction_MD5
+	res, err = s.BatchReadBlobs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from BatchReadBlobs: %v", err)
+	}
+	if c := codes.Code(res.GetResponses()[0].GetStatus().GetCode()); c != codes.InvalidArgument {
+		t.Fatalf("Expected status %s, got: %s", codes.InvalidArgument, c)
+	}
+}
+
 func TestWriteEmpty(t *testing.T) {
 	f := &store.FakeStore{}
-	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
 
 	w := makeFakeWriteServer(bazel.EmptySha, bazel.EmptySize, []byte{}, 1)
 
@@ -205,10 +266,10 @@ func TestWriteEmpty(t *testing.T) {
 
 func TestWriteExisting(t *testing.T) {
 	f := &store.FakeStore{}
-	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
 
 	// Pre-write data directly to underlying Store
-	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	d := &remoteexecution.Digest{Hash: testDataHash1, SizeBytes: testSize1}
 
 	resourceName := bazel.DigestStoreName(d)
 	err := f.Write(resourceName, bytes.NewReader(testData1), nil)
@@ -216,7 +277,7 @@ func TestWriteExisting(t *testing.T) {
 		t.Fatalf("Failed to write into FakeStore: %v", err)
 	}
 
-	w := makeFakeWriteServer(testHash1, testSize1, testData1, 3)
+	w := makeFakeWriteServer(testDataHash1, testSize1, testData1, 3)
 
 	// Make Write request with test data matching pre-written data
 	err = s.Write(w)
@@ -233,57 +294,390 @@ func TestWriteExisting(t *testing.T) {
 	}
 }
 
-func TestQueryWriteStatusStub(t *testing.T) {
+func TestWriteMismatch(t *testing.T) {
 	f := &store.FakeStore{}
-	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: statsReceiver, writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
+
+	// Data doesn't match the hash of the Digest
+	w := makeFakeWriteServer(testDataHash1, testSize1, []byte("abc1235"), 3)
+	if err := s.Write(w); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument writing mismatched data, got: %v", err)
+	}
+
+	// Data exceeds the size of the Digest, rejected before it's all sent
+	w = makeFakeWriteServer(testDataHash1, 2, testData1, 3)
+	if err := s.Write(w); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument writing oversized data, got: %v", err)
+	}
+	if w.recvCount != 2 {
+		t.Fatalf("Expected Write to be rejected on the 2nd chunk, got %d", w.recvCount)
+	}
 
-	req := &bytestream.QueryWriteStatusRequest{}
+	if len(f.Files) != 0 {
+		t.Fatalf("Expected rejected data not to be written to Store, got: %v", f.Files)
+	}
+	if len(s.writes.spools) != 0 {
+		t.Fatalf("Expected no spools after rejected Writes, got: %d", len(s.writes.spools))
+	}
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.BzWriteRejectedCounter: {Checker: stats.Int64EqTest, Value: 2},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+func TestWriteResume(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
+
+	// Interrupt the stream after the first of 3 chunks
+	w := makeFakeWriteServer(testDataHash1, testSize1, testData1, 3)
+	w.failAfter = 1
+	if err := s.Write(w); err == nil {
+		t.Fatal("Expected error response from interrupted Write, got nil")
+	}
+
+	// Query status of the interrupted Write
+	req := &bytestream.QueryWriteStatusRequest{ResourceName: w.resourceName}
+	res, err := s.QueryWriteStatus(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from QueryWriteStatus: %v", err)
+	}
+	if res.GetComplete() || res.GetCommittedSize() != w.offset {
+		t.Fatalf("Expected incomplete Write with %d bytes committed, got: %v", w.offset, res)
+	}
+
+	// Resuming from the wrong offset is rejected, and doesn't lose the spool
+	bad := makeFakeWriteServer(testDataHash1, testSize1, testData1, 3)
+	bad.resourceName = w.resourceName
+	if err := s.Write(bad); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument resuming from offset 0, got: %v", err)
+	}
+
+	// Resume from the committed size
+	r := makeFakeWriteServer(testDataHash1, testSize1, testData1, 3)
+	r.resourceName = w.resourceName
+	r.offset = res.GetCommittedSize()
+	r.recvCount = 1
+	if err := s.Write(r); err != nil {
+		t.Fatalf("Error response from resumed Write: %v", err)
+	}
+	if r.committedSize != testSize1 {
+		t.Fatalf("Size committed to fake server did not match - expected: %d, got: %d", testSize1, r.committedSize)
+	}
+
+	// Verify Write by reading directly from underlying Store
+	d := &remoteexecution.Digest{Hash: testDataHash1, SizeBytes: testSize1}
+	rc, err := f.OpenForRead(bazel.DigestStoreName(d))
+	if err != nil {
+		t.Fatalf("Failed to open expected resource for reading: %v", err)
+	}
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("Error reading from fake store: %v", err)
+	}
+	if bytes.Compare(b, testData1) != 0 {
+		t.Fatalf("Data read from store did not match - expected: %s, got: %s", testData1, b)
+	}
+
+	// Once written, the Write is complete and its spool is gone
+	res, err = s.QueryWriteStatus(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from QueryWriteStatus: %v", err)
+	}
+	if !res.GetComplete() || res.GetCommittedSize() != testSize1 {
+		t.Fatalf("Expected complete Write with %d bytes committed, got: %v", testSize1, res)
+	}
+	if len(s.writes.spools) != 0 {
+		t.Fatalf("Expected no spools after completed Write, got: %d", len(s.writes.spools))
+	}
+}
+
+func TestWriteDedupe(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
+
+	// Hold a claim on the digest as if another client was writing it
+	d := &remoteexecution.Digest{Hash: testDataHash1, SizeBytes: testSize1}
+	wait, release := s.writes.claimDigest(bazel.DigestStoreName(d))
+	if wait != nil {
+		t.Fatal("Expected to claim unclaimed digest")
+	}
+
+	w := makeFakeWriteServer(testDataHash1, testSize1, testData1, 3)
+	errCh := make(chan error)
+	go func() { errCh <- s.Write(w) }()
+
+	// The other client finishes writing the data
+	if err := f.Write(bazel.DigestStoreName(d), bytes.NewReader(testData1), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	release()
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("Error response from Write: %v", err)
+	}
+	if w.committedSize != testSize1 {
+		t.Fatalf("Size committed to fake server did not match - expected: %d, got: %d", testSize1, w.committedSize)
+	}
+	if w.recvCount != 1 {
+		t.Fatalf("Expected deduplicated Write to stop after the first request, got %d", w.recvCount)
+	}
+}
+
+func TestQueryWriteStatusNotFound(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), writes: newWriteSpools("", DefaultWriteSpoolTimeout, DefaultWriteSpoolMaxSize)}
+
+	w := makeFakeWriteServer(testDataHash1, testSize1, testData1, 3)
+	req := &bytestream.QueryWriteStatusRequest{ResourceName: w.resourceName}
 
 	_, err := s.QueryWriteStatus(context.Background(), req)
-	if err == nil {
-		t.Fatal("Expected error response from QueryWriteStatus, got nil")
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected status code %s, got: %v", codes.NotFound, err)
+	}
+
+	req.ResourceName = "invalid"
+	_, err = s.QueryWriteStatus(context.Background(), req)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected status code %s, got: %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestBatchUpdateBlobs(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	d := &remoteexecution.Digest{Hash: fmt.Sprintf("%x", sha256.Sum256(testData1)), SizeBytes: testSize1}
+	dEmpty := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: bazel.EmptySize}
+	dMismatch := &remoteexecution.Digest{Hash: d.Hash, SizeBytes: testSize1}
+	dInvalid := &remoteexecution.Digest{Hash: "abc123", SizeBytes: 3}
+	req := &remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.UpdateBlobRequest{
+			{ContentDigest: d, Data: testData1},
+			{ContentDigest: dEmpty},
+			{ContentDigest: dMismatch, Data: []byte("1234abc")},
+			{ContentDigest: dInvalid, Data: []byte("abc")},
+		},
 	}
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Not ok reading grpc status from error")
+	expected := []codes.Code{codes.OK, codes.OK, codes.InvalidArgument, codes.InvalidArgument}
+
+	res, err := s.BatchUpdateBlobs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from BatchUpdateBlobs: %v", err)
 	}
-	if st.Code() != codes.Unimplemented {
-		t.Fatalf("Expected status code %d, got: %d", codes.Unimplemented, st.Code())
+	if len(res.GetResponses()) != len(expected) {
+		t.Fatalf("Expected %d responses, got: %d", len(expected), len(res.GetResponses()))
+	}
+	for i, r := range res.GetResponses() {
+		if r.GetBlobDigest() != req.Requests[i].ContentDigest {
+			t.Fatalf("Expected response %d for digest %s, got: %s", i, req.Requests[i].ContentDigest, r.GetBlobDigest())
+		}
+		if codes.Code(r.GetStatus().GetCode()) != expected[i] {
+			t.Fatalf("Expected response %d to have status %s, got: %s", i, expected[i], r.GetStatus())
+		}
+	}
+
+	// Only the valid blob is written to the Store
+	r, err := f.OpenForRead(bazel.DigestStoreName(d))
+	if err != nil {
+		t.Fatalf("Failed to open expected resource for reading: %s: %v", bazel.DigestStoreName(d), err)
+	}
+	if b, _ := ioutil.ReadAll(r); !bytes.Equal(b, testData1) {
+		t.Fatalf("Expected stored data %s, got: %s", testData1, b)
+	}
+	if exists, _ := f.Exists(bazel.DigestStoreName(dInvalid)); exists {
+		t.Fatalf("Expected invalid blob not to be written")
 	}
 }
 
-func TestBatchUpdateBlobsStub(t *testing.T) {
-	s := casServer{stat: stats.NilStatsReceiver()}
-	req := &remoteexecution.BatchUpdateBlobsRequest{}
+func TestBatchUpdateBlobsTooLarge(t *testing.T) {
+	s := casServer{storeConfig: &store.StoreConfig{Store: &store.FakeStore{}}, stat: stats.NilStatsReceiver()}
+	data := make([]byte, MaxBatchUpdateSize/2+1)
+	d := &remoteexecution.Digest{Hash: fmt.Sprintf("%x", sha256.Sum256(data)), SizeBytes: int64(len(data))}
+	req := &remoteexecution.BatchUpdateBlobsRequest{
+		Requests: []*remoteexecution.UpdateBlobRequest{
+			{ContentDigest: d, Data: data},
+			{ContentDigest: d, Data: data},
+		},
+	}
 
 	_, err := s.BatchUpdateBlobs(context.Background(), req)
-	if err == nil {
-		t.Fatalf("Non-error response from BatchUpdateBlobs")
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected status code %d, got: %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestBatchReadBlobs(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	if err := f.Write(bazel.DigestStoreName(d), bytes.NewReader(testData1), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	dEmpty := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: bazel.EmptySize}
+	dMissing := &remoteexecution.Digest{Hash: bazel.EmptySha[:60] + "0000", SizeBytes: 1}
+	dInvalid := &remoteexecution.Digest{Hash: "abc123", SizeBytes: 3}
+	req := &remoteexecution.BatchReadBlobsRequest{Digests: []*remoteexecution.Digest{d, dEmpty, dMissing, dInvalid}}
+	expected := []codes.Code{codes.OK, codes.OK, codes.NotFound, codes.InvalidArgument}
+
+	res, err := s.BatchReadBlobs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Error response from BatchReadBlobs: %v", err)
+	}
+	if len(res.GetResponses()) != len(expected) {
+		t.Fatalf("Expected %d responses, got: %d", len(expected), len(res.GetResponses()))
+	}
+	for i, r := range res.GetResponses() {
+		if r.GetDigest() != req.Digests[i] {
+			t.Fatalf("Expected response %d for digest %s, got: %s", i, req.Digests[i], r.GetDigest())
+		}
+		if codes.Code(r.GetStatus().GetCode()) != expected[i] {
+			t.Fatalf("Expected response %d to have status %s, got: %s", i, expected[i], r.GetStatus())
+		}
 	}
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Not ok reading grpc status from error")
+	if !bytes.Equal(res.GetResponses()[0].GetData(), testData1) {
+		t.Fatalf("Expected data %s, got: %s", testData1, res.GetResponses()[0].GetData())
 	}
-	if st.Code() != codes.Unimplemented {
-		t.Fatalf("Expected status code %d, got: %d", codes.Unimplemented, st.Code())
+
+	// Too large
+	req = &remoteexecution.BatchReadBlobsRequest{Digests: []*remoteexecution.Digest{
+		&remoteexecution.Digest{Hash: testHash1, SizeBytes: MaxBatchReadSize + 1},
+	}}
+	if _, err := s.BatchReadBlobs(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected status code %d, got: %v", codes.InvalidArgument, err)
 	}
 }
 
-func TestGetTreeStub(t *testing.T) {
-	s := casServer{stat: stats.NilStatsReceiver()}
-	req := &remoteexecution.GetTreeRequest{}
+func TestGetTree(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	// root -> {a -> {c}, b -> {c, missing}}, where c appears twice but is only returned once
+	c := &remoteexecution.Directory{Files: []*remoteexecution.FileNode{{Name: "c.txt"}}}
+	cDigest := writeDirectory(t, f, c)
+	missingDigest := &remoteexecution.Digest{Hash: bazel.EmptySha[:60] + "0000", SizeBytes: 1}
+	a := &remoteexecution.Directory{Directories: []*remoteexecution.DirectoryNode{{Name: "c", Digest: cDigest}}}
+	b := &remoteexecution.Directory{Directories: []*remoteexecution.DirectoryNode{
+		{Name: "c", Digest: cDigest},
+		{Name: "missing", Digest: missingDigest},
+	}}
+	root := &remoteexecution.Directory{Directories: []*remoteexecution.DirectoryNode{
+		{Name: "a", Digest: writeDirectory(t, f, a)},
+		{Name: "b", Digest: writeDirectory(t, f, b)},
+	}}
+	rootDigest := writeDirectory(t, f, root)
+	expected := []*remoteexecution.Directory{root, a, b, c}
+
+	// All directories fit in one page
 	gtServer := &fakeGetTreeServer{}
+	if err := s.GetTree(&remoteexecution.GetTreeRequest{RootDigest: rootDigest}, gtServer); err != nil {
+		t.Fatalf("Error response from GetTree: %v", err)
+	}
+	if len(gtServer.responses) != 1 || gtServer.responses[0].GetNextPageToken() != "" {
+		t.Fatalf("Expected a single page, got: %v", gtServer.responses)
+	}
+	checkDirectories(t, gtServer.responses[0].GetDirectories(), expected)
 
-	err := s.GetTree(req, gtServer)
-	if err == nil {
-		t.Fatalf("Non-error response from GetTree")
+	// Paged, and resumed from the first page's token
+	gtServer = &fakeGetTreeServer{}
+	if err := s.GetTree(&remoteexecution.GetTreeRequest{RootDigest: rootDigest, PageSize: 3}, gtServer); err != nil {
+		t.Fatalf("Error response from GetTree: %v", err)
 	}
-	st, ok := status.FromError(err)
-	if !ok {
-		t.Fatalf("Not ok reading grpc status from error")
+	if len(gtServer.responses) != 2 || gtServer.responses[1].GetNextPageToken() != "" {
+		t.Fatalf("Expected two pages, got: %v", gtServer.responses)
 	}
-	if st.Code() != codes.Unimplemented {
-		t.Fatalf("Expected status code %d, got: %d", codes.Unimplemented, st.Code())
+	checkDirectories(t, gtServer.responses[0].GetDirectories(), expected[:3])
+	checkDirectories(t, gtServer.responses[1].GetDirectories(), expected[3:])
+
+	token := gtServer.responses[0].GetNextPageToken()
+	gtServer = &fakeGetTreeServer{}
+	req := &remoteexecution.GetTreeRequest{RootDigest: rootDigest, PageSize: 3, PageToken: token}
+	if err := s.GetTree(req, gtServer); err != nil {
+		t.Fatalf("Error response from GetTree: %v", err)
+	}
+	if len(gtServer.responses) != 1 {
+		t.Fatalf("Expected one page, got: %v", gtServer.responses)
+	}
+	checkDirectories(t, gtServer.responses[0].GetDirectories(), expected[3:])
+
+	// Resuming only reads the Directories after the token, so it works from a store that only has those
+	cOnly := &store.FakeStore{}
+	writeDirectory(t, cOnly, c)
+	s2 := casServer{storeConfig: &store.StoreConfig{Store: cOnly}, stat: stats.NilStatsReceiver()}
+	gtServer = &fakeGetTreeServer{}
+	if err := s2.GetTree(req, gtServer); err != nil {
+		t.Fatalf("Error response from GetTree: %v", err)
+	}
+	if len(gtServer.responses) != 1 {
+		t.Fatalf("Expected one page, got: %v", gtServer.responses)
+	}
+	checkDirectories(t, gtServer.responses[0].GetDirectories(), expected[3:])
+
+	// Missing root and invalid token
+	err := s.GetTree(&remoteexecution.GetTreeRequest{RootDigest: missingDigest}, &fakeGetTreeServer{})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected status code %d, got: %v", codes.NotFound, err)
+	}
+	err = s.GetTree(&remoteexecution.GetTreeRequest{RootDigest: rootDigest, PageToken: "x"}, &fakeGetTreeServer{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected status code %d, got: %v", codes.InvalidArgument, err)
+	}
+}
+
+func TestGetTreeWide(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{
+		storeConfig: &store.StoreConfig{Store: f},
+		stat:        stats.NilStatsReceiver(),
+		treeCursors: newTreeCursors(DefaultTreeCursorTimeout, DefaultTreeCursorMaxDigests),
+	}
+
+	// root -> {0, 1, ..., n-1}, too many to list in a page token
+	n := 10 * MaxGetTreeTokenDigests
+	root := &remoteexecution.Directory{}
+	expected := []*remoteexecution.Directory{root}
+	for i := 0; i < n; i++ {
+		dir := &remoteexecution.Directory{Files: []*remoteexecution.FileNode{{Name: fmt.Sprintf("%d.txt", i)}}}
+		root.Directories = append(root.Directories, &remoteexecution.DirectoryNode{Name: fmt.Sprint(i), Digest: writeDirectory(t, f, dir)})
+		expected = append(expected, dir)
+	}
+	rootDigest := writeDirectory(t, f, root)
+
+	gtServer := &fakeGetTreeServer{}
+	req := &remoteexecution.GetTreeRequest{RootDigest: rootDigest, PageSize: int32(n / 2)}
+	if err := s.GetTree(req, gtServer); err != nil {
+		t.Fatalf("Error response from GetTree: %v", err)
+	}
+	if len(gtServer.responses) != 3 {
+		t.Fatalf("Expected three pages, got: %d", len(gtServer.responses))
+	}
+	token := gtServer.responses[0].GetNextPageToken()
+	if len(token) > 64 {
+		t.Fatalf("Expected a short page token for a wide tree, got %d bytes", len(token))
+	}
+
+	gtServer = &fakeGetTreeServer{}
+	req.PageToken = token
+	if err := s.GetTree(req, gtServer); err != nil {
+		t.Fatalf("Error response from GetTree: %v", err)
+	}
+	if len(gtServer.responses) != 2 {
+		t.Fatalf("Expected two pages, got: %d", len(gtServer.responses))
+	}
+	checkDirectories(t, gtServer.responses[0].GetDirectories(), expected[n/2:n])
+	checkDirectories(t, gtServer.responses[1].GetDirectories(), expected[n:])
+
+	// Cursors are removed once unused for the timeout, and their tokens are rejected
+	if removed := s.treeCursors.gc(time.Now().Add(DefaultTreeCursorTimeout + time.Second)); removed != 1 {
+		t.Fatalf("Expected the cursor to be removed, got: %d", removed)
+	}
+	if err := s.GetTree(req, &fakeGetTreeServer{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected status code %d, got: %v", codes.InvalidArgument, err)
 	}
 }
 
@@ -293,7 +687,7 @@ func TestMakeResultAddress(t *testing.T) {
 	knownHash := "fdc8c407bc2aa6d6cb514ace4299b2f414c4476a77123e3557dafd103d889124"
 	storeName := fmt.Sprintf("%s-%s.%s", bazel.StorePrefix, knownHash, bazel.StorePrefix)
 
-	resultAddr, err := makeCacheResultAddress(ad)
+	resultAddr, err := makeCacheResultAddress(bazel.SHA256, ad)
 	if err != nil {
 		t.Fatalf("Failed to create cache result address: %v", err)
 	}
@@ -313,7 +707,7 @@ func TestGetActionResult(t *testing.T) {
 
 	// Get ActionDigest and Write AR to underlying store using our result cache addressing convention
 	ad := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
-	address, err := makeCacheResultAddress(ad)
+	address, err := makeCacheResultAddress(bazel.SHA256, ad)
 	if err != nil {
 		t.Fatalf("Failed to create cache result adress: %v", err)
 	}
@@ -323,6 +717,12 @@ func TestGetActionResult(t *testing.T) {
 		t.Fatalf("Failed to write into FakeStore: %v", err)
 	}
 
+	// Write the outputs referenced by the AR, which all share a digest
+	err = f.Write(bazel.DigestStoreName(ad), bytes.NewReader(testData1), nil)
+	if err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+
 	// Make GetActionResult request
 	req := &remoteexecution.GetActionResultRequest{ActionDigest: ad}
 
@@ -367,6 +767,119 @@ func TestGetActionResultMissing(t *testing.T) {
 	}
 }
 
+func TestGetActionResultMissingOutputs(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
+
+	// Write an AR without writing the outputs it references
+	ad := writeFakeActionResult(t, f)
+	req := &remoteexecution.GetActionResultRequest{ActionDigest: ad}
+
+	// The AR is a miss, and stays one after its outputs are written as it was purged
+	for i := 0; i < 2; i++ {
+		_, err := s.GetActionResult(context.Background(), req)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("Expected NotFound for ActionResult with missing outputs, got: %v", err)
+		}
+		if err := f.Write(bazel.DigestStoreName(ad), bytes.NewReader(testData1), nil); err != nil {
+			t.Fatalf("Failed to write into FakeStore: %v", err)
+		}
+	}
+}
+
+func TestGetActionResultMissingOutputsAsync(t *testing.T) {
+	f := &store.FakeStore{}
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver(), actionCache: *DefaultActionCacheConfig()}
+	s.actionCache.OutputValidation = ValidateOutputsAsync
+	s.startValidationWorkers()
+
+	// Write an AR without writing the outputs it references
+	ad := writeFakeActionResult(t, f)
+	req := &remoteexecution.GetActionResultRequest{ActionDigest: ad}
+
+	// The AR is returned, and purged in the background
+	if _, err := s.GetActionResult(context.Background(), req); err != nil {
+		t.Fatalf("Error from GetActionResult: %v", err)
+	}
+	for i := 0; ; i++ {
+		_, err := s.GetActionResult(context.Background(), req)
+		if status.Code(err) == codes.NotFound {
+			break
+		} else if i == 100 {
+			t.Fatalf("Expected ActionResult with missing outputs to be purged, got: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestPurgeActionResult(t *testing.T) {
+	f := &store.FakeStore{}
+	// Serve through a cache that never replaces data, like groupcache
+	c := &immutableCacheStore{Store: f}
+	s := casServer{storeConfig: &store.StoreConfig{Store: c}, stat: stats.NilStatsReceiver(), actionCache: *DefaultActionCacheConfig()}
+	s.actionCache.OutputValidation = ValidateOutputsNone
+
+	ad := writeFakeActionResult(t, f)
+	req := &remoteexecution.GetActionResultRequest{ActionDigest: ad}
+	if _, err := s.GetActionResult(context.Background(), req); err != nil {
+		t.Fatalf("Error from GetActionResult: %v", err)
+	}
+
+	if err := PurgeActionResult(s.storeConfig, bazel.SHA256, ad); err != nil {
+		t.Fatalf("Error from PurgeActionResult: %v", err)
+	}
+	if _, err := s.GetActionResult(context.Background(), req); status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound for purged ActionResult, got: %v", err)
+	}
+
+	// A new result for the ActionDigest isn't purged
+	ar := &remoteexecution.ActionResult{ExitCode: int32(3)}
+	if _, err := s.UpdateActionResult(context.Background(), &remoteexecution.UpdateActionResultRequest{ActionDigest: ad, ActionResult: ar}); err != nil {
+		t.Fatalf("Error from UpdateActionResult: %v", err)
+	}
+	c.clear()
+	if res, err := s.GetActionResult(context.Background(), req); err != nil {
+		t.Fatalf("Error from GetActionResult: %v", err)
+	} else if res.GetExitCode() != ar.GetExitCode() {
+		t.Fatalf("Expected new ActionResult %v, got: %v", ar, res)
+	}
+
+	// Purging uncached results is a no-op
+	f = &store.FakeStore{}
+	if err := PurgeActionResult(&store.StoreConfig{Store: f}, bazel.SHA256, ad); err != nil {
+		t.Fatalf("Error from PurgeActionResult: %v", err)
+	}
+	if len(f.Files) != 0 {
+		t.Fatalf("Expected nothing written purging uncached ActionResult, got: %v", f.Files)
+	}
+}
+
+func TestGetActionResultValidationQueueFull(t *testing.T) {
+	f := &store.FakeStore{}
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: statsReceiver, actionCache: *DefaultActionCacheConfig()}
+	s.actionCache.OutputValidation = ValidateOutputsAsync
+	s.actionCache.AsyncValidationWorkers = 0
+	s.actionCache.AsyncValidationQueue = 1
+	s.startValidationWorkers()
+
+	// With no workers, hits beyond the queue size aren't validated but are still returned
+	ad := writeFakeActionResult(t, f)
+	req := &remoteexecution.GetActionResultRequest{ActionDigest: ad}
+	for i := 0; i < 3; i++ {
+		if _, err := s.GetActionResult(context.Background(), req); err != nil {
+			t.Fatalf("Error from GetActionResult: %v", err)
+		}
+	}
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.BzGetActionValidationDroppedCounter: {Checker: stats.Int64EqTest, Value: 2},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
 func TestUpdateActionResult(t *testing.T) {
 	f := &store.FakeStore{}
 	s := casServer{storeConfig: &store.StoreConfig{Store: f}, stat: stats.NilStatsReceiver()}
@@ -382,7 +895,7 @@ func TestUpdateActionResult(t *testing.T) {
 	}
 
 	// Read from underlying store
-	address, err := makeCacheResultAddress(ad)
+	address, err := makeCacheResultAddress(bazel.SHA256, ad)
 	if err != nil {
 		t.Fatalf("Failed to create cache result adress: %v", err)
 	}
@@ -416,6 +929,25 @@ type fakeReadServer struct {
 	grpc.ServerStream
 }
 
+func TestGetCapabilities(t *testing.T) {
+	s := casServer{storeConfig: &store.StoreConfig{Store: &store.FakeStore{}}, stat: stats.NilStatsReceiver()}
+
+	res, err := s.GetCapabilities(context.Background(), &remoteexecution.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("Error response from GetCapabilities: %v", err)
+	}
+	cc := res.GetCacheCapabilities()
+	if len(cc.GetDigestFunction()) != len(bazel.DigestFunctions) || cc.GetDigestFunction()[0] != remoteexecution.DigestFunction_SHA256 {
+		t.Fatalf("Unexpected digest functions: %s", cc.GetDigestFunction())
+	}
+	if !cc.GetActionCacheUpdateCapabilities().GetUpdateEnabled() {
+		t.Fatalf("Expected ActionCache updates to be enabled")
+	}
+	if cc.GetMaxBatchTotalSizeBytes() != MaxBatchReadSize {
+		t.Fatalf("Expected max batch size %d, got: %d", MaxBatchReadSize, cc.GetMaxBatchTotalSizeBytes())
+	}
+}
+
 func makeFakeReadServer() *fakeReadServer {
 	return &fakeReadServer{
 		buffer: new(bytes.Buffer),
@@ -446,6 +978,7 @@ type fakeWriteServer struct {
 	recvCount     int
 	offset        int64
 	committedSize int64
+	failAfter     int // if nonzero, Recv fails once this many chunks have been received
 	grpc.ServerStream
 }
 
@@ -465,7 +998,14 @@ func (s *fakeWriteServer) SendAndClose(res *bytestream.WriteResponse) error {
 	return nil
 }
 
+func (s *fakeWriteServer) Context() context.Context {
+	return context.Background()
+}
+
 func (s *fakeWriteServer) Recv() (*bytestream.WriteRequest, error) {
+	if s.failAfter > 0 && s.recvCount >= s.failAfter {
+		return nil, fmt.Errorf("Stream interrupted after %d chunks", s.recvCount)
+	}
 	// Format a WriteRequest based on the chunks requested and the offset of what has been recvd
 	chunkSize := int64(len(s.data) / s.recvChunks)
 	if s.recvCount+1 >= s.recvChunks {
@@ -486,6 +1026,23 @@ func (s *fakeWriteServer) Recv() (*bytestream.WriteRequest, error) {
 	return r, nil
 }
 
+// Write a fake ActionResult to the Store using our result cache addressing convention, returns its ActionDigest
+func writeFakeActionResult(t *testing.T, f *store.FakeStore) *remoteexecution.Digest {
+	arAsBytes, err := getFakeActionResult()
+	if err != nil {
+		t.Fatalf("Error getting ActionResult: %s", err)
+	}
+	ad := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+	address, err := makeCacheResultAddress(bazel.SHA256, ad)
+	if err != nil {
+		t.Fatalf("Failed to create cache result adress: %v", err)
+	}
+	if err := f.Write(address.storeName, bytes.NewReader(arAsBytes), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	return ad
+}
+
 // Serialize an ActionResult for placement in a Store for use in ActionCache testing
 func getFakeActionResult() ([]byte, error) {
 	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
@@ -507,12 +1064,93 @@ func getFakeActionResult() ([]byte, error) {
 	return arAsBytes, nil
 }
 
+func writeDirectory(t *testing.T, f *store.FakeStore, dir *remoteexecution.Directory) *remoteexecution.Digest {
+	b, err := proto.Marshal(dir)
+	if err != nil {
+		t.Fatalf("Failed to serialize Directory: %v", err)
+	}
+	d := &remoteexecution.Digest{Hash: fmt.Sprintf("%x", sha256.Sum256(b)), SizeBytes: int64(len(b))}
+	if err := f.Write(bazel.DigestStoreName(d), bytes.NewReader(b), nil); err != nil {
+		t.Fatalf("Failed to write into FakeStore: %v", err)
+	}
+	return d
+}
+
+func checkDirectories(t *testing.T, actual, expected []*remoteexecution.Directory) {
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d directories, got: %v", len(expected), actual)
+	}
+	for i := range actual {
+		if !proto.Equal(actual[i], expected[i]) {
+			t.Fatalf("Expected directory %d to be %s, got: %s", i, expected[i], actual[i])
+		}
+	}
+}
+
 // Fake GetTreeServer
 // Implements ContentAddressableStorage_GetTreeServer interface
 type fakeGetTreeServer struct {
 	grpc.ServerStream
+	responses []*remoteexecution.GetTreeResponse
+}
+
+func (s *fakeGetTreeServer) Send(res *remoteexecution.GetTreeResponse) error {
+	s.responses = append(s.responses, res)
+	return nil
+}
+
+// Store that caches data on first read or write and never replaces it, like groupcache
+type immutableCacheStore struct {
+	store.Store
+	cache map[string][]byte
+	mutex sync.Mutex
+}
+
+func (c *immutableCacheStore) OpenForRead(name string) (io.ReadCloser, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if b, ok := c.cache[name]; ok {
+		return ioutil.NopCloser(bytes.NewReader(b)), nil
+	}
+	r, err := c.Store.OpenForRead(name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c.add(name, b)
+	return ioutil.NopCloser(bytes.NewReader(b)), nil
 }
 
-func (s *fakeGetTreeServer) Send(*remoteexecution.GetTreeResponse) error {
+func (c *immutableCacheStore) Write(name string, data io.Reader, ttl *store.TTLValue) error {
+	b, err := ioutil.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	if err := c.Store.Write(name, bytes.NewReader(b), ttl); err != nil {
+		return err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.add(name, b)
 	return nil
 }
+
+func (c *immutableCacheStore) add(name string, b []byte) {
+	if c.cache == nil {
+		c.cache = make(map[string][]byte)
+	}
+	if _, ok := c.cache[name]; !ok {
+		c.cache[name] = b
+	}
+}
+
+// Evicts all cached data
+func (c *immutableCacheStore) clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.cache = nil
+}
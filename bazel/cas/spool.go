@@ -0,0 +1,231 @@
+package cas
+
+// Spools for in progress ByteStream Writes. Data is written to temp files rather than buffered
+// in memory, so that large Writes can be resumed from their committed size after a dropped connection.
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+
+	"github.com/twitter/scoot/bazel"
+)
+
+// Returned when a Write stream tries to use a spool that another stream is still writing to
+var errSpoolInUse = errors.New("Write with this UUID is already in progress")
+
+// Returned when spooling data would exceed the maximum size of all spools, even after evicting idle spools
+var errSpoolFull = errors.New("Write spools are full")
+
+// The spooled data of a Write, identified by the UUID in its resource name
+type writeSpool struct {
+	uuid      string
+	fn        *bazel.DigestFunction
+	digest    *remoteexecution.Digest
+	file      *os.File
+	committed int64     // bytes of data durably written to file
+	hash      hash.Hash // running hash of the committed data, for verification as data streams in
+	updated   time.Time // last time the spool was written to or released
+	active    bool      // true while a Write stream owns the spool
+}
+
+// Tracks the spools of in progress Writes and which digests are currently being written.
+// Spools that haven't been updated within the timeout are removed by gc.
+// The data of all spools is limited to maxSize, idle spools are evicted oldest first to make room.
+type writeSpools struct {
+	dir     string
+	timeout time.Duration
+	maxSize int64
+	size    int64 // bytes of data committed or being appended to all spools
+	spools  map[string]*writeSpool
+	digests map[string]chan struct{} // store name to a chan closed when the Write of that digest ends
+	mutex   sync.Mutex
+}
+
+// Makes writeSpools that store up to maxSize bytes of spooled data in dir, or the default temp dir if dir is empty
+func newWriteSpools(dir string, timeout time.Duration, maxSize int64) *writeSpools {
+	return &writeSpools{
+		dir:     dir,
+		timeout: timeout,
+		maxSize: maxSize,
+		spools:  make(map[string]*writeSpool),
+		digests: make(map[string]chan struct{}),
+	}
+}
+
+// Claims the digest with the specified store name for a single Write stream.
+// If no other stream is writing it, returns a nil chan and a func that must be called
+// to release the claim when the stream ends. Otherwise, returns a chan that is closed
+// when the other stream ends, after which the caller should check the Store and try again.
+func (w *writeSpools) claimDigest(storeName string) (<-chan struct{}, func()) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if wait, ok := w.digests[storeName]; ok {
+		return wait, nil
+	}
+
+	done := make(chan struct{})
+	w.digests[storeName] = done
+	return nil, func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		delete(w.digests, storeName)
+		close(done)
+	}
+}
+
+// Gets the spool for a Write with the specified UUID and digest for use by a single stream,
+// creating the spool if the Write is new. The spool must be released once the stream ends.
+func (w *writeSpools) acquire(uuid string, fn *bazel.DigestFunction, digest *remoteexecution.Digest) (*writeSpool, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if sp, ok := w.spools[uuid]; ok {
+		if sp.active {
+			return nil, errSpoolInUse
+		}
+		if sp.fn != fn || bazel.DigestToStr(sp.digest) != bazel.DigestToStr(digest) {
+			return nil, fmt.Errorf("Write with UUID %s is for %s digest %s, not %s digest %s",
+				uuid, sp.fn.Name, bazel.DigestToStr(sp.digest), fn.Name, bazel.DigestToStr(digest))
+		}
+		sp.active = true
+		return sp, nil
+	}
+
+	f, err := ioutil.TempFile(w.dir, fmt.Sprintf("cas-write-%s-", uuid))
+	if err != nil {
+		return nil, err
+	}
+	sp := &writeSpool{
+		uuid:    uuid,
+		fn:      fn,
+		digest:  digest,
+		file:    f,
+		hash:    fn.New(),
+		updated: time.Now(),
+		active:  true,
+	}
+	w.spools[uuid] = sp
+	return sp, nil
+}
+
+// Appends data to an acquired spool, updating its committed size once the data is written.
+// Data is written at the committed size, so a partially failed append is overwritten on resumption.
+// Returns errSpoolFull if there's no room for the data.
+func (w *writeSpools) append(sp *writeSpool, data []byte) error {
+	if err := w.reserve(int64(len(data))); err != nil {
+		return err
+	}
+	if _, err := sp.file.WriteAt(data, sp.committed); err != nil {
+		w.mutex.Lock()
+		w.size -= int64(len(data))
+		w.mutex.Unlock()
+		return err
+	}
+	sp.hash.Write(data)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	sp.committed += int64(len(data))
+	sp.updated = time.Now()
+	return nil
+}
+
+// Reserves room for n bytes of data, evicting the least recently updated idle spools if needed.
+func (w *writeSpools) reserve(n int64) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for w.size+n > w.maxSize {
+		var oldest *writeSpool
+		for _, sp := range w.spools {
+			if !sp.active && (oldest == nil || sp.updated.Before(oldest.updated)) {
+				oldest = sp
+			}
+		}
+		if oldest == nil {
+			return errSpoolFull
+		}
+		log.Infof("Evicting spool for idle Write %s of %s to make room, %d bytes committed",
+			oldest.uuid, bazel.DigestToStr(oldest.digest), oldest.committed)
+		w.remove(oldest)
+	}
+	w.size += n
+	return nil
+}
+
+// Returns the hex encoded hash of the data committed to the spool
+func (sp *writeSpool) sum() string {
+	return fmt.Sprintf("%x", sp.hash.Sum(nil))
+}
+
+// Releases an acquired spool. If the Write is done, successfully or not, the spool is removed.
+// Otherwise it's kept so that the Write can be resumed until it times out.
+func (w *writeSpools) release(sp *writeSpool, done bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	sp.active = false
+	sp.updated = time.Now()
+	if done {
+		w.remove(sp)
+	}
+}
+
+// Returns the committed size of the Write with the specified UUID and digest,
+// and false if there's no such Write in progress.
+func (w *writeSpools) status(uuid string, fn *bazel.DigestFunction, digest *remoteexecution.Digest) (int64, bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	sp, ok := w.spools[uuid]
+	if !ok || sp.fn != fn || bazel.DigestToStr(sp.digest) != bazel.DigestToStr(digest) {
+		return 0, false
+	}
+	return sp.committed, true
+}
+
+// Removes spools that aren't in use and haven't been updated within the timeout.
+// Returns the number of spools removed.
+func (w *writeSpools) gc(now time.Time) int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	removed := 0
+	for _, sp := range w.spools {
+		if !sp.active && now.Sub(sp.updated) > w.timeout {
+			log.Infof("Removing spool for abandoned Write %s of %s, %d bytes committed",
+				sp.uuid, bazel.DigestToStr(sp.digest), sp.committed)
+			w.remove(sp)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Periodically runs gc until stop is closed
+func (w *writeSpools) gcLoop(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			w.gc(now)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Deletes the spool and its data, the caller must hold the mutex.
+func (w *writeSpools) remove(sp *writeSpool) {
+	delete(w.spools, sp.uuid)
+	w.size -= sp.committed
+	sp.file.Close()
+	if err := os.Remove(sp.file.Name()); err != nil {
+		log.Errorf("Failed to remove spool file %s: %v", sp.file.Name(), err)
+	}
+}
@@ -0,0 +1,115 @@
+package cas
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+
+	"github.com/twitter/scoot/bazel"
+)
+
+func TestWriteSpoolsGC(t *testing.T) {
+	w := newWriteSpools("", time.Minute, DefaultWriteSpoolMaxSize)
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+
+	active, err := w.acquire("active", bazel.SHA256, d)
+	if err != nil {
+		t.Fatalf("Failed to acquire spool: %v", err)
+	}
+	idle, err := w.acquire("idle", bazel.SHA256, d)
+	if err != nil {
+		t.Fatalf("Failed to acquire spool: %v", err)
+	}
+	if err := w.append(idle, testData1); err != nil {
+		t.Fatalf("Failed to append to spool: %v", err)
+	}
+	w.release(idle, false)
+
+	if _, err := w.acquire("active", bazel.SHA256, d); err != errSpoolInUse {
+		t.Fatalf("Expected %v acquiring active spool, got: %v", errSpoolInUse, err)
+	}
+
+	// Nothing has timed out yet
+	if n := w.gc(time.Now()); n != 0 {
+		t.Fatalf("Expected no spools removed, got %d", n)
+	}
+	if c, ok := w.status("idle", bazel.SHA256, d); !ok || c != testSize1 {
+		t.Fatalf("Expected idle spool with %d bytes committed, got %d, %t", testSize1, c, ok)
+	}
+
+	// Only the idle spool is removed after the timeout
+	if n := w.gc(time.Now().Add(2 * time.Minute)); n != 1 {
+		t.Fatalf("Expected 1 spool removed, got %d", n)
+	}
+	if _, ok := w.status("idle", bazel.SHA256, d); ok {
+		t.Fatal("Expected idle spool to be removed")
+	}
+	if _, err := os.Stat(idle.file.Name()); !os.IsNotExist(err) {
+		t.Fatalf("Expected idle spool file to be removed, got: %v", err)
+	}
+	if _, ok := w.status("active", bazel.SHA256, d); !ok {
+		t.Fatal("Expected active spool to be kept")
+	}
+
+	w.release(active, true)
+	if len(w.spools) != 0 {
+		t.Fatalf("Expected no spools, got %d", len(w.spools))
+	}
+}
+
+func TestWriteSpoolsMaxSize(t *testing.T) {
+	w := newWriteSpools("", time.Minute, 2*testSize1)
+	d := &remoteexecution.Digest{Hash: testHash1, SizeBytes: testSize1}
+
+	idle, err := w.acquire("idle", bazel.SHA256, d)
+	if err != nil {
+		t.Fatalf("Failed to acquire spool: %v", err)
+	}
+	if err := w.append(idle, testData1); err != nil {
+		t.Fatalf("Failed to append to spool: %v", err)
+	}
+	w.release(idle, false)
+
+	active, err := w.acquire("active", bazel.SHA256, d)
+	if err != nil {
+		t.Fatalf("Failed to acquire spool: %v", err)
+	}
+	if err := w.append(active, testData1); err != nil {
+		t.Fatalf("Failed to append to spool: %v", err)
+	}
+
+	// The idle spool is evicted to make room, then appends that don't fit are rejected
+	if err := w.append(active, testData1[:1]); err != nil {
+		t.Fatalf("Failed to append to spool: %v", err)
+	}
+	if _, ok := w.status("idle", bazel.SHA256, d); ok {
+		t.Fatal("Expected idle spool to be evicted")
+	}
+	if err := w.append(active, testData1); err != errSpoolFull {
+		t.Fatalf("Expected %v appending to full spools, got: %v", errSpoolFull, err)
+	}
+
+	w.release(active, true)
+	if w.size != 0 {
+		t.Fatalf("Expected no spooled data, got %d bytes", w.size)
+	}
+}
+
+func TestWriteSpoolsGCLoopStop(t *testing.T) {
+	w := newWriteSpools("", time.Minute, DefaultWriteSpoolMaxSize)
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		w.gcLoop(time.Millisecond, stop)
+		close(stopped)
+	}()
+
+	close(stop)
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected gcLoop to return once stopped")
+	}
+}
@@ -0,0 +1,127 @@
+package cas
+
+// Cursors of GetTree traversals whose frontier is too wide to fit in a page token.
+// The frontier is kept server-side and the page token only holds a short id for it.
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/nu7hatch/gouuid"
+	log "github.com/sirupsen/logrus"
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+// Prefix of page tokens that refer to a server-side cursor rather than listing the frontier
+const treeCursorTokenPrefix = "cursor:"
+
+// Returned when a frontier doesn't fit in the cursors even after evicting all others
+var errTreeCursorsFull = errors.New("GetTree cursors are full")
+
+// The queued Directories of a GetTree traversal, identified by the id in its page token
+type treeCursor struct {
+	id      string
+	digests []*remoteexecution.Digest
+	updated time.Time // last time the cursor was stored or read
+}
+
+// Tracks the cursors of GetTree traversals, which are removed by gc once they haven't been
+// read within the timeout. The digests of all cursors are limited to maxDigests, the least
+// recently used cursors are evicted to make room.
+type treeCursors struct {
+	timeout    time.Duration
+	maxDigests int
+	size       int // number of digests in all cursors
+	cursors    map[string]*treeCursor
+	mutex      sync.Mutex
+}
+
+func newTreeCursors(timeout time.Duration, maxDigests int) *treeCursors {
+	return &treeCursors{
+		timeout:    timeout,
+		maxDigests: maxDigests,
+		cursors:    make(map[string]*treeCursor),
+	}
+}
+
+// Stores the digests of a frontier and returns the page token to resume from them.
+// Returns errTreeCursorsFull if the frontier alone exceeds maxDigests.
+func (c *treeCursors) put(digests []*remoteexecution.Digest) (string, error) {
+	if len(digests) > c.maxDigests {
+		return "", errTreeCursorsFull
+	}
+	uid, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.size+len(digests) > c.maxDigests {
+		var oldest *treeCursor
+		for _, tc := range c.cursors {
+			if oldest == nil || tc.updated.Before(oldest.updated) {
+				oldest = tc
+			}
+		}
+		log.Infof("Evicting GetTree cursor %s of %d Directories to make room", oldest.id, len(oldest.digests))
+		c.remove(oldest)
+	}
+	tc := &treeCursor{id: uid.String(), digests: digests, updated: time.Now()}
+	c.cursors[tc.id] = tc
+	c.size += len(digests)
+	return treeCursorTokenPrefix + tc.id, nil
+}
+
+// Returns the digests of the frontier for a page token from put, and false if the token
+// isn't a cursor token or its cursor has been removed. The cursor is kept so the page can be retried.
+func (c *treeCursors) get(token string) ([]*remoteexecution.Digest, bool) {
+	if !strings.HasPrefix(token, treeCursorTokenPrefix) {
+		return nil, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	tc, ok := c.cursors[strings.TrimPrefix(token, treeCursorTokenPrefix)]
+	if !ok {
+		return nil, false
+	}
+	tc.updated = time.Now()
+	return tc.digests, true
+}
+
+// Removes cursors that haven't been used within the timeout.
+// Returns the number of cursors removed.
+func (c *treeCursors) gc(now time.Time) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	removed := 0
+	for _, tc := range c.cursors {
+		if now.Sub(tc.updated) > c.timeout {
+			c.remove(tc)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Periodically runs gc until stop is closed
+func (c *treeCursors) gcLoop(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			c.gc(now)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// Deletes the cursor, the caller must hold the mutex.
+func (c *treeCursors) remove(tc *treeCursor) {
+	delete(c.cursors, tc.id)
+	c.size -= len(tc.digests)
+}
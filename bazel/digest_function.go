@@ -0,0 +1,117 @@
+package bazel
+
+// Digest functions supported by Bazel servers
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"lukechampine.com/blake3"
+)
+
+// A hash function used to compute Digests. Digests don't identify the function that computed them,
+// so clients specify it in requests and resource names, and data is stored under names namespaced
+// by function so that Digests with equal hashes from different functions don't collide.
+type DigestFunction struct {
+	Name      string // lowercase name, as used in resource names and store names
+	Proto     remoteexecution.DigestFunction
+	EmptyHash string // hash of empty data, which is never written to Stores
+	New       func() hash.Hash
+}
+
+var (
+	SHA256 = &DigestFunction{
+		Name:      "sha256",
+		Proto:     remoteexecution.DigestFunction_SHA256,
+		EmptyHash: EmptySha,
+		New:       sha256.New,
+	}
+	SHA1 = &DigestFunction{
+		Name:      "sha1",
+		Proto:     remoteexecution.DigestFunction_SHA1,
+		EmptyHash: "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+		New:       sha1.New,
+	}
+	BLAKE3 = &DigestFunction{
+		Name:      "blake3",
+		Proto:     remoteexecution.DigestFunction_BLAKE3,
+		EmptyHash: "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262",
+		New:       func() hash.Hash { return blake3.New(32, nil) },
+	}
+
+	// All DigestFunctions supported by CAS servers, the first is the default
+	DigestFunctions = []*DigestFunction{SHA256, SHA1, BLAKE3}
+
+	// The only DigestFunction supported by execution servers. Workers check out input roots and
+	// ingest outputs with fs_util, which only computes SHA256 digests, and Bazel SnapshotIDs don't
+	// record a function. Actions using other functions can't be executed, only cached.
+	ExecutionDigestFunction = SHA256
+)
+
+// Returns the DigestFunction specified by a request's digest_function field.
+// Requests that don't specify a function use SHA256, the default before the field existed.
+func DigestFunctionFromProto(fn remoteexecution.DigestFunction) (*DigestFunction, error) {
+	if fn == remoteexecution.DigestFunction_UNKNOWN {
+		return SHA256, nil
+	}
+	for _, f := range DigestFunctions {
+		if f.Proto == fn {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("Unsupported digest function %s", fn)
+}
+
+// Returns the DigestFunction with the lowercase name, as used in resource names
+func DigestFunctionFromName(name string) (*DigestFunction, bool) {
+	for _, f := range DigestFunctions {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Returns the protobuf enums of all supported DigestFunctions, to advertise to clients
+func SupportedDigestFunctions() []remoteexecution.DigestFunction {
+	fns := []remoteexecution.DigestFunction{}
+	for _, f := range DigestFunctions {
+		fns = append(fns, f.Proto)
+	}
+	return fns
+}
+
+// Length of hex encoded hashes computed by this function
+func (f *DigestFunction) HashLen() int {
+	return 2 * f.New().Size()
+}
+
+// Validate Digest hash and size components for this function.
+// Size -1 indicates unknown size, see IsValidDigest
+func (f *DigestFunction) IsValidDigest(hash string, size int64) bool {
+	return len(hash) == f.HashLen() && size >= -1
+}
+
+// Returns true if the Digest is of empty data
+func (f *DigestFunction) IsEmpty(digest *remoteexecution.Digest) bool {
+	return digest.GetHash() == f.EmptyHash
+}
+
+// Returns the hex encoded hash of data
+func (f *DigestFunction) Hash(data []byte) string {
+	h := f.New()
+	h.Write(data)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// Translate a Digest computed by this function into a unique resource name for use in a bundleStore.
+// SHA256 names aren't namespaced, for compatibility with data stored before other functions were supported.
+func (f *DigestFunction) StoreName(digest *remoteexecution.Digest) string {
+	if f == SHA256 || digest == nil {
+		return DigestStoreName(digest)
+	}
+	return fmt.Sprintf("%s-%s-%s.%s", StorePrefix, f.Name, digest.GetHash(), StorePrefix)
+}
@@ -0,0 +1,46 @@
+package bazel
+
+import (
+	"fmt"
+	"testing"
+
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+)
+
+func TestDigestFunctionEmptyHash(t *testing.T) {
+	for _, fn := range DigestFunctions {
+		if h := fn.Hash([]byte{}); h != fn.EmptyHash {
+			t.Fatalf("Wrong %s empty hash, expected: %s, got: %s", fn.Name, fn.EmptyHash, h)
+		}
+		if !fn.IsValidDigest(fn.EmptyHash, EmptySize) {
+			t.Fatalf("Expected %s empty hash to be valid", fn.Name)
+		}
+	}
+}
+
+func TestDigestFunctionFromProto(t *testing.T) {
+	if fn, err := DigestFunctionFromProto(remoteexecution.DigestFunction_UNKNOWN); err != nil || fn != SHA256 {
+		t.Fatalf("Expected SHA256 by default, got: %v, %v", fn, err)
+	}
+	if fn, err := DigestFunctionFromProto(remoteexecution.DigestFunction_SHA1); err != nil || fn != SHA1 {
+		t.Fatalf("Expected SHA1, got: %v, %v", fn, err)
+	}
+	if fn, err := DigestFunctionFromProto(remoteexecution.DigestFunction_BLAKE3); err != nil || fn != BLAKE3 {
+		t.Fatalf("Expected BLAKE3, got: %v, %v", fn, err)
+	}
+	if _, err := DigestFunctionFromProto(remoteexecution.DigestFunction_MD5); err == nil {
+		t.Fatalf("Expected error for unsupported digest function MD5")
+	}
+}
+
+func TestDigestFunctionStoreName(t *testing.T) {
+	d := &remoteexecution.Digest{Hash: EmptySha, SizeBytes: 123}
+	if n := SHA256.StoreName(d); n != DigestStoreName(d) {
+		t.Fatalf("Expected SHA256 store name to match DigestStoreName, got: %s", n)
+	}
+
+	expected := fmt.Sprintf("%s-blake3-%s.%s", StorePrefix, EmptySha, StorePrefix)
+	if n := BLAKE3.StoreName(d); n != expected {
+		t.Fatalf("Wrong digest store name, expected: %s, got: %s", expected, n)
+	}
+}
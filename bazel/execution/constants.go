@@ -3,4 +3,12 @@ package execution
 const (
 	TaskIDPrefix   = "Bazel_ExecuteRequest"
 	CommandDefault = "BZ_PLACEHOLDER"
+
+	// ListOperations page sizes used when the request's page size is unset or too large
+	DefaultListOperationsPageSize = 100
+	MaxListOperationsPageSize     = 1000
+
+	// Maximum number of jobs ListOperations reads the sagas of for a page, pages of jobs that mostly
+	// don't match the filter end early with a page token to continue from
+	MaxListOperationsScanned = 1000
 )
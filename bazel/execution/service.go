@@ -5,6 +5,7 @@ package execution
 import (
 	"fmt"
 	"net"
+	"sort"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	log "github.com/sirupsen/logrus"
@@ -16,6 +17,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/twitter/scoot/bazel"
 	"github.com/twitter/scoot/bazel/execution/bazelapi"
 	"github.com/twitter/scoot/common/grpchelpers"
 	loghelpers "github.com/twitter/scoot/common/log/helpers"
@@ -23,10 +25,12 @@ import (
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/scootapi/server/api"
 )
 
-// Implements GRPCServer, remoteexecution.ExecutionServer, and longrunning.OperationsServer interfaces
+// Implements GRPCServer, remoteexecution.ExecutionServer, longrunning.OperationsServer,
+// and remoteexecution.CapabilitiesServer interfaces
 type executionServer struct {
 	listener  net.Listener
 	sagaCoord saga.SagaCoordinator
@@ -35,7 +39,7 @@ type executionServer struct {
 	stat      stats.StatsReceiver
 }
 
-// Creates a new GRPCServer (executionServer) based on a listener, and preregisters the service
+// Creates a new GRPCServer (executionServer) based on a listener, and preregisters the services
 func MakeExecutionServer(l net.Listener, s scheduler.Scheduler, stat stats.StatsReceiver) *executionServer {
 	g := executionServer{
 		listener:  l,
@@ -46,6 +50,7 @@ func MakeExecutionServer(l net.Listener, s scheduler.Scheduler, stat stats.Stats
 	}
 	remoteexecution.RegisterExecutionServer(g.server, &g)
 	longrunning.RegisterOperationsServer(g.server, &g)
+	remoteexecution.RegisterCapabilitiesServer(g.server, &g)
 	return &g
 }
 
@@ -67,8 +72,8 @@ func (s *executionServer) Serve() error {
 
 // Takes an ExecuteRequest and forms an ExecuteResponse that is returned as part of a
 // google LongRunning Operation message via a stream.
-// By convention, we will not reuse this stream and the client should
-// continue to use GetOperation polling to determine execution state.
+// The stream is kept open and an updated Operation is sent each time the job's execution stage
+// changes, ending with the completed Operation carrying the ExecuteResponse.
 func (s *executionServer) Execute(
 	req *remoteexecution.ExecuteRequest, execServer remoteexecution.Execution_ExecuteServer) error {
 	log.Debugf("Received Execute request: %s", req)
@@ -77,6 +82,24 @@ func (s *executionServer) Execute(
 		return status.Error(codes.Internal, "Server not initialized")
 	}
 	s.stat.Counter(stats.BzExecRequestCounter).Inc(1)
+
+	id, err := s.acceptExecute(req, execServer)
+	if err != nil {
+		return err
+	}
+
+	if err := s.streamOperation(id, remoteexecution.ExecuteOperationMetadata_QUEUED, execServer); err != nil {
+		return err
+	}
+
+	log.Debug("ExecuteRequest completed successfully")
+	return nil
+}
+
+// Schedules the request as a Scoot job and sends its initial Operation on the stream, returning the job's id.
+// Only the time spent accepting the request is recorded, not the time spent waiting on the job.
+func (s *executionServer) acceptExecute(
+	req *remoteexecution.ExecuteRequest, execServer remoteexecution.Execution_ExecuteServer) (string, error) {
 	defer s.stat.Latency(stats.BzExecRequestLatency_ms).Time().Stop()
 
 	// Transform ExecuteRequest into Scoot Job, validate and schedule
@@ -84,19 +107,19 @@ func (s *executionServer) Execute(
 	job, err := execReqToScoot(req)
 	if err != nil {
 		log.Errorf("Failed to convert request to Scoot JobDefinition: %s", err)
-		return status.Error(codes.InvalidArgument, fmt.Sprintf("Error converting request to internal definition: %s", err))
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("Error converting request to internal definition: %s", err))
 	}
 
 	err = sched.ValidateJob(job)
 	if err != nil {
 		log.Errorf("Scoot Job generated from request invalid: %s", err)
-		return status.Error(codes.Internal, fmt.Sprintf("Internal job definition invalid: %s", err))
+		return "", status.Error(codes.Internal, fmt.Sprintf("Internal job definition invalid: %s", err))
 	}
 
 	id, err := s.scheduler.ScheduleJob(job)
 	if err != nil {
 		log.Errorf("Failed to schedule Scoot job: %s", err)
-		return status.Error(codes.Internal, fmt.Sprintf("Failed to schedule Scoot job: %s", err))
+		return "", status.Error(codes.Internal, fmt.Sprintf("Failed to schedule Scoot job: %s", err))
 	}
 	log.WithFields(
 		log.Fields{
@@ -111,7 +134,7 @@ func (s *executionServer) Execute(
 	// Marshal ExecuteActionMetadata to protobuf.Any format
 	eomAsPBAny, err := marshalAny(eom)
 	if err != nil {
-		return status.Error(codes.Internal, err.Error())
+		return "", status.Error(codes.Internal, err.Error())
 	}
 
 	// Include the response message in the longrunning operation message
@@ -124,17 +147,30 @@ func (s *executionServer) Execute(
 	// Send the initial operation on the exec server stream
 	err = execServer.Send(op)
 	if err != nil {
-		return status.Error(codes.Internal, err.Error())
+		return "", status.Error(codes.Internal, err.Error())
 	}
-
-	log.Debug("ExecuteRequest completed successfully")
-	return nil
+	return id, nil
 }
 
+// Takes a WaitExecutionRequest for an existing operation and streams its current state,
+// followed by an updated Operation each time the job's execution stage changes,
+// ending with the completed Operation carrying the ExecuteResponse.
 func (s *executionServer) WaitExecution(
-	*remoteexecution.WaitExecutionRequest,
-	remoteexecution.Execution_WaitExecutionServer) error {
-	return status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+	req *remoteexecution.WaitExecutionRequest,
+	waitServer remoteexecution.Execution_WaitExecutionServer) error {
+	log.Debugf("Received WaitExecution request: %s", req)
+
+	if !s.IsInitialized() {
+		return status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzWaitExecRequestCounter).Inc(1)
+
+	if err := s.streamOperation(req.GetName(), remoteexecution.ExecuteOperationMetadata_UNKNOWN, waitServer); err != nil {
+		return err
+	}
+
+	log.Debug("WaitExecutionRequest completed successfully")
+	return nil
 }
 
 // Google LongRunning APIs
@@ -159,6 +195,198 @@ func (s *executionServer) GetOperation(
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
+	op, err := makeOperation(req.Name, rs)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	log.Debug("GetOperationRequest completed successfully")
+	return op, nil
+}
+
+// Takes a ListOperations request and lists in-flight operations, i.e. the scheduler's in progress jobs,
+// and recently completed ones, see scheduler.MaxCompletedJobIds.
+// Only jobs created from an ExecuteRequest are listed, ordered by operation name.
+// See parseOperationsFilter for the supported filter format. The page token is the name of the
+// last operation considered for the previous page, which may have fewer operations than the page size
+// if few match the filter, see MaxListOperationsScanned.
+func (s *executionServer) ListOperations(
+	_ context.Context,
+	req *longrunning.ListOperationsRequest) (*longrunning.ListOperationsResponse, error) {
+	log.Debugf("Received ListOperations request: %v", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzListOpsRequestCounter).Inc(1)
+	defer s.stat.Latency(stats.BzListOpsRequestLatency_ms).Time().Stop()
+
+	filter, err := parseOperationsFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = DefaultListOperationsPageSize
+	} else if pageSize > MaxListOperationsPageSize {
+		pageSize = MaxListOperationsPageSize
+	}
+
+	ids := listedJobIds(s.scheduler.GetInProgressJobIds(), s.scheduler.GetCompletedJobIds())
+	start := sort.SearchStrings(ids, req.GetPageToken())
+	if start < len(ids) && ids[start] == req.GetPageToken() {
+		start++
+	}
+
+	res := &longrunning.ListOperationsResponse{}
+	for i, id := range ids[start:] {
+		if len(res.Operations) == pageSize {
+			res.NextPageToken = res.Operations[pageSize-1].GetName()
+			break
+		} else if i == MaxListOperationsScanned {
+			res.NextPageToken = ids[start+i-1]
+			break
+		}
+
+		state, err := s.sagaCoord.GetSagaState(id)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		// The saga may have been removed from the log since we listed it.
+		if state == nil {
+			continue
+		}
+		job, err := sched.DeserializeJob(state.Job())
+		if err != nil {
+			log.Errorf("Failed to deserialize job for operation %s: %s", id, err)
+			continue
+		}
+		execReq := scootToExecReq(job)
+		if execReq == nil {
+			continue
+		}
+
+		rs, err := runStatusFromSagaState(state)
+		if err != nil {
+			log.Errorf("Failed to get run status for operation %s: %s", id, err)
+			continue
+		}
+		if !filter.matches(runStatusToExecuteOperationMetadata_Stage(rs), job.Def.Requestor, execReq.GetActionDigest()) {
+			continue
+		}
+
+		op, err := makeOperation(id, rs)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		res.Operations = append(res.Operations, op)
+	}
+
+	log.Debug("ListOperationsRequest completed successfully")
+	return res, nil
+}
+
+// Takes a DeleteOperation request and kills the underlying Scoot job if it's still running,
+// as the client is no longer interested in its result. Scoot doesn't discard the results of
+// finished operations, so deleting one is a no-op.
+func (s *executionServer) DeleteOperation(
+	_ context.Context,
+	req *longrunning.DeleteOperationRequest) (*empty.Empty, error) {
+	log.Debugf("Received DeleteOperation request: %v", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzDeleteOpRequestCounter).Inc(1)
+	defer s.stat.Latency(stats.BzDeleteOpRequestLatency_ms).Time().Stop()
+
+	if err := s.killOperation(req.Name); err != nil && status.Code(err) != codes.FailedPrecondition {
+		return nil, err
+	}
+
+	log.Debug("DeleteOperationRequest completed successfully")
+	return &empty.Empty{}, nil
+}
+
+// Takes a CancelOperation request and kills the underlying Scoot job.
+// Returns NotFound for unknown operations and FailedPrecondition for operations that have already finished.
+func (s *executionServer) CancelOperation(
+	_ context.Context,
+	req *longrunning.CancelOperationRequest) (*empty.Empty, error) {
+	log.Debugf("Received CancelOperation request: %v", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzCancelOpRequestCounter).Inc(1)
+	defer s.stat.Latency(stats.BzCancelOpRequestLatency_ms).Time().Stop()
+
+	if err := s.killOperation(req.Name); err != nil {
+		return nil, err
+	}
+
+	log.Debug("CancelOperationRequest completed successfully")
+	return &empty.Empty{}, nil
+}
+
+// Capabilities APIs
+
+// GetCapabilities reports the digest function and priority range supported for execution.
+// Cache capabilities are reported by the CAS server, which supports more digest functions than
+// execution does: only bazel.ExecutionDigestFunction can be used for actions that are executed.
+func (s *executionServer) GetCapabilities(
+	ctx context.Context,
+	req *remoteexecution.GetCapabilitiesRequest) (*remoteexecution.ServerCapabilities, error) {
+	log.Debugf("Received GetCapabilities request: %s", req)
+
+	if !s.IsInitialized() {
+		return nil, status.Error(codes.Internal, "Server not initialized")
+	}
+	s.stat.Counter(stats.BzExecGetCapabilitiesRequestCounter).Inc(1)
+
+	return &remoteexecution.ServerCapabilities{
+		ExecutionCapabilities: &remoteexecution.ExecutionCapabilities{
+			DigestFunction: bazel.ExecutionDigestFunction.Proto,
+			ExecEnabled:    true,
+			ExecutionPriorityCapabilities: &remoteexecution.PriorityCapabilities{
+				Priorities: []*remoteexecution.PriorityCapabilities_PriorityRange{execPriorityRange()},
+			},
+		},
+	}, nil
+}
+
+// Internal functions
+
+// Kills the Scoot job backing the named operation, returning a grpc status error if it can't be killed.
+func (s *executionServer) killOperation(jobID string) error {
+	state, err := s.sagaCoord.GetSagaState(jobID)
+	if err != nil {
+		if _, ok := err.(saga.InvalidRequestError); ok {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	if state == nil {
+		return status.Error(codes.NotFound, fmt.Sprintf("Operation %s not found", jobID))
+	}
+	if state.IsSagaCompleted() {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Operation %s already finished", jobID))
+	}
+
+	// The job may finish or be killed by someone else between the status check and the kill request.
+	if err := s.scheduler.KillJob(jobID); err != nil {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("Failed to kill operation %s: %s", jobID, err))
+	}
+	log.WithFields(
+		log.Fields{
+			"jobID": jobID,
+		}).Info("Killed Scoot job for operation")
+	return nil
+}
+
+// Forms a google LongRunning Operation from the run status of the named operation's task.
+// If the run is done, the Operation's Result contains an ExecuteResponse.
+func makeOperation(name string, rs *runStatus) (*longrunning.Operation, error) {
 	actionResult := bazelapi.MakeActionResultDomainFromThrift(rs.GetBazelResult())
 
 	eom := &remoteexecution.ExecuteOperationMetadata{
@@ -169,14 +397,14 @@ func (s *executionServer) GetOperation(
 	// Marshal ExecuteActionMetadata to protobuf.Any format
 	eomAsPBAny, err := marshalAny(eom)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, err
 	}
 
 	isDone := runStatusToDoneBool(rs)
-	op := longrunning.Operation{
-		Name:     req.Name,
+	op := &longrunning.Operation{
+		Name:     name,
 		Metadata: eomAsPBAny,
-		Done:     runStatusToDoneBool(rs),
+		Done:     isDone,
 	}
 
 	// If done, create ExecuteResponse in protobuf.Any format and include in Operation.Result.
@@ -196,40 +424,111 @@ func (s *executionServer) GetOperation(
 		}
 		resAsPBAny, err := marshalAny(res)
 		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+			return nil, err
 		}
 		op.Result = &longrunning.Operation_Response{
 			Response: resAsPBAny,
 		}
 	}
-
-	log.Debug("GetOperationRequest completed successfully")
-	return &op, nil
+	return op, nil
 }
 
-func (s *executionServer) ListOperations(context.Context, *longrunning.ListOperationsRequest) (*longrunning.ListOperationsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+// An Execute or WaitExecution server stream
+type operationStream interface {
+	Send(*longrunning.Operation) error
+	Context() context.Context
 }
 
-// TODO hook up to Job Kill API
-func (s *executionServer) DeleteOperation(context.Context, *longrunning.DeleteOperationRequest) (*empty.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+// Sends the current Operation for the named job on the stream, and then an updated
+// Operation every time the execution stage changes until the job is done.
+// Operations in lastStage aren't sent until the stage changes.
+// Updates are driven by changes to the job's saga rather than by polling the SagaLog.
+func (s *executionServer) streamOperation(
+	jobID string, lastStage remoteexecution.ExecuteOperationMetadata_Stage, stream operationStream) error {
+	// Watch before reading the current state so that no updates are missed in between.
+	updateCh, stopWatching := s.sagaCoord.WatchSagaState(jobID)
+	defer stopWatching()
+
+	state, err := s.sagaCoord.GetSagaState(jobID)
+	if err != nil {
+		if _, ok := err.(saga.InvalidRequestError); ok {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	if state == nil {
+		return status.Error(codes.NotFound, fmt.Sprintf("Operation %s not found", jobID))
+	}
+
+	for {
+		rs, err := runStatusFromSagaState(state)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		stage := runStatusToExecuteOperationMetadata_Stage(rs)
+		isDone := runStatusToDoneBool(rs)
+		if stage != lastStage || isDone {
+			op, err := makeOperation(jobID, rs)
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			if err := stream.Send(op); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+			lastStage = stage
+		}
+		if isDone || state.IsSagaCompleted() {
+			return nil
+		}
+
+		select {
+		case state = <-updateCh:
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, stream.Context().Err().Error())
+		}
+	}
 }
 
-func (s *executionServer) CancelOperation(context.Context, *longrunning.CancelOperationRequest) (*empty.Empty, error) {
-	return nil, status.Error(codes.Unimplemented, fmt.Sprint("Unsupported in Scoot"))
+// Returns the sorted union of the in progress and completed job ids.
+// A job that just completed may be in both until the scheduler publishes its in progress jobs again.
+func listedJobIds(inProgress, completed []string) []string {
+	ids := append(inProgress, completed...)
+	sort.Strings(ids)
+	listed := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			listed = append(listed, id)
+		}
+	}
+	return listed
 }
 
-// Internal functions
+// Gets the run status of a Bazel job from its saga.
+// Tasks without a run status haven't been picked up by a worker yet, and are reported as pending.
+func runStatusFromSagaState(state *saga.SagaState) (*runStatus, error) {
+	rs, err := runStatusFromJobStatus(api.ConvertSagaStateToJobStatus(state))
+	if err != nil {
+		return nil, err
+	}
+	if rs.RunStatus == nil {
+		rs = &runStatus{&scoot.RunStatus{Status: scoot.RunStatusState_PENDING}}
+	}
+	return rs, nil
+}
 
 func (s *executionServer) getRunStatusAndValidate(jobID string) (*runStatus, error) {
 	js, err := api.GetJobStatus(jobID, s.sagaCoord)
 	if err != nil {
 		return nil, err
 	}
+	return runStatusFromJobStatus(js)
+}
+
+func runStatusFromJobStatus(js *scoot.JobStatus) (*runStatus, error) {
 	log.Debugf("Received job status %s", js)
 
-	err = validateBzJobStatus(js)
+	err := validateBzJobStatus(js)
 	if err != nil {
 		return nil, err
 	}
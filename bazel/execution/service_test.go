@@ -1,7 +1,10 @@
 package execution
 
 import (
+	"errors"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/ptypes"
@@ -9,11 +12,19 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/genproto/googleapis/longrunning"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/common/log/tags"
 	scootproto "github.com/twitter/scoot/common/proto"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/workerapi"
 )
 
 // Determine that Execute can accept a well-formed request and returns a well-formed response
@@ -23,9 +34,16 @@ func TestExecute(t *testing.T) {
 	sc := scheduler.NewMockScheduler(mockCtrl)
 	sc.EXPECT().ScheduleJob(gomock.Any()).Return("testJobID", nil)
 
-	s := executionServer{scheduler: sc, stat: stats.NilStatsReceiver()}
+	sagaC := sagalogs.MakeInMemorySagaCoordinator()
+	s := executionServer{scheduler: sc, sagaCoord: sagaC, stat: stats.NilStatsReceiver()}
 
-	fs := &fakeExecServer{}
+	// The scheduler is mocked, so make the job's saga look like it already ran to completion.
+	sg, _ := sagaC.MakeSaga("testJobID", nil)
+	sg.StartTask("task1", nil)
+	sg.EndTask("task1", serializeStatus(t, runner.CompleteStatus("run1", "", 0, tags.LogTags{})))
+	sg.EndSaga()
+
+	fs := &fakeExecServer{ops: make(chan *longrunning.Operation, 10)}
 
 	a := &remoteexecution.Action{}
 	actionSha, actionLen, err := scootproto.GetSha256(a)
@@ -45,6 +63,102 @@ func TestExecute(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Non-nil error from Execute: %v", err)
 	}
+
+	expectOperation(t, fs.ops, remoteexecution.ExecuteOperationMetadata_QUEUED, false)
+	expectOperation(t, fs.ops, remoteexecution.ExecuteOperationMetadata_COMPLETED, true)
+	if len(fs.ops) != 0 {
+		t.Fatalf("Expected no more operations, got: %d", len(fs.ops))
+	}
+}
+
+// Determine that requests Execute fails to accept are still counted in its latency
+func TestExecuteErrorLatency(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	sc.EXPECT().ScheduleJob(gomock.Any()).Return("", errors.New("scheduler unavailable"))
+
+	statsRegistry := stats.NewFinagleStatsRegistry()
+	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
+	s := executionServer{scheduler: sc, sagaCoord: sagalogs.MakeInMemorySagaCoordinator(), stat: statsReceiver}
+
+	a := &remoteexecution.Action{}
+	actionSha, actionLen, err := scootproto.GetSha256(a)
+	if err != nil {
+		t.Fatalf("Failed to get sha: %v", err)
+	}
+	req := remoteexecution.ExecuteRequest{ActionDigest: &remoteexecution.Digest{Hash: actionSha, SizeBytes: actionLen}}
+
+	fs := &fakeExecServer{ops: make(chan *longrunning.Operation, 10)}
+	if err := s.Execute(&req, fs); err == nil {
+		t.Fatalf("Expected an error from Execute when the job can't be scheduled")
+	}
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.BzExecRequestCounter:               {Checker: stats.Int64EqTest, Value: 1},
+			stats.BzExecRequestLatency_ms + ".count": {Checker: stats.Int64EqTest, Value: 1},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+}
+
+// Determine that WaitExecution streams an operation every time the execution stage changes
+func TestWaitExecution(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	sagaC := sagalogs.MakeInMemorySagaCoordinator()
+
+	s := executionServer{
+		scheduler: sc,
+		sagaCoord: sagaC,
+		stat:      stats.NilStatsReceiver(),
+	}
+
+	// Unknown operation
+	fs := &fakeExecServer{ops: make(chan *longrunning.Operation, 10)}
+	req := remoteexecution.WaitExecutionRequest{Name: "testJobID"}
+	if err := s.WaitExecution(&req, fs); status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound from WaitExecution, got: %v", err)
+	}
+
+	sg, _ := sagaC.MakeSaga("testJobID", nil)
+	sg.StartTask("task1", nil)
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- s.WaitExecution(&req, fs)
+	}()
+	expectOperation(t, fs.ops, remoteexecution.ExecuteOperationMetadata_QUEUED, false)
+
+	sg.StartTask("task1", serializeStatus(t, runner.RunningStatus("run1", "", "", tags.LogTags{})))
+	expectOperation(t, fs.ops, remoteexecution.ExecuteOperationMetadata_EXECUTING, false)
+
+	// Updates that don't change the stage aren't sent
+	sg.StartTask("task1", serializeStatus(t, runner.RunningStatus("run1", "stdout", "stderr", tags.LogTags{})))
+	sg.EndTask("task1", serializeStatus(t, runner.CompleteStatus("run1", "", 0, tags.LogTags{})))
+	expectOperation(t, fs.ops, remoteexecution.ExecuteOperationMetadata_COMPLETED, true)
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("Non-nil error from WaitExecution: %v", err)
+	}
+	if len(fs.ops) != 0 {
+		t.Fatalf("Expected no more operations, got: %d", len(fs.ops))
+	}
+
+	// Client goes away before the operation is done
+	sg2, _ := sagaC.MakeSaga("testJobID2", nil)
+	sg2.StartTask("task1", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	fs = &fakeExecServer{ctx: ctx, ops: make(chan *longrunning.Operation, 10)}
+	go func() {
+		errCh <- s.WaitExecution(&remoteexecution.WaitExecutionRequest{Name: "testJobID2"}, fs)
+	}()
+	expectOperation(t, fs.ops, remoteexecution.ExecuteOperationMetadata_QUEUED, false)
+	cancel()
+	if err := <-errCh; status.Code(err) != codes.Canceled {
+		t.Fatalf("Expected Canceled from WaitExecution, got: %v", err)
+	}
 }
 
 // Determine that GetOperation can accept a well-formed request and returns a well-formed response
@@ -91,12 +205,285 @@ func TestGetOperation(t *testing.T) {
 	}
 }
 
+// Determine that ListOperations lists Bazel jobs matching the filter, a page at a time
+func TestListOperations(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	sagaC := sagalogs.MakeInMemorySagaCoordinator()
+
+	s := executionServer{
+		scheduler: sc,
+		sagaCoord: sagaC,
+		stat:      stats.NilStatsReceiver(),
+	}
+	ctx := context.Background()
+
+	digest1 := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 1}
+	digest2 := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 2}
+	makeJob := func(id string, requestor string, digest *remoteexecution.Digest) (*saga.Saga, string) {
+		def, err := execReqToScoot(&remoteexecution.ExecuteRequest{ActionDigest: digest})
+		if err != nil {
+			t.Fatalf("Failed to convert request: %v", err)
+		}
+		def.Requestor = requestor
+		job := sched.Job{Id: id, Def: def}
+		b, err := job.Serialize()
+		if err != nil {
+			t.Fatalf("Failed to serialize job: %v", err)
+		}
+		sg, _ := sagaC.MakeSaga(id, b)
+		sg.StartTask(def.Tasks[0].TaskID, nil)
+		return sg, def.Tasks[0].TaskID
+	}
+
+	makeJob("job1", "alice", digest1)
+	sg, taskID := makeJob("job2", "bob", digest2)
+	sg.StartTask(taskID, serializeStatus(t, runner.RunningStatus("run1", "", "", tags.LogTags{})))
+	makeJob("job3", "alice", digest2)
+
+	// Non-Bazel jobs aren't listed
+	thriftJob := sched.GenJob("job0", 2)
+	b, _ := thriftJob.Serialize()
+	sagaC.MakeSaga("job0", b)
+
+	completedIds := []string{}
+	listOps := func(filter string, pageSize int32, pageToken string) ([]*longrunning.Operation, string) {
+		sc.EXPECT().GetInProgressJobIds().Return([]string{"job4", "job3", "job2", "job1", "job0"})
+		sc.EXPECT().GetCompletedJobIds().Return(completedIds)
+		res, err := s.ListOperations(ctx, &longrunning.ListOperationsRequest{
+			Filter:    filter,
+			PageSize:  pageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			t.Fatalf("Non-nil error from ListOperations: %v", err)
+		}
+		return res.GetOperations(), res.GetNextPageToken()
+	}
+	listIds := func(filter string, pageSize int32, pageToken string) ([]string, string) {
+		ops, token := listOps(filter, pageSize, pageToken)
+		ids := []string{}
+		for _, op := range ops {
+			ids = append(ids, op.GetName())
+		}
+		return ids, token
+	}
+
+	if ids, token := listIds("", 0, ""); !reflect.DeepEqual(ids, []string{"job1", "job2", "job3"}) || token != "" {
+		t.Fatalf("Expected all Bazel jobs to be listed, got: %v %q", ids, token)
+	}
+	if ids, _ := listIds("requestor=alice", 0, ""); !reflect.DeepEqual(ids, []string{"job1", "job3"}) {
+		t.Fatalf("Expected alice's jobs to be listed, got: %v", ids)
+	}
+	if ids, _ := listIds("stage=EXECUTING", 0, ""); !reflect.DeepEqual(ids, []string{"job2"}) {
+		t.Fatalf("Expected executing jobs to be listed, got: %v", ids)
+	}
+	if ids, _ := listIds("stage=QUEUED action_digest="+bazel.DigestToStr(digest2), 0, ""); !reflect.DeepEqual(ids, []string{"job3"}) {
+		t.Fatalf("Expected queued jobs with digest2 to be listed, got: %v", ids)
+	}
+
+	ids, token := listIds("", 2, "")
+	if !reflect.DeepEqual(ids, []string{"job1", "job2"}) || token != "job2" {
+		t.Fatalf("Expected first page to be listed, got: %v %q", ids, token)
+	}
+	ids, token = listIds("", 2, token)
+	if !reflect.DeepEqual(ids, []string{"job3"}) || token != "" {
+		t.Fatalf("Expected last page to be listed, got: %v %q", ids, token)
+	}
+
+	// Recently completed jobs are listed along with in progress ones, once each
+	sg, taskID = makeJob("job5", "alice", digest1)
+	sg.EndTask(taskID, serializeStatus(t, runner.CompleteStatus("run1", "", 1, tags.LogTags{})))
+	sg.EndSaga()
+	completedIds = []string{"job3", "job5"}
+	ops, _ := listOps("requestor=alice", 0, "")
+	if len(ops) != 3 || ops[0].GetName() != "job1" || ops[1].GetName() != "job3" || ops[2].GetName() != "job5" {
+		t.Fatalf("Expected alice's in progress and completed jobs to be listed, got: %v", ops)
+	}
+	if !ops[2].GetDone() || ops[2].GetResponse() == nil {
+		t.Fatalf("Expected completed job to be done with a response, got: %v", ops[2])
+	}
+	res := remoteexecution.ExecuteResponse{}
+	if err := ptypes.UnmarshalAny(ops[2].GetResponse(), &res); err != nil {
+		t.Fatalf("Failed to unmarshal response from any: %v", err)
+	}
+	if res.GetStatus() == nil {
+		t.Fatalf("Expected completed job's response to have a status, got: %v", res)
+	}
+	if ids, _ := listIds("stage=COMPLETED", 0, ""); !reflect.DeepEqual(ids, []string{"job5"}) {
+		t.Fatalf("Expected completed jobs to be listed, got: %v", ids)
+	}
+
+	if _, err := s.ListOperations(ctx, &longrunning.ListOperationsRequest{Filter: "color=red"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument from ListOperations, got: %v", err)
+	}
+}
+
+// Determine that CancelOperation kills in progress jobs and returns appropriate statuses otherwise
+func TestCancelOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	mockSagaLog := saga.NewMockSagaLog(mockCtrl)
+	sagaC := saga.MakeSagaCoordinator(mockSagaLog)
+
+	s := executionServer{
+		scheduler: sc,
+		sagaCoord: sagaC,
+		stat:      stats.NilStatsReceiver(),
+	}
+	ctx := context.Background()
+	req := longrunning.CancelOperationRequest{
+		Name: "testJobID",
+	}
+
+	// In progress job is killed
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return(
+		[]saga.SagaMessage{saga.MakeStartSagaMessage("testJobID", nil)}, nil)
+	sc.EXPECT().KillJob("testJobID").Return(nil)
+	if _, err := s.CancelOperation(ctx, &req); err != nil {
+		t.Fatalf("Non-nil error from CancelOperation: %v", err)
+	}
+
+	// Kill failure in the scheduler
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return(
+		[]saga.SagaMessage{saga.MakeStartSagaMessage("testJobID", nil)}, nil)
+	sc.EXPECT().KillJob("testJobID").Return(errors.New("already killed"))
+	if _, err := s.CancelOperation(ctx, &req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition from CancelOperation, got: %v", err)
+	}
+
+	// Finished job is not killed
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return(
+		[]saga.SagaMessage{saga.MakeStartSagaMessage("testJobID", nil), saga.MakeEndSagaMessage("testJobID")}, nil)
+	if _, err := s.CancelOperation(ctx, &req); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected FailedPrecondition from CancelOperation, got: %v", err)
+	}
+
+	// Unknown job
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return([]saga.SagaMessage{}, nil)
+	if _, err := s.CancelOperation(ctx, &req); status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound from CancelOperation, got: %v", err)
+	}
+
+	// Sagalog failure
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return(nil, saga.NewInternalLogError("test error"))
+	if _, err := s.CancelOperation(ctx, &req); status.Code(err) != codes.Internal {
+		t.Fatalf("Expected Internal from CancelOperation, got: %v", err)
+	}
+}
+
+// Determine that DeleteOperation kills in progress jobs and ignores finished ones
+func TestDeleteOperation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	sc := scheduler.NewMockScheduler(mockCtrl)
+	mockSagaLog := saga.NewMockSagaLog(mockCtrl)
+	sagaC := saga.MakeSagaCoordinator(mockSagaLog)
+
+	s := executionServer{
+		scheduler: sc,
+		sagaCoord: sagaC,
+		stat:      stats.NilStatsReceiver(),
+	}
+	ctx := context.Background()
+	req := longrunning.DeleteOperationRequest{
+		Name: "testJobID",
+	}
+
+	// In progress job is killed
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return(
+		[]saga.SagaMessage{saga.MakeStartSagaMessage("testJobID", nil)}, nil)
+	sc.EXPECT().KillJob("testJobID").Return(nil)
+	if _, err := s.DeleteOperation(ctx, &req); err != nil {
+		t.Fatalf("Non-nil error from DeleteOperation: %v", err)
+	}
+
+	// Finished job is a no-op
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return(
+		[]saga.SagaMessage{saga.MakeStartSagaMessage("testJobID", nil), saga.MakeEndSagaMessage("testJobID")}, nil)
+	if _, err := s.DeleteOperation(ctx, &req); err != nil {
+		t.Fatalf("Non-nil error from DeleteOperation: %v", err)
+	}
+
+	// Unknown job
+	mockSagaLog.EXPECT().GetMessages("testJobID").Return(nil, nil)
+	if _, err := s.DeleteOperation(ctx, &req); status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound from DeleteOperation, got: %v", err)
+	}
+}
+
+func TestGetCapabilities(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := executionServer{scheduler: scheduler.NewMockScheduler(mockCtrl), stat: stats.NilStatsReceiver()}
+
+	res, err := s.GetCapabilities(context.Background(), &remoteexecution.GetCapabilitiesRequest{})
+	if err != nil {
+		t.Fatalf("Non-nil error from GetCapabilities: %v", err)
+	}
+	ec := res.GetExecutionCapabilities()
+	if !ec.GetExecEnabled() || ec.GetDigestFunction() != remoteexecution.DigestFunction_SHA256 {
+		t.Fatalf("Unexpected execution capabilities: %s", ec)
+	}
+	ranges := ec.GetExecutionPriorityCapabilities().GetPriorities()
+	if len(ranges) != 1 || ranges[0].GetMinPriority() != 0 || ranges[0].GetMaxPriority() != 0 {
+		t.Fatalf("Unexpected execution priority ranges: %s", ranges)
+	}
+}
+
+func serializeStatus(t *testing.T, st runner.RunStatus) []byte {
+	b, err := workerapi.SerializeProcessStatus(st)
+	if err != nil {
+		t.Fatalf("Failed to serialize run status: %v", err)
+	}
+	return b
+}
+
+// Reads the next operation sent on a fake server and checks its stage and whether it's done
+func expectOperation(
+	t *testing.T, ops chan *longrunning.Operation, stage remoteexecution.ExecuteOperationMetadata_Stage, done bool) {
+	var op *longrunning.Operation
+	select {
+	case op = <-ops:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Timed out waiting for a %s operation", stage)
+	}
+
+	metadata := remoteexecution.ExecuteOperationMetadata{}
+	if err := ptypes.UnmarshalAny(op.GetMetadata(), &metadata); err != nil {
+		t.Fatalf("Failed to unmarshal metadata from any: %v", err)
+	}
+	if metadata.GetStage() != stage {
+		t.Fatalf("Expected stage %s, got: %s", stage, metadata.GetStage())
+	}
+	if op.GetDone() != done {
+		t.Fatalf("Expected done to be %t, got: %s", done, op)
+	}
+	if done && op.GetResponse() == nil {
+		t.Fatalf("Nil response for completed operation: %s", op)
+	}
+}
+
 // Fake Execution_ExecuteServer
-// Implements Execution_ExecuteServer interface
+// Implements Execution_ExecuteServer and Execution_WaitExecutionServer interfaces
 type fakeExecServer struct {
 	grpc.ServerStream
+	ctx context.Context
+	ops chan *longrunning.Operation
 }
 
 func (s *fakeExecServer) Send(op *longrunning.Operation) error {
+	if s.ops != nil {
+		s.ops <- op
+	}
 	return nil
 }
+
+func (s *fakeExecServer) Context() context.Context {
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
@@ -2,6 +2,7 @@ package execution
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -34,13 +35,27 @@ func validateExecRequest(req *remoteexecution.ExecuteRequest) error {
 	if req == nil {
 		return fmt.Errorf("Unexpected nil execute request")
 	}
+	// Other digest functions are only supported by the CAS, see bazel.ExecutionDigestFunction
+	fn := bazel.ExecutionDigestFunction
+	if reqFn := req.GetDigestFunction(); reqFn != remoteexecution.DigestFunction_UNKNOWN && reqFn != fn.Proto {
+		return fmt.Errorf("Unsupported digest function for execution: %s, only %s is supported", reqFn, fn.Proto)
+	}
 	actionDigest := req.GetActionDigest()
-	if !bazel.IsValidDigest(actionDigest.GetHash(), actionDigest.GetSizeBytes()) {
+	if !fn.IsValidDigest(actionDigest.GetHash(), actionDigest.GetSizeBytes()) {
 		return fmt.Errorf("Request action digest is invalid")
 	}
 	return nil
 }
 
+// Requests are all scheduled at P0 regardless of their ExecutionPolicy, see execReqToScoot,
+// so only Bazel's default priority of 0 is supported.
+func execPriorityRange() *remoteexecution.PriorityCapabilities_PriorityRange {
+	return &remoteexecution.PriorityCapabilities_PriorityRange{
+		MinPriority: 0,
+		MaxPriority: 0,
+	}
+}
+
 // Extract Scoot-related job fields from request to populate a JobDef, and pass through bazel request
 func execReqToScoot(req *remoteexecution.ExecuteRequest) (
 	result sched.JobDefinition, err error) {
@@ -77,6 +92,74 @@ func execReqToScoot(req *remoteexecution.ExecuteRequest) (
 	return result, nil
 }
 
+// Returns the ExecuteRequest of a job created from a Bazel ExecuteRequest, or nil for other jobs
+func scootToExecReq(job *sched.Job) *remoteexecution.ExecuteRequest {
+	if len(job.Def.Tasks) != 1 || job.Def.Tasks[0].ExecuteRequest == nil {
+		return nil
+	}
+	return job.Def.Tasks[0].ExecuteRequest.GetRequest()
+}
+
+// Conditions that operations listed by ListOperations must match, empty fields match any operation.
+type operationsFilter struct {
+	stage        remoteexecution.ExecuteOperationMetadata_Stage
+	hasStage     bool
+	requestor    string
+	actionDigest string // either "<hash>/<size>" or just "<hash>"
+}
+
+// Parses a ListOperations filter string of space separated "key=value" terms, all of which must match.
+// Supported keys are "stage" (an ExecuteOperationMetadata Stage name), "requestor" and
+// "action_digest" (either "<hash>/<size>" or just "<hash>").
+func parseOperationsFilter(filter string) (*operationsFilter, error) {
+	f := &operationsFilter{}
+	for _, term := range strings.Fields(filter) {
+		kv := strings.SplitN(term, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("Invalid filter term %q, expected 'key=value'", term)
+		}
+		switch kv[0] {
+		case "stage":
+			stage, ok := remoteexecution.ExecuteOperationMetadata_Stage_value[strings.ToUpper(kv[1])]
+			if !ok {
+				return nil, fmt.Errorf("Invalid stage %q in filter", kv[1])
+			}
+			f.stage = remoteexecution.ExecuteOperationMetadata_Stage(stage)
+			f.hasStage = true
+		case "requestor":
+			f.requestor = kv[1]
+		case "action_digest":
+			if strings.Contains(kv[1], "/") {
+				if _, err := bazel.DigestFromString(kv[1]); err != nil {
+					return nil, fmt.Errorf("Invalid action_digest %q in filter: %s", kv[1], err)
+				}
+			}
+			f.actionDigest = kv[1]
+		default:
+			return nil, fmt.Errorf("Unsupported filter key %q", kv[0])
+		}
+	}
+	return f, nil
+}
+
+// Returns true if an operation with the given stage, requestor and action digest matches the filter
+func (f *operationsFilter) matches(
+	stage remoteexecution.ExecuteOperationMetadata_Stage, requestor string, actionDigest *remoteexecution.Digest) bool {
+	if f.hasStage && stage != f.stage {
+		return false
+	}
+	if f.requestor != "" && requestor != f.requestor {
+		return false
+	}
+	if f.actionDigest != "" {
+		if strings.Contains(f.actionDigest, "/") {
+			return bazel.DigestToStr(actionDigest) == f.actionDigest
+		}
+		return actionDigest.GetHash() == f.actionDigest
+	}
+	return true
+}
+
 func validateBzJobStatus(js *scoot.JobStatus) error {
 	if len(js.GetTaskData()) > 1 || len(js.GetTaskStatus()) > 1 {
 		return fmt.Errorf(
@@ -45,6 +45,18 @@ func TestValidateExecResponse(t *testing.T) {
 	if err == nil {
 		t.Fatalf("Expected req validation to fail")
 	}
+
+	req4 := &remoteexecution.ExecuteRequest{
+		ActionDigest: &remoteexecution.Digest{
+			Hash:      bazel.BLAKE3.EmptyHash,
+			SizeBytes: bazel.EmptySize,
+		},
+		DigestFunction: remoteexecution.DigestFunction_BLAKE3,
+	}
+	err = validateExecRequest(req4)
+	if err == nil {
+		t.Fatalf("Expected req validation to fail for unsupported digest function")
+	}
 }
 
 func TestValidateBzJobStatus(t *testing.T) {
@@ -105,3 +117,43 @@ func TestGetBazelResult(t *testing.T) {
 		t.Fatalf("Expected nil BazelResult, got %v", br)
 	}
 }
+
+func TestParseOperationsFilter(t *testing.T) {
+	d := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 10}
+	other := &remoteexecution.Digest{Hash: bazel.EmptySha, SizeBytes: 20}
+
+	f, err := parseOperationsFilter("")
+	if err != nil {
+		t.Fatalf("Expected empty filter to be ok, got %v", err)
+	}
+	if !f.matches(remoteexecution.ExecuteOperationMetadata_QUEUED, "", nil) {
+		t.Fatalf("Expected empty filter to match everything")
+	}
+
+	f, err = parseOperationsFilter("stage=executing requestor=alice action_digest=" + bazel.DigestToStr(d))
+	if err != nil {
+		t.Fatalf("Expected filter to be ok, got %v", err)
+	}
+	if !f.matches(remoteexecution.ExecuteOperationMetadata_EXECUTING, "alice", d) {
+		t.Fatalf("Expected filter %+v to match", f)
+	}
+	if f.matches(remoteexecution.ExecuteOperationMetadata_COMPLETED, "alice", d) ||
+		f.matches(remoteexecution.ExecuteOperationMetadata_EXECUTING, "bob", d) ||
+		f.matches(remoteexecution.ExecuteOperationMetadata_EXECUTING, "alice", other) {
+		t.Fatalf("Expected filter %+v to only match when all terms match", f)
+	}
+
+	f, err = parseOperationsFilter("action_digest=" + bazel.EmptySha)
+	if err != nil {
+		t.Fatalf("Expected filter to be ok, got %v", err)
+	}
+	if !f.matches(remoteexecution.ExecuteOperationMetadata_QUEUED, "", other) {
+		t.Fatalf("Expected hash only digest filter to match any size")
+	}
+
+	for _, invalid := range []string{"stage", "stage=", "stage=bogus", "digest=abc", "action_digest=abc/1"} {
+		if _, err := parseOperationsFilter(invalid); err == nil {
+			t.Fatalf("Expected filter %q to be invalid", invalid)
+		}
+	}
+}
@@ -37,6 +37,7 @@ const (
 	DigestFunction_SHA256  DigestFunction = 1
 	DigestFunction_SHA1    DigestFunction = 2
 	DigestFunction_MD5     DigestFunction = 3
+	DigestFunction_BLAKE3  DigestFunction = 9
 )
 
 var DigestFunction_name = map[int32]string{
@@ -44,12 +45,14 @@ var DigestFunction_name = map[int32]string{
 	1: "SHA256",
 	2: "SHA1",
 	3: "MD5",
+	9: "BLAKE3",
 }
 var DigestFunction_value = map[string]int32{
 	"UNKNOWN": 0,
 	"SHA256":  1,
 	"SHA1":    2,
 	"MD5":     3,
+	"BLAKE3":  9,
 }
 
 func (x DigestFunction) String() string {
@@ -1407,10 +1410,13 @@ type ExecuteRequest struct {
 	// An optional policy for the results of this execution in the remote cache.
 	// The server will have a default policy if this is not provided.
 	// This may be applied to both the ActionResult and the associated blobs.
-	ResultsCachePolicy   *ResultsCachePolicy `protobuf:"bytes,8,opt,name=results_cache_policy,json=resultsCachePolicy,proto3" json:"results_cache_policy,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	ResultsCachePolicy *ResultsCachePolicy `protobuf:"bytes,8,opt,name=results_cache_policy,json=resultsCachePolicy,proto3" json:"results_cache_policy,omitempty"`
+	// The digest function used to compute the digests in the request. If unset,
+	// the server uses SHA256.
+	DigestFunction       DigestFunction `protobuf:"varint,9,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction" json:"digest_function,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *ExecuteRequest) Reset()         { *m = ExecuteRequest{} }
@@ -1472,6 +1478,13 @@ func (m *ExecuteRequest) GetResultsCachePolicy() *ResultsCachePolicy {
 	return nil
 }
 
+func (m *ExecuteRequest) GetDigestFunction() DigestFunction {
+	if m != nil {
+		return m.DigestFunction
+	}
+	return DigestFunction_UNKNOWN
+}
+
 // A `LogFile` is a log stored in the CAS.
 type LogFile struct {
 	// The digest of the log contents.
@@ -1739,10 +1752,13 @@ type GetActionResultRequest struct {
 	InstanceName string `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
 	// The digest of the [Action][build.bazel.remote.execution.v2.Action]
 	// whose result is requested.
-	ActionDigest         *Digest  `protobuf:"bytes,2,opt,name=action_digest,json=actionDigest,proto3" json:"action_digest,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ActionDigest *Digest `protobuf:"bytes,2,opt,name=action_digest,json=actionDigest,proto3" json:"action_digest,omitempty"`
+	// The digest function used to compute the digests in the request. If unset,
+	// the server uses SHA256.
+	DigestFunction       DigestFunction `protobuf:"varint,6,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction" json:"digest_function,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GetActionResultRequest) Reset()         { *m = GetActionResultRequest{} }
@@ -1783,6 +1799,13 @@ func (m *GetActionResultRequest) GetActionDigest() *Digest {
 	return nil
 }
 
+func (m *GetActionResultRequest) GetDigestFunction() DigestFunction {
+	if m != nil {
+		return m.DigestFunction
+	}
+	return DigestFunction_UNKNOWN
+}
+
 // A request message for
 // [ActionCache.UpdateActionResult][build.bazel.remote.execution.v2.ActionCache.UpdateActionResult].
 type UpdateActionResultRequest struct {
@@ -1801,10 +1824,13 @@ type UpdateActionResultRequest struct {
 	// An optional policy for the results of this execution in the remote cache.
 	// The server will have a default policy if this is not provided.
 	// This may be applied to both the ActionResult and the associated blobs.
-	ResultsCachePolicy   *ResultsCachePolicy `protobuf:"bytes,4,opt,name=results_cache_policy,json=resultsCachePolicy,proto3" json:"results_cache_policy,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
-	XXX_unrecognized     []byte              `json:"-"`
-	XXX_sizecache        int32               `json:"-"`
+	ResultsCachePolicy *ResultsCachePolicy `protobuf:"bytes,4,opt,name=results_cache_policy,json=resultsCachePolicy,proto3" json:"results_cache_policy,omitempty"`
+	// The digest function used to compute the digests in the request. If unset,
+	// the server uses SHA256.
+	DigestFunction       DigestFunction `protobuf:"varint,5,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction" json:"digest_function,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *UpdateActionResultRequest) Reset()         { *m = UpdateActionResultRequest{} }
@@ -1859,6 +1885,13 @@ func (m *UpdateActionResultRequest) GetResultsCachePolicy() *ResultsCachePolicy
 	return nil
 }
 
+func (m *UpdateActionResultRequest) GetDigestFunction() DigestFunction {
+	if m != nil {
+		return m.DigestFunction
+	}
+	return DigestFunction_UNKNOWN
+}
+
 // A request message for
 // [ContentAddressableStorage.FindMissingBlobs][build.bazel.remote.execution.v2.ContentAddressableStorage.FindMissingBlobs].
 type FindMissingBlobsRequest struct {
@@ -1869,10 +1902,13 @@ type FindMissingBlobsRequest struct {
 	// omitted.
 	InstanceName string `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
 	// A list of the blobs to check.
-	BlobDigests          []*Digest `protobuf:"bytes,2,rep,name=blob_digests,json=blobDigests,proto3" json:"blob_digests,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	BlobDigests []*Digest `protobuf:"bytes,2,rep,name=blob_digests,json=blobDigests,proto3" json:"blob_digests,omitempty"`
+	// The digest function used to compute the digests in the request. If unset,
+	// the server uses SHA256.
+	DigestFunction       DigestFunction `protobuf:"varint,3,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction" json:"digest_function,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *FindMissingBlobsRequest) Reset()         { *m = FindMissingBlobsRequest{} }
@@ -1913,6 +1949,13 @@ func (m *FindMissingBlobsRequest) GetBlobDigests() []*Digest {
 	return nil
 }
 
+func (m *FindMissingBlobsRequest) GetDigestFunction() DigestFunction {
+	if m != nil {
+		return m.DigestFunction
+	}
+	return DigestFunction_UNKNOWN
+}
+
 // A response message for
 // [ContentAddressableStorage.FindMissingBlobs][build.bazel.remote.execution.v2.ContentAddressableStorage.FindMissingBlobs].
 type FindMissingBlobsResponse struct {
@@ -2014,10 +2057,13 @@ type BatchUpdateBlobsRequest struct {
 	// omitted.
 	InstanceName string `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
 	// The individual upload requests.
-	Requests             []*UpdateBlobRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	Requests []*UpdateBlobRequest `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	// The digest function used to compute the digests in the request. If unset,
+	// the server uses SHA256.
+	DigestFunction       DigestFunction `protobuf:"varint,5,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction" json:"digest_function,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *BatchUpdateBlobsRequest) Reset()         { *m = BatchUpdateBlobsRequest{} }
@@ -2058,6 +2104,13 @@ func (m *BatchUpdateBlobsRequest) GetRequests() []*UpdateBlobRequest {
 	return nil
 }
 
+func (m *BatchUpdateBlobsRequest) GetDigestFunction() DigestFunction {
+	if m != nil {
+		return m.DigestFunction
+	}
+	return DigestFunction_UNKNOWN
+}
+
 // A response message for
 // [ContentAddressableStorage.BatchUpdateBlobs][build.bazel.remote.execution.v2.ContentAddressableStorage.BatchUpdateBlobs].
 type BatchUpdateBlobsResponse struct {
@@ -2148,6 +2201,169 @@ func (m *BatchUpdateBlobsResponse_Response) GetStatus() *status.Status {
 	return nil
 }
 
+// A request message for
+// [ContentAddressableStorage.BatchReadBlobs][build.bazel.remote.execution.v2.ContentAddressableStorage.BatchReadBlobs].
+type BatchReadBlobsRequest struct {
+	// The instance of the execution system to operate against. A server may
+	// support multiple instances of the execution system (with their own workers,
+	// storage, caches, etc.). The server MAY require use of this field to select
+	// between them in an implementation-defined fashion, otherwise it can be
+	// omitted.
+	InstanceName string `protobuf:"bytes,1,opt,name=instance_name,json=instanceName,proto3" json:"instance_name,omitempty"`
+	// The individual blob digests.
+	Digests []*Digest `protobuf:"bytes,2,rep,name=digests,proto3" json:"digests,omitempty"`
+	// The digest function used to compute the digests in the request. If unset,
+	// the server uses SHA256.
+	DigestFunction       DigestFunction `protobuf:"varint,4,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction" json:"digest_function,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchReadBlobsRequest) Reset()         { *m = BatchReadBlobsRequest{} }
+func (m *BatchReadBlobsRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchReadBlobsRequest) ProtoMessage()    {}
+func (*BatchReadBlobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_remote_execution_1121cd279bad7f30, []int{36}
+}
+func (m *BatchReadBlobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchReadBlobsRequest.Unmarshal(m, b)
+}
+func (m *BatchReadBlobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchReadBlobsRequest.Marshal(b, m, deterministic)
+}
+func (dst *BatchReadBlobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchReadBlobsRequest.Merge(dst, src)
+}
+func (m *BatchReadBlobsRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchReadBlobsRequest.Size(m)
+}
+func (m *BatchReadBlobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchReadBlobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchReadBlobsRequest proto.InternalMessageInfo
+
+func (m *BatchReadBlobsRequest) GetInstanceName() string {
+	if m != nil {
+		return m.InstanceName
+	}
+	return ""
+}
+
+func (m *BatchReadBlobsRequest) GetDigests() []*Digest {
+	if m != nil {
+		return m.Digests
+	}
+	return nil
+}
+
+func (m *BatchReadBlobsRequest) GetDigestFunction() DigestFunction {
+	if m != nil {
+		return m.DigestFunction
+	}
+	return DigestFunction_UNKNOWN
+}
+
+// A response message for
+// [ContentAddressableStorage.BatchReadBlobs][build.bazel.remote.execution.v2.ContentAddressableStorage.BatchReadBlobs].
+type BatchReadBlobsResponse struct {
+	// The responses to the requests.
+	Responses            []*BatchReadBlobsResponse_Response `protobuf:"bytes,1,rep,name=responses,proto3" json:"responses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                           `json:"-"`
+	XXX_unrecognized     []byte                             `json:"-"`
+	XXX_sizecache        int32                              `json:"-"`
+}
+
+func (m *BatchReadBlobsResponse) Reset()         { *m = BatchReadBlobsResponse{} }
+func (m *BatchReadBlobsResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchReadBlobsResponse) ProtoMessage()    {}
+func (*BatchReadBlobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_remote_execution_1121cd279bad7f30, []int{37}
+}
+func (m *BatchReadBlobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchReadBlobsResponse.Unmarshal(m, b)
+}
+func (m *BatchReadBlobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchReadBlobsResponse.Marshal(b, m, deterministic)
+}
+func (dst *BatchReadBlobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchReadBlobsResponse.Merge(dst, src)
+}
+func (m *BatchReadBlobsResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchReadBlobsResponse.Size(m)
+}
+func (m *BatchReadBlobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchReadBlobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchReadBlobsResponse proto.InternalMessageInfo
+
+func (m *BatchReadBlobsResponse) GetResponses() []*BatchReadBlobsResponse_Response {
+	if m != nil {
+		return m.Responses
+	}
+	return nil
+}
+
+// A response corresponding to a single blob that the client tried to download.
+type BatchReadBlobsResponse_Response struct {
+	// The digest to which this response corresponds.
+	Digest *Digest `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	// The raw binary data.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// The result of attempting to download that blob.
+	Status               *status.Status `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *BatchReadBlobsResponse_Response) Reset()         { *m = BatchReadBlobsResponse_Response{} }
+func (m *BatchReadBlobsResponse_Response) String() string { return proto.CompactTextString(m) }
+func (*BatchReadBlobsResponse_Response) ProtoMessage()    {}
+func (*BatchReadBlobsResponse_Response) Descriptor() ([]byte, []int) {
+	return fileDescriptor_remote_execution_1121cd279bad7f30, []int{37, 0}
+}
+func (m *BatchReadBlobsResponse_Response) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchReadBlobsResponse_Response.Unmarshal(m, b)
+}
+func (m *BatchReadBlobsResponse_Response) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchReadBlobsResponse_Response.Marshal(b, m, deterministic)
+}
+func (dst *BatchReadBlobsResponse_Response) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchReadBlobsResponse_Response.Merge(dst, src)
+}
+func (m *BatchReadBlobsResponse_Response) XXX_Size() int {
+	return xxx_messageInfo_BatchReadBlobsResponse_Response.Size(m)
+}
+func (m *BatchReadBlobsResponse_Response) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchReadBlobsResponse_Response.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchReadBlobsResponse_Response proto.InternalMessageInfo
+
+func (m *BatchReadBlobsResponse_Response) GetDigest() *Digest {
+	if m != nil {
+		return m.Digest
+	}
+	return nil
+}
+
+func (m *BatchReadBlobsResponse_Response) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *BatchReadBlobsResponse_Response) GetStatus() *status.Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
 // A request message for
 // [ContentAddressableStorage.GetTree][build.bazel.remote.execution.v2.ContentAddressableStorage.GetTree].
 type GetTreeRequest struct {
@@ -2170,10 +2386,13 @@ type GetTreeRequest struct {
 	// A page token, which must be a value received in a previous
 	// [GetTreeResponse][build.bazel.remote.execution.v2.GetTreeResponse].
 	// If present, the server will use it to return the following page of results.
-	PageToken            string   `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// The digest function used to compute the digests in the request. If unset,
+	// the server uses SHA256.
+	DigestFunction       DigestFunction `protobuf:"varint,5,opt,name=digest_function,json=digestFunction,proto3,enum=build.bazel.remote.execution.v2.DigestFunction" json:"digest_function,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *GetTreeRequest) Reset()         { *m = GetTreeRequest{} }
@@ -2228,6 +2447,13 @@ func (m *GetTreeRequest) GetPageToken() string {
 	return ""
 }
 
+func (m *GetTreeRequest) GetDigestFunction() DigestFunction {
+	if m != nil {
+		return m.DigestFunction
+	}
+	return DigestFunction_UNKNOWN
+}
+
 // A response message for
 // [ContentAddressableStorage.GetTree][build.bazel.remote.execution.v2.ContentAddressableStorage.GetTree].
 type GetTreeResponse struct {
@@ -2512,9 +2738,14 @@ type CacheCapabilities struct {
 	ActionCacheUpdateCapabilities *ActionCacheUpdateCapabilities `protobuf:"bytes,2,opt,name=action_cache_update_capabilities,json=actionCacheUpdateCapabilities,proto3" json:"action_cache_update_capabilities,omitempty"`
 	// Supported cache priority range for both CAS and ActionCache.
 	CachePriorityCapabilities *PriorityCapabilities `protobuf:"bytes,3,opt,name=cache_priority_capabilities,json=cachePriorityCapabilities,proto3" json:"cache_priority_capabilities,omitempty"`
-	XXX_NoUnkeyedLiteral      struct{}              `json:"-"`
-	XXX_unrecognized          []byte                `json:"-"`
-	XXX_sizecache             int32                 `json:"-"`
+	// Maximum total size of blobs to be uploaded/downloaded using
+	// batch methods. A value of 0 means no limit is set, although
+	// in practice there will always be a message size limitation
+	// of the protocol in use, e.g. GRPC.
+	MaxBatchTotalSizeBytes int64    `protobuf:"varint,4,opt,name=max_batch_total_size_bytes,json=maxBatchTotalSizeBytes,proto3" json:"max_batch_total_size_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *CacheCapabilities) Reset()         { *m = CacheCapabilities{} }
@@ -2562,6 +2793,13 @@ func (m *CacheCapabilities) GetCachePriorityCapabilities() *PriorityCapabilities
 	return nil
 }
 
+func (m *CacheCapabilities) GetMaxBatchTotalSizeBytes() int64 {
+	if m != nil {
+		return m.MaxBatchTotalSizeBytes
+	}
+	return 0
+}
+
 // Capabilities of the remote execution system.
 type ExecutionCapabilities struct {
 	// Remote execution may only support a single digest function.
@@ -2787,6 +3025,9 @@ func init() {
 	proto.RegisterType((*ExecutionCapabilities)(nil), "build.bazel.remote.execution.v2.ExecutionCapabilities")
 	proto.RegisterType((*ToolDetails)(nil), "build.bazel.remote.execution.v2.ToolDetails")
 	proto.RegisterType((*RequestMetadata)(nil), "build.bazel.remote.execution.v2.RequestMetadata")
+	proto.RegisterType((*BatchReadBlobsRequest)(nil), "build.bazel.remote.execution.v2.BatchReadBlobsRequest")
+	proto.RegisterType((*BatchReadBlobsResponse)(nil), "build.bazel.remote.execution.v2.BatchReadBlobsResponse")
+	proto.RegisterType((*BatchReadBlobsResponse_Response)(nil), "build.bazel.remote.execution.v2.BatchReadBlobsResponse.Response")
 	proto.RegisterEnum("build.bazel.remote.execution.v2.DigestFunction", DigestFunction_name, DigestFunction_value)
 	proto.RegisterEnum("build.bazel.remote.execution.v2.ExecuteOperationMetadata_Stage", ExecuteOperationMetadata_Stage_name, ExecuteOperationMetadata_Stage_value)
 }
@@ -3253,6 +3494,24 @@ type ContentAddressableStorageClient interface {
 	// [Digest][build.bazel.remote.execution.v2.Digest] does not match the
 	// provided data.
 	BatchUpdateBlobs(ctx context.Context, in *BatchUpdateBlobsRequest, opts ...grpc.CallOption) (*BatchUpdateBlobsResponse, error)
+	// Download many blobs at once.
+	//
+	// The client MUST NOT download blobs with a combined total size of more than 4
+	// MiB using this API. Such requests should either be split into smaller
+	// chunks or downloaded using the
+	// [ByteStream API][google.bytestream.ByteStream], as appropriate.
+	//
+	// This request is equivalent to calling a hypothetical `GetBlob` request
+	// on each individual blob, in parallel. The requests may succeed or fail
+	// independently.
+	//
+	// Errors:
+	// * `INVALID_ARGUMENT`: The client attempted to read more than the
+	//   server supported limit.
+	//
+	// Every error on individual read will be returned in the corresponding digest
+	// status.
+	BatchReadBlobs(ctx context.Context, in *BatchReadBlobsRequest, opts ...grpc.CallOption) (*BatchReadBlobsResponse, error)
 	// Fetch the entire directory tree rooted at a node.
 	//
 	// This request must be targeted at a
@@ -3303,6 +3562,15 @@ func (c *contentAddressableStorageClient) BatchUpdateBlobs(ctx context.Context,
 	return out, nil
 }
 
+func (c *contentAddressableStorageClient) BatchReadBlobs(ctx context.Context, in *BatchReadBlobsRequest, opts ...grpc.CallOption) (*BatchReadBlobsResponse, error) {
+	out := new(BatchReadBlobsResponse)
+	err := c.cc.Invoke(ctx, "/build.bazel.remote.execution.v2.ContentAddressableStorage/BatchReadBlobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *contentAddressableStorageClient) GetTree(ctx context.Context, in *GetTreeRequest, opts ...grpc.CallOption) (ContentAddressableStorage_GetTreeClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_ContentAddressableStorage_serviceDesc.Streams[0], "/build.bazel.remote.execution.v2.ContentAddressableStorage/GetTree", opts...)
 	if err != nil {
@@ -3365,6 +3633,24 @@ type ContentAddressableStorageServer interface {
 	// [Digest][build.bazel.remote.execution.v2.Digest] does not match the
 	// provided data.
 	BatchUpdateBlobs(context.Context, *BatchUpdateBlobsRequest) (*BatchUpdateBlobsResponse, error)
+	// Download many blobs at once.
+	//
+	// The client MUST NOT download blobs with a combined total size of more than 4
+	// MiB using this API. Such requests should either be split into smaller
+	// chunks or downloaded using the
+	// [ByteStream API][google.bytestream.ByteStream], as appropriate.
+	//
+	// This request is equivalent to calling a hypothetical `GetBlob` request
+	// on each individual blob, in parallel. The requests may succeed or fail
+	// independently.
+	//
+	// Errors:
+	// * `INVALID_ARGUMENT`: The client attempted to read more than the
+	//   server supported limit.
+	//
+	// Every error on individual read will be returned in the corresponding digest
+	// status.
+	BatchReadBlobs(context.Context, *BatchReadBlobsRequest) (*BatchReadBlobsResponse, error)
 	// Fetch the entire directory tree rooted at a node.
 	//
 	// This request must be targeted at a
@@ -3429,6 +3715,24 @@ func _ContentAddressableStorage_BatchUpdateBlobs_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ContentAddressableStorage_BatchReadBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchReadBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ContentAddressableStorageServer).BatchReadBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/build.bazel.remote.execution.v2.ContentAddressableStorage/BatchReadBlobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ContentAddressableStorageServer).BatchReadBlobs(ctx, req.(*BatchReadBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ContentAddressableStorage_GetTree_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetTreeRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -3462,6 +3766,10 @@ var _ContentAddressableStorage_serviceDesc = grpc.ServiceDesc{
 			MethodName: "BatchUpdateBlobs",
 			Handler:    _ContentAddressableStorage_BatchUpdateBlobs_Handler,
 		},
+		{
+			MethodName: "BatchReadBlobs",
+			Handler:    _ContentAddressableStorage_BatchReadBlobs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3544,174 +3852,184 @@ func init() {
 }
 
 var fileDescriptor_remote_execution_1121cd279bad7f30 = []byte{
-	// 2694 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x1a, 0xdd, 0x6f, 0x5b, 0x57,
-	0x9d, 0x6b, 0x3b, 0x8e, 0xfd, 0xb3, 0x13, 0x3b, 0x87, 0xac, 0x75, 0xdd, 0x75, 0x6d, 0xef, 0xb4,
-	0x11, 0xd2, 0xd5, 0xee, 0x5c, 0x36, 0x46, 0xd8, 0x56, 0x1a, 0xc7, 0x59, 0x9a, 0xa5, 0x49, 0xb8,
-	0x49, 0xba, 0x8e, 0x2f, 0x73, 0xe2, 0x7b, 0xe2, 0x5c, 0xc5, 0xbe, 0xc7, 0xbd, 0xf7, 0x38, 0x6d,
-	0x3a, 0x55, 0x42, 0x48, 0x68, 0xd2, 0x40, 0x7b, 0x19, 0x4f, 0x3c, 0x20, 0x81, 0x78, 0x42, 0xfc,
-	0x03, 0x08, 0x78, 0x87, 0x57, 0x90, 0xe0, 0x91, 0x97, 0x09, 0x09, 0x69, 0xe2, 0x8d, 0x67, 0xd0,
-	0xf9, 0xb8, 0x5f, 0xb6, 0xb3, 0x6b, 0x67, 0x1d, 0xe2, 0xed, 0xfa, 0x77, 0x7e, 0xdf, 0xe7, 0xf7,
-	0x79, 0x12, 0x38, 0xe7, 0x90, 0x2e, 0x65, 0xa4, 0x49, 0x1e, 0x91, 0x56, 0x9f, 0x59, 0xd4, 0xae,
-	0xf4, 0x1c, 0xca, 0x28, 0xba, 0xbc, 0xdf, 0xb7, 0x3a, 0x66, 0x65, 0x1f, 0x3f, 0x26, 0x9d, 0x8a,
-	0xc4, 0xa9, 0x04, 0x38, 0xc7, 0xb5, 0xf2, 0xb3, 0x6d, 0x4a, 0xdb, 0x1d, 0x52, 0xc5, 0x3d, 0xab,
-	0x8a, 0x6d, 0x9b, 0x32, 0xcc, 0x4f, 0x5c, 0x49, 0x5e, 0x7e, 0x5e, 0x9d, 0x76, 0xa8, 0xdd, 0x76,
-	0xfa, 0xb6, 0x6d, 0xd9, 0xed, 0x2a, 0xed, 0x11, 0x27, 0x82, 0xf4, 0x9c, 0x42, 0x12, 0xbf, 0xf6,
-	0xfb, 0x07, 0x55, 0xb3, 0x2f, 0x11, 0xd4, 0xf9, 0xe5, 0xc1, 0x73, 0x66, 0x75, 0x89, 0xcb, 0x70,
-	0xb7, 0xa7, 0x10, 0xce, 0x2b, 0x04, 0xa7, 0xd7, 0xaa, 0xba, 0x0c, 0xb3, 0xbe, 0xe2, 0xac, 0x7f,
-	0x90, 0x80, 0xf4, 0xed, 0x16, 0x67, 0x85, 0x36, 0x61, 0xb6, 0x45, 0xbb, 0x5d, 0x6c, 0x9b, 0x4d,
-	0xd3, 0x6a, 0x13, 0x97, 0x95, 0xb4, 0x2b, 0xda, 0x42, 0xae, 0xf6, 0xa5, 0x4a, 0x8c, 0x85, 0x95,
-	0x15, 0x81, 0x6e, 0xcc, 0x28, 0x72, 0xf9, 0x13, 0xed, 0xc0, 0x9c, 0x65, 0xf7, 0xfa, 0xac, 0xe9,
-	0x50, 0xca, 0x3c, 0x96, 0x89, 0xc9, 0x58, 0x16, 0x04, 0x07, 0x83, 0x52, 0xa6, 0x98, 0xde, 0x84,
-	0x69, 0x6e, 0x1b, 0xed, 0xb3, 0x52, 0x5a, 0xb0, 0xba, 0x50, 0x91, 0xa6, 0x55, 0x3c, 0xdb, 0x2b,
-	0x2b, 0xca, 0x37, 0x86, 0x87, 0x89, 0xae, 0x40, 0xde, 0xa4, 0x4d, 0x9b, 0xb2, 0x66, 0x0b, 0xb7,
-	0x0e, 0x49, 0x69, 0xfa, 0x8a, 0xb6, 0x90, 0x31, 0xc0, 0xa4, 0x9b, 0x94, 0xd5, 0x39, 0x64, 0x3d,
-	0x95, 0x49, 0x16, 0xd3, 0xfa, 0x2f, 0x92, 0x30, 0x5d, 0x97, 0x36, 0xa0, 0x67, 0x21, 0x8b, 0x9d,
-	0x76, 0xbf, 0x4b, 0x6c, 0xe6, 0x96, 0xb4, 0x2b, 0xc9, 0x85, 0xac, 0x11, 0x00, 0xd0, 0x03, 0x78,
-	0x86, 0xd8, 0xc7, 0x96, 0x43, 0x6d, 0xfe, 0xbb, 0x79, 0x8c, 0x1d, 0x0b, 0xef, 0x77, 0x88, 0x5b,
-	0x4a, 0x5c, 0x49, 0x2e, 0xe4, 0x6a, 0xaf, 0xc7, 0xda, 0xa7, 0xc4, 0x54, 0x1a, 0x01, 0x97, 0x7b,
-	0x8a, 0x89, 0x31, 0x4f, 0x86, 0x81, 0x2e, 0xba, 0x0a, 0x79, 0xda, 0x67, 0xdc, 0x9f, 0x07, 0x16,
-	0x97, 0x94, 0x14, 0x3a, 0xe5, 0x24, 0x6c, 0x95, 0x83, 0xd0, 0x75, 0x40, 0x0a, 0xc5, 0xb4, 0x1c,
-	0xd2, 0x62, 0xd4, 0xb1, 0x88, 0x5b, 0x4a, 0x09, 0xc4, 0x39, 0x79, 0xb2, 0x12, 0x1c, 0xa0, 0x06,
-	0x64, 0x7a, 0x1d, 0xcc, 0x0e, 0xa8, 0xd3, 0x2d, 0x4d, 0x09, 0x67, 0x7e, 0x39, 0x56, 0xef, 0x6d,
-	0x45, 0x60, 0xf8, 0xa4, 0xe8, 0x1a, 0xcc, 0x3d, 0xa4, 0xce, 0x91, 0x65, 0xb7, 0x7d, 0xb1, 0x27,
-	0xe2, 0x72, 0xb2, 0x46, 0x51, 0x1d, 0x78, 0x52, 0x4f, 0xca, 0xb7, 0xe0, 0x8b, 0x23, 0x4c, 0x46,
-	0x08, 0x52, 0x36, 0xee, 0x12, 0x11, 0x71, 0x59, 0x43, 0x7c, 0xa3, 0x79, 0x98, 0x3a, 0xc6, 0x9d,
-	0x3e, 0x11, 0x31, 0x93, 0x35, 0xe4, 0x0f, 0xfd, 0xa7, 0x1a, 0x64, 0x3c, 0x25, 0x90, 0x01, 0xd0,
-	0x73, 0x78, 0xb6, 0x30, 0x6e, 0xa8, 0x26, 0x7c, 0x5f, 0x1b, 0xdb, 0x86, 0xca, 0xb6, 0xa4, 0x3d,
-	0x31, 0x42, 0x5c, 0xca, 0x5f, 0x81, 0x8c, 0x07, 0x9f, 0x40, 0xad, 0x9f, 0x6b, 0x90, 0xf5, 0xad,
-	0x44, 0xb7, 0x60, 0x4a, 0x5e, 0x92, 0x54, 0x29, 0xde, 0xad, 0xfc, 0xfe, 0x36, 0xa9, 0x49, 0x0c,
-	0x49, 0x87, 0xb6, 0x21, 0x17, 0xbe, 0x42, 0x19, 0x55, 0x95, 0x31, 0xb2, 0x46, 0x69, 0x20, 0x78,
-	0x85, 0x59, 0xe8, 0x1f, 0x68, 0x90, 0xf1, 0xa4, 0x8c, 0xb4, 0xeb, 0x16, 0xa4, 0xcf, 0x96, 0xa3,
-	0x8a, 0x0c, 0x3d, 0x0f, 0x33, 0x96, 0xab, 0xca, 0x23, 0xbf, 0xd4, 0x52, 0x4a, 0xa4, 0x59, 0xde,
-	0x72, 0x1b, 0x3e, 0x4c, 0x24, 0x5a, 0x4a, 0x37, 0x61, 0x26, 0xa2, 0xea, 0xe7, 0xa2, 0x90, 0xfe,
-	0x75, 0x48, 0xab, 0xaa, 0x81, 0x20, 0x75, 0x88, 0xdd, 0x43, 0x8f, 0x3d, 0xff, 0x46, 0x97, 0x00,
-	0x5c, 0xeb, 0x31, 0x69, 0xee, 0x9f, 0x30, 0xe1, 0x61, 0x6d, 0x21, 0x69, 0x64, 0x39, 0x64, 0x99,
-	0x03, 0xf4, 0xbf, 0xa7, 0xe1, 0x9c, 0xd4, 0x9b, 0x98, 0xb2, 0x40, 0xde, 0x25, 0x0c, 0x9b, 0x98,
-	0x61, 0x74, 0x0e, 0xd2, 0x3c, 0xae, 0x89, 0xa3, 0xf8, 0xa9, 0x5f, 0xa8, 0x01, 0xc5, 0x07, 0x7d,
-	0xd2, 0x27, 0x66, 0xd3, 0x2f, 0xbf, 0x4a, 0xf5, 0xf2, 0x50, 0x91, 0xda, 0xf5, 0x30, 0x8c, 0x82,
-	0xa4, 0xf1, 0x01, 0x68, 0x1b, 0xce, 0x49, 0x86, 0x4d, 0x97, 0x61, 0x87, 0x85, 0x98, 0x25, 0x63,
-	0x99, 0xcd, 0x4b, 0xca, 0x1d, 0x4e, 0x18, 0x70, 0xbc, 0x0f, 0x65, 0xc5, 0xb1, 0x45, 0xbb, 0xbd,
-	0x0e, 0x61, 0x11, 0x15, 0x53, 0xb1, 0x5c, 0x4b, 0x92, 0xba, 0xee, 0x11, 0x07, 0x9c, 0xdf, 0x85,
-	0x8b, 0xb2, 0xc6, 0x1f, 0x10, 0xd6, 0x3a, 0x1c, 0x52, 0x78, 0x2a, 0x9e, 0xb5, 0x20, 0x5f, 0xe5,
-	0xd4, 0x03, 0x4a, 0x63, 0xb8, 0x1c, 0x66, 0x3d, 0x4a, 0xf3, 0x74, 0x2c, 0xfb, 0x67, 0x03, 0xf6,
-	0x23, 0xb4, 0xbf, 0x07, 0x17, 0xfc, 0xf8, 0x19, 0xd2, 0x7d, 0x3a, 0x96, 0xf9, 0x79, 0x9f, 0x78,
-	0x40, 0xf5, 0xef, 0xc1, 0xa5, 0x80, 0xef, 0x28, 0xc5, 0x33, 0xb1, 0xbc, 0x2f, 0xfa, 0x0c, 0x46,
-	0xe8, 0xfd, 0x5d, 0xb8, 0xa4, 0xea, 0x7c, 0xbf, 0xd7, 0xa1, 0xd8, 0x1c, 0xd2, 0x3d, 0x1b, 0xcb,
-	0xbf, 0x2c, 0x19, 0xec, 0x09, 0xfa, 0x01, 0xf5, 0x09, 0x5c, 0x8d, 0xb2, 0x1f, 0x65, 0x02, 0xc4,
-	0x8a, 0x78, 0x2e, 0x2c, 0x62, 0xd8, 0x0a, 0xfd, 0x57, 0x29, 0xc8, 0xcb, 0xcc, 0x32, 0x88, 0xdb,
-	0xef, 0x30, 0xb4, 0x39, 0xd0, 0xe1, 0x64, 0xd5, 0xbb, 0x16, 0x9b, 0xf6, 0x5b, 0x7e, 0x0b, 0x8c,
-	0xb6, 0xc3, 0xe6, 0xc8, 0x76, 0x98, 0x14, 0x5c, 0x6f, 0x8c, 0xc9, 0xd5, 0x2f, 0x53, 0xa3, 0x1a,
-	0xe8, 0x45, 0xc8, 0x92, 0x47, 0x16, 0x6b, 0xb6, 0xa8, 0x29, 0xab, 0xdd, 0x94, 0x91, 0xe1, 0x80,
-	0x3a, 0x2f, 0x69, 0xbc, 0xbe, 0x30, 0x93, 0xf2, 0xf9, 0x07, 0x3f, 0x14, 0x99, 0x90, 0x37, 0xb2,
-	0x12, 0x62, 0xe0, 0x87, 0x68, 0x03, 0x66, 0xd4, 0xb1, 0x2a, 0x72, 0xe9, 0xc9, 0x8a, 0x5c, 0x5e,
-	0x52, 0xab, 0x02, 0x27, 0x85, 0x11, 0xc7, 0x11, 0xc2, 0xa6, 0x7d, 0x61, 0xc4, 0x71, 0x02, 0x61,
-	0xfc, 0x58, 0x09, 0xcb, 0x4c, 0x2e, 0x8c, 0x38, 0x8e, 0x12, 0x76, 0x00, 0x28, 0x08, 0xef, 0xae,
-	0xaa, 0x8a, 0x2a, 0xe6, 0xbe, 0x1a, 0xcb, 0x72, 0x74, 0x51, 0x35, 0xe6, 0x7c, 0x24, 0x0f, 0xb4,
-	0x9e, 0xca, 0x68, 0xc5, 0x84, 0xfe, 0x13, 0x0d, 0x20, 0xb8, 0x61, 0x5e, 0xca, 0x7b, 0x98, 0xf9,
-	0xa5, 0x9c, 0x7f, 0xff, 0x4f, 0x5b, 0xd7, 0x87, 0x1a, 0xa4, 0x76, 0x1d, 0x42, 0xd0, 0x9b, 0x90,
-	0xe2, 0x83, 0xad, 0x1a, 0x92, 0x17, 0xc7, 0xef, 0xcd, 0x86, 0xa0, 0x43, 0xab, 0x90, 0x69, 0x1d,
-	0x5a, 0x1d, 0xd3, 0x21, 0xb6, 0x8a, 0xf4, 0x49, 0x78, 0xf8, 0xb4, 0x7a, 0x1f, 0x0a, 0x03, 0xa1,
-	0x3a, 0xd2, 0x47, 0x6b, 0x90, 0x63, 0x0e, 0x21, 0x5e, 0x00, 0x24, 0x27, 0x73, 0x14, 0x70, 0x5a,
-	0xf9, 0xbd, 0x9e, 0xca, 0x24, 0x8a, 0x49, 0xfd, 0x3a, 0x14, 0x1a, 0x1e, 0xe2, 0x36, 0xed, 0x58,
-	0xad, 0x13, 0x54, 0x86, 0x4c, 0xcf, 0xb1, 0xa8, 0x63, 0xb1, 0x13, 0x21, 0x7a, 0xca, 0xf0, 0x7f,
-	0xeb, 0x37, 0x00, 0xc9, 0x2c, 0x77, 0xc5, 0xc0, 0x3d, 0x06, 0xc5, 0x8f, 0x92, 0x30, 0xab, 0x62,
-	0xc5, 0x20, 0x0f, 0xfa, 0xde, 0x35, 0xd9, 0x2e, 0xc3, 0x76, 0x8b, 0x34, 0x43, 0xe3, 0x42, 0xde,
-	0x03, 0x6e, 0xf2, 0xb1, 0x61, 0x11, 0xe6, 0xdc, 0x23, 0xab, 0x27, 0x47, 0xfd, 0x66, 0x87, 0xd2,
-	0xa3, 0xbe, 0xec, 0x9c, 0x19, 0xa3, 0xc0, 0x0f, 0x84, 0xfc, 0x0d, 0x01, 0xe6, 0x79, 0x81, 0x45,
-	0x18, 0x9e, 0x35, 0x09, 0x25, 0xb5, 0xca, 0x8b, 0x6f, 0x43, 0x31, 0xc8, 0x8b, 0x9e, 0xb0, 0x50,
-	0x75, 0x91, 0x1b, 0x63, 0x66, 0x85, 0xef, 0x4b, 0xa3, 0x40, 0x06, 0x9c, 0x4b, 0x60, 0xde, 0x91,
-	0x0e, 0x54, 0x96, 0x29, 0x01, 0x32, 0x93, 0x6f, 0xc6, 0x0a, 0x18, 0xf6, 0xbe, 0x81, 0x9c, 0x21,
-	0x98, 0xbc, 0xdc, 0xf5, 0x54, 0x26, 0x55, 0x9c, 0x5a, 0x4f, 0x65, 0xa6, 0x8a, 0x69, 0xfd, 0x01,
-	0x4c, 0x6f, 0xd0, 0xb6, 0xc8, 0xbd, 0x20, 0xcf, 0xb4, 0xb3, 0xe5, 0xd9, 0x0b, 0x30, 0x7b, 0xd8,
-	0xef, 0x62, 0xbb, 0xe9, 0x10, 0x6c, 0x8a, 0x44, 0x4b, 0x88, 0x8b, 0x99, 0x11, 0x50, 0x43, 0x01,
-	0xf5, 0x7f, 0x27, 0xbc, 0xe0, 0x22, 0x06, 0x71, 0x7b, 0xd4, 0x76, 0x09, 0x6a, 0x40, 0x5a, 0xaa,
-	0xab, 0x64, 0x5f, 0x8f, 0x95, 0x1d, 0xee, 0x2d, 0x86, 0x22, 0xe6, 0x21, 0x24, 0xdc, 0x67, 0x36,
-	0x15, 0x37, 0xa9, 0x40, 0x5e, 0x02, 0x55, 0x23, 0x5a, 0x84, 0xb4, 0x5c, 0x92, 0x55, 0x9a, 0x20,
-	0xaf, 0xcb, 0x39, 0xbd, 0x56, 0x65, 0x47, 0x9c, 0x18, 0x0a, 0x03, 0x61, 0xc8, 0xb9, 0xc4, 0x39,
-	0x26, 0x4e, 0xb3, 0x43, 0xdb, 0x72, 0xd9, 0xca, 0xd5, 0xbe, 0x31, 0x6e, 0x15, 0xf4, 0xcc, 0xab,
-	0xec, 0x08, 0x1e, 0x1b, 0xb4, 0xed, 0x36, 0x6c, 0xe6, 0x9c, 0x18, 0xe0, 0xfa, 0x80, 0x72, 0x1b,
-	0x0a, 0x03, 0xc7, 0xa8, 0x08, 0xc9, 0x23, 0x72, 0xa2, 0xe2, 0x9f, 0x7f, 0xa2, 0x37, 0xc3, 0x6b,
-	0x49, 0xae, 0xb6, 0x10, 0xab, 0x81, 0xba, 0x54, 0xb5, 0xc0, 0x2c, 0x25, 0x5e, 0xd3, 0xf4, 0x4f,
-	0x12, 0x50, 0x52, 0x8a, 0x6d, 0x79, 0x4f, 0x10, 0xfe, 0xd4, 0xbb, 0x07, 0x53, 0x2e, 0xc3, 0x6d,
-	0x99, 0x74, 0xb3, 0xb5, 0x5b, 0xe3, 0x9a, 0x38, 0xc4, 0x89, 0x7b, 0xb0, 0x4d, 0x0c, 0xc9, 0x6d,
-	0x38, 0x05, 0x13, 0x9f, 0x25, 0x05, 0x5f, 0x02, 0xa4, 0xba, 0xaa, 0xcb, 0x1c, 0x82, 0xbb, 0xb2,
-	0x4c, 0x24, 0xe5, 0x32, 0x2a, 0x4f, 0x76, 0xc4, 0x81, 0x28, 0x15, 0x12, 0x9b, 0xb7, 0xc5, 0x30,
-	0x76, 0xca, 0xc7, 0x26, 0x8e, 0x13, 0x60, 0xeb, 0x5b, 0x30, 0x25, 0x34, 0x47, 0x39, 0x98, 0xde,
-	0xdb, 0x7c, 0x7b, 0x73, 0xeb, 0x9d, 0xcd, 0xe2, 0x17, 0x50, 0x01, 0x72, 0xf5, 0xdb, 0xf5, 0xb5,
-	0x46, 0xb3, 0xbe, 0xd6, 0xa8, 0xbf, 0x5d, 0xd4, 0x10, 0x40, 0xfa, 0x9b, 0x7b, 0x8d, 0xbd, 0xc6,
-	0x4a, 0x31, 0x81, 0x66, 0x20, 0xdb, 0xb8, 0xdf, 0xa8, 0xef, 0xed, 0xde, 0xd9, 0x7c, 0xab, 0x98,
-	0xe4, 0x3f, 0xeb, 0x5b, 0x77, 0xb7, 0x37, 0x1a, 0xbb, 0x8d, 0x95, 0x62, 0x4a, 0x5f, 0x84, 0xf9,
-	0x77, 0xb0, 0xc5, 0xfc, 0xd4, 0xf7, 0xca, 0xdc, 0x88, 0x65, 0x48, 0xff, 0xb1, 0x06, 0xe7, 0xde,
-	0x22, 0x2c, 0x12, 0xd3, 0x93, 0x54, 0xc5, 0xa7, 0xea, 0x66, 0xfd, 0x4f, 0x09, 0xb8, 0xb0, 0xd7,
-	0x33, 0x31, 0x23, 0xff, 0x1f, 0x0a, 0x21, 0xc3, 0xe7, 0xa6, 0xd2, 0x3a, 0x79, 0x96, 0x22, 0xa1,
-	0x78, 0xaa, 0x2a, 0x70, 0x5a, 0xc5, 0x4d, 0x3d, 0xd5, 0x8a, 0xcb, 0x17, 0xf3, 0xf3, 0xab, 0x96,
-	0x6d, 0xde, 0xb5, 0x5c, 0xd7, 0xb2, 0xdb, 0xcb, 0x1d, 0xba, 0xef, 0x4e, 0xe4, 0xc9, 0x75, 0xc8,
-	0xef, 0x77, 0xe8, 0xbe, 0xf2, 0xa3, 0x37, 0x36, 0x8f, 0xed, 0xc8, 0x1c, 0x27, 0x96, 0xdf, 0xae,
-	0xde, 0x87, 0xd2, 0xb0, 0x2e, 0xaa, 0x02, 0xbf, 0x0b, 0xf3, 0x5d, 0x09, 0x6f, 0x7e, 0x16, 0x79,
-	0xa8, 0x1b, 0x30, 0xf7, 0xc4, 0x3e, 0x84, 0x39, 0x19, 0x4e, 0x1c, 0xe8, 0x19, 0x2f, 0xde, 0x23,
-	0x6d, 0x46, 0x6c, 0x76, 0xf6, 0xf7, 0x48, 0x41, 0x1e, 0x3c, 0x02, 0x88, 0x41, 0x35, 0x21, 0xa6,
-	0x63, 0xf1, 0xcd, 0xa7, 0xb9, 0xf3, 0xcb, 0x98, 0xb5, 0x0e, 0x03, 0xf1, 0x93, 0x39, 0x7f, 0x13,
-	0x32, 0x8e, 0xc4, 0xf7, 0x1c, 0x11, 0xff, 0xfe, 0x34, 0x64, 0xaa, 0xe1, 0xf3, 0xd0, 0xff, 0xa3,
-	0x41, 0x69, 0x58, 0x21, 0x75, 0x03, 0xdf, 0x87, 0xac, 0xa3, 0xbe, 0xbd, 0xa7, 0xa5, 0xe5, 0x58,
-	0x69, 0xa7, 0x71, 0xab, 0x78, 0x1f, 0x46, 0xc0, 0xb4, 0xfc, 0x03, 0x0d, 0x32, 0xbe, 0xb8, 0x35,
-	0xc8, 0x85, 0x2e, 0x7a, 0x52, 0xef, 0x43, 0x10, 0x57, 0xa1, 0x86, 0x9a, 0x88, 0x6b, 0xa8, 0xfa,
-	0xef, 0x34, 0x98, 0x7d, 0x8b, 0x30, 0x3e, 0x63, 0x4f, 0x74, 0x13, 0x6b, 0x90, 0xfb, 0x0c, 0x0f,
-	0xcd, 0xe0, 0x04, 0x6f, 0xcc, 0x17, 0x21, 0xdb, 0xc3, 0x6d, 0xd2, 0x74, 0xad, 0xc7, 0xb2, 0x77,
-	0xf0, 0xb1, 0x14, 0xb7, 0xc9, 0x8e, 0xf5, 0x58, 0xac, 0x75, 0xe2, 0x90, 0xd1, 0x23, 0x62, 0xab,
-	0x5e, 0x21, 0xd0, 0x77, 0x39, 0x40, 0x7f, 0x5f, 0x83, 0x82, 0xaf, 0xbd, 0xf2, 0xe3, 0x46, 0xf4,
-	0x31, 0x4f, 0x9b, 0x78, 0xd8, 0x0f, 0x93, 0xa3, 0x17, 0xa1, 0x60, 0x93, 0x47, 0xac, 0x19, 0xd2,
-	0x42, 0xbe, 0x44, 0xce, 0x70, 0xf0, 0xb6, 0xaf, 0xc9, 0x1b, 0xa2, 0x61, 0xd4, 0x71, 0x0f, 0xef,
-	0x5b, 0x1d, 0x8b, 0x59, 0x64, 0xa2, 0xc0, 0xd6, 0xff, 0xa1, 0x01, 0x92, 0x53, 0x47, 0x98, 0x05,
-	0xc2, 0x80, 0x64, 0x31, 0x6c, 0x85, 0xa0, 0x2a, 0x34, 0xe2, 0x23, 0x5f, 0xd4, 0xbd, 0x88, 0x4a,
-	0x73, 0xad, 0x41, 0x10, 0xea, 0xc2, 0xb9, 0xd0, 0xeb, 0x49, 0x58, 0x8c, 0xbc, 0xd3, 0x57, 0xc7,
-	0x1f, 0xa6, 0x23, 0xa2, 0x9e, 0x21, 0xa3, 0xc0, 0xfa, 0x2a, 0x5c, 0x92, 0x4d, 0x40, 0x28, 0x27,
-	0x13, 0x25, 0xa2, 0xcf, 0x0b, 0x30, 0xdb, 0x17, 0xd0, 0x26, 0xb1, 0xf9, 0x78, 0x6a, 0x0a, 0x73,
-	0x33, 0xc6, 0x8c, 0x84, 0x36, 0x24, 0x50, 0xff, 0xb3, 0x06, 0xf3, 0xdb, 0x6a, 0x79, 0x89, 0xd0,
-	0xb7, 0x00, 0xd4, 0x52, 0x13, 0xdc, 0x7e, 0x3d, 0xfe, 0x91, 0x7a, 0x04, 0x2b, 0x1f, 0x68, 0x60,
-	0xbb, 0x4d, 0x8c, 0x10, 0xdb, 0xf2, 0x1e, 0xcc, 0x44, 0x0e, 0xd1, 0x55, 0xc8, 0x77, 0x2d, 0xbb,
-	0x39, 0xb0, 0x5e, 0xe5, 0xba, 0x96, 0xed, 0xe1, 0x09, 0x14, 0xfc, 0x28, 0x40, 0x49, 0x28, 0x14,
-	0xfc, 0xc8, 0x43, 0xd1, 0xff, 0x95, 0x80, 0xb9, 0xa1, 0x4b, 0x43, 0xf7, 0xa1, 0x20, 0xb3, 0xac,
-	0x79, 0xd0, 0xb7, 0x85, 0xef, 0x84, 0x59, 0xb3, 0xb5, 0xea, 0x98, 0xe9, 0xb6, 0xaa, 0xc8, 0x8c,
-	0x59, 0x33, 0xf2, 0x1b, 0xbd, 0xaf, 0xc1, 0x15, 0xd5, 0xc8, 0x65, 0x98, 0x29, 0xcf, 0x8f, 0x08,
-	0x83, 0x37, 0xc7, 0xec, 0xed, 0xa7, 0x5c, 0xab, 0x71, 0x09, 0x7f, 0xea, 0xad, 0xf7, 0xe1, 0xa2,
-	0xea, 0xfa, 0xca, 0x17, 0x51, 0x1d, 0xe4, 0x7c, 0xf1, 0xca, 0x99, 0xae, 0xd1, 0xb8, 0x20, 0x38,
-	0x8f, 0x3a, 0xd2, 0x3f, 0x4a, 0xc0, 0x33, 0x23, 0xc3, 0x77, 0xb4, 0xd3, 0xb5, 0xa7, 0xe1, 0xf4,
-	0xab, 0x90, 0xe7, 0xe8, 0x7e, 0x78, 0xcb, 0x95, 0x28, 0xc7, 0x61, 0x2a, 0xb8, 0xd1, 0x13, 0xb8,
-	0x1c, 0x5a, 0x6d, 0x9f, 0xbe, 0x47, 0x82, 0xf7, 0xd2, 0x91, 0x5e, 0xb9, 0x0b, 0xb9, 0x5d, 0x4a,
-	0x3b, 0x2b, 0x84, 0x61, 0xab, 0x23, 0xde, 0xdd, 0x18, 0xa5, 0x9d, 0x70, 0xf1, 0xca, 0x70, 0x80,
-	0xe8, 0x03, 0x57, 0x21, 0x2f, 0x0e, 0x8f, 0x89, 0xe3, 0x72, 0x27, 0xc9, 0xe2, 0x98, 0xe3, 0xb0,
-	0x7b, 0x12, 0xa4, 0xff, 0x53, 0x83, 0x82, 0x2a, 0x86, 0xfe, 0x7a, 0xb3, 0xa5, 0xc8, 0x4c, 0x29,
-	0x43, 0x95, 0xb4, 0x97, 0x62, 0xcd, 0x09, 0xe9, 0x25, 0x85, 0x84, 0x94, 0x54, 0x91, 0x6c, 0x99,
-	0x4a, 0x89, 0x8c, 0x04, 0xdc, 0x31, 0xf9, 0xe6, 0x21, 0xa4, 0x59, 0xf6, 0x31, 0x6d, 0x61, 0x0f,
-	0x4b, 0xed, 0x29, 0xfc, 0xe4, 0x8e, 0x7f, 0x70, 0xc7, 0x44, 0x4b, 0x70, 0xa1, 0x45, 0x1d, 0x87,
-	0x74, 0x30, 0x23, 0x66, 0x88, 0xc6, 0xe5, 0x44, 0xb2, 0x05, 0x9d, 0x0f, 0x10, 0x02, 0x52, 0xf7,
-	0x8e, 0xb9, 0xf8, 0x3a, 0xcc, 0x46, 0xaf, 0x3f, 0xba, 0xbe, 0x00, 0xa4, 0x77, 0xd6, 0x6e, 0xd7,
-	0x5e, 0x79, 0xb5, 0xa8, 0xa1, 0x0c, 0xa4, 0x76, 0xd6, 0x6e, 0xbf, 0x5c, 0x4c, 0xa0, 0x69, 0x48,
-	0xde, 0x5d, 0x79, 0xa5, 0x98, 0xac, 0xfd, 0x36, 0x01, 0x59, 0x3f, 0x1c, 0xd1, 0x87, 0x1a, 0x4c,
-	0xab, 0xad, 0x0e, 0x55, 0xc7, 0x5f, 0x71, 0x85, 0xa3, 0xcb, 0x97, 0xbc, 0x9e, 0x1f, 0xfa, 0x4b,
-	0x77, 0xc5, 0x5f, 0x0e, 0xf5, 0x97, 0x7f, 0xf8, 0x97, 0x8f, 0x3f, 0x4a, 0x5c, 0xd3, 0x5f, 0xac,
-	0x1e, 0xd7, 0xaa, 0xef, 0x45, 0xfa, 0xd3, 0x1b, 0x8b, 0x8b, 0x4f, 0xaa, 0xd2, 0x75, 0xee, 0x92,
-	0x14, 0x41, 0x96, 0xb4, 0xc5, 0x1b, 0x1a, 0xfa, 0x99, 0x06, 0x33, 0x91, 0x15, 0x0a, 0xc5, 0xc7,
-	0xdf, 0xa8, 0x95, 0x6b, 0x32, 0xe5, 0x84, 0x4e, 0xc1, 0xdf, 0xe8, 0xab, 0x8b, 0x8b, 0x4f, 0x96,
-	0x1e, 0x86, 0xb9, 0x0a, 0xe5, 0x6a, 0x7f, 0x4d, 0x42, 0x2e, 0x54, 0x82, 0xd0, 0xdf, 0xe4, 0x68,
-	0x10, 0x79, 0xf2, 0x8e, 0x7f, 0x2e, 0x1d, 0xbd, 0xf4, 0x95, 0x27, 0xdb, 0x6c, 0xf4, 0xef, 0x08,
-	0x03, 0xee, 0xa1, 0xdd, 0x4f, 0xf5, 0xae, 0xda, 0x58, 0xaa, 0xef, 0x45, 0x36, 0xb3, 0xca, 0x21,
-	0x76, 0x0f, 0x9f, 0x0c, 0x02, 0x83, 0xbf, 0x9d, 0x3d, 0x41, 0x9f, 0x68, 0x80, 0x86, 0xd7, 0x41,
-	0xb4, 0x34, 0xe6, 0x24, 0xfc, 0x14, 0xec, 0x3b, 0x12, 0xf6, 0x91, 0xf2, 0xe7, 0x62, 0xdf, 0x52,
-	0x74, 0xb7, 0xac, 0x7d, 0x9c, 0x82, 0x0b, 0x75, 0xb9, 0x5a, 0xdc, 0x36, 0x4d, 0x87, 0xb8, 0x2e,
-	0x2f, 0x92, 0x3b, 0x8c, 0x3a, 0xb8, 0x4d, 0xd0, 0xef, 0x35, 0x28, 0x0e, 0xee, 0x50, 0xe8, 0xb5,
-	0x31, 0xfe, 0x02, 0x3c, 0x72, 0x05, 0x2c, 0x7f, 0xed, 0x0c, 0x94, 0x72, 0xee, 0xd4, 0x6f, 0x0a,
-	0xa7, 0x5c, 0xd7, 0x17, 0x4e, 0x71, 0x0a, 0x1f, 0xd0, 0xdd, 0xa5, 0x83, 0x80, 0x7c, 0x49, 0x5b,
-	0x14, 0xea, 0x0f, 0xae, 0x0c, 0x63, 0xa8, 0x7f, 0xca, 0x12, 0x35, 0x86, 0xfa, 0xa7, 0xed, 0x27,
-	0x63, 0xaa, 0xbf, 0x1f, 0x90, 0x73, 0xf5, 0xff, 0xa8, 0xc1, 0xb4, 0x9a, 0xbf, 0xc7, 0x28, 0x51,
-	0xd1, 0x3d, 0xa3, 0x7c, 0x63, 0x7c, 0x02, 0xa5, 0xe3, 0x7d, 0xa1, 0xa3, 0x81, 0xb6, 0x3f, 0x4d,
-	0xc7, 0xea, 0x7b, 0xa1, 0xc5, 0xc4, 0x8b, 0xb6, 0x30, 0x28, 0x1c, 0x6b, 0x6d, 0x29, 0xe1, 0x86,
-	0x56, 0xfb, 0x83, 0x06, 0xf9, 0xc8, 0x04, 0xf0, 0x1b, 0x59, 0x40, 0x22, 0xb0, 0xb1, 0x0a, 0xc8,
-	0x88, 0x25, 0xa0, 0x1c, 0xff, 0x7e, 0x31, 0x3c, 0xfd, 0xeb, 0xd7, 0x84, 0xb9, 0x2f, 0xa0, 0xe7,
-	0x4f, 0x31, 0x37, 0x3c, 0x10, 0x2c, 0x3b, 0x10, 0xf7, 0xaf, 0x51, 0xcb, 0xf3, 0x86, 0x00, 0x06,
-	0x8f, 0xdf, 0x0e, 0x65, 0x74, 0x5b, 0xfb, 0x56, 0x41, 0x22, 0xfb, 0xb8, 0xbf, 0x4c, 0x24, 0x8d,
-	0xc6, 0xfd, 0x5f, 0x27, 0x2e, 0x2f, 0x0b, 0x86, 0xcb, 0x82, 0xa1, 0xa4, 0x0d, 0x86, 0xfd, 0xca,
-	0xbd, 0xda, 0x7e, 0x5a, 0xfc, 0x1d, 0xf2, 0xe6, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xca, 0xab,
-	0x3f, 0x15, 0xad, 0x25, 0x00, 0x00,
+	// 2854 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3a, 0x4b, 0x6c, 0x1b, 0xc7,
+	0xd9, 0xff, 0x92, 0x14, 0x45, 0x7e, 0x94, 0x44, 0x6a, 0x7e, 0x59, 0xa6, 0xe9, 0x38, 0x76, 0x36,
+	0x48, 0xaa, 0xca, 0x31, 0xa9, 0xd0, 0xcd, 0x4b, 0x6d, 0xe2, 0x48, 0x14, 0x1d, 0xd9, 0x91, 0x65,
+	0x75, 0x25, 0x39, 0x4e, 0x1f, 0xd9, 0xae, 0xb8, 0x23, 0x6a, 0x61, 0x72, 0x87, 0xde, 0x1d, 0xca,
+	0x56, 0x02, 0x03, 0x45, 0x0f, 0x0d, 0x90, 0x16, 0xb9, 0xa4, 0xbd, 0xb4, 0x40, 0x81, 0x3e, 0x4e,
+	0x41, 0x8f, 0xbd, 0x14, 0x68, 0xef, 0x3d, 0xb7, 0x40, 0x8b, 0x9e, 0x72, 0x49, 0x8b, 0x16, 0xc8,
+	0xb5, 0xe7, 0x16, 0xf3, 0xd8, 0x17, 0xb9, 0xf6, 0x92, 0xb2, 0x5c, 0xf4, 0xb6, 0xfb, 0xcd, 0xf7,
+	0x9c, 0xf9, 0x1e, 0xf3, 0x7d, 0xbb, 0x30, 0xef, 0xe0, 0x2e, 0xa1, 0x58, 0xc7, 0xf7, 0x71, 0xab,
+	0x4f, 0x2d, 0x62, 0x57, 0x7b, 0x0e, 0xa1, 0x04, 0x9d, 0xdf, 0xeb, 0x5b, 0x1d, 0xb3, 0xba, 0x67,
+	0xbc, 0x8f, 0x3b, 0x55, 0x81, 0x53, 0x0d, 0x70, 0x0e, 0xeb, 0x95, 0xa7, 0xda, 0x84, 0xb4, 0x3b,
+	0xb8, 0x66, 0xf4, 0xac, 0x9a, 0x61, 0xdb, 0x84, 0x1a, 0x6c, 0xc5, 0x15, 0xe4, 0x95, 0x67, 0xe5,
+	0x6a, 0x87, 0xd8, 0x6d, 0xa7, 0x6f, 0xdb, 0x96, 0xdd, 0xae, 0x91, 0x1e, 0x76, 0x22, 0x48, 0x4f,
+	0x4b, 0x24, 0xfe, 0xb6, 0xd7, 0xdf, 0xaf, 0x99, 0x7d, 0x81, 0x20, 0xd7, 0xcf, 0x0f, 0xae, 0x53,
+	0xab, 0x8b, 0x5d, 0x6a, 0x74, 0x7b, 0x12, 0xe1, 0xb4, 0x44, 0x70, 0x7a, 0xad, 0x9a, 0x4b, 0x0d,
+	0xda, 0x97, 0x9c, 0xd5, 0x8f, 0x52, 0x90, 0x5d, 0x69, 0x31, 0x56, 0x68, 0x13, 0x66, 0x5a, 0xa4,
+	0xdb, 0x35, 0x6c, 0x53, 0x37, 0xad, 0x36, 0x76, 0x69, 0x59, 0xb9, 0xa0, 0x2c, 0x14, 0xea, 0x5f,
+	0xaa, 0x26, 0x58, 0x58, 0x5d, 0xe3, 0xe8, 0xda, 0xb4, 0x24, 0x17, 0xaf, 0x68, 0x1b, 0x66, 0x2d,
+	0xbb, 0xd7, 0xa7, 0xba, 0x43, 0x08, 0xf5, 0x58, 0xa6, 0xc6, 0x63, 0x59, 0xe4, 0x1c, 0x34, 0x42,
+	0xa8, 0x64, 0x7a, 0x19, 0x26, 0x99, 0x6d, 0xa4, 0x4f, 0xcb, 0x59, 0xce, 0xea, 0x4c, 0x55, 0x98,
+	0x56, 0xf5, 0x6c, 0xaf, 0xae, 0xc9, 0xbd, 0xd1, 0x3c, 0x4c, 0x74, 0x01, 0xa6, 0x4c, 0xa2, 0xdb,
+	0x84, 0xea, 0x2d, 0xa3, 0x75, 0x80, 0xcb, 0x93, 0x17, 0x94, 0x85, 0x9c, 0x06, 0x26, 0xd9, 0x24,
+	0xb4, 0xc1, 0x20, 0xd7, 0x33, 0xb9, 0x74, 0x29, 0xab, 0xfe, 0x3c, 0x0d, 0x93, 0x0d, 0x61, 0x03,
+	0x7a, 0x0a, 0xf2, 0x86, 0xd3, 0xee, 0x77, 0xb1, 0x4d, 0xdd, 0xb2, 0x72, 0x21, 0xbd, 0x90, 0xd7,
+	0x02, 0x00, 0xba, 0x0b, 0xa7, 0xb0, 0x7d, 0x68, 0x39, 0xc4, 0x66, 0xef, 0xfa, 0xa1, 0xe1, 0x58,
+	0xc6, 0x5e, 0x07, 0xbb, 0xe5, 0xd4, 0x85, 0xf4, 0x42, 0xa1, 0xfe, 0xb5, 0x44, 0xfb, 0xa4, 0x98,
+	0x6a, 0x33, 0xe0, 0x72, 0x4b, 0x32, 0xd1, 0xe6, 0xf0, 0x30, 0xd0, 0x45, 0xcf, 0xc0, 0x14, 0xe9,
+	0x53, 0xb6, 0x9f, 0xfb, 0x16, 0x93, 0x94, 0xe6, 0x3a, 0x15, 0x04, 0xec, 0x2a, 0x03, 0xa1, 0x4b,
+	0x80, 0x24, 0x8a, 0x69, 0x39, 0xb8, 0x45, 0x89, 0x63, 0x61, 0xb7, 0x9c, 0xe1, 0x88, 0xb3, 0x62,
+	0x65, 0x2d, 0x58, 0x40, 0x4d, 0xc8, 0xf5, 0x3a, 0x06, 0xdd, 0x27, 0x4e, 0xb7, 0x3c, 0xc1, 0x37,
+	0xf3, 0xcb, 0x89, 0x7a, 0x6f, 0x49, 0x02, 0xcd, 0x27, 0x45, 0x17, 0x61, 0xf6, 0x1e, 0x71, 0xee,
+	0x58, 0x76, 0xdb, 0x17, 0x7b, 0xc4, 0x0f, 0x27, 0xaf, 0x95, 0xe4, 0x82, 0x27, 0xf5, 0xa8, 0x72,
+	0x05, 0xfe, 0x3f, 0xc6, 0x64, 0x84, 0x20, 0x63, 0x1b, 0x5d, 0xcc, 0x3d, 0x2e, 0xaf, 0xf1, 0x67,
+	0x34, 0x07, 0x13, 0x87, 0x46, 0xa7, 0x8f, 0xb9, 0xcf, 0xe4, 0x35, 0xf1, 0xa2, 0xfe, 0x48, 0x81,
+	0x9c, 0xa7, 0x04, 0xd2, 0x00, 0x7a, 0x0e, 0x8b, 0x16, 0x6a, 0x61, 0x71, 0x4a, 0x85, 0x7a, 0x7d,
+	0x64, 0x1b, 0xaa, 0x5b, 0x82, 0xf6, 0x48, 0x0b, 0x71, 0xa9, 0x7c, 0x05, 0x72, 0x1e, 0x7c, 0x0c,
+	0xb5, 0x7e, 0xa6, 0x40, 0xde, 0xb7, 0x12, 0x5d, 0x81, 0x09, 0x71, 0x48, 0x42, 0xa5, 0xe4, 0x6d,
+	0x65, 0xe7, 0xb7, 0x49, 0x4c, 0xac, 0x09, 0x3a, 0xb4, 0x05, 0x85, 0xf0, 0x11, 0x0a, 0xaf, 0xaa,
+	0x8e, 0x10, 0x35, 0x52, 0x03, 0xce, 0x2b, 0xcc, 0x42, 0xfd, 0x48, 0x81, 0x9c, 0x27, 0x25, 0xd6,
+	0xae, 0x2b, 0x90, 0x3d, 0x5e, 0x8c, 0x4a, 0x32, 0xf4, 0x2c, 0x4c, 0x5b, 0xae, 0x4c, 0x8f, 0xec,
+	0x50, 0xcb, 0x19, 0x1e, 0x66, 0x53, 0x96, 0xdb, 0xf4, 0x61, 0x3c, 0xd0, 0x32, 0xaa, 0x09, 0xd3,
+	0x11, 0x55, 0x9f, 0x88, 0x42, 0xea, 0x57, 0x21, 0x2b, 0x20, 0x8c, 0xfd, 0x81, 0xe1, 0x1e, 0x78,
+	0xec, 0xd9, 0x33, 0x3a, 0x07, 0xe0, 0x5a, 0xef, 0x63, 0x7d, 0xef, 0x88, 0xf2, 0x1d, 0x56, 0x16,
+	0xd2, 0x5a, 0x9e, 0x41, 0x56, 0x19, 0x40, 0xfd, 0x2c, 0x0b, 0xf3, 0x42, 0x6f, 0x6c, 0x8a, 0x04,
+	0x79, 0x03, 0x53, 0xc3, 0x34, 0xa8, 0x81, 0xe6, 0x21, 0xcb, 0xfc, 0x1a, 0x3b, 0x92, 0x9f, 0x7c,
+	0x43, 0x4d, 0x28, 0xdd, 0xed, 0xe3, 0x3e, 0x36, 0x75, 0x3f, 0xfd, 0x4a, 0xd5, 0x2b, 0x43, 0x49,
+	0x6a, 0xc7, 0xc3, 0xd0, 0x8a, 0x82, 0xc6, 0x07, 0xa0, 0x2d, 0x98, 0x17, 0x0c, 0x75, 0x97, 0x1a,
+	0x0e, 0x0d, 0x31, 0x4b, 0x27, 0x32, 0x9b, 0x13, 0x94, 0xdb, 0x8c, 0x30, 0xe0, 0x78, 0x1b, 0x2a,
+	0x92, 0x63, 0x8b, 0x74, 0x7b, 0x1d, 0x4c, 0x23, 0x2a, 0x66, 0x12, 0xb9, 0x96, 0x05, 0x75, 0xc3,
+	0x23, 0x0e, 0x38, 0xbf, 0x0b, 0x67, 0x45, 0x8e, 0xdf, 0xc7, 0xb4, 0x75, 0x30, 0xa4, 0xf0, 0x44,
+	0x32, 0x6b, 0x4e, 0x7e, 0x95, 0x51, 0x0f, 0x28, 0x6d, 0xc0, 0xf9, 0x30, 0xeb, 0x38, 0xcd, 0xb3,
+	0x89, 0xec, 0x9f, 0x0a, 0xd8, 0xc7, 0x68, 0x7f, 0x0b, 0xce, 0xf8, 0xfe, 0x33, 0xa4, 0xfb, 0x64,
+	0x22, 0xf3, 0xd3, 0x3e, 0xf1, 0x80, 0xea, 0xef, 0xc1, 0xb9, 0x80, 0x6f, 0x9c, 0xe2, 0xb9, 0x44,
+	0xde, 0x67, 0x7d, 0x06, 0x31, 0x7a, 0x7f, 0x1b, 0xce, 0xc9, 0x3c, 0xdf, 0xef, 0x75, 0x88, 0x61,
+	0x0e, 0xe9, 0x9e, 0x4f, 0xe4, 0x5f, 0x11, 0x0c, 0x76, 0x39, 0xfd, 0x80, 0xfa, 0x18, 0x9e, 0x89,
+	0xb2, 0x8f, 0x33, 0x01, 0x12, 0x45, 0x3c, 0x1d, 0x16, 0x31, 0x6c, 0x85, 0xfa, 0xab, 0x0c, 0x4c,
+	0x89, 0xc8, 0xd2, 0xb0, 0xdb, 0xef, 0x50, 0xb4, 0x39, 0x50, 0xe1, 0x44, 0xd6, 0xbb, 0x98, 0x18,
+	0xf6, 0x37, 0xfd, 0x12, 0x18, 0x2d, 0x87, 0x7a, 0x6c, 0x39, 0x4c, 0x73, 0xae, 0x4b, 0x23, 0x72,
+	0xf5, 0xd3, 0x54, 0x5c, 0x01, 0x3d, 0x0b, 0x79, 0x7c, 0xdf, 0xa2, 0x7a, 0x8b, 0x98, 0x22, 0xdb,
+	0x4d, 0x68, 0x39, 0x06, 0x68, 0xb0, 0x94, 0xc6, 0xf2, 0x0b, 0x35, 0x09, 0xbb, 0xff, 0x18, 0xf7,
+	0x78, 0x24, 0x4c, 0x69, 0x79, 0x01, 0xd1, 0x8c, 0x7b, 0x68, 0x03, 0xa6, 0xe5, 0xb2, 0x4c, 0x72,
+	0xd9, 0xf1, 0x92, 0xdc, 0x94, 0xa0, 0x16, 0x6f, 0x52, 0x18, 0x76, 0x1c, 0x2e, 0x6c, 0xd2, 0x17,
+	0x86, 0x1d, 0x27, 0x10, 0xc6, 0x96, 0xa5, 0xb0, 0xdc, 0xf8, 0xc2, 0xb0, 0xe3, 0x48, 0x61, 0xfb,
+	0x80, 0x02, 0xf7, 0xee, 0xca, 0xac, 0x28, 0x7d, 0xee, 0x95, 0x44, 0x96, 0xf1, 0x49, 0x55, 0x9b,
+	0xf5, 0x91, 0x3c, 0xd0, 0xf5, 0x4c, 0x4e, 0x29, 0xa5, 0xd4, 0x1f, 0x2a, 0x00, 0xc1, 0x09, 0xb3,
+	0x54, 0xde, 0x33, 0xa8, 0x9f, 0xca, 0xd9, 0xf3, 0x7f, 0xb5, 0x74, 0x7d, 0xac, 0x40, 0x66, 0xc7,
+	0xc1, 0x18, 0xbd, 0x01, 0x19, 0x87, 0x10, 0xef, 0x92, 0xbc, 0x38, 0x7a, 0x6d, 0xd6, 0x38, 0x1d,
+	0xba, 0x0a, 0xb9, 0xd6, 0x81, 0xd5, 0x31, 0x1d, 0x6c, 0x4b, 0x4f, 0x1f, 0x87, 0x87, 0x4f, 0xab,
+	0xf6, 0xa1, 0x38, 0xe0, 0xaa, 0xb1, 0x7b, 0xb4, 0x0e, 0x05, 0xea, 0x60, 0xec, 0x39, 0x40, 0x7a,
+	0xbc, 0x8d, 0x02, 0x46, 0x2b, 0x9e, 0xaf, 0x67, 0x72, 0xa9, 0x52, 0x5a, 0xbd, 0x04, 0xc5, 0xa6,
+	0x87, 0xb8, 0x45, 0x3a, 0x56, 0xeb, 0x08, 0x55, 0x20, 0xd7, 0x73, 0x2c, 0xe2, 0x58, 0xf4, 0x88,
+	0x8b, 0x9e, 0xd0, 0xfc, 0x77, 0x75, 0x09, 0x90, 0x88, 0x72, 0x97, 0x5f, 0xb8, 0x47, 0xa0, 0xf8,
+	0x2c, 0x0d, 0x33, 0x42, 0x02, 0xd6, 0xf0, 0xdd, 0xbe, 0x77, 0x4c, 0xb6, 0x4b, 0x0d, 0xbb, 0x85,
+	0xf5, 0xd0, 0x75, 0x61, 0xca, 0x03, 0x6e, 0xb2, 0x6b, 0xc3, 0x22, 0xcc, 0xba, 0x77, 0xac, 0x9e,
+	0xb8, 0xea, 0xeb, 0x1d, 0x42, 0xee, 0xf4, 0x45, 0xe5, 0xcc, 0x69, 0x45, 0xb6, 0xc0, 0xe5, 0x6f,
+	0x70, 0x30, 0x8b, 0x0b, 0x83, 0xbb, 0xe1, 0x71, 0x83, 0x50, 0x50, 0x8b, 0x37, 0xf4, 0x4d, 0x28,
+	0x05, 0x71, 0xd1, 0xe3, 0x16, 0xca, 0x2a, 0xb2, 0x34, 0x62, 0x54, 0xf8, 0x7b, 0xa9, 0x15, 0xf1,
+	0xc0, 0xe6, 0x62, 0x98, 0x73, 0xc4, 0x06, 0x4a, 0xcb, 0xa4, 0x00, 0x11, 0xc9, 0x97, 0x13, 0x05,
+	0x0c, 0xef, 0xbe, 0x86, 0x9c, 0xe1, 0x13, 0xb9, 0x0d, 0x45, 0xb1, 0x15, 0xfa, 0x7e, 0xdf, 0xe6,
+	0xc6, 0xf1, 0xc0, 0x9e, 0xa9, 0xd7, 0x46, 0xdc, 0x93, 0xab, 0x92, 0x4c, 0x9b, 0x31, 0x23, 0xef,
+	0xc2, 0x6d, 0xae, 0x67, 0x72, 0x99, 0xd2, 0xc4, 0xf5, 0x4c, 0x6e, 0xa2, 0x94, 0x55, 0xef, 0xc2,
+	0xe4, 0x06, 0x69, 0xf3, 0xa8, 0x0e, 0x22, 0x58, 0x39, 0x5e, 0x04, 0x3f, 0x07, 0x33, 0x07, 0xfd,
+	0xae, 0x61, 0xeb, 0x0e, 0x36, 0x4c, 0x1e, 0xc2, 0x29, 0x7e, 0xe4, 0xd3, 0x1c, 0xaa, 0x49, 0xa0,
+	0xfa, 0xaf, 0x94, 0xe7, 0xb6, 0x58, 0xc3, 0x6e, 0x8f, 0xd8, 0x2e, 0x46, 0x4d, 0xc8, 0x8a, 0x8d,
+	0x90, 0xb2, 0x2f, 0x25, 0xca, 0x0e, 0x57, 0x2d, 0x4d, 0x12, 0x33, 0xe7, 0xe4, 0x07, 0x63, 0xea,
+	0x92, 0x9b, 0x50, 0x60, 0x4a, 0x00, 0x65, 0x89, 0x5b, 0x84, 0xac, 0x68, 0xbf, 0x65, 0x00, 0x22,
+	0xaf, 0x7e, 0x3a, 0xbd, 0x56, 0x75, 0x9b, 0xaf, 0x68, 0x12, 0x03, 0x19, 0x50, 0x70, 0xb1, 0x73,
+	0x88, 0x1d, 0xbd, 0x43, 0xda, 0xa2, 0x8d, 0x2b, 0xd4, 0xdf, 0x1c, 0x35, 0xbf, 0x7a, 0xe6, 0x55,
+	0xb7, 0x39, 0x8f, 0x0d, 0xd2, 0x76, 0x9b, 0x36, 0x75, 0x8e, 0x34, 0x70, 0x7d, 0x40, 0xa5, 0x0d,
+	0xc5, 0x81, 0x65, 0x54, 0x82, 0xf4, 0x1d, 0x7c, 0x24, 0x23, 0x8b, 0x3d, 0xa2, 0x37, 0xc2, 0x0d,
+	0x4f, 0xa1, 0xbe, 0x90, 0xa8, 0x81, 0x3c, 0x54, 0xd9, 0x1a, 0x2d, 0xa7, 0x5e, 0x55, 0xd4, 0x2f,
+	0x52, 0x50, 0x96, 0x8a, 0xdd, 0xf4, 0x86, 0x1b, 0xfe, 0x7d, 0x7a, 0x17, 0x26, 0x5c, 0x6a, 0xb4,
+	0x45, 0x38, 0xcf, 0xd4, 0xaf, 0x8c, 0x6a, 0xe2, 0x10, 0x27, 0xb6, 0x83, 0x6d, 0xac, 0x09, 0x6e,
+	0xc3, 0xc1, 0x9d, 0x7a, 0x9c, 0xe0, 0x7e, 0x01, 0x90, 0xac, 0xd7, 0x2e, 0x75, 0xb0, 0xd1, 0x15,
+	0x09, 0x28, 0x2d, 0xda, 0x5c, 0xb1, 0xb2, 0xcd, 0x17, 0x78, 0x12, 0x12, 0xd8, 0xac, 0xe0, 0x86,
+	0xb1, 0x33, 0x3e, 0x36, 0x76, 0x9c, 0x00, 0x5b, 0xbd, 0x09, 0x13, 0x5c, 0x73, 0x54, 0x80, 0xc9,
+	0xdd, 0xcd, 0xb7, 0x37, 0x6f, 0xbe, 0xb3, 0x59, 0xfa, 0x3f, 0x54, 0x84, 0x42, 0x63, 0xa5, 0xb1,
+	0xde, 0xd4, 0x1b, 0xeb, 0xcd, 0xc6, 0xdb, 0x25, 0x05, 0x01, 0x64, 0xbf, 0xbe, 0xdb, 0xdc, 0x6d,
+	0xae, 0x95, 0x52, 0x68, 0x1a, 0xf2, 0xcd, 0xdb, 0xcd, 0xc6, 0xee, 0xce, 0xb5, 0xcd, 0xb7, 0x4a,
+	0x69, 0xf6, 0xda, 0xb8, 0x79, 0x63, 0x6b, 0xa3, 0xb9, 0xd3, 0x5c, 0x2b, 0x65, 0xd4, 0x45, 0x98,
+	0x7b, 0xc7, 0xb0, 0xa8, 0x9f, 0x54, 0xbc, 0x04, 0x1a, 0xd3, 0x66, 0xa9, 0x7f, 0x53, 0x60, 0xfe,
+	0x2d, 0x4c, 0x23, 0x3e, 0x3d, 0x4e, 0xbe, 0x3d, 0xd9, 0x6d, 0x8e, 0xc9, 0x3f, 0xd9, 0x13, 0xc9,
+	0x3f, 0xea, 0x2f, 0xd3, 0x70, 0x66, 0xb7, 0x67, 0x1a, 0x14, 0xff, 0x8f, 0x98, 0xaa, 0xf9, 0xdc,
+	0x64, 0xc2, 0x48, 0x1f, 0x27, 0xfd, 0x4c, 0x19, 0xa1, 0xb7, 0x87, 0x56, 0x89, 0xcc, 0x13, 0xaf,
+	0x12, 0x13, 0x27, 0x73, 0x4a, 0x9f, 0x2b, 0x70, 0xfa, 0xaa, 0x65, 0x9b, 0x37, 0x2c, 0xd7, 0xb5,
+	0xec, 0xf6, 0x6a, 0x87, 0xec, 0xb9, 0x63, 0x9d, 0xd1, 0x75, 0x98, 0xda, 0xeb, 0x90, 0x3d, 0x79,
+	0x42, 0x5e, 0x13, 0x31, 0xf2, 0x11, 0x15, 0x18, 0xb1, 0x78, 0x76, 0xe3, 0xcc, 0x4c, 0x9f, 0x8c,
+	0x99, 0x7d, 0x28, 0x0f, 0x5b, 0x29, 0xeb, 0xd1, 0xbb, 0x30, 0xd7, 0x15, 0x70, 0xfd, 0x71, 0x2c,
+	0x41, 0xdd, 0x80, 0xb9, 0x34, 0x48, 0xbd, 0x07, 0xb3, 0x22, 0x04, 0x18, 0xd0, 0xdb, 0x56, 0x3e,
+	0xf7, 0xb5, 0x29, 0xb6, 0xe9, 0xf1, 0xe7, 0xbe, 0x9c, 0x3c, 0x18, 0xb6, 0xf0, 0x86, 0x20, 0xc5,
+	0xbb, 0x10, 0xfe, 0xac, 0xfe, 0x43, 0x81, 0xd3, 0xab, 0x06, 0x6d, 0x1d, 0x04, 0xe2, 0xc7, 0x3b,
+	0xd6, 0x4d, 0xc8, 0x39, 0x02, 0xdf, 0xdb, 0x88, 0xe4, 0x39, 0xdf, 0x90, 0xa9, 0x9a, 0xcf, 0xe3,
+	0x09, 0x7a, 0xf0, 0xbf, 0x15, 0x28, 0x0f, 0x9b, 0x2a, 0xcf, 0xf6, 0x3b, 0x90, 0x77, 0xe4, 0xb3,
+	0x37, 0x1c, 0x5c, 0x4d, 0x14, 0xf8, 0x30, 0x6e, 0x55, 0xef, 0x41, 0x0b, 0x98, 0x56, 0xbe, 0xab,
+	0x40, 0xce, 0x17, 0xb7, 0x0e, 0x85, 0x90, 0x0b, 0x8d, 0x7b, 0xae, 0x10, 0xc4, 0x42, 0xe8, 0xe2,
+	0x92, 0x4a, 0xba, 0xb8, 0xa8, 0x3f, 0x4e, 0xc1, 0xcc, 0x5b, 0x98, 0xb2, 0x2e, 0x69, 0xac, 0x33,
+	0x5e, 0x87, 0xc2, 0x63, 0x7c, 0x2a, 0x00, 0x27, 0xf8, 0x4a, 0x70, 0x16, 0xf2, 0x3d, 0xa3, 0x8d,
+	0x75, 0x36, 0xce, 0x2b, 0xa7, 0x65, 0x63, 0x61, 0xb4, 0xf1, 0xb6, 0xf5, 0x3e, 0x6f, 0xcc, 0xf9,
+	0x22, 0x25, 0x77, 0xb0, 0x2d, 0x6b, 0x32, 0x47, 0xdf, 0x61, 0x80, 0x27, 0xe8, 0x19, 0x1f, 0x2a,
+	0x50, 0xf4, 0xf7, 0x45, 0x9e, 0xd0, 0x46, 0x74, 0xd0, 0xab, 0x8c, 0xdd, 0x08, 0x86, 0xc9, 0xd1,
+	0xf3, 0x50, 0xb4, 0xf1, 0x7d, 0xaa, 0x87, 0xec, 0x13, 0x53, 0xea, 0x69, 0x06, 0xde, 0xf2, 0x6c,
+	0x54, 0x5f, 0xe7, 0x25, 0xbf, 0x61, 0xf4, 0x8c, 0x3d, 0xab, 0x63, 0x51, 0x0b, 0x8f, 0x15, 0x8c,
+	0xea, 0xdf, 0x15, 0x40, 0xe2, 0xde, 0x18, 0x66, 0x81, 0x0c, 0x40, 0xa2, 0xe8, 0xb4, 0x42, 0x50,
+	0xe9, 0x74, 0xc9, 0xd1, 0xca, 0xeb, 0x4b, 0x44, 0xa5, 0xd9, 0xd6, 0x20, 0x08, 0x75, 0x61, 0x3e,
+	0x34, 0x59, 0x0b, 0x8b, 0x11, 0xde, 0xf2, 0xf2, 0xe8, 0x8d, 0x56, 0x44, 0xd4, 0x29, 0x1c, 0x07,
+	0x56, 0xaf, 0xc2, 0x39, 0x51, 0x6c, 0xb9, 0x72, 0x22, 0x04, 0x23, 0xfa, 0x3c, 0x07, 0x33, 0x7d,
+	0x0e, 0xd5, 0xb1, 0xcd, 0x1a, 0x0c, 0x93, 0x9b, 0x9b, 0xd3, 0xa6, 0x05, 0xb4, 0x29, 0x80, 0xea,
+	0x1f, 0x15, 0x98, 0xdb, 0x92, 0x8d, 0x6d, 0x84, 0xbe, 0xc5, 0x3e, 0x60, 0x70, 0x78, 0x70, 0xfa,
+	0x8d, 0xe4, 0x0f, 0x18, 0x31, 0xac, 0x7c, 0xa0, 0x66, 0xd8, 0x6d, 0xac, 0x85, 0xd8, 0x56, 0x76,
+	0x61, 0x3a, 0xb2, 0xc8, 0x3e, 0x25, 0x75, 0x2d, 0x5b, 0x1f, 0x68, 0xbd, 0x0b, 0x5d, 0xcb, 0xf6,
+	0xf0, 0x38, 0x8a, 0x71, 0x3f, 0x40, 0x49, 0x49, 0x14, 0xe3, 0xbe, 0x87, 0xa2, 0x7e, 0x9a, 0x86,
+	0xd9, 0xa1, 0x43, 0x8b, 0x0b, 0x1f, 0x66, 0xd6, 0xe3, 0x87, 0x0f, 0xfa, 0x50, 0x81, 0x0b, 0xf2,
+	0xc2, 0x24, 0xdc, 0x4c, 0xee, 0x7c, 0x8c, 0x1b, 0xbc, 0x31, 0xe2, 0x1d, 0xea, 0x21, 0xc7, 0xaa,
+	0x9d, 0x33, 0x1e, 0x79, 0xea, 0x7d, 0x38, 0x2b, 0x6f, 0x57, 0x72, 0x2f, 0xa2, 0x3a, 0x88, 0x7b,
+	0xdc, 0x4b, 0xc7, 0x3a, 0x46, 0xed, 0x0c, 0xe7, 0x1c, 0xeb, 0x2c, 0xcb, 0x50, 0x61, 0x67, 0xb2,
+	0xc7, 0xca, 0x81, 0x4e, 0x09, 0x35, 0x3a, 0x7a, 0xe8, 0x0b, 0x46, 0x86, 0x7f, 0xc1, 0x98, 0xef,
+	0x1a, 0xf7, 0x79, 0xbd, 0xd8, 0x61, 0xeb, 0xdb, 0xfe, 0xe7, 0x8c, 0x4f, 0x52, 0x70, 0x2a, 0xd6,
+	0xf5, 0xe3, 0x0f, 0xec, 0x24, 0xf2, 0x1d, 0xf3, 0x21, 0x86, 0xee, 0x87, 0x86, 0x68, 0x88, 0x0b,
+	0x0c, 0x26, 0x03, 0x03, 0x3d, 0x80, 0xf3, 0xa1, 0x91, 0xc9, 0xc9, 0xef, 0x66, 0x30, 0x87, 0x8f,
+	0x5b, 0x56, 0x6f, 0x40, 0x61, 0x87, 0x90, 0xce, 0x1a, 0xa6, 0x86, 0xd5, 0xe1, 0xf3, 0x5c, 0x4a,
+	0x48, 0x27, 0x9c, 0xf8, 0x72, 0x0c, 0xc0, 0xab, 0xd3, 0x33, 0x30, 0xc5, 0x17, 0x0f, 0xb1, 0xe3,
+	0xb2, 0x4d, 0x12, 0x89, 0xb5, 0xc0, 0x60, 0xb7, 0x04, 0x48, 0xfd, 0xa7, 0x02, 0x45, 0x99, 0x48,
+	0xfd, 0xe6, 0xf6, 0xa6, 0x24, 0x33, 0x85, 0x0c, 0x99, 0x0e, 0x5f, 0x48, 0x34, 0x27, 0xa4, 0x97,
+	0x10, 0x12, 0x52, 0x52, 0x46, 0x81, 0x65, 0x4a, 0x25, 0x72, 0x02, 0x70, 0xcd, 0x64, 0x7d, 0x27,
+	0x97, 0x66, 0xd9, 0x87, 0xa4, 0x65, 0x78, 0x58, 0xb2, 0x4b, 0x65, 0x2b, 0xd7, 0xfc, 0x85, 0x6b,
+	0x26, 0x5a, 0x86, 0x33, 0x2d, 0xe2, 0x38, 0xb8, 0x63, 0xb0, 0xd9, 0x7e, 0x40, 0xe3, 0x32, 0x22,
+	0x51, 0x18, 0x4f, 0x07, 0x08, 0x01, 0xa9, 0x7b, 0xcd, 0x54, 0xff, 0xaa, 0xc0, 0x29, 0xee, 0x68,
+	0x6c, 0xb6, 0x32, 0xfe, 0x7d, 0x6e, 0x05, 0x26, 0x8f, 0x79, 0xaf, 0xf5, 0xe8, 0xe2, 0x1c, 0x37,
+	0x73, 0x32, 0x85, 0xfa, 0xfb, 0x29, 0x98, 0x1f, 0xb4, 0x4d, 0xd6, 0xeb, 0xf7, 0x86, 0x2f, 0x70,
+	0x6f, 0x8e, 0x76, 0x81, 0x1b, 0xe2, 0x15, 0x7b, 0x7d, 0xfb, 0x41, 0xf8, 0xfa, 0xf6, 0xd8, 0x53,
+	0xb1, 0x98, 0xab, 0xf8, 0x38, 0x23, 0xa8, 0xc5, 0x75, 0x98, 0x89, 0x6e, 0x55, 0x74, 0x42, 0x01,
+	0x90, 0xdd, 0x5e, 0x5f, 0xa9, 0xbf, 0xf4, 0x72, 0x49, 0x41, 0x39, 0xc8, 0x6c, 0xaf, 0xaf, 0xbc,
+	0x58, 0x4a, 0xa1, 0x49, 0x48, 0xdf, 0x58, 0x7b, 0xa9, 0x94, 0x66, 0xcb, 0xab, 0x1b, 0x2b, 0x6f,
+	0x37, 0x2f, 0x97, 0xf2, 0xf5, 0xdf, 0xa6, 0x20, 0xef, 0xe7, 0x1f, 0xf4, 0xb1, 0x02, 0x93, 0xe2,
+	0x0d, 0xa3, 0xda, 0xe8, 0x13, 0x2d, 0xee, 0x5f, 0x95, 0x73, 0x9e, 0xc2, 0xa1, 0x5f, 0x66, 0xaa,
+	0xfe, 0x2c, 0x48, 0x7d, 0xf1, 0x7b, 0x7f, 0xfa, 0xfc, 0x93, 0xd4, 0xc5, 0x65, 0x65, 0x51, 0x7d,
+	0xbe, 0x76, 0x58, 0xaf, 0x7d, 0x10, 0x71, 0xc6, 0xd7, 0x17, 0x17, 0x1f, 0xd4, 0x44, 0xb8, 0xb8,
+	0xcb, 0x42, 0x0a, 0x5e, 0x52, 0xd0, 0x4f, 0x14, 0x98, 0x8e, 0x4c, 0x4c, 0x50, 0x72, 0xc2, 0x89,
+	0x9b, 0xb0, 0x8c, 0xad, 0x1c, 0xd7, 0x29, 0xf8, 0xdf, 0xa7, 0xb6, 0xb8, 0xf8, 0x60, 0xf9, 0x5e,
+	0x98, 0xf1, 0x92, 0x52, 0xff, 0x73, 0x1a, 0x0a, 0xa1, 0x7a, 0x85, 0xfe, 0x22, 0xee, 0x91, 0x91,
+	0x6f, 0x67, 0xc9, 0xdf, 0x5d, 0xe2, 0x67, 0x3c, 0x95, 0xf1, 0xc6, 0x0d, 0xea, 0xb7, 0xb8, 0x01,
+	0xb7, 0xd0, 0xce, 0x23, 0xb7, 0x56, 0x20, 0xbb, 0xb5, 0x0f, 0x22, 0xe3, 0x92, 0x2a, 0xfb, 0xee,
+	0xfe, 0x60, 0x10, 0x18, 0x94, 0xb0, 0x07, 0xe8, 0x0b, 0x05, 0xd0, 0xf0, 0x8c, 0x06, 0x2d, 0x8f,
+	0xd8, 0xea, 0x9d, 0x80, 0x7d, 0x77, 0xb8, 0x7d, 0x78, 0x39, 0x3a, 0x9a, 0xa9, 0x3c, 0x11, 0x73,
+	0xeb, 0x3f, 0xcd, 0xc2, 0x99, 0x86, 0xe8, 0x9d, 0x57, 0x4c, 0xd3, 0xc1, 0xae, 0xcb, 0xaa, 0xe2,
+	0x36, 0x25, 0x0e, 0x1b, 0x06, 0xfe, 0x4e, 0x81, 0xd2, 0xe0, 0x90, 0x00, 0xbd, 0x3a, 0xc2, 0xaf,
+	0x24, 0xb1, 0xd3, 0x93, 0xca, 0x6b, 0xc7, 0xa0, 0x14, 0x79, 0x48, 0xbd, 0xcc, 0x37, 0xe5, 0x12,
+	0xf3, 0xda, 0x85, 0x87, 0x6c, 0x04, 0x6b, 0x15, 0xdd, 0xe5, 0xfd, 0x80, 0x03, 0x57, 0x7f, 0xb0,
+	0x73, 0x1d, 0x41, 0xfd, 0x87, 0x4c, 0x09, 0x2a, 0xaf, 0x1d, 0x83, 0x72, 0x5c, 0xf5, 0xf7, 0x02,
+	0x0e, 0xe8, 0x37, 0x0a, 0xcc, 0x44, 0xf3, 0x36, 0x7a, 0x79, 0xec, 0x44, 0x2f, 0x54, 0x7f, 0xe5,
+	0x98, 0x05, 0x62, 0x94, 0x54, 0x16, 0x52, 0x9c, 0xd1, 0xa3, 0x3f, 0x28, 0x30, 0x29, 0x7b, 0xcc,
+	0x11, 0x32, 0x6b, 0xb4, 0x4b, 0xaf, 0x2c, 0x8d, 0x4e, 0x20, 0x35, 0xbc, 0xcd, 0x35, 0xd4, 0xd0,
+	0xd6, 0xa3, 0xd4, 0xab, 0x7d, 0x10, 0x6a, 0xeb, 0xbd, 0xa8, 0x08, 0x83, 0x42, 0x31, 0xb1, 0xdc,
+	0x16, 0x12, 0x96, 0x94, 0xfa, 0xef, 0x15, 0x98, 0x8a, 0xdc, 0x54, 0x7f, 0x2d, 0xf2, 0x5e, 0x04,
+	0x36, 0x52, 0xde, 0x8b, 0x69, 0x74, 0x2b, 0xc9, 0xb3, 0xd0, 0xe1, 0x0e, 0x57, 0xbd, 0xc8, 0xcd,
+	0x7d, 0x0e, 0x3d, 0xfb, 0x10, 0x73, 0xc3, 0x17, 0xd7, 0x55, 0x07, 0x92, 0x7e, 0x0d, 0x5d, 0x9d,
+	0xd3, 0x38, 0x30, 0xf8, 0xf8, 0xe7, 0x10, 0x4a, 0xb6, 0x94, 0x6f, 0x14, 0x05, 0xb2, 0x8f, 0xfb,
+	0x8b, 0x54, 0x5a, 0x6b, 0xde, 0xfe, 0x34, 0x75, 0x7e, 0x95, 0x33, 0x5c, 0xe5, 0x0c, 0x05, 0x6d,
+	0xd0, 0xd0, 0x56, 0x6f, 0xd5, 0xf7, 0xb2, 0xfc, 0x3f, 0x8c, 0xcb, 0xff, 0x19, 0x00, 0xdb, 0x50,
+	0x1a, 0xd0, 0xad, 0x2a, 0x00, 0x00,
 }
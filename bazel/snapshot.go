@@ -11,6 +11,7 @@ import (
 )
 
 // Generate a SnapshotID based on digest sha and size
+// SnapshotIDs are always of ExecutionDigestFunction digests.
 func SnapshotID(sha string, size int64) string {
 	return fmt.Sprintf("%s-%s-%d", SnapshotIDPrefix, sha, size)
 }
@@ -10,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/cloud/cluster/local"
 	"github.com/twitter/scoot/common/endpoints"
@@ -32,6 +33,7 @@ func main() {
 	configFlag := flag.String("config", "{}", "API Server Config (either a filename like local.local or JSON text")
 	logLevelFlag := flag.String("log_level", "info", "Log everything at this level and above (error|info|debug)")
 	cacheSize := flag.Int64("cache_size", 2*1024*1024*1024, "In-memory bundle cache size in bytes.")
+	outputValidation := flag.String("output_validation", "sync", "How cached ActionResults are checked for missing outputs (sync|async|none)")
 	flag.Parse()
 
 	level, err := log.ParseLevel(*logLevelFlag)
@@ -39,6 +41,13 @@ func main() {
 		log.Error(err)
 		return
 	}
+
+	actionCacheConfig := cas.DefaultActionCacheConfig()
+	actionCacheConfig.OutputValidation, err = cas.ParseOutputValidation(*outputValidation)
+	if err != nil {
+		log.Error(err)
+		return
+	}
 	log.SetLevel(level)
 
 	// The same config will be used for both bundlestore and frontend (TODO: frontend).
@@ -66,6 +75,7 @@ func main() {
 	bag.PutMany(
 		func() endpoints.StatScope { return "apiserver" },
 		func() endpoints.Addr { return endpoints.Addr(*httpAddr) },
+		func() *cas.ActionCacheConfig { return actionCacheConfig },
 		func(bs *bundlestore.Server, vs *snapshots.ViewServer, sh *StoreAndHandler) map[string]http.Handler {
 			return map[string]http.Handler{
 				"/bundle/": bs,
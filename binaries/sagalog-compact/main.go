@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/log/hooks"
+	"github.com/twitter/scoot/saga/sagalogs"
+)
+
+// Archives or deletes the sagas of a file saga log that ended more than --older_than ago.
+// It's safe to run alongside the scheduler, which never writes to sagas once they've ended,
+// except for --rebuild_index which locks the log and fails while the scheduler is running.
+func main() {
+	log.AddHook(hooks.NewContextHook())
+
+	dirFlag := flag.String("dir", "", "Directory of the file saga log to compact")
+	olderThanFlag := flag.Duration("older_than", 7*24*time.Hour, "Remove sagas that ended at least this long ago")
+	archiveDirFlag := flag.String("archive_dir", "", "Move removed sagas to this directory instead of deleting them, must be on the same filesystem")
+	dryRunFlag := flag.Bool("dry_run", false, "Print the sagas that would be removed without removing them")
+	rebuildIndexFlag := flag.Bool("rebuild_index", false, "Rebuild the index of active sagas from the sagas in the log before compacting")
+	logLevelFlag := flag.String("log_level", "info", "Log everything at this level and above (error|info|debug)")
+	flag.Parse()
+
+	level, err := log.ParseLevel(*logLevelFlag)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	log.SetLevel(level)
+
+	if *dirFlag == "" {
+		log.Fatal("--dir is required")
+	}
+	slog, err := sagalogs.MakeFileSagaLog(*dirFlag)
+	if err != nil {
+		log.Fatalf("Error opening saga log %s: %v", *dirFlag, err)
+	}
+
+	if *rebuildIndexFlag {
+		// The scheduler writes to the index, so it must not be using the log while it's rebuilt.
+		if err := slog.Lock(); err == sagalogs.ErrFileSagaLogLocked {
+			log.Fatal("The saga log is in use, stop the scheduler before rebuilding the active saga index")
+		} else if err != nil {
+			log.Fatalf("Error locking saga log %s: %v", *dirFlag, err)
+		}
+		defer slog.Close()
+		if err := slog.RebuildActiveIndex(); err != nil {
+			log.Fatalf("Error rebuilding the active saga index: %v", err)
+		}
+		active, _ := slog.GetActiveSagas()
+		log.Infof("Rebuilt the active saga index, %d sagas are active", len(active))
+	}
+
+	sagaIds, err := slog.GetCompletedSagas(*olderThanFlag)
+	if err != nil {
+		log.Fatalf("Error finding completed sagas: %v", err)
+	}
+	if *dryRunFlag {
+		for _, sagaId := range sagaIds {
+			fmt.Println(sagaId)
+		}
+		return
+	}
+	if err := slog.RemoveSagas(sagaIds, *archiveDirFlag); err != nil {
+		log.Fatalf("Error removing completed sagas: %v", err)
+	}
+	if *archiveDirFlag != "" {
+		log.Infof("Archived %d sagas to %s", len(sagaIds), *archiveDirFlag)
+	} else {
+		log.Infof("Deleted %d sagas", len(sagaIds))
+	}
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/common/log/hooks"
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+)
+
+// The parts of the file saga log the commands use.
+type fileSagaLog interface {
+	saga.SagaLog
+	GetAllSagas() ([]string, error)
+	ValidateSaga(sagaId string) (*saga.SagaState, error)
+	ForceEndSaga(sagaId string, abort bool, reason string) ([]saga.SagaMessage, error)
+	GetAuditRecords() ([]sagalogs.SagaAuditRecord, error)
+}
+
+// Inspects and repairs the sagas of a file saga log.
+//
+//	Supported commands: (see "-h" for all options)
+//		list
+//		dump [saga id]
+//		validate [saga ids, all sagas if none]
+//		complete [saga id] --reason
+//		abort [saga id] --reason
+//		audit
+//	Global flags:
+//		--dir [directory of the file saga log]
+//		--log_level [<error|info|debug> level and above should be logged]
+//
+// complete and abort force a stuck saga to end and are recorded in the log's audit trail,
+// they refuse to run while a scheduler has the log open, so stop it first.
+func main() {
+	log.AddHook(hooks.NewContextHook())
+
+	var dir, logLevel, reason string
+	var slog fileSagaLog
+
+	rootCmd := &cobra.Command{
+		Use:   "sagalog-inspect",
+		Short: "sagalog-inspect lists, validates and repairs the sagas of a file saga log",
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			level, err := log.ParseLevel(logLevel)
+			if err != nil {
+				return err
+			}
+			log.SetLevel(level)
+			if dir == "" {
+				return errors.New("--dir is required")
+			}
+			slog, err = sagalogs.MakeFileSagaLog(dir)
+			return err
+		},
+		SilenceUsage: true,
+	}
+	rootCmd.PersistentFlags().StringVar(&dir, "dir", "", "Directory of the file saga log")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log_level", "info", "Log everything at this level and above (error|info|debug)")
+
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List the sagas in the log and whether they're active",
+		RunE: func(*cobra.Command, []string) error {
+			return list(slog)
+		},
+	})
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "dump [saga id]",
+		Short: "Print a saga's messages with their job, task and checkpoint data decoded",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return dump(slog, args[0])
+		},
+	})
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "validate [saga ids]",
+		Short: "Check that each message of the sagas is a valid transition, all sagas if none are given",
+		RunE: func(_ *cobra.Command, args []string) error {
+			return validate(slog, args)
+		},
+	})
+	for _, abort := range []bool{false, true} {
+		abort := abort
+		cmd := &cobra.Command{
+			Use:   "complete [saga id]",
+			Short: "Force a stuck saga to complete by ending its started tasks and the saga",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(_ *cobra.Command, args []string) error {
+				return forceEnd(slog, args[0], abort, reason)
+			},
+		}
+		if abort {
+			cmd.Use = "abort [saga id]"
+			cmd.Short = "Force a stuck saga to abort by rolling back its started tasks and ending the saga"
+		}
+		cmd.Flags().StringVar(&reason, "reason", "", "Why the saga is being forced to end, recorded in the audit trail (required)")
+		rootCmd.AddCommand(cmd)
+	}
+	rootCmd.AddCommand(&cobra.Command{
+		Use:   "audit",
+		Short: "Print the audit trail of sagas that were forced to end",
+		RunE: func(*cobra.Command, []string) error {
+			return audit(slog)
+		},
+	})
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func list(slog fileSagaLog) error {
+	sagaIds, err := slog.GetAllSagas()
+	if err != nil {
+		return err
+	}
+	active, err := slog.GetActiveSagas()
+	if err != nil {
+		return err
+	}
+	isActive := make(map[string]bool, len(active))
+	for _, sagaId := range active {
+		isActive[sagaId] = true
+	}
+	for _, sagaId := range sagaIds {
+		status := "ended"
+		if isActive[sagaId] {
+			status = "active"
+		}
+		fmt.Printf("%s\t%s\n", sagaId, status)
+	}
+	return nil
+}
+
+func dump(slog fileSagaLog, sagaId string) error {
+	msgs, err := slog.GetMessages(sagaId)
+	if err != nil {
+		return err
+	}
+	if msgs == nil {
+		return fmt.Errorf("Saga %s doesn't exist", sagaId)
+	}
+	for i, msg := range msgs {
+		fmt.Printf("%d\t%v\t%s\t%s\n", i, msg.MsgType, msg.TaskId, describeData(msg))
+	}
+	return nil
+}
+
+// Decodes the message's data as the scheduler logs it: a job for StartSaga, a SagaState
+// for Checkpoint, and JSON task statuses for task messages.
+func describeData(msg saga.SagaMessage) string {
+	if len(msg.Data) == 0 {
+		return ""
+	}
+	switch msg.MsgType {
+	case saga.StartSaga:
+		if job, err := sched.DeserializeJob(msg.Data); err == nil {
+			if def, err := json.Marshal(job.Def); err == nil {
+				return string(def)
+			}
+		}
+	case saga.Checkpoint:
+		if state, err := saga.DeserializeSagaState(msg.Data); err == nil {
+			return state.String()
+		}
+	default:
+		if utf8.Valid(msg.Data) {
+			return string(msg.Data)
+		}
+	}
+	return fmt.Sprintf("%q", msg.Data)
+}
+
+func validate(slog fileSagaLog, sagaIds []string) error {
+	if len(sagaIds) == 0 {
+		var err error
+		if sagaIds, err = slog.GetAllSagas(); err != nil {
+			return err
+		}
+	}
+	invalid := 0
+	for _, sagaId := range sagaIds {
+		state, err := slog.ValidateSaga(sagaId)
+		switch {
+		case err != nil:
+			invalid++
+			fmt.Printf("%s\tinvalid\t%v\n", sagaId, err)
+		case state == nil:
+			invalid++
+			fmt.Printf("%s\tmissing\n", sagaId)
+		default:
+			fmt.Printf("%s\tvalid\t%v\n", sagaId, state)
+		}
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d sagas are invalid", invalid, len(sagaIds))
+	}
+	return nil
+}
+
+func forceEnd(slog fileSagaLog, sagaId string, abort bool, reason string) error {
+	if reason == "" {
+		return errors.New("--reason is required")
+	}
+	msgs, err := slog.ForceEndSaga(sagaId, abort, reason)
+	if err == sagalogs.ErrFileSagaLogLocked {
+		return errors.New("The saga log is in use, stop the scheduler before forcing sagas to end")
+	}
+	for _, msg := range msgs {
+		log.Infof("Logged %v %s", msg.MsgType, msg.TaskId)
+	}
+	if err != nil {
+		return err
+	}
+	state, err := slog.ValidateSaga(sagaId)
+	if err != nil {
+		return err
+	}
+	fmt.Println(state)
+	return nil
+}
+
+func audit(slog fileSagaLog) error {
+	records, err := slog.GetAuditRecords()
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		fmt.Printf("%s\t%s@%s\t%s\t%s\t%q\t%v\n", r.Time.Format(time.RFC3339), r.User, r.Host,
+			r.Action, r.SagaId, r.Reason, r.Messages)
+	}
+	return nil
+}
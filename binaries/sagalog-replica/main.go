@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/log/hooks"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/scootapi"
+)
+
+// Serves a replica of a durable saga log's journal, see the "durable" SagaLog config of the scheduler.
+// If the scheduler's host is lost, run a scheduler with a durable SagaLog whose Directory is this replica's dir.
+func main() {
+	log.AddHook(hooks.NewContextHook())
+
+	httpAddr := flag.String("http_addr", scootapi.DefaultSagaLogReplica_HTTP, "'host:port' addr to serve http on")
+	dirFlag := flag.String("dir", "", "Directory to store the replicated journal in")
+	peersFlag := flag.String("peers", "", "Comma separated hosts of the schedulers allowed to write to this replica")
+	logLevelFlag := flag.String("log_level", "info", "Log everything at this level and above (error|info|debug)")
+	flag.Parse()
+
+	level, err := log.ParseLevel(*logLevelFlag)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	log.SetLevel(level)
+
+	if *dirFlag == "" {
+		log.Fatal("--dir is required")
+	}
+	peers := []string{}
+	for _, peer := range strings.Split(*peersFlag, ",") {
+		if peer != "" {
+			peers = append(peers, peer)
+		}
+	}
+	if len(peers) == 0 {
+		log.Fatal("--peers is required")
+	}
+	replica, err := sagalogs.MakeDirSagaLogReplica(*dirFlag)
+	if err != nil {
+		log.Fatalf("Error opening saga log replica in %s: %v", *dirFlag, err)
+	}
+	defer replica.Close()
+
+	http.Handle(sagalogs.SagaLogReplicaPath, sagalogs.NewSagaLogReplicaHandler(replica, peers))
+	log.Infof("Serving saga log replica %s on %s", *dirFlag, *httpAddr)
+	log.Fatal(http.ListenAndServe(*httpAddr, nil))
+}
@@ -6,8 +6,11 @@ package main
 import (
 	"flag"
 	"net"
+	"net/http"
 
 	"github.com/apache/thrift/lib/go/thrift"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/twitter/scoot/bazel"
@@ -54,8 +57,8 @@ func main() {
 			return scootconfig.ClientTimeout(scootconfig.DefaultClientTimeout)
 		},
 
-		func(s stats.StatsReceiver) *endpoints.TwitterServer {
-			return endpoints.NewTwitterServer(endpoints.Addr(*httpAddr), s, nil)
+		func(s stats.StatsReceiver, handlers map[string]http.Handler) *endpoints.TwitterServer {
+			return endpoints.NewTwitterServer(endpoints.Addr(*httpAddr), s, handlers)
 		},
 
 		func() (bazel.GRPCListener, error) {
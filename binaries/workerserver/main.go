@@ -18,11 +18,14 @@ import (
 	"github.com/twitter/scoot/common/dialer"
 	"github.com/twitter/scoot/common/endpoints"
 	"github.com/twitter/scoot/common/log/hooks"
+	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/config/jsonconfig"
 	"github.com/twitter/scoot/ice"
 	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
+	"github.com/twitter/scoot/runner/execer/execers"
+	osexec "github.com/twitter/scoot/runner/execer/os"
 	"github.com/twitter/scoot/runner/runners"
 	"github.com/twitter/scoot/scootapi"
 	"github.com/twitter/scoot/snapshot"
@@ -35,15 +38,29 @@ import (
 )
 
 func main() {
+	// The worker re-execs itself to set up sandboxes for commands, in which case this doesn't return.
+	osexec.RunSandboxInit()
 	log.AddHook(hooks.NewContextHook())
 
 	thriftAddr := flag.String("thrift_addr", scootapi.DefaultWorker_Thrift, "addr to serve thrift on")
 	httpAddr := flag.String("http_addr", scootapi.DefaultWorker_HTTP, "addr to serve http on")
 	configFlag := flag.String("config", "local.local", "Worker Server Config (either a filename like local.local or JSON text")
 	memCapFlag := flag.Uint64("mem_cap", 0, "Kill runs that exceed this amount of memory, in bytes. Zero means no limit.")
+	cgroupRoot := flag.String("cgroup_root", "", "Abs path of a cgroup v2 hierarchy to run each command in its own cgroup, limiting memory to mem_cap.")
+	cgroupCPUs := flag.Float64("cgroup_cpus", 0, "With cgroup_root, limit each run to this many CPUs. Zero means no limit.")
+	cgroupPids := flag.Int("cgroup_pids", 0, "With cgroup_root, limit each run to this many processes. Zero means no limit.")
+	sandboxPaths := flag.String("sandbox_paths", "", "Comma-separated abs paths, ex: /usr,/bin,/lib. If set, each run is sandboxed with Linux namespaces and sees only its checkout and these paths, read-only, without network unless the task allows it. Requires root.")
+	sandboxUid := flag.Int("sandbox_uid", 0, "Unprivileged uid that sandboxed runs use, nobody if unset")
+	sandboxGid := flag.Int("sandbox_gid", 0, "Unprivileged gid that sandboxed runs use, nobody if unset")
+	slots := flag.Int("slots", 1, "Number of runs to execute concurrently. Memory and cpu capacity are shared by all runs.")
+	capacityMemory := flag.Int64("capacity_memory", 0, "Memory in bytes offered to tasks, advertised to the scheduler. Defaults to mem_cap, zero means unlimited.")
+	capacityCPUs := flag.Float64("capacity_cpus", 0, "Number of CPUs offered to tasks, advertised to the scheduler. Zero means unlimited.")
+	capacityDisk := flag.Int64("capacity_disk", 0, "Disk in bytes offered to tasks, advertised to the scheduler. Zero means unlimited.")
 	repoDir := flag.String("repo", "", "Abs dir path to a git repo to run against (don't use important repos yet!).")
 	storeHandle := flag.String("bundlestore", "", "Abs file path or an http 'host:port' to store/get bundles.")
 	casAddr := flag.String("cas_addr", "", "'host:port' of a server supporting CAS API over GRPC")
+	uploadLogs := flag.Bool("upload_logs", false, "Upload the stdout/stderr of each run to the bundlestore when it's done, so they outlive this worker.")
+	logTTL := flag.Duration("log_ttl", 7*24*time.Hour, "With upload_logs, how long uploaded logs are kept.")
 	logLevelFlag := flag.String("log_level", "info", "Log everything at this level and above (error|info|debug)")
 	flag.Parse()
 
@@ -91,6 +108,22 @@ func main() {
 		func() execer.Memory {
 			return execer.Memory(*memCapFlag)
 		},
+		func() runners.Slots {
+			return runners.Slots(*slots)
+		},
+		func(s store.Store) *runners.LogStore {
+			if !*uploadLogs {
+				return nil
+			}
+			return &runners.LogStore{Store: s, TTL: &store.TTLConfig{TTL: *logTTL, TTLKey: store.DefaultTTLKey}}
+		},
+		func() runner.Resources {
+			memory := *capacityMemory
+			if memory == 0 {
+				memory = int64(*memCapFlag)
+			}
+			return runner.Resources{Memory: memory, CPUs: *capacityCPUs, Disk: *capacityDisk}
+		},
 		// Use storeHandle if provided, else try Fetching, then GetScootApiAddr(), then fallback to tmp file store.
 		func(tmp *temp.TempDir) (store.Store, error) {
 			if *storeHandle != "" {
@@ -144,6 +177,37 @@ func main() {
 		},
 	)
 
+	// Enforce limits with cgroups instead of polling memory usage, and isolate runs in sandboxes if requested
+	var cgroupCfg *osexec.CgroupConfig
+	if *cgroupRoot != "" {
+		cgroupCfg = &osexec.CgroupConfig{Root: *cgroupRoot, CPUs: *cgroupCPUs, Pids: *cgroupPids}
+	}
+	if *sandboxPaths != "" {
+		bag.Put(func(m execer.Memory, s stats.StatsReceiver) (execer.Execer, error) {
+			cfg := osexec.SandboxConfig{ReadOnlyPaths: strings.Split(*sandboxPaths, ","), Uid: *sandboxUid, Gid: *sandboxGid}
+			if cgroupCfg != nil {
+				cgroups := *cgroupCfg
+				cgroups.Memory = m
+				cfg.Cgroups = &cgroups
+			}
+			e, err := osexec.NewSandboxExecer(cfg, m, s)
+			if err != nil {
+				return nil, err
+			}
+			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), e), nil
+		})
+	} else if cgroupCfg != nil {
+		bag.Put(func(m execer.Memory, s stats.StatsReceiver) (execer.Execer, error) {
+			cfg := *cgroupCfg
+			cfg.Memory = m
+			e, err := osexec.NewCgroupExecer(cfg, s)
+			if err != nil {
+				return nil, err
+			}
+			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), e), nil
+		})
+	}
+
 	log.Info("Serving thrift on", *thriftAddr) //It's hard to access the thriftAddr value downstream, print it here.
 	server.RunServer(bag, schema, configText)
 }
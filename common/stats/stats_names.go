@@ -42,6 +42,16 @@ const (
 	*/
 	BundlestoreDownloadOkCounter = "downloadOkCounter"
 
+	/*
+		number of admin requests to purge an ActionResult from the action cache
+	*/
+	BundlestorePurgeActionCounter = "purgeActionCounter"
+
+	/*
+		number of admin requests to purge an ActionResult from the action cache that failed
+	*/
+	BundlestorePurgeActionErrCounter = "purgeActionErrCounter"
+
 	/*
 	   bundlestore service request
 	*/
@@ -343,6 +353,11 @@ const (
 	*/
 	SchedServerJobKillLatency_ms = "jobKillLatency_ms"
 
+	/*
+		the number of job history requests the http server received
+	*/
+	SchedServerJobHistoryCounter = "jobHistoryRpmCounter"
+
 	/*
 		the number of job status requests the thrift server received
 	*/
@@ -363,6 +378,21 @@ const (
 	*/
 	SchedServerRunJobLatency_ms = "runJobLatency_ms"
 
+	/*
+		the number of task log requests the http server received
+	*/
+	SchedServerTaskLogsCounter = "taskLogsRpmCounter"
+
+	/*
+		the number of tasks that were never run because a task they depend on failed
+	*/
+	SchedSkippedTaskCounter = "skippedTaskCounter"
+
+	/*
+		the number of second attempts started for tasks running much longer than their average duration
+	*/
+	SchedSpeculativeTaskCounter = "speculativeTaskCounter"
+
 	/*
 		The amount of time it takes to assign the tasks to nodes
 	*/
@@ -430,6 +460,22 @@ const (
 	*/
 	WorkerActiveInitLatency_ms = "workerActiveInitLatency_ms"
 
+	/*
+		the number of times the worker uploaded a run's stdout, stderr and stdlog to the log store
+	*/
+	WorkerLogUploads = "workerLogUploads"
+
+	/*
+		the number of log uploads that failed, leaving the run's logs on the worker only
+	*/
+	WorkerLogUploadFailures = "workerLogUploadFailures"
+
+	/*
+		the amount of time spent uploading a run's logs to the log store.  This includes time for
+		successful as well as erroring uploads
+	*/
+	WorkerLogUploadLatency_ms = "workerLogUploadLatency_ms"
+
 	/*
 		the amount of worker's memory currently consumed by the current command (and its subprocesses)
 		TODO- verify with Ryan that this description is correct
@@ -527,6 +573,11 @@ const (
 	*/
 	BzExecRequestLatency_ms = "bzExecRequestLatency_ms"
 
+	/*
+		Number of WaitExecution requests received
+	*/
+	BzWaitExecRequestCounter = "bzWaitExecRequestCounter"
+
 	/*
 		Number of GetOperation requests received
 	*/
@@ -537,7 +588,47 @@ const (
 	*/
 	BzGetOpRequestLatency_ms = "bzGetOpRequestLatency_ms"
 
+	/*
+		Number of ListOperations requests received
+	*/
+	BzListOpsRequestCounter = "bzListOpsRequestCounter"
+
+	/*
+		Amount of time the server takes to process a ListOperations request
+	*/
+	BzListOpsRequestLatency_ms = "bzListOpsRequestLatency_ms"
+
+	/*
+		Number of CancelOperation requests received
+	*/
+	BzCancelOpRequestCounter = "bzCancelOpRequestCounter"
+
+	/*
+		Amount of time the server takes to process a CancelOperation request
+	*/
+	BzCancelOpRequestLatency_ms = "bzCancelOpRequestLatency_ms"
+
+	/*
+		Number of DeleteOperation requests received
+	*/
+	BzDeleteOpRequestCounter = "bzDeleteOpRequestCounter"
+
+	/*
+		Amount of time the server takes to process a DeleteOperation request
+	*/
+	BzDeleteOpRequestLatency_ms = "bzDeleteOpRequestLatency_ms"
+
+	/*
+		Number of execution GetCapabilities requests received
+	*/
+	BzExecGetCapabilitiesRequestCounter = "bzExecGetCapabilitiesRequestCounter"
+
 	/****************************** CAS Service ******************************************/
+	/*
+		Number of CAS GetCapabilities requests received
+	*/
+	BzCASGetCapabilitiesRequestCounter = "bzCASGetCapabilitiesRequestCounter"
+
 	/*
 		Number of FindMissingBlobs requests received
 	*/
@@ -548,6 +639,36 @@ const (
 	*/
 	BzFindBlobsRequestLatency_ms = "bzFindBlobsRequestLatency_ms"
 
+	/*
+		Number of BatchUpdateBlobs requests received
+	*/
+	BzBatchUpdateBlobsRequestCounter = "bzBatchUpdateBlobsRequestCounter"
+
+	/*
+		Amount of time the server takes to process a BatchUpdateBlobs request
+	*/
+	BzBatchUpdateBlobsRequestLatency_ms = "bzBatchUpdateBlobsRequestLatency_ms"
+
+	/*
+		Number of BatchReadBlobs requests received
+	*/
+	BzBatchReadBlobsRequestCounter = "bzBatchReadBlobsRequestCounter"
+
+	/*
+		Amount of time the server takes to process a BatchReadBlobs request
+	*/
+	BzBatchReadBlobsRequestLatency_ms = "bzBatchReadBlobsRequestLatency_ms"
+
+	/*
+		Number of GetTree requests received
+	*/
+	BzGetTreeRequestCounter = "bzGetTreeRequestCounter"
+
+	/*
+		Amount of time the server takes to process a GetTree request
+	*/
+	BzGetTreeRequestLatency_ms = "bzGetTreeRequestLatency_ms"
+
 	/*
 		Number of CAS Read requests received
 	*/
@@ -568,6 +689,27 @@ const (
 	*/
 	BzWriteRequestLatency_ms = "bzWriteRequestLatency_ms"
 
+	/*
+		Number of CAS Write requests that resumed a previously interrupted Write
+	*/
+	BzWriteResumedCounter = "bzWriteResumedCounter"
+
+	/*
+		Number of CAS Writes rejected because the data didn't match the size or hash of the Digest,
+		including blobs rejected by BatchUpdateBlobs
+	*/
+	BzWriteRejectedCounter = "bzWriteRejectedCounter"
+
+	/*
+		Number of CAS Write requests rejected because the spools of in progress Writes were full
+	*/
+	BzWriteSpoolFullCounter = "bzWriteSpoolFullCounter"
+
+	/*
+		Number of CAS QueryWriteStatus requests received
+	*/
+	BzQueryWriteStatusRequestCounter = "bzQueryWriteStatusRequestCounter"
+
 	/****************************** ActionCache Service ****************************************/
 	/*
 		Number of GetActionResult requests received
@@ -579,6 +721,16 @@ const (
 	*/
 	BzGetActionRequestLatency_ms = "bzGetActionRequestLatency_ms"
 
+	/*
+		Number of cached ActionResults purged because outputs they reference are missing from the Store
+	*/
+	BzGetActionMissingOutputsCounter = "bzGetActionMissingOutputsCounter"
+
+	/*
+		Number of ActionResult hits not validated because the background validation queue was full
+	*/
+	BzGetActionValidationDroppedCounter = "bzGetActionValidationDroppedCounter"
+
 	/*
 		Number of UpdateActionResult requests received
 	*/
@@ -1,11 +1,22 @@
 package scootconfig
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/twitter/scoot/ice"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
 )
 
+// How long to wait for a replica of the durable SagaLog to write a message.
+const DefaultSagaLogReplicaTimeout = 5 * time.Second
+
+// How often to archive or delete sagas past the FileSagaLog's retention.
+const DefaultSagaLogCompactionInterval = time.Hour
+
 // InMemorySagaLog struct is used by goice to create an InMemory instance
 // of the SagaLog interface.
 type InMemorySagaLogConfig struct {
@@ -26,9 +37,14 @@ func (c *InMemorySagaLogConfig) Create() saga.SagaLog {
 // instance of the SagaLog interface
 // Directory specifies the name of the directory to store
 // Sagalog files in.
+// Retention, if set, is how long to keep sagas after they end, human readable ex: "168h".
+// Older sagas are moved to ArchiveDirectory if it's set, or deleted otherwise.
+// Compaction runs until the log is closed.
 type FileSagaLogConfig struct {
-	Type      string
-	Directory string
+	Type             string
+	Directory        string
+	Retention        string
+	ArchiveDirectory string
 }
 
 // Adds the FileSagaLogConfig Create function to the goice MagicBag
@@ -38,5 +54,89 @@ func (c *FileSagaLogConfig) Install(bag *ice.MagicBag) {
 
 // Creates an instance of the FileSagaLog
 func (c *FileSagaLogConfig) Create() (saga.SagaLog, error) {
-	return sagalogs.MakeFileSagaLog(c.Directory)
+	var retention time.Duration
+	if c.Retention != "" {
+		var err error
+		if retention, err = time.ParseDuration(c.Retention); err != nil {
+			return nil, err
+		}
+	}
+	slog, err := sagalogs.MakeFileSagaLog(c.Directory)
+	if err != nil {
+		return nil, err
+	}
+	// Keeps sagalog-inspect from changing sagas while the scheduler is running them.
+	if err := slog.Lock(); err != nil {
+		return nil, fmt.Errorf("Couldn't lock saga log %s: %v", c.Directory, err)
+	}
+	if retention > 0 {
+		// The log keeps the stop func, compaction is stopped by closing the log.
+		slog.StartCompaction(DefaultSagaLogCompactionInterval, retention, c.ArchiveDirectory)
+	}
+	return slog, nil
+}
+
+// DurableSagaLogConfig struct is used by goice to create a durable SagaLog
+// that's replicated to other hosts before messages are acknowledged.
+// Directory specifies the name of the directory to store the journal in.
+// Replicas is a comma separated list of sagalog-replica addresses, ex: "host1:9095,host2:9095".
+// Each sagalog-replica must list this scheduler's host in its peers.
+// ReplicaTimeout is how long to wait for each replica to write a message, human readable ex: "5s".
+type DurableSagaLogConfig struct {
+	Type           string
+	Directory      string
+	Replicas       string
+	ReplicaTimeout string
+}
+
+// Adds the DurableSagaLogConfig Create function to the goice MagicBag
+func (c *DurableSagaLogConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates an instance of the durable SagaLog
+func (c *DurableSagaLogConfig) Create() (saga.SagaLog, error) {
+	timeout := DefaultSagaLogReplicaTimeout
+	if c.ReplicaTimeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(c.ReplicaTimeout); err != nil {
+			return nil, err
+		}
+	}
+	replicas := []sagalogs.SagaLogReplica{}
+	for _, addr := range strings.Split(c.Replicas, ",") {
+		if addr != "" {
+			replicas = append(replicas, sagalogs.MakeHTTPSagaLogReplica(addr, timeout))
+		}
+	}
+	return sagalogs.MakeDurableSagaLog(c.Directory, replicas...)
+}
+
+// SQLSagaLogConfig struct is used by goice to create a SagaLog stored in
+// a SQL database, which also serves the job history.
+// Driver is "postgres" or "sqlite3", the drivers linked into the scheduler binary.
+// DataSource is the driver specific connection string, ex: "postgres://scoot@dbhost/scoot" or "/var/scoot/sagas.db".
+type SQLSagaLogConfig struct {
+	Type       string
+	Driver     string
+	DataSource string
+}
+
+// Adds the SQLSagaLogConfig Create function to the goice MagicBag
+func (c *SQLSagaLogConfig) Install(bag *ice.MagicBag) {
+	bag.Put(c.Create)
+}
+
+// Creates an instance of the SQL SagaLog
+func (c *SQLSagaLogConfig) Create() (saga.SagaLog, error) {
+	return sagalogs.OpenSQLSagaLog(c.Driver, c.DataSource, jobInfo)
+}
+
+// Records the requestor, tag and type of the scheduler's jobs in the job history.
+func jobInfo(data []byte) (sagalogs.SagaJobInfo, error) {
+	job, err := sched.DeserializeJob(data)
+	if err != nil {
+		return sagalogs.SagaJobInfo{}, err
+	}
+	return sagalogs.SagaJobInfo{Requestor: job.Def.Requestor, Tag: job.Def.Tag, JobType: job.Def.JobType}, nil
 }
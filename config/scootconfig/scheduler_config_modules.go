@@ -31,15 +31,19 @@ const DefaultReadyFnBackoff = 5 * time.Second
 //
 // See scheduler.SchedulerConfig for comments on the remaining fields.
 type StatefulSchedulerConfig struct {
-	Type                 string
-	MaxRetriesPerTask    int
-	DebugMode            bool
-	RecoverJobsOnStartup bool
-	DefaultTaskTimeout   string
-	TaskTimeoutOverhead  string
-	MaxRequestors        int
-	MaxJobsPerRequestor  int
-	Admins               string
+	Type                    string
+	MaxRetriesPerTask       int
+	DebugMode               bool
+	RecoverJobsOnStartup    bool
+	DefaultTaskTimeout      string
+	TaskTimeoutOverhead     string
+	MaxRequestors           int
+	MaxJobsPerRequestor     int
+	Admins                  string
+	FlakyTaskScore          float64
+	FlakyTaskAttempts       int
+	FlakyTasksFile          string
+	SpeculativeTaskMultiple float64
 }
 
 func (c *StatefulSchedulerConfig) Install(bag *ice.MagicBag) {
@@ -70,16 +74,20 @@ func (c *StatefulSchedulerConfig) Create() (scheduler.SchedulerConfig, error) {
 	}
 
 	return scheduler.SchedulerConfig{
-		MaxRetriesPerTask:    c.MaxRetriesPerTask,
-		DebugMode:            c.DebugMode,
-		RecoverJobsOnStartup: c.RecoverJobsOnStartup,
-		DefaultTaskTimeout:   dtt,
-		TaskTimeoutOverhead:  tto,
-		RunnerRetryTimeout:   DefaultRunnerRetryTimeout,
-		RunnerRetryInterval:  DefaultRunnerRetryInterval,
-		ReadyFnBackoff:       DefaultReadyFnBackoff,
-		MaxRequestors:        c.MaxRequestors,
-		MaxJobsPerRequestor:  c.MaxJobsPerRequestor,
-		Admins:               admins,
+		MaxRetriesPerTask:       c.MaxRetriesPerTask,
+		DebugMode:               c.DebugMode,
+		RecoverJobsOnStartup:    c.RecoverJobsOnStartup,
+		DefaultTaskTimeout:      dtt,
+		TaskTimeoutOverhead:     tto,
+		RunnerRetryTimeout:      DefaultRunnerRetryTimeout,
+		RunnerRetryInterval:     DefaultRunnerRetryInterval,
+		ReadyFnBackoff:          DefaultReadyFnBackoff,
+		MaxRequestors:           c.MaxRequestors,
+		MaxJobsPerRequestor:     c.MaxJobsPerRequestor,
+		Admins:                  admins,
+		FlakyTaskScore:          c.FlakyTaskScore,
+		FlakyTaskAttempts:       c.FlakyTaskAttempts,
+		FlakyTasksFile:          c.FlakyTasksFile,
+		SpeculativeTaskMultiple: c.SpeculativeTaskMultiple,
 	}, nil
 }
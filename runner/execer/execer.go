@@ -21,6 +21,15 @@ type Command struct {
 	Stdout  io.Writer
 	Stderr  io.Writer
 	tags.LogTags
+
+	// Limits requested for this command, overriding the execer's own limits. Zero values are ignored.
+	MemCap Memory
+	CPUs   float64
+	// Caps the size of Dir, which is polled so the command may briefly exceed it before being killed.
+	DiskCap int64
+
+	// Give the command network access if the execer runs commands in a sandbox.
+	AllowNetwork bool
 }
 
 type ProcessState int
@@ -64,8 +73,38 @@ type Process interface {
 	Abort() ProcessStatus
 }
 
+// Why the execer failed a process, for failures the process didn't cause by exiting on its own
+type FailureReason int
+
+const (
+	NoFailureReason FailureReason = iota
+
+	// Killed by the execer after its memory usage exceeded the execer's MemoryCap
+	MemoryCapExceeded
+
+	// Killed by the kernel OOM killer after exceeding the memory limit of its cgroup
+	OOMKilled
+
+	// Killed by the execer after the size of its directory exceeded the command's DiskCap
+	DiskCapExceeded
+)
+
+func (r FailureReason) String() string {
+	if r == MemoryCapExceeded {
+		return "MemoryCapExceeded"
+	}
+	if r == OOMKilled {
+		return "OOMKilled"
+	}
+	if r == DiskCapExceeded {
+		return "DiskCapExceeded"
+	}
+	return "NoFailureReason"
+}
+
 type ProcessStatus struct {
-	State    ProcessState
-	ExitCode int
-	Error    string
+	State         ProcessState
+	ExitCode      int
+	Error         string
+	FailureReason FailureReason
 }
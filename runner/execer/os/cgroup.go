@@ -0,0 +1,195 @@
+package os
+
+// Resource isolation with cgroup v2. Each command runs in its own cgroup, created under a root
+// hierarchy that's been delegated to the worker, with limits enforced by the kernel rather than by polling.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Period of cpu.max quotas in microseconds, the kernel default
+const cgroupCPUPeriod = 100000
+
+// How long to wait for the processes of a killed cgroup to exit before giving up on removing it
+var cgroupRemoveTimeout = 5 * time.Second
+
+// Limits applied to each command run by a cgroup execer. Zero values mean no limit.
+type CgroupConfig struct {
+	// A cgroup v2 hierarchy writable by the worker, ex: /sys/fs/cgroup/scoot.
+	// Commands run in child cgroups of Root, so the worker itself must not run in Root.
+	Root string
+
+	// Memory limit of each command, written to memory.max
+	Memory execer.Memory
+
+	// CPU limit of each command as a number of CPUs, written to cpu.max
+	CPUs float64
+
+	// Limit on the number of processes of each command, written to pids.max
+	Pids int
+}
+
+// Controllers that must be enabled in the child cgroups of Root to apply the configured limits.
+// The memory controller is always needed to read usage and OOM kills.
+func (c CgroupConfig) controllers() []string {
+	controllers := []string{"memory"}
+	if c.CPUs > 0 {
+		controllers = append(controllers, "cpu")
+	}
+	if c.Pids > 0 {
+		controllers = append(controllers, "pids")
+	}
+	return controllers
+}
+
+// Controllers enabled whenever they're available, so that commands can request their own limits
+var optionalCgroupControllers = []string{"cpu"}
+
+// Checks that Root is a cgroup v2 hierarchy and enables the needed controllers for its children.
+// Returns the set of enabled controllers.
+func setupCgroupRoot(cfg CgroupConfig) (map[string]bool, error) {
+	b, err := ioutil.ReadFile(filepath.Join(cfg.Root, "cgroup.controllers"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a cgroup v2 hierarchy: %v", cfg.Root, err)
+	}
+	available := map[string]bool{}
+	for _, a := range strings.Fields(string(b)) {
+		available[a] = true
+	}
+	enabled := map[string]bool{}
+	enable := []string{}
+	for _, c := range cfg.controllers() {
+		if !available[c] {
+			return nil, fmt.Errorf("cgroup controller %s is not available in %s", c, cfg.Root)
+		}
+		enabled[c] = true
+		enable = append(enable, "+"+c)
+	}
+	for _, c := range optionalCgroupControllers {
+		if available[c] && !enabled[c] {
+			enabled[c] = true
+			enable = append(enable, "+"+c)
+		}
+	}
+	return enabled, writeCgroupFile(cfg.Root, "cgroup.subtree_control", strings.Join(enable, " "))
+}
+
+// A cgroup holding the processes of a single command
+type cgroup struct {
+	path string
+}
+
+// Distinguishes the cgroups of commands run by this worker
+var cgroupCount uint64
+
+// Creates a new child cgroup of cfg.Root with the configured limits
+func newCgroup(cfg CgroupConfig) (*cgroup, error) {
+	name := fmt.Sprintf("cmd-%d-%d", os.Getpid(), atomic.AddUint64(&cgroupCount, 1))
+	cg := &cgroup{path: filepath.Join(cfg.Root, name)}
+	if err := os.Mkdir(cg.path, 0755); err != nil {
+		return nil, err
+	}
+
+	limits := map[string]string{}
+	if cfg.Memory > 0 {
+		limits["memory.max"] = strconv.FormatUint(uint64(cfg.Memory), 10)
+	}
+	if cfg.CPUs > 0 {
+		limits["cpu.max"] = fmt.Sprintf("%d %d", int64(cfg.CPUs*cgroupCPUPeriod), cgroupCPUPeriod)
+	}
+	if cfg.Pids > 0 {
+		limits["pids.max"] = strconv.Itoa(cfg.Pids)
+	}
+	for file, limit := range limits {
+		if err := writeCgroupFile(cg.path, file, limit); err != nil {
+			cg.destroy()
+			return nil, err
+		}
+	}
+	return cg, nil
+}
+
+// Wraps argv so that the command moves itself into the cgroup before it execs.
+// Moving the process after it starts would let any children it forks in the meantime escape the cgroup.
+func (c *cgroup) wrap(argv []string) []string {
+	return append([]string{"/bin/sh", "-c", `echo $$ > "$0" && exec "$@"`, filepath.Join(c.path, "cgroup.procs")}, argv...)
+}
+
+// Current memory usage of all processes in the cgroup
+func (c *cgroup) memUsage() (execer.Memory, error) {
+	b, err := ioutil.ReadFile(filepath.Join(c.path, "memory.current"))
+	if err != nil {
+		return 0, err
+	}
+	u, err := strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+	return execer.Memory(u), err
+}
+
+// Number of processes in the cgroup killed by the OOM killer
+func (c *cgroup) oomKills() (int, error) {
+	b, err := ioutil.ReadFile(filepath.Join(c.path, "memory.events"))
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 && fields[0] == "oom_kill" {
+			return strconv.Atoi(fields[1])
+		}
+	}
+	return 0, nil
+}
+
+// Kills all processes in the cgroup, including any that left the command's process group
+func (c *cgroup) kill() {
+	// cgroup.kill is only available on newer kernels, otherwise kill each process individually
+	if err := writeCgroupFile(c.path, "cgroup.kill", "1"); err == nil {
+		return
+	}
+	b, err := ioutil.ReadFile(filepath.Join(c.path, "cgroup.procs"))
+	if err != nil {
+		return
+	}
+	for _, p := range strings.Fields(string(b)) {
+		if pid, err := strconv.Atoi(p); err == nil {
+			syscall.Kill(pid, syscall.SIGKILL)
+		}
+	}
+}
+
+// Kills any remaining processes and removes the cgroup. Safe to call more than once.
+// Killed processes take a moment to exit, and the cgroup can't be removed until they have.
+func (c *cgroup) destroy() {
+	c.kill()
+	deadline := time.Now().Add(cgroupRemoveTimeout)
+	for {
+		err := syscall.Rmdir(c.path)
+		if err == nil || os.IsNotExist(err) {
+			return
+		}
+		if time.Now().After(deadline) {
+			log.WithFields(
+				log.Fields{
+					"cgroup": c.path,
+					"error":  err,
+				}).Error("Failed to remove cgroup")
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func writeCgroupFile(dir, file, value string) error {
+	return ioutil.WriteFile(filepath.Join(dir, file), []byte(value), 0644)
+}
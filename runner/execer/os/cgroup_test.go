@@ -0,0 +1,135 @@
+package os
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Makes a fake cgroup v2 hierarchy in a temp dir. Since the fake cgroups are regular directories
+// holding files, they're left behind after commands finish, which lets tests inspect them.
+func makeFakeCgroupRoot(t *testing.T, controllers string) string {
+	cgroupRemoveTimeout = 0
+	root, err := ioutil.TempDir("", "cgroup-test-")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := ioutil.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte(controllers), 0644); err != nil {
+		t.Fatalf(err.Error())
+	}
+	return root
+}
+
+func readCgroupFile(t *testing.T, path string) string {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func TestCgroupExecerSetup(t *testing.T) {
+	root := makeFakeCgroupRoot(t, "cpuset memory pids")
+	defer os.RemoveAll(root)
+
+	if _, err := NewCgroupExecer(CgroupConfig{Root: filepath.Join(root, "missing")}, stats.NilStatsReceiver()); err == nil {
+		t.Fatalf("Expected error for a root that isn't a cgroup hierarchy")
+	}
+	if _, err := NewCgroupExecer(CgroupConfig{Root: root, CPUs: 1}, stats.NilStatsReceiver()); err == nil {
+		t.Fatalf("Expected error for a limit with an unavailable controller")
+	}
+	if _, err := NewCgroupExecer(CgroupConfig{Root: root, Pids: 10}, stats.NilStatsReceiver()); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if c := readCgroupFile(t, filepath.Join(root, "cgroup.subtree_control")); c != "+memory +pids" {
+		t.Fatalf("Expected memory and pids controllers to be enabled, got: %s", c)
+	}
+}
+
+func TestCgroupExecerLimits(t *testing.T) {
+	root := makeFakeCgroupRoot(t, "cpu memory pids")
+	defer os.RemoveAll(root)
+
+	cfg := CgroupConfig{Root: root, Memory: execer.Memory(10 * 1024 * 1024), CPUs: 0.5, Pids: 100}
+	e, err := NewCgroupExecer(cfg, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	p, err := e.Exec(execer.Command{Argv: []string{"false"}})
+	if err != nil {
+		t.Fatalf("Couldn't run false %v", err)
+	}
+	pid := p.(*osProcess).cmd.Process.Pid
+	if status := p.Wait(); status.State != execer.COMPLETE || status.ExitCode != 1 {
+		t.Fatalf("Got unexpected status running false %v", status)
+	}
+
+	dir := p.(*osProcess).cgroup.path
+	expected := map[string]string{
+		"memory.max":   strconv.Itoa(10 * 1024 * 1024),
+		"cpu.max":      "50000 100000",
+		"pids.max":     "100",
+		"cgroup.procs": strconv.Itoa(pid),
+	}
+	for file, value := range expected {
+		if v := readCgroupFile(t, filepath.Join(dir, file)); v != value {
+			t.Fatalf("Expected %s to be %s, got: %s", file, value, v)
+		}
+	}
+}
+
+func TestCgroupExecerCommandLimits(t *testing.T) {
+	root := makeFakeCgroupRoot(t, "cpu memory")
+	defer os.RemoveAll(root)
+
+	e, err := NewCgroupExecer(CgroupConfig{Root: root, Memory: execer.Memory(10 * 1024 * 1024)}, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if c := readCgroupFile(t, filepath.Join(root, "cgroup.subtree_control")); c != "+memory +cpu" {
+		t.Fatalf("Expected the available cpu controller to be enabled, got: %s", c)
+	}
+	p, err := e.Exec(execer.Command{Argv: []string{"true"}, MemCap: execer.Memory(20 * 1024 * 1024), CPUs: 2})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	p.Wait()
+
+	dir := p.(*osProcess).cgroup.path
+	expected := map[string]string{
+		"memory.max": strconv.Itoa(20 * 1024 * 1024),
+		"cpu.max":    "200000 100000",
+	}
+	for file, value := range expected {
+		if v := readCgroupFile(t, filepath.Join(dir, file)); v != value {
+			t.Fatalf("Expected %s to be %s, got: %s", file, value, v)
+		}
+	}
+}
+
+func TestCgroupExecerOOMKilled(t *testing.T) {
+	root := makeFakeCgroupRoot(t, "memory")
+	defer os.RemoveAll(root)
+
+	e, err := NewCgroupExecer(CgroupConfig{Root: root, Memory: execer.Memory(1024 * 1024)}, stats.NilStatsReceiver())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	// Stand in for the kernel, which records OOM kills in memory.events
+	script := fmt.Sprintf(`for d in %s/cmd-*; do echo "oom_kill 1" > $d/memory.events; done`, root)
+	p, err := e.Exec(execer.Command{Argv: []string{"sh", "-c", script}})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	status := p.Wait()
+	if status.State != execer.FAILED || status.FailureReason != execer.OOMKilled {
+		t.Fatalf("Expected OOMKilled failure, got: %v", status)
+	}
+}
@@ -2,6 +2,9 @@ package os
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -144,3 +147,67 @@ func TestMemCap(t *testing.T) {
 		t.Fatalf("Expected usage to be 0MB, was: %dB", usage)
 	}
 }
+
+func TestDiskCap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disk_cap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(interval time.Duration) { diskPollInterval = interval }(diskPollInterval)
+	diskPollInterval = 10 * time.Millisecond
+
+	// Write 2MB to the command's directory then wait to be killed.
+	cmd := execer.Command{
+		Argv:    []string{"sh", "-c", "head -c 2097152 /dev/zero > out && sleep 30"},
+		Dir:     dir,
+		DiskCap: 1024 * 1024,
+	}
+	e := NewExecer()
+	process, err := e.Exec(cmd)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer process.Abort()
+
+	stCh := make(chan execer.ProcessStatus)
+	go func() { stCh <- process.Wait() }()
+	select {
+	case st := <-stCh:
+		if st.State != execer.FAILED || st.FailureReason != execer.DiskCapExceeded {
+			t.Fatalf("Expected the command to fail with DiskCapExceeded, got %+v", st)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Expected the command to be killed after exceeding its DiskCap")
+	}
+}
+
+func TestDiskCapBaseline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "disk_cap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	defer func(interval time.Duration) { diskPollInterval = interval }(diskPollInterval)
+	diskPollInterval = 10 * time.Millisecond
+
+	// The directory starts out over the cap, but only what the command writes counts towards it.
+	if err := ioutil.WriteFile(filepath.Join(dir, "checkout"), make([]byte, 2*1024*1024), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := execer.Command{
+		Argv:    []string{"sh", "-c", "head -c 1024 /dev/zero > out && sleep 1"},
+		Dir:     dir,
+		DiskCap: 1024 * 1024,
+	}
+	e := NewExecer()
+	process, err := e.Exec(cmd)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer process.Abort()
+
+	if st := process.Wait(); st.State != execer.COMPLETE || st.ExitCode != 0 {
+		t.Fatalf("Expected the command to complete, got %+v", st)
+	}
+}
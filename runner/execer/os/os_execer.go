@@ -8,6 +8,7 @@ import (
 	"math"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -25,16 +26,31 @@ func NewExecer() *osExecer {
 	return &osExecer{}
 }
 
-// For now memory can be capped on a per-execer basis rather than a per-command basis.
-// This is ok since we currently (Q1 2017) only support one run at a time in our codebase.
+// Memory is capped at memCap unless a command requests its own cap with Command.MemCap.
 func NewBoundedExecer(memCap execer.Memory, stat stats.StatsReceiver) *osExecer {
 	return &osExecer{memCap: memCap, stat: stat.Scope("osexecer")}
 }
 
+// Runs each command in its own cgroup with the configured limits, which are enforced by the kernel.
+// Returns an error if cfg.Root isn't a usable cgroup v2 hierarchy.
+func NewCgroupExecer(cfg CgroupConfig, stat stats.StatsReceiver) (*osExecer, error) {
+	controllers, err := setupCgroupRoot(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &osExecer{cgroups: &cfg, cgroupControllers: controllers, stat: stat.Scope("osexecer")}, nil
+}
+
 type osExecer struct {
 	// Best effort monitoring of command to kill it if resident memory usage exceeds this cap. Ignored if zero.
 	memCap execer.Memory
-	stat   stats.StatsReceiver
+	// If set, each command runs in its own cgroup with these limits
+	cgroups *CgroupConfig
+	// Controllers enabled for the cgroups of commands
+	cgroupControllers map[string]bool
+	// If set, each command runs in its own sandbox
+	sandbox *sandbox
+	stat    stats.StatsReceiver
 }
 
 type WriterDelegater interface {
@@ -51,7 +67,47 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 		return nil, errors.New("No command specified.")
 	}
 
-	cmd := exec.Command(command.Argv[0], command.Argv[1:]...)
+	argv := command.Argv
+	// Sets pgid of all child processes to cmd's pid
+	procAttr := &syscall.SysProcAttr{Setpgid: true}
+	if e.sandbox != nil {
+		if argv, err = e.sandbox.wrap(argv, command.Dir, command.AllowNetwork); err != nil {
+			return nil, err
+		}
+		procAttr = e.sandbox.sysProcAttr(command.AllowNetwork)
+	}
+
+	// The cgroup wrapper runs first, so that the sandbox and everything in it are part of the cgroup
+	var cg *cgroup
+	if e.cgroups != nil {
+		// Limits requested by the command replace the configured ones
+		cfg := *e.cgroups
+		if command.MemCap > 0 {
+			cfg.Memory = command.MemCap
+		}
+		if command.CPUs > 0 && e.cgroupControllers["cpu"] {
+			cfg.CPUs = command.CPUs
+		}
+		if cg, err = newCgroup(cfg); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if err != nil {
+				cg.destroy()
+			}
+		}()
+		argv = cg.wrap(argv)
+	}
+
+	// Only growth past the directory's initial size counts towards DiskCap, measure it before the command can write.
+	var diskBaseline int64
+	if command.DiskCap > 0 {
+		if diskBaseline, err = diskUsage(command.Dir); err != nil {
+			return nil, err
+		}
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
 	cmd.Dir = command.Dir
 
 	// Use the parent environment plus whatever additional env vars are provided.
@@ -60,8 +116,7 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 		cmd.Env = append(cmd.Env, k+"="+v)
 	}
 
-	// Sets pgid of all child processes to cmd's pid
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.SysProcAttr = procAttr
 
 	// Make sure to get the best possible Writer, so if possible os/exec can connect
 	// the command's stdout/stderr directly to a file, instead of having to go through
@@ -100,9 +155,20 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 		return nil, err
 	}
 
-	proc := &osProcess{cmd: cmd, wg: &wg, LogTags: command.LogTags}
-	if e.memCap > 0 {
-		go e.monitorMem(proc)
+	proc := &osProcess{cmd: cmd, wg: &wg, cgroup: cg, LogTags: command.LogTags}
+	// A cgroup's memory limit is enforced by the kernel, otherwise poll usage against the command's cap or our own
+	memCap := e.memCap
+	if command.MemCap > 0 {
+		memCap = command.MemCap
+	}
+	if memCap > 0 && cg == nil {
+		go e.monitorMem(proc, memCap)
+	}
+	if cg != nil {
+		go e.monitorCgroup(proc)
+	}
+	if command.DiskCap > 0 {
+		go e.monitorDisk(proc, command.Dir, diskBaseline, command.DiskCap)
 	}
 	return proc, nil
 }
@@ -110,6 +176,7 @@ func (e *osExecer) Exec(command execer.Command) (result execer.Process, err erro
 type osProcess struct {
 	cmd    *exec.Cmd
 	wg     *sync.WaitGroup
+	cgroup *cgroup
 	result *execer.ProcessStatus
 	mutex  sync.Mutex
 	tags.LogTags
@@ -121,7 +188,7 @@ type osProcess struct {
 //
 // Periodically check to make sure memory constraints are respected,
 // and clean up after ourselves when the process has completed
-func (e *osExecer) monitorMem(p *osProcess) {
+func (e *osExecer) monitorMem(p *osProcess, memCap execer.Memory) {
 	pid := p.cmd.Process.Pid
 	pgid, err := syscall.Getpgid(pid)
 	if err != nil {
@@ -166,12 +233,12 @@ func (e *osExecer) monitorMem(p *osProcess) {
 			mem, _ := e.memUsage(pid)
 			e.stat.Gauge(stats.WorkerMemory).Update(int64(mem))
 			// Aborting process, above memCap
-			if mem >= e.memCap {
-				msg := fmt.Sprintf("Cmd exceeded MemoryCap, aborting %d: %d > %d (%v)", pid, mem, e.memCap, p.cmd.Args)
+			if mem >= memCap {
+				msg := fmt.Sprintf("Cmd exceeded MemoryCap, aborting %d: %d > %d (%v)", pid, mem, memCap, p.cmd.Args)
 				log.WithFields(
 					log.Fields{
 						"mem":    mem,
-						"memCap": e.memCap,
+						"memCap": memCap,
 						"args":   p.cmd.Args,
 						"pid":    pid,
 						"tag":    p.Tag,
@@ -179,21 +246,22 @@ func (e *osExecer) monitorMem(p *osProcess) {
 						"taskID": p.TaskID,
 					}).Info(msg)
 				p.result = &execer.ProcessStatus{
-					State: execer.FAILED,
-					Error: msg,
+					State:         execer.FAILED,
+					Error:         msg,
+					FailureReason: execer.MemoryCapExceeded,
 				}
 				p.mutex.Unlock()
 				p.Abort()
 				return
 			}
 			// Report on larger changes when utilization is low, and smaller changes as utilization reaches 100%.
-			memUsagePct := math.Min(1.0, float64(mem)/float64(e.memCap))
+			memUsagePct := math.Min(1.0, float64(mem)/float64(memCap))
 			if memUsagePct > reportThresholds[thresholdsIdx] {
 				log.WithFields(
 					log.Fields{
 						"memUsagePct": int(memUsagePct * 100),
 						"mem":         mem,
-						"memCap":      e.memCap,
+						"memCap":      memCap,
 						"args":        p.cmd.Args,
 						"pid":         pid,
 						"tag":         p.Tag,
@@ -225,6 +293,110 @@ func (e *osExecer) monitorMem(p *osProcess) {
 	}
 }
 
+// Periodically report the memory usage of the command's cgroup until the process has completed.
+// Unlike monitorMem, limits don't need to be checked since they're enforced by the kernel.
+func (e *osExecer) monitorCgroup(p *osProcess) {
+	memTicker := time.NewTicker(250 * time.Millisecond)
+	defer memTicker.Stop()
+	for range memTicker.C {
+		p.mutex.Lock()
+		done := p.result != nil
+		p.mutex.Unlock()
+		if done {
+			return
+		}
+		if mem, err := p.cgroup.memUsage(); err == nil {
+			e.stat.Gauge(stats.WorkerMemory).Update(int64(mem))
+		}
+	}
+}
+
+// How often the size of a command's directory is checked against its DiskCap, walking the directory isn't cheap.
+// Directories that take longer to walk are checked less often, see diskPollWalkMultiple.
+var diskPollInterval = 5 * time.Second
+
+// Minimum time between checks of a command's directory, as a multiple of the time the last walk took.
+const diskPollWalkMultiple = 10
+
+// Periodically check how much the command's directory has grown past its baseline size,
+// and kill the command if it has grown by diskCap or more.
+func (e *osExecer) monitorDisk(p *osProcess, dir string, baseline, diskCap int64) {
+	diskTimer := time.NewTimer(diskPollInterval)
+	defer diskTimer.Stop()
+	for range diskTimer.C {
+		p.mutex.Lock()
+		done := p.result != nil
+		p.mutex.Unlock()
+		if done {
+			return
+		}
+		start := time.Now()
+		usage, err := diskUsage(dir)
+		if walked := time.Since(start) * diskPollWalkMultiple; walked > diskPollInterval {
+			diskTimer.Reset(walked)
+		} else {
+			diskTimer.Reset(diskPollInterval)
+		}
+		disk := usage - baseline
+		if err != nil || disk < diskCap {
+			continue
+		}
+
+		pid := p.cmd.Process.Pid
+		msg := fmt.Sprintf("Cmd exceeded DiskCap, aborting %d: %d > %d (%v)", pid, disk, diskCap, p.cmd.Args)
+		log.WithFields(
+			log.Fields{
+				"disk":     disk,
+				"baseline": baseline,
+				"diskCap":  diskCap,
+				"dir":      dir,
+				"pid":      pid,
+				"tag":      p.Tag,
+				"jobID":    p.JobID,
+				"taskID":   p.TaskID,
+			}).Info(msg)
+		p.mutex.Lock()
+		if p.result != nil {
+			p.mutex.Unlock()
+			return
+		}
+		p.result = &execer.ProcessStatus{
+			State:         execer.FAILED,
+			ExitCode:      -1,
+			Error:         msg,
+			FailureReason: execer.DiskCapExceeded,
+		}
+		p.mutex.Unlock()
+		// Wait returns the result above without cleaning up, so kill everything the command started here.
+		if p.cgroup != nil {
+			p.cgroup.destroy()
+		} else if pgid, err := syscall.Getpgid(pid); err == nil {
+			cleanupProcs(pgid)
+		} else {
+			p.cmd.Process.Kill()
+		}
+		return
+	}
+}
+
+// Returns the total size of the regular files under dir. Files removed during the walk are skipped.
+func diskUsage(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
 // Query for all sets of (pid, pgid, rss). Given a pid, find its associated pgid.
 // From there, sum the memory of all processes with the same pgid.
 func (e *osExecer) memUsage(pid int) (execer.Memory, error) {
@@ -304,6 +476,25 @@ func (p *osProcess) Wait() (result execer.ProcessStatus) {
 	} else {
 		p.result = &result
 	}
+	if p.cgroup != nil {
+		// Clean up any processes left behind in the cgroup, whether or not they stayed in the process group
+		defer p.cgroup.destroy()
+		if kills, _ := p.cgroup.oomKills(); kills > 0 {
+			result.State = execer.FAILED
+			result.ExitCode = -1
+			result.Error = fmt.Sprintf("Cmd exceeded cgroup memory limit, %d processes killed by the OOM killer (%v)", kills, p.cmd.Args)
+			result.FailureReason = execer.OOMKilled
+			log.WithFields(
+				log.Fields{
+					"pid":    pid,
+					"cgroup": p.cgroup.path,
+					"tag":    p.Tag,
+					"jobID":  p.JobID,
+					"taskID": p.TaskID,
+				}).Info(result.Error)
+			return result
+		}
+	}
 	if err == nil {
 		// the command finished without an error
 		result.State = execer.COMPLETE
@@ -352,6 +543,9 @@ func (p *osProcess) Abort() (result execer.ProcessStatus) {
 			result.ExitCode = status.ExitStatus()
 		}
 	}
+	if p.cgroup != nil {
+		p.cgroup.destroy()
+	}
 	return result
 }
 
@@ -0,0 +1,362 @@
+package os
+
+// Isolation with Linux namespaces. Each command runs in fresh mount, PID and network namespaces,
+// pivoted into a root that's built from bind mounts: the command's Dir is the only writable tree,
+// and the configured toolchain paths are visible read-only at their usual locations.
+//
+// The namespaces are created when the command is started, but the mounts have to be made from inside
+// them, so the worker re-execs itself to set up the sandbox and then execs the command in its place.
+// Setting up the sandbox takes root, but the command runs as an unprivileged user without any
+// capabilities, so it can't undo the mounts or make new ones.
+// Binaries that run sandboxed commands must call RunSandboxInit first thing in main().
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"unsafe"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Passed as the first argument when the worker re-execs itself to set up a sandbox
+const sandboxInitArg = "__scoot_sandbox_init__"
+
+// Exit code of a sandboxed command when the sandbox couldn't be set up
+const sandboxInitFailedExitCode = 125
+
+// Device nodes made available in the sandbox's /dev
+var sandboxDevices = []string{"/dev/null", "/dev/zero", "/dev/full", "/dev/random", "/dev/urandom"}
+
+// Uid and gid of nobody, that commands run as by default
+const sandboxDefaultId = 65534
+
+// Where the host's root is moved to by pivot_root before it's unmounted
+const sandboxOldRoot = ".oldroot"
+
+// Not defined by the syscall package
+const prSetNoNewPrivs = 38
+
+// Paths visible in the sandbox besides the command's Dir.
+type SandboxConfig struct {
+	// Host paths made visible read-only at the same location in the sandbox, ex: /usr, /bin, /lib.
+	// Symlinks are recreated as-is, so a link like /bin -> usr/bin also needs /usr to be listed.
+	ReadOnlyPaths []string
+
+	// If set, each sandboxed command also runs in its own cgroup with these limits.
+	Cgroups *CgroupConfig
+
+	// Unprivileged user and group that commands run as, nobody if unset. Root isn't allowed.
+	// The command's Dir is chowned to them so that it stays writable.
+	Uid int
+	Gid int
+}
+
+// Describes the sandbox of a single command, passed as JSON to the re-exec'd worker.
+type sandboxSpec struct {
+	Root          string
+	Dir           string
+	ReadOnlyPaths []string
+	Network       bool
+	Uid           int
+	Gid           int
+}
+
+type sandbox struct {
+	cfg SandboxConfig
+	// Executable to re-exec to set up each sandbox, the running worker binary
+	exe string
+	// Empty dir that each sandbox mounts its root over. Mounts are private to the sandbox's
+	// mount namespace, so every command can share it.
+	root string
+}
+
+// Runs each command in its own sandbox, polling memory usage against memCap unless cfg.Cgroups is set.
+// Returns an error if this process lacks the privileges to create namespaces or a read-only path doesn't exist.
+func NewSandboxExecer(cfg SandboxConfig, memCap execer.Memory, stat stats.StatsReceiver) (*osExecer, error) {
+	if os.Geteuid() != 0 {
+		return nil, errors.New("sandboxed execution requires running as root")
+	}
+	if cfg.Uid == 0 {
+		cfg.Uid = sandboxDefaultId
+	}
+	if cfg.Gid == 0 {
+		cfg.Gid = sandboxDefaultId
+	}
+	if cfg.Uid < 0 || cfg.Gid < 0 {
+		return nil, fmt.Errorf("sandbox uid %d and gid %d must be unprivileged", cfg.Uid, cfg.Gid)
+	}
+	for _, p := range cfg.ReadOnlyPaths {
+		if !filepath.IsAbs(p) {
+			return nil, fmt.Errorf("sandbox read-only path %s must be absolute", p)
+		}
+		if _, err := os.Lstat(p); err != nil {
+			return nil, fmt.Errorf("sandbox read-only path %s: %v", p, err)
+		}
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	root, err := ioutil.TempDir("", "scoot-sandbox-")
+	if err != nil {
+		return nil, err
+	}
+
+	e := &osExecer{memCap: memCap, sandbox: &sandbox{cfg: cfg, exe: exe, root: root}, stat: stat.Scope("osexecer")}
+	if cfg.Cgroups != nil {
+		if e.cgroupControllers, err = setupCgroupRoot(*cfg.Cgroups); err != nil {
+			return nil, err
+		}
+		e.cgroups = cfg.Cgroups
+	}
+	return e, nil
+}
+
+// Wraps argv so that the worker re-execs itself to set up the sandbox, then execs argv inside it.
+func (s *sandbox) wrap(argv []string, dir string, network bool) ([]string, error) {
+	spec, err := json.Marshal(sandboxSpec{
+		Root:          s.root,
+		Dir:           dir,
+		ReadOnlyPaths: s.cfg.ReadOnlyPaths,
+		Network:       network,
+		Uid:           s.cfg.Uid,
+		Gid:           s.cfg.Gid,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{s.exe, sandboxInitArg, string(spec)}, argv...), nil
+}
+
+// Creates the new namespaces when the wrapped command is started.
+// The network namespace is skipped for commands that are allowed network access.
+func (s *sandbox) sysProcAttr(network bool) *syscall.SysProcAttr {
+	flags := syscall.CLONE_NEWNS | syscall.CLONE_NEWPID
+	if !network {
+		flags |= syscall.CLONE_NEWNET
+	}
+	return &syscall.SysProcAttr{Setpgid: true, Cloneflags: uintptr(flags)}
+}
+
+// If this process was started by a sandbox execer, sets up the sandbox and execs the command, never returning.
+// Otherwise returns immediately. Binaries that use NewSandboxExecer must call this at the start of main().
+func RunSandboxInit() {
+	if len(os.Args) < 4 || os.Args[1] != sandboxInitArg {
+		return
+	}
+	// Capabilities and no_new_privs are per thread, the command is exec'd from the thread that dropped them
+	runtime.LockOSThread()
+	var spec sandboxSpec
+	err := json.Unmarshal([]byte(os.Args[2]), &spec)
+	if err == nil {
+		err = initSandbox(spec, os.Args[3:])
+	}
+	fmt.Fprintf(os.Stderr, "scoot sandbox: %v\n", err)
+	os.Exit(sandboxInitFailedExitCode)
+}
+
+// Builds the sandbox's root, moves into it and execs argv. Only returns on error.
+// Runs as pid 1 of the sandbox's PID namespace, in its own mount namespace.
+func initSandbox(spec sandboxSpec, argv []string) error {
+	// Keep our mounts from propagating back to the host
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("making mounts private: %v", err)
+	}
+	if err := syscall.Mount("tmpfs", spec.Root, "tmpfs", syscall.MS_NOSUID|syscall.MS_NODEV, "mode=0755"); err != nil {
+		return fmt.Errorf("mounting root: %v", err)
+	}
+	for _, p := range spec.ReadOnlyPaths {
+		if err := bindSandboxPath(spec.Root, p, true); err != nil {
+			return err
+		}
+	}
+	for _, d := range sandboxDevices {
+		if _, err := os.Stat(d); err == nil {
+			if err := bindSandboxPath(spec.Root, d, false); err != nil {
+				return err
+			}
+		}
+	}
+	if spec.Dir != "" {
+		if err := bindSandboxPath(spec.Root, spec.Dir, false); err != nil {
+			return err
+		}
+	}
+	proc := filepath.Join(spec.Root, "proc")
+	if err := os.MkdirAll(proc, 0755); err != nil {
+		return err
+	}
+	if err := syscall.Mount("proc", proc, "proc", syscall.MS_NOSUID|syscall.MS_NODEV|syscall.MS_NOEXEC, ""); err != nil {
+		return fmt.Errorf("mounting /proc: %v", err)
+	}
+	if spec.Dir != "" {
+		if err := chownTree(spec.Dir, spec.Uid, spec.Gid); err != nil {
+			return fmt.Errorf("chowning %s: %v", spec.Dir, err)
+		}
+	}
+	if !spec.Network {
+		// A new network namespace only has a loopback interface, and it starts out down
+		if err := setLoopbackUp(); err != nil {
+			return fmt.Errorf("setting up loopback: %v", err)
+		}
+	}
+
+	// Unlike chroot, pivot_root leaves nothing of the host's root to get back to once it's unmounted
+	if err := os.Mkdir(filepath.Join(spec.Root, sandboxOldRoot), 0700); err != nil {
+		return err
+	}
+	if err := syscall.PivotRoot(spec.Root, filepath.Join(spec.Root, sandboxOldRoot)); err != nil {
+		return fmt.Errorf("pivot_root: %v", err)
+	}
+	if err := os.Chdir("/"); err != nil {
+		return err
+	}
+	if err := syscall.Unmount("/"+sandboxOldRoot, syscall.MNT_DETACH); err != nil {
+		return fmt.Errorf("unmounting the host root: %v", err)
+	}
+	if err := os.Remove("/" + sandboxOldRoot); err != nil {
+		return err
+	}
+	// Everything outside of the bind mounts is read-only too
+	if err := syscall.Mount("", "/", "", syscall.MS_REMOUNT|syscall.MS_RDONLY|syscall.MS_NOSUID|syscall.MS_NODEV, ""); err != nil {
+		return fmt.Errorf("remounting root read-only: %v", err)
+	}
+
+	dir := spec.Dir
+	if dir == "" {
+		dir = "/"
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	path, err := exec.LookPath(argv[0])
+	if err != nil {
+		return err
+	}
+	if err := dropPrivileges(spec.Uid, spec.Gid); err != nil {
+		return fmt.Errorf("dropping privileges: %v", err)
+	}
+	return syscall.Exec(path, argv, os.Environ())
+}
+
+// Switches the calling thread to uid and gid without supplementary groups or capabilities,
+// and keeps it from gaining privileges through setuid binaries or file capabilities.
+// The thread must exec right after, the process's other threads keep running as root.
+func dropPrivileges(uid, gid int) error {
+	if _, _, errno := syscall.RawSyscall6(syscall.SYS_PRCTL, prSetNoNewPrivs, 1, 0, 0, 0, 0); errno != 0 {
+		return errno
+	}
+	// Stops at the first capability the kernel doesn't know about
+	for c := 0; ; c++ {
+		if _, _, errno := syscall.RawSyscall6(syscall.SYS_PRCTL, syscall.PR_CAPBSET_DROP, uintptr(c), 0, 0, 0, 0); errno == syscall.EINVAL {
+			break
+		} else if errno != 0 {
+			return errno
+		}
+	}
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SETGROUPS, 0, 0, 0); errno != 0 {
+		return errno
+	}
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SETRESGID, uintptr(gid), uintptr(gid), uintptr(gid)); errno != 0 {
+		return errno
+	}
+	// Leaving uid 0 clears the permitted, effective and ambient capabilities
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SETRESUID, uintptr(uid), uintptr(uid), uintptr(uid)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// Makes everything under dir owned by uid and gid, skipping what already is.
+func chownTree(dir string, uid, gid int) error {
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if st, ok := info.Sys().(*syscall.Stat_t); ok && int(st.Uid) == uid && int(st.Gid) == gid {
+			return nil
+		}
+		return os.Lchown(p, uid, gid)
+	})
+}
+
+// Makes the host path p visible at the same location under root.
+// Symlinks are recreated rather than followed so that relative links resolve within the sandbox.
+func bindSandboxPath(root, p string, readOnly bool) error {
+	target := filepath.Join(root, p)
+	info, err := os.Lstat(p)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err := os.Readlink(p)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(link, target)
+	}
+
+	// Files need a file to be mounted over, dirs need a dir
+	if info.IsDir() {
+		err = os.MkdirAll(target, 0755)
+	} else {
+		err = ioutil.WriteFile(target, nil, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	if err := syscall.Mount(p, target, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return fmt.Errorf("bind mounting %s: %v", p, err)
+	}
+	if readOnly {
+		// Bind mounts ignore MS_RDONLY until they're remounted
+		flags := uintptr(syscall.MS_REMOUNT | syscall.MS_BIND | syscall.MS_RDONLY)
+		if err := syscall.Mount("", target, "", flags|mountFlags(target), ""); err != nil {
+			return fmt.Errorf("remounting %s read-only: %v", p, err)
+		}
+	}
+	return nil
+}
+
+// Returns the nosuid, nodev and noexec flags of the mount at path, which have to be kept when it's remounted.
+func mountFlags(path string) uintptr {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0
+	}
+	// The ST_* flags reported by statfs share the values of the corresponding MS_* mount flags
+	return uintptr(st.Flags) & (syscall.MS_NOSUID | syscall.MS_NODEV | syscall.MS_NOEXEC)
+}
+
+// Brings up the loopback interface so commands without network access can still use localhost.
+func setLoopbackUp() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+
+	// struct ifreq, with the flags member of its union
+	var ifr struct {
+		name  [syscall.IFNAMSIZ]byte
+		flags uint16
+		_     [22]byte
+	}
+	copy(ifr.name[:], "lo")
+	ifr.flags = syscall.IFF_UP | syscall.IFF_RUNNING
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCSIFFLAGS, uintptr(unsafe.Pointer(&ifr))); errno != 0 {
+		return errno
+	}
+	return nil
+}
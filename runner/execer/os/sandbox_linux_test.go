@@ -0,0 +1,208 @@
+package os
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Passed to the test binary to run it as a sandboxed command that tries to escape, see TestSandboxEscape.
+const sandboxEscapeArg = "__scoot_sandbox_escape__"
+
+// The test binary is re-exec'd to set up sandboxes, just like the worker.
+func TestMain(m *testing.M) {
+	RunSandboxInit()
+	if len(os.Args) == 3 && os.Args[1] == sandboxEscapeArg {
+		os.Exit(escapeSandbox(os.Args[2]))
+	}
+	os.Exit(m.Run())
+}
+
+// Tries the usual chroot escape: chroot into a subdir while the cwd stays outside of it,
+// walk up past the sandbox's root and chroot there. Exits 0 if hostPath is visible afterwards.
+func escapeSandbox(hostPath string) int {
+	if err := os.MkdirAll("escape", 0755); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if err := syscall.Chroot("escape"); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	for i := 0; i < 256; i++ {
+		syscall.Chdir("..")
+	}
+	if err := syscall.Chroot("."); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if _, err := os.Stat(hostPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	return 0
+}
+
+// Makes a sandbox execer with the usual toolchain paths and any extra ones,
+// skipping the test if namespaces aren't usable.
+func makeSandboxExecer(t *testing.T, extraPaths ...string) *osExecer {
+	paths := extraPaths
+	for _, p := range []string{"/bin", "/usr", "/lib", "/lib64"} {
+		if _, err := os.Lstat(p); err == nil {
+			paths = append(paths, p)
+		}
+	}
+	e, err := NewSandboxExecer(SandboxConfig{ReadOnlyPaths: paths}, 0, stats.NilStatsReceiver())
+	if err != nil {
+		t.Skipf("Sandboxes aren't supported here: %v", err)
+	}
+	// Probe for namespace support, which may be missing even as root, ex: in containers
+	if status := runSandboxed(t, e, execer.Command{Argv: []string{"true"}}, nil); status.ExitCode != 0 {
+		t.Skipf("Sandboxes aren't supported here: %v", status)
+	}
+	return e
+}
+
+func runSandboxed(t *testing.T, e *osExecer, cmd execer.Command, stdout *bytes.Buffer) execer.ProcessStatus {
+	if stdout == nil {
+		stdout = &bytes.Buffer{}
+	}
+	cmd.Stdout = stdout
+	cmd.Stderr = &bytes.Buffer{}
+	p, err := e.Exec(cmd)
+	if err != nil {
+		t.Fatalf("Couldn't run %v: %v", cmd.Argv, err)
+	}
+	return p.Wait()
+}
+
+func TestSandboxFilesystem(t *testing.T) {
+	e := makeSandboxExecer(t)
+	dir, err := ioutil.TempDir("", "sandbox-test-")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	// The checkout dir is writable.
+	cmd := execer.Command{Argv: []string{"sh", "-c", "echo hello > out"}, Dir: dir}
+	if status := runSandboxed(t, e, cmd, nil); status.State != execer.COMPLETE || status.ExitCode != 0 {
+		t.Fatalf("Expected writing to the checkout to succeed, got: %v", status)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "out")); err != nil || string(b) != "hello\n" {
+		t.Fatalf("Expected output in the checkout, got: %q, %v", b, err)
+	}
+
+	// Toolchain paths are read-only and nothing else from the host is visible.
+	hostFile, err := ioutil.TempFile("", "sandbox-test-")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	hostFile.Close()
+	defer os.Remove(hostFile.Name())
+	for _, script := range []string{"touch /usr/sandbox-test", "touch /sandbox-test", "test -e " + hostFile.Name()} {
+		cmd = execer.Command{Argv: []string{"sh", "-c", script}, Dir: dir}
+		if status := runSandboxed(t, e, cmd, nil); status.State != execer.COMPLETE || status.ExitCode == 0 {
+			t.Fatalf("Expected %q to fail in the sandbox, got: %v", script, status)
+		}
+	}
+	if _, err := os.Stat("/sandbox-test"); err == nil {
+		os.Remove("/sandbox-test")
+		t.Fatalf("Expected sandbox writes to stay out of the host")
+	}
+}
+
+func TestSandboxPidNamespace(t *testing.T) {
+	e := makeSandboxExecer(t)
+	stdout := &bytes.Buffer{}
+	if status := runSandboxed(t, e, execer.Command{Argv: []string{"sh", "-c", "echo $$"}}, stdout); status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status %v", status)
+	}
+	if pid := strings.TrimSpace(stdout.String()); pid != "1" {
+		t.Fatalf("Expected the command to be pid 1 in its namespace, got: %s", pid)
+	}
+}
+
+func TestSandboxNetwork(t *testing.T) {
+	e := makeSandboxExecer(t)
+	interfaces := func(allowNetwork bool) []string {
+		stdout := &bytes.Buffer{}
+		cmd := execer.Command{Argv: []string{"cat", "/proc/net/dev"}, AllowNetwork: allowNetwork}
+		if status := runSandboxed(t, e, cmd, stdout); status.ExitCode != 0 {
+			t.Fatalf("Got unexpected status %v", status)
+		}
+		names := []string{}
+		for _, line := range strings.Split(stdout.String(), "\n") {
+			if i := strings.Index(line, ":"); i > 0 {
+				names = append(names, strings.TrimSpace(line[:i]))
+			}
+		}
+		return names
+	}
+
+	if names := interfaces(false); len(names) != 1 || names[0] != "lo" {
+		t.Fatalf("Expected only a loopback interface without network, got: %v", names)
+	}
+	host, err := ioutil.ReadFile("/proc/net/dev")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if names := interfaces(true); len(names) != strings.Count(string(host), ":") {
+		t.Fatalf("Expected the host's interfaces with network, got: %v", names)
+	}
+}
+
+func TestSandboxEscape(t *testing.T) {
+	// The test binary tries to escape from inside the sandbox, so it has to be visible there.
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	e := makeSandboxExecer(t, filepath.Dir(exe))
+	dir, err := ioutil.TempDir("", "sandbox-test-")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.RemoveAll(dir)
+	hostFile, err := ioutil.TempFile("", "sandbox-test-")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	hostFile.Close()
+	defer os.Remove(hostFile.Name())
+
+	// Read-only binds can't be remounted read-write.
+	script := "mount -o remount,bind,rw /usr; touch /usr/sandbox-test"
+	cmd := execer.Command{Argv: []string{"sh", "-c", script}, Dir: dir}
+	if status := runSandboxed(t, e, cmd, nil); status.State != execer.COMPLETE || status.ExitCode == 0 {
+		t.Fatalf("Expected remounting /usr read-write to fail in the sandbox, got: %v", status)
+	}
+	if _, err := os.Stat("/usr/sandbox-test"); err == nil {
+		os.Remove("/usr/sandbox-test")
+		t.Fatalf("Expected sandbox writes to stay out of /usr")
+	}
+
+	// And there's no way back to the host's root.
+	cmd = execer.Command{Argv: []string{exe, sandboxEscapeArg, hostFile.Name()}, Dir: dir}
+	if status := runSandboxed(t, e, cmd, nil); status.State != execer.COMPLETE || status.ExitCode == 0 {
+		t.Fatalf("Expected escaping the sandbox's root to fail, got: %v", status)
+	}
+
+	// Both need privileges that the command doesn't have.
+	stdout := &bytes.Buffer{}
+	cmd = execer.Command{Argv: []string{"sh", "-c", "id -u; grep CapEff /proc/self/status"}, Dir: dir}
+	if status := runSandboxed(t, e, cmd, stdout); status.ExitCode != 0 {
+		t.Fatalf("Got unexpected status %v", status)
+	}
+	if lines := strings.Fields(stdout.String()); len(lines) != 3 || lines[0] == "0" || lines[2] != "0000000000000000" {
+		t.Fatalf("Expected an unprivileged user without capabilities, got: %q", stdout.String())
+	}
+}
@@ -0,0 +1,35 @@
+// +build !linux
+
+package os
+
+import (
+	"errors"
+	"syscall"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner/execer"
+)
+
+// Paths visible in the sandbox besides the command's Dir. Sandboxes require Linux namespaces.
+type SandboxConfig struct {
+	ReadOnlyPaths []string
+	Cgroups       *CgroupConfig
+	Uid           int
+	Gid           int
+}
+
+type sandbox struct{}
+
+func NewSandboxExecer(cfg SandboxConfig, memCap execer.Memory, stat stats.StatsReceiver) (*osExecer, error) {
+	return nil, errors.New("sandboxed execution is only supported on linux")
+}
+
+func (s *sandbox) wrap(argv []string, dir string, network bool) ([]string, error) {
+	return nil, errors.New("sandboxed execution is only supported on linux")
+}
+
+func (s *sandbox) sysProcAttr(network bool) *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+func RunSandboxInit() {}
@@ -46,6 +46,9 @@ type Command struct {
 	// Runner can optionally use this to run against a particular snapshot. Empty value is ignored.
 	SnapshotID string
 
+	// Runner copies the contents of this snapshot over the SnapshotID checkout before running. Empty value is ignored.
+	MergeSnapshotID string
+
 	// TODO(jschiller): get consensus on design and either implement or delete.
 	// Runner can optionally use this to specify content if creating a new snapshot.
 	// Keys: relative src file & dir paths in SnapshotId checkout. May contain '*' wildcard.
@@ -59,6 +62,50 @@ type Command struct {
 
 	// Bazel ExecuteRequest data for tasks initiated from the Bazel API
 	ExecuteRequest *bazelapi.ExecuteRequest
+
+	// Resources requested for the command. The scheduler only assigns it to a node with enough spare
+	// capacity, and the runner limits the command to the requested memory, cpus and disk. Zero values are ignored.
+	Resources Resources
+
+	// Give the command network access when the runner executes commands in a sandbox.
+	// Sandboxed commands have no network by default.
+	AllowNetwork bool
+}
+
+// An amount of memory, cpu and disk, either requested by a command or offered by a worker.
+// For capacity, a zero value means the worker doesn't limit that resource.
+type Resources struct {
+	// Memory in bytes
+	Memory int64
+
+	// Number of cpus, may be fractional
+	CPUs float64
+
+	// Disk in bytes
+	Disk int64
+}
+
+func (r Resources) IsZero() bool {
+	return r == Resources{}
+}
+
+func (r Resources) Add(o Resources) Resources {
+	return Resources{Memory: r.Memory + o.Memory, CPUs: r.CPUs + o.CPUs, Disk: r.Disk + o.Disk}
+}
+
+func (r Resources) Sub(o Resources) Resources {
+	return Resources{Memory: r.Memory - o.Memory, CPUs: r.CPUs - o.CPUs, Disk: r.Disk - o.Disk}
+}
+
+// Returns true if r fits within capacity, ignoring any resource that capacity doesn't limit.
+func (r Resources) FitsIn(capacity Resources) bool {
+	return (capacity.Memory == 0 || r.Memory <= capacity.Memory) &&
+		(capacity.CPUs == 0 || r.CPUs <= capacity.CPUs) &&
+		(capacity.Disk == 0 || r.Disk <= capacity.Disk)
+}
+
+func (r Resources) String() string {
+	return fmt.Sprintf("Memory: %d, CPUs: %g, Disk: %d", r.Memory, r.CPUs, r.Disk)
 }
 
 func (c Command) String() string {
@@ -70,6 +117,10 @@ func (c Command) String() string {
 		c.TaskID,
 		c.Tag)
 
+	if c.MergeSnapshotID != "" {
+		s += fmt.Sprintf(" # MergeSnapshotID: %s", c.MergeSnapshotID)
+	}
+
 	if len(c.EnvVars) > 0 {
 		s += fmt.Sprintf(" # Env:")
 		for k, v := range c.EnvVars {
@@ -77,6 +128,14 @@ func (c Command) String() string {
 		}
 	}
 
+	if !c.Resources.IsZero() {
+		s += fmt.Sprintf(" # Resources: %s", c.Resources)
+	}
+
+	if c.AllowNetwork {
+		s += " # AllowNetwork: true"
+	}
+
 	if c.ExecuteRequest != nil {
 		s += fmt.Sprintf("  ExecuteRequest=%s", c.ExecuteRequest)
 	}
@@ -25,12 +25,13 @@ import (
 
 // invoke.go: Invoker runs a Scoot command.
 
-// NewInvoker creates an Invoker that will use the supplied helpers
-func NewInvoker(exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, stat stats.StatsReceiver) *Invoker {
+// NewInvoker creates an Invoker that will use the supplied helpers.
+// If logs is non-nil, the output of each run is uploaded there when it's done.
+func NewInvoker(exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, logs *LogStore, stat stats.StatsReceiver) *Invoker {
 	if stat == nil {
 		stat = stats.NilStatsReceiver()
 	}
-	return &Invoker{exec: exec, filerMap: filerMap, output: output, tmp: tmp, stat: stat}
+	return &Invoker{exec: exec, filerMap: filerMap, output: output, tmp: tmp, logs: logs, stat: stat}
 }
 
 // Invoker Runs a Scoot Command by performing the Scoot setup and gathering.
@@ -41,6 +42,7 @@ type Invoker struct {
 	filerMap runner.RunTypeMap
 	output   runner.OutputCreator
 	tmp      *temp.TempDir
+	logs     *LogStore
 	stat     stats.StatsReceiver
 }
 
@@ -162,7 +164,20 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 					"snapshotID": cmd.SnapshotID,
 				}).Info("Checking out snapshotID")
 			var err error
-			co, err = inv.filerMap[runType].Filer.Checkout(cmd.SnapshotID)
+			if cmd.MergeSnapshotID == "" {
+				co, err = inv.filerMap[runType].Filer.Checkout(cmd.SnapshotID)
+			} else {
+				log.WithFields(
+					log.Fields{
+						"runID":           id,
+						"tag":             cmd.Tag,
+						"jobID":           cmd.JobID,
+						"taskID":          cmd.TaskID,
+						"snapshotID":      cmd.SnapshotID,
+						"mergeSnapshotID": cmd.MergeSnapshotID,
+					}).Info("Merging snapshotID into checkout")
+				co, err = inv.mergedCheckout(inv.filerMap[runType].Filer, cmd.SnapshotID, cmd.MergeSnapshotID)
+			}
 			checkoutCh <- err
 		}
 	}()
@@ -239,6 +254,13 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 	}
 	defer stdlog.Close()
 
+	// Runs before the outputs are closed, so anything written to them on the way out is included
+	if inv.logs != nil {
+		defer func() {
+			inv.uploadLogs(&r, cmd, id, stdout, stderr, stdlog)
+		}()
+	}
+
 	marker := "###########################################\n###########################################\n"
 	format := "%s\n\nDate: %v\nOut: %s\tErr: %s\tOutErr: %s\tCmd:\n%v\n\n%s\n\n\nSCOOT_CMD_LOG\n"
 	header := fmt.Sprintf(format, marker, time.Now(), stdout.URI(), stderr.URI(), stdlog.URI(), cmd, marker)
@@ -262,12 +284,16 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 
 	rts.execStart = stamp() // candidate for availability via Execer
 	p, err := inv.exec.Exec(execer.Command{
-		Argv:    cmd.Argv,
-		EnvVars: cmd.EnvVars,
-		Dir:     co.Path(),
-		Stdout:  io.MultiWriter(stdout, stdlog),
-		Stderr:  io.MultiWriter(stderr, stdlog),
-		LogTags: cmd.LogTags,
+		Argv:         cmd.Argv,
+		EnvVars:      cmd.EnvVars,
+		Dir:          co.Path(),
+		Stdout:       io.MultiWriter(stdout, stdlog),
+		Stderr:       io.MultiWriter(stderr, stdlog),
+		LogTags:      cmd.LogTags,
+		MemCap:       execer.Memory(cmd.Resources.Memory),
+		CPUs:         cmd.Resources.CPUs,
+		DiskCap:      cmd.Resources.Disk,
+		AllowNetwork: cmd.AllowNetwork,
 	})
 	if err != nil {
 		return runner.FailedStatus(id, fmt.Errorf("could not exec: %v", err),
@@ -417,8 +443,12 @@ func (inv *Invoker) run(cmd *runner.Command, id runner.RunID, abortCh chan struc
 				tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
 		}
 	case execer.FAILED:
-		return runner.FailedStatus(id, fmt.Errorf("error execing: %v", st.Error),
+		status := runner.FailedStatus(id, fmt.Errorf("error execing: %v", st.Error),
 			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
+		if st.FailureReason != execer.NoFailureReason {
+			status.FailureReason = st.FailureReason.String()
+		}
+		return status
 	default:
 		return runner.FailedStatus(id, fmt.Errorf("unexpected exec state: %v", st),
 			tags.LogTags{JobID: cmd.JobID, TaskID: cmd.TaskID, Tag: cmd.Tag})
@@ -478,3 +508,40 @@ type runTimes struct {
 func stamp() time.Time {
 	return time.Now()
 }
+
+// Checks out mergeID over snapshotID in a new dir owned by the run, so the merge never touches
+// a checkout the Filer may reuse, ex: gitdb's shared work tree.
+// Each snapshot is copied in with CheckoutAt, which releases the Filer's own checkout before returning.
+func (inv *Invoker) mergedCheckout(filer snapshot.Filer, snapshotID, mergeID string) (snapshot.Checkout, error) {
+	tmp, err := inv.tmp.TempDir("invoke_merge_checkout")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := filer.CheckoutAt(snapshotID, tmp.Dir); err != nil {
+		os.RemoveAll(tmp.Dir)
+		return nil, err
+	}
+	if _, err := filer.CheckoutAt(mergeID, tmp.Dir); err != nil {
+		os.RemoveAll(tmp.Dir)
+		return nil, fmt.Errorf("error merging snapshot %s: %v", mergeID, err)
+	}
+	return &mergedCheckout{id: snapshotID, dir: tmp.Dir}, nil
+}
+
+// A checkout of one snapshot merged over another, removed when released.
+type mergedCheckout struct {
+	id  string
+	dir string
+}
+
+func (c *mergedCheckout) Path() string {
+	return c.dir
+}
+
+func (c *mergedCheckout) ID() string {
+	return c.id
+}
+
+func (c *mergedCheckout) Release() error {
+	return os.RemoveAll(c.dir)
+}
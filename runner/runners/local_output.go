@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/twitter/scoot/os/temp"
 	"github.com/twitter/scoot/runner"
@@ -17,6 +18,9 @@ import (
 
 // local_output.go: output that's stored locally
 
+// How often to check for new output when following a file that's still being written.
+var followInterval = 250 * time.Millisecond
+
 type HttpOutputCreator interface {
 	http.Handler
 	runner.OutputCreator
@@ -29,7 +33,9 @@ type localOutputCreator struct {
 	httpUri  string
 	httpPath string
 	pathMap  map[string]string
-	mutex    sync.Mutex
+	// Abs paths of outputs that are still being written, closed when the output is.
+	openMap map[string]chan struct{}
+	mutex   sync.Mutex
 }
 
 // Takes a tempdir to place new files and optionally an httpUri, ex: 'http://HOST:PORT/ENDPOINT/', to use instead of 'file://HOST/PATH'
@@ -50,6 +56,7 @@ func NewHttpOutputCreator(tmp *temp.TempDir, httpUri string) (HttpOutputCreator,
 		tmp: tmp, hostname: hostname,
 		httpUri: httpUri, httpPath: httpPath,
 		pathMap: make(map[string]string),
+		openMap: make(map[string]chan struct{}),
 	}, nil
 }
 
@@ -66,20 +73,29 @@ func (s *localOutputCreator) Create(id string) (runner.Output, error) {
 	}
 	// We don't need a / between hostname and path because absolute paths start with /
 	uri := fmt.Sprintf("file://%s%s", s.hostname, absPath)
+	closedCh := make(chan struct{})
 	if s.httpUri != "" {
 		uri = fmt.Sprintf("%s/%s?file=%s", s.httpUri, id, uri)
+		s.mutex.Lock()
 		s.pathMap[strings.Trim(id, "/")] = absPath
 		s.pathMap[filepath.Base(absPath)] = absPath
+		s.openMap[absPath] = closedCh
+		s.mutex.Unlock()
+	}
+	closed := func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		delete(s.openMap, absPath)
+		close(closedCh)
 	}
-	return &localOutput{f: f, absPath: absPath, uri: uri}, nil
+	return &localOutput{f: f, absPath: absPath, uri: uri, closed: closed}, nil
 }
 
 // Serves a minimal page that does ajax log tailing of the specified path
 // When '?content=true' is specified, this serves the content directly without ajax.
+// When '?follow=true' is specified, this streams the content as it's written until the output is closed.
 // Does not check the request path, either it finds the local file or 404s.
 func (s *localOutputCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
 
 	clientHtml :=
 		`<html>
@@ -136,7 +152,10 @@ func (s *localOutputCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	path := strings.TrimPrefix(r.URL.Path, s.HttpPath())
+	s.mutex.Lock()
 	filepath, ok := s.pathMap[path]
+	closedCh, open := s.openMap[filepath]
+	s.mutex.Unlock()
 	if !ok {
 		http.Error(w, "Unrecognized path", http.StatusNotFound)
 	} else if resource, err := os.Open(filepath); err != nil {
@@ -146,7 +165,13 @@ func (s *localOutputCreator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	} else {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("X-Resource-Id", filepath)
-		if r.URL.Query().Get("content") == "true" {
+		if r.URL.Query().Get("follow") == "true" {
+			defer resource.Close()
+			if !open {
+				closedCh = nil
+			}
+			follow(w, r, resource, closedCh)
+		} else if r.URL.Query().Get("content") == "true" {
 			http.ServeContent(w, r, "", info.ModTime(), resource)
 		} else {
 			fmt.Fprintf(w, clientHtml)
@@ -158,10 +183,45 @@ func (s *localOutputCreator) HttpPath() string {
 	return s.httpPath
 }
 
+// Streams the content of resource as it's written, until closedCh is closed or the client goes away.
+// A nil closedCh means the output is no longer being written, so only its current content is sent.
+func follow(w http.ResponseWriter, r *http.Request, resource io.Reader, closedCh chan struct{}) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	flusher, _ := w.(http.Flusher)
+	for {
+		// Check before reading so that nothing written before the output was closed is missed.
+		done := closedCh == nil
+		if !done {
+			select {
+			case <-closedCh:
+				done = true
+			default:
+			}
+		}
+		if _, err := io.Copy(w, resource); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		if done {
+			return
+		}
+		select {
+		case <-closedCh:
+		case <-r.Context().Done():
+			return
+		case <-time.After(followInterval):
+		}
+	}
+}
+
 type localOutput struct {
 	f       *os.File
 	absPath string
 	uri     string
+	closed  func()
+	once    sync.Once
 }
 
 // URI returns a URI to this Output
@@ -181,6 +241,7 @@ func (o *localOutput) Write(p []byte) (n int, err error) {
 
 // Close implements io.Closer
 func (o *localOutput) Close() error {
+	o.once.Do(o.closed)
 	return o.f.Close()
 }
 
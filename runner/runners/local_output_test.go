@@ -0,0 +1,73 @@
+package runners
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/os/temp"
+)
+
+func TestFollowOutput(t *testing.T) {
+	tmp, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer os.RemoveAll(tmp.Dir)
+
+	oc, err := NewHttpOutputCreator(tmp, "http://localhost/output")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	server := httptest.NewServer(oc)
+	defer server.Close()
+
+	out, err := oc.Create("stdout")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	out.Write([]byte("one\n"))
+
+	resp, err := http.Get(server.URL + "/output/stdout?follow=true")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer resp.Body.Close()
+	bodyCh := make(chan string)
+	go func() {
+		b, _ := ioutil.ReadAll(resp.Body)
+		bodyCh <- string(b)
+	}()
+
+	// The response stays open while the output is being written
+	out.Write([]byte("two\n"))
+	select {
+	case b := <-bodyCh:
+		t.Fatalf("Expected the response to stay open, got: %q", b)
+	case <-time.After(2 * followInterval):
+	}
+
+	out.Write([]byte("three\n"))
+	out.Close()
+	select {
+	case b := <-bodyCh:
+		if b != "one\ntwo\nthree\n" {
+			t.Fatalf("Expected all output to be streamed, got: %q", b)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the response to end when the output was closed")
+	}
+
+	// Closed output is sent as-is
+	resp, err = http.Get(server.URL + "/output/stdout?follow=true")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer resp.Body.Close()
+	if b, err := ioutil.ReadAll(resp.Body); err != nil || string(b) != "one\ntwo\nthree\n" {
+		t.Fatalf("Expected closed output to be sent, got: %q, %v", b, err)
+	}
+}
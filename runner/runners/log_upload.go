@@ -0,0 +1,84 @@
+package runners
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/snapshot/store"
+)
+
+// log_upload.go: uploading run logs so that they outlive the worker
+
+// Prefix of the names logs are uploaded as, ex: log-<sha1>-stdout.txt
+const LogNamePrefix = "log-"
+
+// LogStore is where the Invoker uploads the stdout, stderr and stdlog of each run once it's done.
+// The final RunStatus points at the uploaded copies rather than the worker's local files,
+// which are gone once the worker is recycled.
+type LogStore struct {
+	Store store.Store
+	// Logs expire after this long, or the Store's default if nil.
+	TTL *store.TTLConfig
+}
+
+// Uploads the run's logs and rewrites the stdout and stderr refs of st to point at them.
+// Failures are logged and leave st untouched, the run's result doesn't depend on its logs.
+func (inv *Invoker) uploadLogs(st *runner.RunStatus, cmd *runner.Command, id runner.RunID, stdout, stderr, stdlog runner.Output) {
+	defer inv.stat.Latency(stats.WorkerLogUploadLatency_ms).Time().Stop()
+	inv.stat.Counter(stats.WorkerLogUploads).Inc(1)
+
+	key := logKey(cmd, id)
+	ttl := store.GetTTLValue(inv.logs.TTL)
+	uris := make(map[string]string)
+	for _, o := range []struct {
+		name string
+		out  runner.Output
+	}{{"stdout", stdout}, {"stderr", stderr}, {"stdlog", stdlog}} {
+		name := fmt.Sprintf("%s%s-%s.txt", LogNamePrefix, key, o.name)
+		f, err := os.Open(o.out.AsFile())
+		if err == nil {
+			err = inv.logs.Store.Write(name, f, ttl)
+			f.Close()
+		}
+		if err != nil {
+			log.WithFields(
+				log.Fields{
+					"runID":  id,
+					"tag":    cmd.Tag,
+					"jobID":  cmd.JobID,
+					"taskID": cmd.TaskID,
+					"name":   name,
+					"err":    err,
+				}).Error("Couldn't upload logs, they'll only be available on this worker")
+			inv.stat.Counter(stats.WorkerLogUploadFailures).Inc(1)
+			return
+		}
+		uris[o.name] = logURI(inv.logs.Store.Root(), name)
+	}
+	st.StdoutRef = uris["stdout"]
+	st.StderrRef = uris["stderr"]
+}
+
+// Makes a key that's unique to this run across workers, since run ids are only unique per worker.
+func logKey(cmd *runner.Command, id runner.RunID) string {
+	hostname, _ := os.Hostname()
+	h := sha1.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%d", hostname, cmd.JobID, cmd.TaskID, id, time.Now().UnixNano())
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// Http stores have a base uri as their root, others like the FileStore have a local dir.
+func logURI(root, name string) string {
+	if strings.HasPrefix(root, "http://") || strings.HasPrefix(root, "https://") {
+		return root + name
+	}
+	return "file://" + filepath.Join(root, name)
+}
@@ -54,6 +54,16 @@ type cmdAndID struct {
 	id  runner.RunID
 }
 
+// A command occupying one of the queue's slots
+type runningCmd struct {
+	cmd   *runner.Command
+	abort chan<- struct{}
+}
+
+// The number of commands a runner executes concurrently.
+// Defined as a type so it can be injected via ICE.
+type Slots int
+
 /*
 NewQueueRunner creates a new Service that uses a Queue
 If the worker has an initialization step (indicated by non-nil in idc) the queue will wait for the
@@ -67,26 +77,47 @@ to complete successfully before serving requests.
 @param: idtCh - channel that queue uses to report (to handler) the time the initialization finished
 @param: output
 @param: tmp
-@param: capacity - the maximum number of commands to support on the queue.  If 0 then the queue is unbounded.
+@param: capacity - the maximum number of commands to support on the queue.  If 0 then it acts as a SingleRunner.
 @param: stats - the stats receiver the queue will use when reporting its metrics
 */
 func NewQueueRunner(
 	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, capacity int, stat stats.StatsReceiver) runner.Service {
+	if capacity <= 0 {
+		return NewSingleRunner(exec, filerMap, output, tmp, stat)
+	}
+	// Unlimited history when acting as a queue (vs single runner).
+	return newQueueRunner(exec, filerMap, output, tmp, nil, capacity, 1, 0, stat)
+}
 
-	if stat == nil {
-		stat = stats.NilStatsReceiver()
+func NewSingleRunner(
+	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, stat stats.StatsReceiver) runner.Service {
+	return NewSlotRunner(exec, filerMap, output, tmp, nil, 1, stat)
+}
+
+/*
+NewSlotRunner creates a new Service that runs up to 'slots' commands concurrently, rejecting any
+commands received while all slots are busy. It's a SingleRunner with more than one slot.
+If logs is non-nil, the output of each command is uploaded there once it's done.
+*/
+func NewSlotRunner(
+	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, logs *LogStore, slots int, stat stats.StatsReceiver) runner.Service {
+	if slots <= 0 {
+		slots = 1
 	}
+	// Keep one finished status per slot around for the scheduler to collect.
+	return newQueueRunner(exec, filerMap, output, tmp, logs, slots, slots, slots, stat)
+}
+
+func newQueueRunner(
+	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, logs *LogStore,
+	capacity int, slots int, history int, stat stats.StatsReceiver) runner.Service {
 
-	//FIXME(jschiller): proper history config rather than keying off of capacity and if this is a SingleRunner.
-	history := 1
-	if capacity > 0 {
-		history = 0 // unlimited if acting as a queue (vs single runner).
-	} else if capacity == 0 {
-		capacity = 1 // singleRunner, override capacity so it can actually run a command.
+	if stat == nil {
+		stat = stats.NilStatsReceiver()
 	}
 
 	statusManager := NewStatusManager(history)
-	inv := NewInvoker(exec, filerMap, output, tmp, stat)
+	inv := NewInvoker(exec, filerMap, output, tmp, logs, stat)
 
 	controller := &QueueController{
 		statusManager: statusManager,
@@ -94,6 +125,8 @@ func NewQueueRunner(
 		filerMap:      filerMap,
 		updateReq:     make(map[runner.RunType]bool),
 		capacity:      capacity,
+		slots:         slots,
+		running:       make(map[runner.RunID]runningCmd),
 		reqCh:         make(chan interface{}),
 		updateCh:      make(chan interface{}),
 		cancelTimerCh: make(chan interface{}),
@@ -129,14 +162,14 @@ func NewQueueRunner(
 			wg.Wait()
 			if initErr != nil {
 				stat.Counter(stats.WorkerDownloadInitFailure).Inc(1)
-				statusManager.UpdateService(runner.ServiceStatus{Initialized: false, Error: initErr})
+				statusManager.UpdateService(runner.ServiceStatus{Initialized: false, Error: initErr, Slots: slots})
 			} else {
-				statusManager.UpdateService(runner.ServiceStatus{Initialized: true})
+				statusManager.UpdateService(runner.ServiceStatus{Initialized: true, Slots: slots})
 				controller.startUpdateTickers()
 			}
 		}()
 	} else {
-		statusManager.UpdateService(runner.ServiceStatus{Initialized: true})
+		statusManager.UpdateService(runner.ServiceStatus{Initialized: true, Slots: slots})
 		controller.startUpdateTickers()
 	}
 
@@ -145,12 +178,7 @@ func NewQueueRunner(
 	return run
 }
 
-func NewSingleRunner(
-	exec execer.Execer, filerMap runner.RunTypeMap, output runner.OutputCreator, tmp *temp.TempDir, stat stats.StatsReceiver) runner.Service {
-	return NewQueueRunner(exec, filerMap, output, tmp, 0, stat)
-}
-
-// QueueController maintains a queue of commands to run (up to capacity).
+// QueueController maintains a queue of commands to run (up to capacity), running up to 'slots' of them at once.
 // Manages updates to underlying Filer via Filer's Update interface,
 // if a non-zero update interval is defined (updates and tasks cannot run concurrently)
 type QueueController struct {
@@ -160,11 +188,11 @@ type QueueController struct {
 	updateLock    sync.Mutex
 	statusManager *StatusManager
 	capacity      int
+	slots         int
 
-	queue        []cmdAndID
-	runningID    runner.RunID
-	runningCmd   *runner.Command
-	runningAbort chan<- struct{}
+	// commands waiting for a free slot, and the commands occupying slots
+	queue   []cmdAndID
+	running map[runner.RunID]runningCmd
 
 	// used to signal a cmd run request
 	reqCh chan interface{}
@@ -220,9 +248,9 @@ func (c *QueueController) enqueue(cmd *runner.Command) (runner.RunStatus, error)
 		log.Fields{
 			"ready":          svcStatus.Initialized,
 			"err":            svcStatus.Error,
-			"availableSlots": c.capacity - len(c.queue),
+			"availableSlots": c.capacity - len(c.queue) - len(c.running),
 			"totalSlots":     c.capacity,
-			"currentRun":     c.runningID,
+			"numRunning":     len(c.running),
 			"jobID":          cmd.JobID,
 			"taskID":         cmd.TaskID,
 			"tag":            cmd.Tag,
@@ -235,7 +263,7 @@ func (c *QueueController) enqueue(cmd *runner.Command) (runner.RunStatus, error)
 		}
 		return runner.RunStatus{Error: errStr}, fmt.Errorf(QueueInitingMsg)
 	}
-	if len(c.queue) >= c.capacity {
+	if len(c.queue)+len(c.running) >= c.capacity {
 		return runner.RunStatus{}, fmt.Errorf(QueueFullMsg)
 	}
 
@@ -257,17 +285,18 @@ func (c *QueueController) Abort(run runner.RunID) (runner.RunStatus, error) {
 }
 
 func (c *QueueController) abort(run runner.RunID) (runner.RunStatus, error) {
-	if run == c.runningID {
-		if c.runningAbort != nil {
+	if r, ok := c.running[run]; ok {
+		if r.abort != nil {
 			log.WithFields(
 				log.Fields{
-					"currentRun": c.runningID,
-					"jobID":      c.runningCmd.JobID,
-					"taskID":     c.runningCmd.TaskID,
-					"tag":        c.runningCmd.Tag,
+					"currentRun": run,
+					"jobID":      r.cmd.JobID,
+					"taskID":     r.cmd.TaskID,
+					"tag":        r.cmd.Tag,
 				}).Info("Aborting")
-			close(c.runningAbort)
-			c.runningAbort = nil
+			close(r.abort)
+			r.abort = nil
+			c.running[run] = r
 		}
 	} else {
 		for i, cmdID := range c.queue {
@@ -303,13 +332,15 @@ func (c *QueueController) Release() {
 
 // Handle requests to run and update, to provide concurrency management between the two.
 // Although we can still receive run requests, runs and updates are done blocking.
+// An update waits for all running commands to finish, and no new commands start until it's done.
 func (c *QueueController) loop() {
-	var watchCh chan runner.RunStatus
+	// Receives the RunID of each finished command
+	watchCh := make(chan runner.RunID)
 	var updateDoneCh chan interface{}
 	updateRequested := false
 
 	tryUpdate := func() {
-		if watchCh == nil && updateDoneCh == nil {
+		if len(c.running) == 0 && updateDoneCh == nil {
 			updateRequested = false
 			updateDoneCh = make(chan interface{})
 			go func() {
@@ -328,14 +359,11 @@ func (c *QueueController) loop() {
 				for _, t := range typesToUpdate {
 					log.Infof("Running filer update for type %v", t)
 					if err := c.filerMap[t].Filer.Update(); err != nil {
-						fields := log.Fields{"err": err}
-						if c.runningCmd != nil {
-							fields["runType"] = t
-							fields["tag"] = c.runningCmd.Tag
-							fields["jobID"] = c.runningCmd.JobID
-							fields["taskID"] = c.runningCmd.TaskID
-						}
-						log.WithFields(fields).Error("Error running Filer Update")
+						log.WithFields(
+							log.Fields{
+								"err":     err,
+								"runType": t,
+							}).Error("Error running Filer Update")
 					}
 				}
 				updateDoneCh <- nil
@@ -346,9 +374,10 @@ func (c *QueueController) loop() {
 	}
 
 	tryRun := func() {
-		if watchCh == nil && updateDoneCh == nil && len(c.queue) > 0 {
+		for updateDoneCh == nil && len(c.running) < c.slots && len(c.queue) > 0 {
 			cmdID := c.queue[0]
-			watchCh = c.runAndWatch(cmdID)
+			c.queue = c.queue[1:]
+			c.runAndWatch(cmdID, watchCh)
 		}
 	}
 
@@ -391,20 +420,16 @@ func (c *QueueController) loop() {
 				r.resultCh <- result{st, err}
 			}
 
-		case <-watchCh:
-			// Handle finished run by resetting state.
-			watchCh = nil
-			c.runningID = ""
-			c.runningCmd = nil
-			c.runningAbort = nil
-			c.queue = c.queue[1:]
+		case id := <-watchCh:
+			// Handle finished run by freeing its slot.
+			delete(c.running, id)
 		}
 	}
 }
 
-// Run cmd and then start a new goroutine to watch the cmd.
-// Returns a watchCh for goroutine completion.
-func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
+// Run cmd in a free slot and then start a new goroutine to watch the cmd.
+// The cmd's RunID is sent to watchCh once it's finished.
+func (c *QueueController) runAndWatch(cmdID cmdAndID, watchCh chan<- runner.RunID) {
 	log.WithFields(
 		log.Fields{
 			"jobID":  cmdID.cmd.JobID,
@@ -413,11 +438,8 @@ func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
 			"newLen": len(c.queue),
 			"tag":    cmdID.cmd.Tag,
 		}).Info("Running")
-	watchCh := make(chan runner.RunStatus)
 	abortCh, statusUpdateCh := c.inv.Run(cmdID.cmd, cmdID.id)
-	c.runningAbort = abortCh
-	c.runningID = cmdID.id
-	c.runningCmd = cmdID.cmd
+	c.running[cmdID.id] = runningCmd{cmd: cmdID.cmd, abort: abortCh}
 	go func() {
 		for st := range statusUpdateCh {
 			log.WithFields(
@@ -435,10 +457,9 @@ func (c *QueueController) runAndWatch(cmdID cmdAndID) chan runner.RunStatus {
 				}).Info("Queue received status update")
 			c.statusManager.Update(st)
 			if st.State.IsDone() {
-				watchCh <- st
+				watchCh <- cmdID.id
 				return
 			}
 		}
 	}()
-	return watchCh
 }
@@ -3,6 +3,8 @@ package runners
 import (
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/ice"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/runner/execer"
 	"github.com/twitter/scoot/runner/execer/execers"
 	osexec "github.com/twitter/scoot/runner/execer/os"
@@ -15,12 +17,28 @@ func Module() ice.Module {
 
 type module struct{}
 
-// Install installs functions for creating a new Runner.
+// Install installs functions for creating a new Runner, which runs one command at a time unless Slots is overridden.
+// Logs stay on the worker unless a *LogStore is provided.
 func (m module) Install(b *ice.MagicBag) {
 	b.PutMany(
 		func(m execer.Memory, s stats.StatsReceiver) execer.Execer {
 			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewBoundedExecer(m, s))
 		},
-		NewSingleRunner,
+		func() Slots {
+			return 1
+		},
+		func() *LogStore {
+			return nil
+		},
+		func(
+			exec execer.Execer,
+			filerMap runner.RunTypeMap,
+			output runner.OutputCreator,
+			tmp *temp.TempDir,
+			logs *LogStore,
+			slots Slots,
+			stat stats.StatsReceiver) runner.Service {
+			return NewSlotRunner(exec, filerMap, output, tmp, logs, int(slots), stat)
+		},
 	)
 }
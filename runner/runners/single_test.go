@@ -3,6 +3,7 @@ package runners
 import (
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"regexp"
 	"strings"
 	"testing"
@@ -18,7 +19,10 @@ import (
 	"github.com/twitter/scoot/runner/execer/execers"
 	os_execer "github.com/twitter/scoot/runner/execer/os"
 	"github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/git/gitdb"
+	"github.com/twitter/scoot/snapshot/git/repo"
 	"github.com/twitter/scoot/snapshot/snapshots"
+	"github.com/twitter/scoot/snapshot/store"
 )
 
 func TestRun(t *testing.T) {
@@ -86,6 +90,88 @@ func TestSimul(t *testing.T) {
 	assertWait(t, r, firstRun, complete(0), firstArgs...)
 }
 
+func TestSlots(t *testing.T) {
+	defer teardown(t)
+	sim := execers.NewSimExecer()
+	tmpDir, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputCreator, err := NewHttpOutputCreator(tmpDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filerMap := runner.MakeRunTypeMap()
+	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeInvalidFiler(), IDC: nil}
+	r := NewSlotRunner(sim, filerMap, outputCreator, tmpDir, nil, 2, nil)
+
+	// Both slots should be able to run at the same time.
+	args := []string{"pause", "complete 0"}
+	firstRun := run(t, r, args)
+	secondRun := run(t, r, args)
+	assertWait(t, r, firstRun, running(), args...)
+	assertWait(t, r, secondRun, running(), args...)
+	if _, svc, err := r.StatusAll(); err != nil || svc.Slots != 2 {
+		t.Fatalf("Expected 2 slots, got %d, err=%v", svc.Slots, err)
+	}
+
+	// A third command is rejected while all slots are busy.
+	cmd := &runner.Command{}
+	cmd.Argv = []string{"complete 3"}
+	if _, err := r.Run(cmd); err == nil {
+		t.Fatal("Expected: no resources available err.")
+	}
+
+	sim.Resume()
+	sim.Resume()
+	assertWait(t, r, firstRun, complete(0), args...)
+	assertWait(t, r, secondRun, complete(0), args...)
+
+	// Once a slot frees up, commands run again.
+	assertRun(t, r, complete(3), "complete 3")
+}
+
+func TestLogUpload(t *testing.T) {
+	defer teardown(t)
+	sim := execers.NewSimExecer()
+	tmpDir, err := temp.TempDirDefault()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputCreator, err := NewHttpOutputCreator(tmpDir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filerMap := runner.MakeRunTypeMap()
+	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeInvalidFiler(), IDC: nil}
+	logStore := &store.FakeStore{}
+	r := NewSlotRunner(sim, filerMap, outputCreator, tmpDir, &LogStore{Store: logStore}, 1, nil)
+
+	id := assertRun(t, r, complete(0), "stdout hello world\n", "stderr hello err\n", "complete 0")
+	st, _, err := r.Status(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The final status points at the uploaded copies, which are named so the bundlestore accepts them
+	nameRE := regexp.MustCompile("log-[a-z0-9]{40}-(stdout|stderr).txt$")
+	for _, ref := range []struct {
+		uri      string
+		expected string
+	}{{st.StdoutRef, "(?s).*SCOOT_CMD_LOG\nhello world\n$"}, {st.StderrRef, "(?s).*SCOOT_CMD_LOG\nhello err\n$"}} {
+		name := nameRE.FindString(ref.uri)
+		if name == "" {
+			t.Fatalf("Expected a ref to an uploaded log, got: %s", ref.uri)
+		}
+		if ok, _ := regexp.Match(ref.expected, logStore.Files[name]); !ok {
+			t.Fatalf("%s was %q; expected %q", name, logStore.Files[name], ref.expected)
+		}
+	}
+	if len(logStore.Files) != 3 {
+		t.Fatalf("Expected stdout, stderr and stdlog to be uploaded, got: %d files", len(logStore.Files))
+	}
+}
+
 func TestAbort(t *testing.T) {
 	defer teardown(t)
 	r, _ := newRunner()
@@ -205,6 +291,123 @@ func TestTimeout(t *testing.T) {
 	}
 }
 
+func TestMergeSnapshot(t *testing.T) {
+	tmp, _ := temp.TempDirDefault()
+	filer := snapshots.MakeTempFiler(tmp)
+	ingest := func(name string) string {
+		dir, _ := tmp.TempDir("ingest")
+		if err := ioutil.WriteFile(dir.Dir+"/"+name, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		id, err := filer.Ingest(dir.Dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	baseID, mergeID := ingest("base"), ingest("merged")
+
+	filerMap := runner.MakeRunTypeMap()
+	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: filer, IDC: nil}
+	r := NewSingleRunner(os_execer.NewExecer(), filerMap, NewNullOutputCreator(), tmp, nil)
+
+	cmd := &runner.Command{
+		Argv:            []string{"sh", "-c", "test -f base && test -f merged"},
+		SnapshotID:      baseID,
+		MergeSnapshotID: mergeID,
+	}
+	if _, err := r.Run(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	query := runner.Query{AllRuns: true, States: runner.DONE_MASK}
+	status, _, err := r.Query(query, runner.Wait{Timeout: 5 * time.Second})
+	if err != nil || len(status) != 1 {
+		t.Fatalf("Expected a single finished run, got %v, %v", status, err)
+	}
+	if status[0].State != runner.COMPLETE || status[0].ExitCode != 0 {
+		t.Fatalf("Expected both snapshots in the checkout, got %v", status[0])
+	}
+}
+
+// Merges into a git commit snapshot, which gitdb checks out in its shared work tree.
+func TestMergeSnapshot_GitDB(t *testing.T) {
+	tmp, _ := temp.NewTempDir("", "merge_gitdb")
+	defer os.RemoveAll(tmp.Dir)
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	newRepo := func(name string) *repo.Repository {
+		dir, _ := tmp.TempDir(name)
+		git(dir.Dir, "init")
+		git(dir.Dir, "config", "user.name", "Scoot Test")
+		git(dir.Dir, "config", "user.email", "scoottest@twitter.github.io")
+		r, err := repo.NewRepository(dir.Dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+
+	external, dataRepo := newRepo("external"), newRepo("data")
+	ioutil.WriteFile(external.Dir()+"/base", []byte("base"), 0644)
+	git(external.Dir(), "add", "base")
+	git(external.Dir(), "commit", "-m", "base")
+
+	db := gitdb.MakeDBFromRepo(dataRepo, nil, tmp, nil, nil, nil, gitdb.AutoUploadNone, stats.NilStatsReceiver())
+	defer db.Close()
+	baseID, err := db.IngestGitCommit(external, git(external.Dir(), "rev-parse", "HEAD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mergeDir, _ := tmp.TempDir("merge")
+	ioutil.WriteFile(mergeDir.Dir+"/merged", []byte("merged"), 0644)
+	mergeID, err := db.IngestDir(mergeDir.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filer := snapshot.NewDBAdapter(db)
+	filerMap := runner.MakeRunTypeMap()
+	filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: filer, IDC: nil}
+	r := NewSingleRunner(os_execer.NewExecer(), filerMap, NewNullOutputCreator(), tmp, nil)
+
+	cmd := &runner.Command{
+		Argv:            []string{"sh", "-c", "test -f base && test -f merged && touch written"},
+		SnapshotID:      string(baseID),
+		MergeSnapshotID: string(mergeID),
+	}
+	if _, err := r.Run(cmd); err != nil {
+		t.Fatal(err)
+	}
+	query := runner.Query{AllRuns: true, States: runner.DONE_MASK}
+	status, _, err := r.Query(query, runner.Wait{Timeout: 10 * time.Second})
+	if err != nil || len(status) != 1 {
+		t.Fatalf("Expected a single finished run, got %v, %v", status, err)
+	}
+	if status[0].State != runner.COMPLETE || status[0].ExitCode != 0 {
+		t.Fatalf("Expected both snapshots in the checkout, got %v", status[0])
+	}
+
+	// The next checkout of the base snapshot must not see the merge or the run's writes.
+	co, err := filer.Checkout(string(baseID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer co.Release()
+	for _, name := range []string{"merged", "written"} {
+		if _, err := os.Stat(co.Path() + "/" + name); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be absent from a later checkout of the base snapshot, got %v", name, err)
+		}
+	}
+}
+
 func newRunner() (runner.Service, *execers.SimExecer) {
 	sim := execers.NewSimExecer()
 	tmpDir, err := temp.TempDirDefault()
@@ -52,8 +52,14 @@ func (s *StatusManager) NewRun() (runner.RunStatus, error) {
 
 	s.fifo = append(s.fifo, id)
 	if s.capacity != 0 && len(s.fifo) > s.capacity {
-		delete(s.runs, s.fifo[0])
-		s.fifo = s.fifo[1:]
+		// Evict the oldest finished (or erased) run, runs that are still active are never evicted.
+		for i, oldID := range s.fifo {
+			if st, ok := s.runs[oldID]; !ok || st.State.IsDone() {
+				delete(s.runs, oldID)
+				s.fifo = append(s.fifo[:i], s.fifo[i+1:]...)
+				break
+			}
+		}
 	}
 
 	return st, nil
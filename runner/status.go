@@ -80,6 +80,8 @@ type RunStatus struct {
 
 	// Only valid if State == (FAILED || BADREQUEST || ABORTED)
 	Error string
+	// Why the worker failed the run, ex: "OOMKilled", see execer.FailureReason. Empty if the run failed on its own.
+	FailureReason string
 
 	tags.LogTags
 	ActionResult *bazelapi.ActionResult
@@ -95,6 +97,9 @@ func (p RunStatus) String() string {
 	if p.State == FAILED || p.State == BADREQUEST {
 		s += fmt.Sprintf(" # Error: %s", p.Error)
 	}
+	if p.FailureReason != "" {
+		s += fmt.Sprintf(" # FailureReason: %s", p.FailureReason)
+	}
 	s += fmt.Sprintf(" # Stdout: %s # Stderr: %s", p.StdoutRef, p.StderrRef)
 
 	if p.ActionResult != nil {
@@ -168,10 +173,16 @@ func PreparingStatus(runID RunID, tags tags.LogTags) (r RunStatus) {
 	return r
 }
 
-// This is for overall runner status, just 'initialized' status and error for now.
+// This is for overall runner status: 'initialized' status, error, and the capacity and slots offered to commands.
 type ServiceStatus struct {
 	Initialized bool
 	Error       error
+
+	// Resources the service offers to commands, zero values mean unlimited
+	Capacity Resources
+
+	// Number of commands the service runs concurrently
+	Slots int
 }
 
 func (s ServiceStatus) String() string {
@@ -5,7 +5,11 @@
 // https://speakerdeck.com/caitiem20/applying-the-saga-pattern
 package saga
 
-import "sync"
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
 
 // Concurrent Object Representing a Saga
 // Methods update the state of the saga or
@@ -15,12 +19,16 @@ type Saga struct {
 	log      SagaLog
 	state    *SagaState
 	updateCh chan sagaUpdate
-	mutex    sync.RWMutex // mutex controls access to Saga.state
+	watchers *sagaWatchers // notified of every successfully applied update, may be nil
+	mutex    sync.RWMutex  // mutex controls access to Saga.state
+
+	checkpointInterval int // messages to log between checkpoints of state, zero to never checkpoint
+	sinceCheckpoint    int // messages logged since the last checkpoint
 }
 
 // Start a New Saga.  Logs a Start Saga Message to the SagaLog
 // returns a Saga, or an error if one occurs
-func newSaga(sagaId string, job []byte, log SagaLog) (*Saga, error) {
+func newSaga(sagaId string, job []byte, log SagaLog, watchers *sagaWatchers, checkpointInterval int) (*Saga, error) {
 
 	state, err := makeSagaState(sagaId, job)
 	if err != nil {
@@ -39,7 +47,10 @@ func newSaga(sagaId string, job []byte, log SagaLog) (*Saga, error) {
 		log:      log,
 		state:    state,
 		updateCh: updateCh,
+		watchers: watchers,
 		mutex:    sync.RWMutex{},
+
+		checkpointInterval: checkpointInterval,
 	}
 
 	go s.updateSagaStateLoop()
@@ -49,14 +60,17 @@ func newSaga(sagaId string, job []byte, log SagaLog) (*Saga, error) {
 
 // Rehydrate a saga from a specified SagaState, does not write
 // to SagaLog assumes that this is a recovered saga.
-func rehydrateSaga(sagaId string, state *SagaState, log SagaLog) *Saga {
+func rehydrateSaga(sagaId string, state *SagaState, log SagaLog, watchers *sagaWatchers, checkpointInterval int) *Saga {
 	updateCh := make(chan sagaUpdate, 0)
 	s := &Saga{
 		id:       sagaId,
 		log:      log,
 		state:    state,
 		updateCh: updateCh,
+		watchers: watchers,
 		mutex:    sync.RWMutex{},
+
+		checkpointInterval: checkpointInterval,
 	}
 
 	if !state.IsSagaCompleted() {
@@ -183,9 +197,37 @@ func (s *Saga) updateSaga(update sagaUpdate) {
 	defer s.mutex.Unlock()
 	var err error
 	s.state, err = logMessage(s.state, update.msg, s.log)
+	if err == nil {
+		s.watchers.notify(s.id, s.state)
+		s.sinceCheckpoint++
+		s.maybeCheckpoint()
+	}
 	update.resultCh <- err
 }
 
+// Logs a Checkpoint message with the current state once checkpointInterval messages have been logged
+// since the last one, so recovery doesn't have to replay them. Failing to checkpoint doesn't fail the
+// update that triggered it, it's retried after the next message.
+// Must be called with the mutex held.
+func (s *Saga) maybeCheckpoint() {
+	if s.checkpointInterval <= 0 || s.sinceCheckpoint < s.checkpointInterval || s.state.IsSagaCompleted() {
+		return
+	}
+	data, err := SerializeSagaState(s.state)
+	if err == nil {
+		err = s.log.LogMessage(MakeCheckpointMessage(s.id, data))
+	}
+	if err != nil {
+		log.WithFields(
+			log.Fields{
+				"sagaId": s.id,
+				"err":    err,
+			}).Error("Failed to checkpoint saga")
+		return
+	}
+	s.sinceCheckpoint = 0
+}
+
 type sagaUpdate struct {
 	msg      SagaMessage
 	resultCh chan error
@@ -0,0 +1,201 @@
+package saga
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Number of messages a Saga logs between checkpoints of its state.
+const DefaultCheckpointInterval = 1000
+
+/*
+ * CheckpointedSagaLog is implemented by SagaLogs that can avoid reading
+ * the messages logged before a saga's latest Checkpoint message.
+ * Recovery uses it when available, otherwise it replays GetMessages.
+ * Such logs may drop the state of earlier checkpoints, which GetMessages
+ * then returns without data.
+ */
+type CheckpointedSagaLog interface {
+	SagaLog
+
+	/*
+	 * Returns the latest Checkpoint message logged for the specified
+	 * saga followed by the messages logged after it, or all of the
+	 * saga's messages if it has no checkpoint.
+	 */
+	GetMessagesSinceCheckpoint(sagaId string) ([]SagaMessage, error)
+}
+
+// Returns the messages from the latest checkpoint on, see CheckpointedSagaLog.
+// The StartSaga message is never dropped if there's no checkpoint after it.
+func TrimToCheckpoint(msgs []SagaMessage) []SagaMessage {
+	for i := len(msgs) - 1; i > 0; i-- {
+		if msgs[i].MsgType == Checkpoint {
+			return msgs[i:]
+		}
+	}
+	return msgs
+}
+
+// Reads the messages to recover a saga from, starting from its latest checkpoint if it has one.
+func getMessagesSinceCheckpoint(log SagaLog, sagaId string) ([]SagaMessage, error) {
+	if clog, ok := log.(CheckpointedSagaLog); ok {
+		return clog.GetMessagesSinceCheckpoint(sagaId)
+	}
+	msgs, err := log.GetMessages(sagaId)
+	return TrimToCheckpoint(msgs), err
+}
+
+// Version of the SagaState serialization, bumped if the format changes.
+const sagaStateVersion = 1
+
+/*
+ * Serializes a SagaState, ex: for a Checkpoint message.
+ * Byte slices are length prefixed, with nil and empty slices kept distinct.
+ */
+func SerializeSagaState(state *SagaState) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(sagaStateVersion)
+	putBytes(&buf, []byte(state.sagaId))
+	putBytes(&buf, state.job)
+	putBool(&buf, state.sagaAborted)
+	putBool(&buf, state.sagaCompleted)
+
+	// Tasks are sorted so the same state always serializes the same way.
+	taskIds := sortedTaskIds(state)
+	putUvarint(&buf, uint64(len(taskIds)))
+	for _, taskId := range taskIds {
+		putBytes(&buf, []byte(taskId))
+		buf.WriteByte(byte(state.taskState[taskId]))
+	}
+
+	taskIds = make([]string, 0, len(state.taskData))
+	for taskId := range state.taskData {
+		taskIds = append(taskIds, taskId)
+	}
+	sort.Strings(taskIds)
+	putUvarint(&buf, uint64(len(taskIds)))
+	for _, taskId := range taskIds {
+		data := state.taskData[taskId]
+		putBytes(&buf, []byte(taskId))
+		putBytes(&buf, data.taskStart)
+		putBytes(&buf, data.taskEnd)
+		putBytes(&buf, data.compTaskStart)
+		putBytes(&buf, data.compTaskEnd)
+		putUvarint(&buf, uint64(len(data.taskStartHistory)))
+		for _, start := range data.taskStartHistory {
+			putBytes(&buf, start)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+/*
+ * Deserializes a SagaState serialized by SerializeSagaState.
+ */
+func DeserializeSagaState(data []byte) (*SagaState, error) {
+	r := &stateReader{buf: bytes.NewReader(data)}
+	if version := r.byte(); r.err == nil && version != sagaStateVersion {
+		return nil, fmt.Errorf("Unsupported SagaState version %d", version)
+	}
+
+	state := initializeSagaState()
+	state.sagaId = string(r.bytes())
+	state.job = r.bytes()
+	state.sagaAborted = r.byte() != 0
+	state.sagaCompleted = r.byte() != 0
+
+	for n := r.uvarint(); n > 0 && r.err == nil; n-- {
+		taskId := string(r.bytes())
+		state.taskState[taskId] = flag(r.byte())
+	}
+	for n := r.uvarint(); n > 0 && r.err == nil; n-- {
+		taskId := string(r.bytes())
+		data := &taskData{
+			taskStart:     r.bytes(),
+			taskEnd:       r.bytes(),
+			compTaskStart: r.bytes(),
+			compTaskEnd:   r.bytes(),
+		}
+		for h := r.uvarint(); h > 0 && r.err == nil; h-- {
+			data.taskStartHistory = append(data.taskStartHistory, r.bytes())
+		}
+		state.taskData[taskId] = data
+	}
+
+	if r.err == nil && r.buf.Len() != 0 {
+		r.err = errors.New("unexpected trailing data")
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("Error deserializing SagaState: %v", r.err)
+	}
+	if err := validateSagaId(state.sagaId); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func putUvarint(buf *bytes.Buffer, v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+// Writes the length plus one, so zero can mean nil.
+func putBytes(buf *bytes.Buffer, data []byte) {
+	if data == nil {
+		putUvarint(buf, 0)
+		return
+	}
+	putUvarint(buf, uint64(len(data))+1)
+	buf.Write(data)
+}
+
+func putBool(buf *bytes.Buffer, b bool) {
+	if b {
+		buf.WriteByte(1)
+	} else {
+		buf.WriteByte(0)
+	}
+}
+
+// Reads a serialized SagaState, keeping the first error so it only needs to be checked at the end.
+type stateReader struct {
+	buf *bytes.Reader
+	err error
+}
+
+func (r *stateReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	var b byte
+	b, r.err = r.buf.ReadByte()
+	return b
+}
+
+func (r *stateReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	var v uint64
+	v, r.err = binary.ReadUvarint(r.buf)
+	return v
+}
+
+func (r *stateReader) bytes() []byte {
+	n := r.uvarint()
+	if r.err != nil || n == 0 {
+		return nil
+	}
+	if n-1 > uint64(r.buf.Len()) {
+		r.err = errors.New("length exceeds data")
+		return nil
+	}
+	data := make([]byte, n-1)
+	_, r.err = io.ReadFull(r.buf, data)
+	return data
+}
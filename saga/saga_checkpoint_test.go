@@ -0,0 +1,138 @@
+package saga
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// SagaLog that keeps messages in memory, without CheckpointedSagaLog so recovery trims GetMessages itself.
+type recordingSagaLog struct {
+	msgs  map[string][]SagaMessage
+	mutex sync.Mutex
+}
+
+func (l *recordingSagaLog) StartSaga(sagaId string, job []byte) error {
+	return l.LogMessage(MakeStartSagaMessage(sagaId, job))
+}
+
+func (l *recordingSagaLog) LogMessage(msg SagaMessage) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.msgs[msg.SagaId] = append(l.msgs[msg.SagaId], msg)
+	return nil
+}
+
+func (l *recordingSagaLog) GetMessages(sagaId string) ([]SagaMessage, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.msgs[sagaId], nil
+}
+
+func (l *recordingSagaLog) GetActiveSagas() ([]string, error) {
+	return nil, nil
+}
+
+func TestSagaState_Serialize(t *testing.T) {
+	state, _ := makeSagaState("sagaId", []byte("job"))
+	for _, msg := range []SagaMessage{
+		MakeStartTaskMessage("sagaId", "task1", []byte{1}),
+		MakeStartTaskMessage("sagaId", "task1", []byte{2}),
+		MakeEndTaskMessage("sagaId", "task1", []byte{}),
+		MakeStartTaskMessage("sagaId", "task2", nil),
+		MakeAbortSagaMessage("sagaId"),
+		MakeStartCompTaskMessage("sagaId", "task2", []byte("comp")),
+	} {
+		if err := updateSagaState(state, msg); err != nil {
+			t.Fatalf("Unexpected error applying %v: %v", msg, err)
+		}
+	}
+
+	data, err := SerializeSagaState(state)
+	if err != nil {
+		t.Fatalf("Unexpected error serializing state: %v", err)
+	}
+	deserialized, err := DeserializeSagaState(data)
+	if err != nil {
+		t.Fatalf("Unexpected error deserializing state: %v", err)
+	}
+	if !reflect.DeepEqual(state, deserialized) {
+		t.Errorf("Expected deserialized state %v to equal %v", deserialized, state)
+	}
+
+	for _, corrupted := range [][]byte{nil, data[:len(data)-1], append(data, 0), append([]byte{99}, data[1:]...)} {
+		if _, err := DeserializeSagaState(corrupted); err == nil {
+			t.Errorf("Expected an error deserializing %v", corrupted)
+		}
+	}
+}
+
+func TestTrimToCheckpoint(t *testing.T) {
+	msgs := []SagaMessage{
+		MakeStartSagaMessage("sagaId", nil),
+		MakeStartTaskMessage("sagaId", "task1", nil),
+	}
+	if trimmed := TrimToCheckpoint(msgs); !reflect.DeepEqual(trimmed, msgs) {
+		t.Errorf("Expected all messages without a checkpoint, got %v", trimmed)
+	}
+
+	msgs = append(msgs,
+		MakeCheckpointMessage("sagaId", []byte{1}),
+		MakeEndTaskMessage("sagaId", "task1", nil),
+		MakeCheckpointMessage("sagaId", []byte{2}),
+		MakeEndSagaMessage("sagaId"))
+	if trimmed := TrimToCheckpoint(msgs); !reflect.DeepEqual(trimmed, msgs[4:]) {
+		t.Errorf("Expected messages from the latest checkpoint on, got %v", trimmed)
+	}
+}
+
+func TestSagaCheckpoint(t *testing.T) {
+	slog := &recordingSagaLog{msgs: make(map[string][]SagaMessage)}
+	sc := MakeSagaCoordinator(slog).WithCheckpointInterval(2)
+	s, err := sc.MakeSaga("sagaId", []byte("job"))
+	if err != nil {
+		t.Fatalf("Unexpected error making saga: %v", err)
+	}
+	for _, taskId := range []string{"task1", "task2"} {
+		if err := s.StartTask(taskId, []byte(taskId)); err != nil {
+			t.Fatalf("Unexpected error starting task: %v", err)
+		}
+		if err := s.EndTask(taskId, nil); err != nil {
+			t.Fatalf("Unexpected error ending task: %v", err)
+		}
+	}
+	if err := s.StartTask("task3", nil); err != nil {
+		t.Fatalf("Unexpected error starting task: %v", err)
+	}
+
+	var types []SagaMessageType
+	for _, msg := range slog.msgs["sagaId"] {
+		types = append(types, msg.MsgType)
+	}
+	expected := []SagaMessageType{StartSaga, StartTask, EndTask, Checkpoint, StartTask, EndTask, Checkpoint, StartTask}
+	if !reflect.DeepEqual(types, expected) {
+		t.Fatalf("Expected messages %v, got %v", expected, types)
+	}
+
+	// Corrupt the messages before the latest checkpoint, recovery mustn't replay them.
+	slog.msgs["sagaId"][0].SagaId = "other"
+	slog.msgs["sagaId"][1].TaskId = ""
+	recovered, err := sc.GetSagaState("sagaId")
+	if err != nil {
+		t.Fatalf("Unexpected error recovering saga: %v", err)
+	}
+	if !reflect.DeepEqual(recovered, s.GetState()) {
+		t.Errorf("Expected recovered state %v to equal %v", recovered, s.GetState())
+	}
+
+	// Completed sagas aren't checkpointed.
+	s.EndTask("task3", nil)
+	s.EndSaga()
+	s.mutex.Lock()
+	s.sinceCheckpoint = 2
+	s.maybeCheckpoint()
+	s.mutex.Unlock()
+	if n := len(slog.msgs["sagaId"]); n != 11 || slog.msgs["sagaId"][n-1].MsgType != EndSaga {
+		t.Errorf("Expected no checkpoint after the saga ended, got %v", slog.msgs["sagaId"])
+	}
+}
@@ -6,7 +6,9 @@ package saga
 // which returns a saga based on its implementation.
 //
 type SagaCoordinator struct {
-	log SagaLog
+	log                SagaLog
+	watchers           *sagaWatchers
+	checkpointInterval int
 }
 
 //
@@ -14,14 +16,23 @@ type SagaCoordinator struct {
 //
 func MakeSagaCoordinator(log SagaLog) SagaCoordinator {
 	return SagaCoordinator{
-		log: log,
+		log:                log,
+		watchers:           newSagaWatchers(),
+		checkpointInterval: DefaultCheckpointInterval,
 	}
 }
 
+// Returns a copy of the SagaCoordinator whose Sagas log a Checkpoint of their state
+// every interval messages, zero disables checkpoints.
+func (s SagaCoordinator) WithCheckpointInterval(interval int) SagaCoordinator {
+	s.checkpointInterval = interval
+	return s
+}
+
 // Make a Saga add it to the SagaCoordinator, if a Saga Already exists
 // with the same id, it will overwrite the already existing one.
 func (s SagaCoordinator) MakeSaga(sagaId string, job []byte) (*Saga, error) {
-	return newSaga(sagaId, job, s.log)
+	return newSaga(sagaId, job, s.log, s.watchers, s.checkpointInterval)
 }
 
 // Read the Current SagaState from the Log, intended for status queries does not check for recovery.
@@ -30,6 +41,15 @@ func (s SagaCoordinator) GetSagaState(sagaId string) (*SagaState, error) {
 	return recoverState(sagaId, s)
 }
 
+// Watch for updates to the specified saga made through this SagaCoordinator.
+// Each time a message is successfully logged for the saga, the resulting SagaState is sent on the
+// returned channel. Only the latest update is buffered, so a slow reader may skip intermediate states.
+// The watch doesn't include the current state, callers should call GetSagaState after watching.
+// The returned func stops the watch and must be called once the caller is done.
+func (s SagaCoordinator) WatchSagaState(sagaId string) (<-chan *SagaState, func()) {
+	return s.watchers.watch(sagaId)
+}
+
 //
 // Should be called at Saga Creation time.
 // Returns a Slice of In Progress SagaIds
@@ -45,7 +65,7 @@ func (s SagaCoordinator) Startup() ([]string, error) {
 }
 
 //
-// Recovers SagaState by reading all logged messages from the log.
+// Recovers SagaState by reading the logged messages from the log, from the latest Checkpoint on.
 // Utilizes the specified recoveryType to determine if Saga needs to be
 // Aborted or can proceed safely.
 //
@@ -63,7 +83,7 @@ func (sc SagaCoordinator) RecoverSagaState(sagaId string, recoveryType SagaRecov
 	}
 
 	// now that we've recovered the saga initialize its update path
-	saga := rehydrateSaga(sagaId, state, sc.log)
+	saga := rehydrateSaga(sagaId, state, sc.log, sc.watchers, sc.checkpointInterval)
 
 	// Check if we can safely proceed forward based on recovery method
 	// RollbackRecovery must check if in a SafeState,
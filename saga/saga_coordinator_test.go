@@ -170,3 +170,52 @@ func TestRecoverSagaState_ReturnsError(t *testing.T) {
 		t.Error("expected returned state to be nil when error occurs")
 	}
 }
+
+func TestWatchSagaState(t *testing.T) {
+	sagaId := "saga1"
+	taskId := "task1"
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	sagaLogMock := NewMockSagaLog(mockCtrl)
+	sagaLogMock.EXPECT().StartSaga(sagaId, nil)
+	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage(sagaId, taskId, nil))
+	sagaLogMock.EXPECT().LogMessage(MakeEndTaskMessage(sagaId, taskId, nil)).Return(errors.New("test error"))
+
+	sc := MakeSagaCoordinator(sagaLogMock)
+	updateCh, cancel := sc.WatchSagaState(sagaId)
+	otherCh, otherCancel := sc.WatchSagaState("saga2")
+	defer otherCancel()
+
+	saga, _ := sc.MakeSaga(sagaId, nil)
+	if err := saga.StartTask(taskId, nil); err != nil {
+		t.Fatal("unexpected error returned ", err)
+	}
+
+	select {
+	case state := <-updateCh:
+		if !state.IsTaskStarted(taskId) {
+			t.Error("expected watched state to have the task started")
+		}
+	default:
+		t.Fatal("expected an update after StartTask was logged")
+	}
+
+	// Failed updates aren't sent to watchers.
+	if err := saga.EndTask(taskId, nil); err == nil {
+		t.Fatal("expected EndTask to return an error")
+	}
+	select {
+	case <-updateCh:
+		t.Error("expected no update after EndTask failed to log")
+	case <-otherCh:
+		t.Error("expected no update for an unrelated saga")
+	default:
+	}
+
+	cancel()
+	if len(sc.watchers.watchers) != 1 {
+		t.Errorf("expected only the unrelated watch to remain, got %v", sc.watchers.watchers)
+	}
+}
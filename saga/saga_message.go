@@ -10,6 +10,7 @@ const (
 	EndTask
 	StartCompTask
 	EndCompTask
+	Checkpoint
 )
 
 func (s SagaMessageType) String() string {
@@ -28,6 +29,8 @@ func (s SagaMessageType) String() string {
 		return "Start Comp Task"
 	case EndCompTask:
 		return "End Comp Task"
+	case Checkpoint:
+		return "Checkpoint"
 	default:
 		return "unknown"
 	}
@@ -144,3 +147,18 @@ func MakeEndCompTaskMessage(sagaId string, taskId string, results []byte) SagaMe
 		Data:    results,
 	}
 }
+
+/*
+ * Checkpoint SagaMessageType
+ *  - sagaId - id of the Saga
+ *  - state  - the Saga's state, serialized by SerializeSagaState.
+ *             Recovery starts from the latest checkpoint instead
+ *             of replaying the messages logged before it.
+ */
+func MakeCheckpointMessage(sagaId string, state []byte) SagaMessage {
+	return SagaMessage{
+		SagaId:  sagaId,
+		MsgType: Checkpoint,
+		Data:    state,
+	}
+}
@@ -20,12 +20,13 @@ const (
 )
 
 //
-// Recovers SagaState from SagaLog messages
+// Recovers SagaState from SagaLog messages, starting from the
+// latest Checkpoint if there is one
 //
 func recoverState(sagaId string, saga SagaCoordinator) (*SagaState, error) {
 
 	// Get Logged Messages For this Saga from the Log.
-	msgs, err := saga.log.GetMessages(sagaId)
+	msgs, err := getMessagesSinceCheckpoint(saga.log, sagaId)
 	if err != nil {
 		return nil, err
 	}
@@ -35,12 +36,19 @@ func recoverState(sagaId string, saga SagaCoordinator) (*SagaState, error) {
 	}
 
 	// Reconstruct Saga State from Logged Messages
+	var state *SagaState
 	startMsg := msgs[0]
-	if startMsg.MsgType != StartSaga {
-		return nil, fmt.Errorf("InvalidMessages: first message must be StartSaga")
+	switch startMsg.MsgType {
+	case StartSaga:
+		state, err = makeSagaState(sagaId, startMsg.Data)
+	case Checkpoint:
+		state, err = DeserializeSagaState(startMsg.Data)
+		if err == nil && state.SagaId() != sagaId {
+			err = fmt.Errorf("InvalidMessages: checkpoint is for saga %s", state.SagaId())
+		}
+	default:
+		return nil, fmt.Errorf("InvalidMessages: first message must be StartSaga or Checkpoint")
 	}
-
-	state, err := makeSagaState(sagaId, startMsg.Data)
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +56,7 @@ func recoverState(sagaId string, saga SagaCoordinator) (*SagaState, error) {
 	for _, msg := range msgs {
 		// skip applying StartSaga message we already did this
 		// duplicate messages are just ignored since msgs are idempotent
-		if msg.MsgType == StartSaga {
+		if msg.MsgType == StartSaga || msg.MsgType == Checkpoint {
 			continue
 		}
 
@@ -0,0 +1,135 @@
+package saga
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// A logged message that's an invalid transition from the state of the messages before it.
+type SagaValidationError struct {
+	Index int // of the message in the saga's messages
+	Msg   SagaMessage
+	Err   error
+}
+
+func (e SagaValidationError) Error() string {
+	return fmt.Sprintf("message %d, %v %s, is invalid: %v", e.Index, e.Msg.MsgType, e.Msg.TaskId, e.Err)
+}
+
+/*
+ * Replays all of a saga's logged messages, checking each is a valid transition
+ * by the same rules as logging it through a Saga.  Checkpoints must agree with
+ * the messages before them on the progress of the saga and its tasks, those
+ * without data are skipped.
+ *
+ * Returns the saga's state, nil if there are no messages, or a SagaValidationError
+ * for the first invalid message.
+ */
+func ValidateSagaMessages(sagaId string, msgs []SagaMessage) (*SagaState, error) {
+	if len(msgs) == 0 {
+		return nil, nil
+	}
+	if msgs[0].MsgType != StartSaga {
+		return nil, SagaValidationError{0, msgs[0], errors.New("first message must be StartSaga")}
+	}
+	state, err := makeSagaState(sagaId, msgs[0].Data)
+	if err != nil {
+		return nil, SagaValidationError{0, msgs[0], err}
+	}
+
+	for i := 1; i < len(msgs); i++ {
+		msg := msgs[i]
+		switch msg.MsgType {
+		case StartSaga:
+			// Restarting a saga may append to its messages, recovery ignores the repeated StartSaga.
+			if msg.SagaId != sagaId {
+				err = NewInvalidSagaMessageError(fmt.Sprintf("sagaId %s & SagaMessage sagaId %s do not match", sagaId, msg.SagaId))
+			}
+		case Checkpoint:
+			// Earlier checkpoints may have had their state dropped, see CheckpointedSagaLog.
+			if len(msg.Data) == 0 {
+				continue
+			}
+			var checkpoint *SagaState
+			if checkpoint, err = DeserializeSagaState(msg.Data); err == nil && !sameProgress(checkpoint, state) {
+				err = fmt.Errorf("checkpoint %v doesn't match the messages before it %v", checkpoint, state)
+			}
+		default:
+			err = updateSagaState(state, msg)
+		}
+		if err != nil {
+			return nil, SagaValidationError{i, msg, err}
+		}
+	}
+	return state, nil
+}
+
+// Compares the flags of two states, ignoring task data which some SagaLogs don't distinguish from empty data.
+func sameProgress(a, b *SagaState) bool {
+	return a.sagaId == b.sagaId && a.sagaAborted == b.sagaAborted && a.sagaCompleted == b.sagaCompleted &&
+		reflect.DeepEqual(a.taskState, b.taskState)
+}
+
+/*
+ * Returns the messages that complete a stuck saga, an EndTask for each started task
+ * that hasn't completed followed by EndSaga.  The EndTask messages have no data.
+ *
+ * Returns an error if the saga has ended or been aborted.
+ */
+func MakeForceCompleteMessages(state *SagaState) ([]SagaMessage, error) {
+	if state.IsSagaAborted() {
+		return nil, NewInvalidSagaStateError("Saga %s has been aborted and can only be rolled back", state.sagaId)
+	}
+	msgs := []SagaMessage{}
+	for _, taskId := range sortedTaskIds(state) {
+		if !state.IsTaskCompleted(taskId) {
+			msgs = append(msgs, MakeEndTaskMessage(state.sagaId, taskId, nil))
+		}
+	}
+	return checkForceMessages(state, append(msgs, MakeEndSagaMessage(state.sagaId)))
+}
+
+/*
+ * Returns the messages that abort a stuck saga and roll it back, AbortSaga if it hasn't
+ * been aborted, then a StartCompTask and an EndCompTask for each started task whose
+ * compensating task hasn't completed, followed by EndSaga.
+ *
+ * Returns an error if the saga has ended.
+ */
+func MakeForceAbortMessages(state *SagaState) ([]SagaMessage, error) {
+	msgs := []SagaMessage{}
+	if !state.IsSagaAborted() {
+		msgs = append(msgs, MakeAbortSagaMessage(state.sagaId))
+	}
+	for _, taskId := range sortedTaskIds(state) {
+		if !state.IsCompTaskStarted(taskId) {
+			msgs = append(msgs, MakeStartCompTaskMessage(state.sagaId, taskId, nil))
+		}
+		if !state.IsCompTaskCompleted(taskId) {
+			msgs = append(msgs, MakeEndCompTaskMessage(state.sagaId, taskId, nil))
+		}
+	}
+	return checkForceMessages(state, append(msgs, MakeEndSagaMessage(state.sagaId)))
+}
+
+// Checks the messages can be applied to the state, without modifying it.
+func checkForceMessages(state *SagaState, msgs []SagaMessage) ([]SagaMessage, error) {
+	if state.IsSagaCompleted() {
+		return nil, NewInvalidSagaStateError("Saga %s has already ended", state.sagaId)
+	}
+	s := copySagaState(state)
+	for _, msg := range msgs {
+		if err := updateSagaState(s, msg); err != nil {
+			return nil, err
+		}
+	}
+	return msgs, nil
+}
+
+func sortedTaskIds(state *SagaState) []string {
+	taskIds := state.GetTaskIds()
+	sort.Strings(taskIds)
+	return taskIds
+}
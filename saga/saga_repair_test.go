@@ -0,0 +1,93 @@
+package saga
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateSagaMessages(t *testing.T) {
+	state, _ := makeSagaState("sagaId", []byte("job"))
+	updateSagaState(state, MakeStartTaskMessage("sagaId", "task1", nil))
+	checkpoint, _ := SerializeSagaState(state)
+
+	msgs := []SagaMessage{
+		MakeStartSagaMessage("sagaId", []byte("job")),
+		MakeStartTaskMessage("sagaId", "task1", nil),
+		MakeCheckpointMessage("sagaId", checkpoint),
+		MakeStartSagaMessage("sagaId", []byte("job")),
+		MakeEndTaskMessage("sagaId", "task1", []byte("result")),
+		MakeEndSagaMessage("sagaId"),
+	}
+	if state, err := ValidateSagaMessages("sagaId", msgs); err != nil || !state.IsSagaCompleted() {
+		t.Errorf("Expected a valid completed saga, got %v, %v", state, err)
+	}
+	if state, err := ValidateSagaMessages("sagaId", nil); err != nil || state != nil {
+		t.Errorf("Expected no state without messages, got %v, %v", state, err)
+	}
+
+	for i, invalid := range [][]SagaMessage{
+		{MakeStartTaskMessage("sagaId", "task1", nil)},
+		{msgs[0], MakeEndTaskMessage("sagaId", "task1", nil)},
+		{msgs[0], MakeCheckpointMessage("sagaId", checkpoint)},
+		{msgs[0], msgs[1], MakeCheckpointMessage("sagaId", []byte("garbage"))},
+		{msgs[0], msgs[1], MakeStartSagaMessage("other", nil)},
+		{msgs[0], msgs[1], msgs[4], msgs[5], MakeAbortSagaMessage("sagaId")},
+	} {
+		_, err := ValidateSagaMessages("sagaId", invalid)
+		if verr, ok := err.(SagaValidationError); !ok || verr.Index != len(invalid)-1 {
+			t.Errorf("Expected the last message of case %d to be invalid, got %v", i, err)
+		}
+	}
+}
+
+func TestMakeForceCompleteMessages(t *testing.T) {
+	state, _ := makeSagaState("sagaId", nil)
+	updateSagaState(state, MakeStartTaskMessage("sagaId", "task2", nil))
+	updateSagaState(state, MakeStartTaskMessage("sagaId", "task1", nil))
+	updateSagaState(state, MakeStartTaskMessage("sagaId", "task3", nil))
+	updateSagaState(state, MakeEndTaskMessage("sagaId", "task3", nil))
+
+	msgs, err := MakeForceCompleteMessages(state)
+	expected := []SagaMessage{
+		MakeEndTaskMessage("sagaId", "task1", nil),
+		MakeEndTaskMessage("sagaId", "task2", nil),
+		MakeEndSagaMessage("sagaId"),
+	}
+	if err != nil || !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected %v, got %v, %v", expected, msgs, err)
+	}
+	if state.IsTaskCompleted("task1") {
+		t.Errorf("Expected the state to be left unchanged")
+	}
+
+	updateSagaState(state, MakeAbortSagaMessage("sagaId"))
+	if _, err := MakeForceCompleteMessages(state); err == nil {
+		t.Errorf("Expected an error completing an aborted saga")
+	}
+}
+
+func TestMakeForceAbortMessages(t *testing.T) {
+	state, _ := makeSagaState("sagaId", nil)
+	updateSagaState(state, MakeStartTaskMessage("sagaId", "task1", nil))
+	updateSagaState(state, MakeStartTaskMessage("sagaId", "task2", nil))
+	updateSagaState(state, MakeAbortSagaMessage("sagaId"))
+	updateSagaState(state, MakeStartCompTaskMessage("sagaId", "task1", nil))
+
+	msgs, err := MakeForceAbortMessages(state)
+	expected := []SagaMessage{
+		MakeEndCompTaskMessage("sagaId", "task1", nil),
+		MakeStartCompTaskMessage("sagaId", "task2", nil),
+		MakeEndCompTaskMessage("sagaId", "task2", nil),
+		MakeEndSagaMessage("sagaId"),
+	}
+	if err != nil || !reflect.DeepEqual(msgs, expected) {
+		t.Errorf("Expected %v, got %v, %v", expected, msgs, err)
+	}
+
+	for _, msg := range msgs {
+		updateSagaState(state, msg)
+	}
+	if _, err := MakeForceAbortMessages(state); err == nil {
+		t.Errorf("Expected an error aborting an ended saga")
+	}
+}
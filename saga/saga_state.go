@@ -51,6 +51,9 @@ type taskData struct {
 	taskEnd       []byte
 	compTaskStart []byte
 	compTaskEnd   []byte
+
+	// all non-nil data supplied with StartTask messages, oldest first
+	taskStartHistory [][]byte
 }
 
 /*
@@ -137,6 +140,20 @@ func (state *SagaState) GetStartTaskData(taskId string) []byte {
 	}
 }
 
+/*
+ * Get all Data Associated with Start Task, supplied as part
+ * of each StartTask Message logged for the task, oldest first.
+ * StartTask may be logged more than once, ex: when a task is retried.
+ */
+func (state *SagaState) GetStartTaskDataHistory(taskId string) [][]byte {
+	data, ok := state.taskData[taskId]
+	if ok {
+		return data.taskStartHistory
+	} else {
+		return nil
+	}
+}
+
 /*
  * Returns true if the specified Task has been completed,
  * fasle otherwise
@@ -233,6 +250,9 @@ func (state *SagaState) addTaskData(taskId string, msgType SagaMessageType, data
 	switch msgType {
 	case StartTask:
 		state.taskData[taskId].taskStart = data
+		if data != nil {
+			state.taskData[taskId].taskStartHistory = append(state.taskData[taskId].taskStartHistory, data)
+		}
 
 	case EndTask:
 		state.taskData[taskId].taskEnd = data
@@ -428,6 +448,8 @@ func copySagaState(s *SagaState) *SagaState {
 			taskEnd:       value.taskEnd,
 			compTaskStart: value.compTaskStart,
 			compTaskEnd:   value.compTaskEnd,
+
+			taskStartHistory: append([][]byte(nil), value.taskStartHistory...),
 		}
 	}
 
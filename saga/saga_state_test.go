@@ -55,3 +55,24 @@ func TestSagaState_Copy(t *testing.T) {
 		t.Error(fmt.Sprintf("Copy Should Preserve SagaId"))
 	}
 }
+
+func TestSagaState_StartTaskDataHistory(t *testing.T) {
+	state, _ := makeSagaState("sagaId", nil)
+	for _, data := range [][]byte{nil, {1}, nil, {2}} {
+		if err := updateSagaState(state, MakeStartTaskMessage("sagaId", "task1", data)); err != nil {
+			t.Fatalf("Unexpected error starting task: %v", err)
+		}
+	}
+
+	// The latest data is kept as the start data, and all non-nil data in the history.
+	if !bytes.Equal(state.GetStartTaskData("task1"), []byte{2}) {
+		t.Errorf("Expected the latest StartTask data, got: %v", state.GetStartTaskData("task1"))
+	}
+	history := copySagaState(state).GetStartTaskDataHistory("task1")
+	if len(history) != 2 || !bytes.Equal(history[0], []byte{1}) || !bytes.Equal(history[1], []byte{2}) {
+		t.Errorf("Expected StartTask data history [[1] [2]], got: %v", history)
+	}
+	if state.GetStartTaskDataHistory("task2") != nil {
+		t.Errorf("Expected no history for a task that wasn't started")
+	}
+}
@@ -17,7 +17,7 @@ func TestEndSaga(t *testing.T) {
 	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.EndSaga()
 	if err != nil {
 		t.Error("Expected EndSaga to not return an error", err)
@@ -38,7 +38,7 @@ func TestEndSagaLogError(t *testing.T) {
 	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log EndSaga Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.EndSaga()
 
 	if err == nil {
@@ -60,7 +60,7 @@ func TestAbortSaga(t *testing.T) {
 	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.AbortSaga()
 
 	if err != nil {
@@ -82,7 +82,7 @@ func TestAbortSagaLogError(t *testing.T) {
 	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log AbortSaga Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.AbortSaga()
 
 	if err == nil {
@@ -104,7 +104,7 @@ func TestStartTask(t *testing.T) {
 	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 
 	if err != nil {
@@ -126,7 +126,7 @@ func TestStartTaskLogError(t *testing.T) {
 	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log StartTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 
 	if err == nil {
@@ -149,7 +149,7 @@ func TestEndTask(t *testing.T) {
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 	err = s.EndTask("task1", nil)
 
@@ -173,7 +173,7 @@ func TestEndTaskLogError(t *testing.T) {
 	sagaLogMock.EXPECT().LogMessage(MakeStartTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log EndTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 	err = s.EndTask("task1", nil)
 
@@ -198,7 +198,7 @@ func TestStartCompTask(t *testing.T) {
 	sagaLogMock.EXPECT().LogMessage(MakeAbortSagaMessage("testSaga"))
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -224,7 +224,7 @@ func TestStartCompTaskLogError(t *testing.T) {
 	sagaLogMock.EXPECT().LogMessage(MakeAbortSagaMessage("testSaga"))
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log StartCompTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -251,7 +251,7 @@ func TestEndCompTask(t *testing.T) {
 	sagaLogMock.EXPECT().LogMessage(MakeStartCompTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -279,7 +279,7 @@ func TestEndCompTaskLogError(t *testing.T) {
 	sagaLogMock.EXPECT().LogMessage(MakeStartCompTaskMessage("testSaga", "task1", nil))
 	sagaLogMock.EXPECT().LogMessage(entry).Return(errors.New("Failed to Log EndCompTask Message"))
 
-	s, err := newSaga("testSaga", nil, sagaLogMock)
+	s, err := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	err = s.StartTask("task1", nil)
 	err = s.AbortSaga()
 	err = s.StartCompensatingTask("task1", nil)
@@ -304,7 +304,7 @@ func TestMessageAfterEndSagaPanics(t *testing.T) {
 	sagaLogMock.EXPECT().StartSaga("testSaga", nil)
 	sagaLogMock.EXPECT().LogMessage(entry)
 
-	s, _ := newSaga("testSaga", nil, sagaLogMock)
+	s, _ := newSaga("testSaga", nil, sagaLogMock, nil, 0)
 	_ = s.EndSaga()
 
 	defer func() {
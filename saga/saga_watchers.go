@@ -0,0 +1,61 @@
+package saga
+
+import "sync"
+
+// sagaWatchers fans out SagaState updates to any interested listeners.
+// Listeners are notified in process as updates are applied to a Saga,
+// so watching a saga doesn't add any read load on the SagaLog.
+type sagaWatchers struct {
+	watchers map[string]map[chan *SagaState]struct{}
+	mutex    sync.Mutex
+}
+
+func newSagaWatchers() *sagaWatchers {
+	return &sagaWatchers{
+		watchers: make(map[string]map[chan *SagaState]struct{}),
+	}
+}
+
+// Registers a listener for the specified saga. The returned channel holds at most
+// one pending update, slow listeners only ever see the latest SagaState.
+// The returned func must be called to unregister the listener.
+func (w *sagaWatchers) watch(sagaId string) (<-chan *SagaState, func()) {
+	ch := make(chan *SagaState, 1)
+	if w == nil {
+		return ch, func() {}
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.watchers[sagaId] == nil {
+		w.watchers[sagaId] = make(map[chan *SagaState]struct{})
+	}
+	w.watchers[sagaId][ch] = struct{}{}
+
+	return ch, func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		delete(w.watchers[sagaId], ch)
+		if len(w.watchers[sagaId]) == 0 {
+			delete(w.watchers, sagaId)
+		}
+	}
+}
+
+// Sends a copy of state to every listener of the specified saga without blocking.
+// If a listener hasn't consumed its previous update, that update is replaced.
+func (w *sagaWatchers) notify(sagaId string, state *SagaState) {
+	if w == nil {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	for ch := range w.watchers[sagaId] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- copySagaState(state)
+	}
+}
@@ -0,0 +1,352 @@
+package sagalogs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// Name of the journal file in the directory of a durable saga log or a SagaLogReplica.
+const journalFileName = "journal"
+
+// The journal is compacted once the messages of ended sagas take up at least this much of it, and at least
+// half of it. Failed compactions are retried after DurableSagaLogCompactionRetry.
+const DefaultDurableSagaLogCompactionBytes = 64 * 1024 * 1024
+const DurableSagaLogCompactionRetry = time.Minute
+
+// Saga Log that survives the loss of its host by replicating every message before acknowledging it.
+// Messages are appended to a journal file that's synced to disk, then written to the replicas.
+// A message is committed once a majority of the log and its replicas have it, ex: the log and one of two replicas.
+// If that fails, the message is dropped from the journal and an InternalLogError is returned so the caller can retry.
+// Replicas that fell behind, ex: after a restart, are caught up by the next message logged.
+//
+// Messages are kept in memory for reads, the journal is only read when the log is opened.
+// Ended sagas are kept until the journal is compacted, which rewrites it with only the active sagas and
+// copies it to the replicas, then evicts the ended sagas from memory.
+//
+// To fail over, open a durable saga log on the directory of the most up to date replica.
+// Each time a durable saga log is opened it increments the epoch in its journal's ID and writes it to a majority
+// of its replicas before returning. Replicas reject writes from older epochs, so once the new log is open,
+// the log that was failed over can't commit anything and stops logging.
+type durableSagaLog struct {
+	dirName  string
+	file     *os.File
+	id       JournalID
+	size     int64 // Length of the committed part of the journal.
+	replicas []SagaLogReplica
+	fenced   error // Set once a replica has a newer epoch, after which nothing is logged.
+
+	sagas     map[string][]saga.SagaMessage
+	sagaBytes map[string]int64 // Length of the records of each saga in the journal.
+	active    map[string]bool  // Sagas that haven't ended.
+	deadBytes int64            // Length of the records of ended or restarted sagas in the journal.
+
+	compactBytes       int64
+	compactionFailedAt time.Time
+	mu                 sync.RWMutex
+}
+
+// Creates a durable saga log with its journal stored in the specified directory, creating it if needed, and
+// recovers the messages already in the journal. Commits require a majority of the log and its replicas.
+func MakeDurableSagaLog(dirName string, replicas ...SagaLogReplica) (*durableSagaLog, error) {
+	if err := os.MkdirAll(dirName, os.ModePerm); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path.Join(dirName, journalFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	l := &durableSagaLog{
+		dirName:      dirName,
+		file:         file,
+		replicas:     replicas,
+		sagas:        make(map[string][]saga.SagaMessage),
+		sagaBytes:    make(map[string]int64),
+		active:       make(map[string]bool),
+		compactBytes: DefaultDurableSagaLogCompactionBytes,
+	}
+	if err := l.recover(); err != nil {
+		l.file.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// Rebuilds the in memory messages from the journal, discarding any torn record at its end,
+// and starts a new epoch on the log and a majority of its replicas.
+func (l *durableSagaLog) recover() error {
+	id, fileSize, err := readJournalID(l.file)
+	if err != nil {
+		return err
+	}
+	size := int64(journalFileHeaderLen)
+	if fileSize >= journalFileHeaderLen {
+		if size, err = replayJournal(io.NewSectionReader(l.file, journalFileHeaderLen, fileSize-journalFileHeaderLen), fileSize, l.apply); err != nil {
+			return err
+		}
+	}
+	if size < fileSize {
+		log.Warnf("Discarding %d bytes of incomplete messages at the end of saga log journal %s",
+			fileSize-size, l.file.Name())
+		if err := l.file.Truncate(size); err != nil {
+			return err
+		}
+	}
+
+	l.id = JournalID{Epoch: id.Epoch + 1, Generation: id.Generation}
+	if _, err := l.file.WriteAt(encodeJournalFileHeader(l.id), 0); err != nil {
+		return err
+	}
+	if err := l.file.Sync(); err != nil {
+		return err
+	}
+	l.size = size
+
+	// Replicas reject the previous epoch once they have the new one, so a majority must have it before
+	// anything is logged, or a failed over log could still commit to a majority of stale replicas.
+	// Replicas are sent the whole journal under the new epoch by the usual catch up.
+	if acks := l.replicate(l.id, l.size, nil); l.fenced != nil {
+		return l.fenced
+	} else if !l.isMajority(acks) {
+		return fmt.Errorf("saga log journal %s with epoch %d replicated to %d of %d replicas, not enough to open it",
+			l.file.Name(), l.id.Epoch, acks, len(l.replicas))
+	}
+	log.Infof("Opened saga log journal %s with epoch %d", l.file.Name(), l.id.Epoch)
+	return nil
+}
+
+// Applies a committed message with a record of the specified length to the in memory messages.
+func (l *durableSagaLog) apply(msg saga.SagaMessage, recordLen int64) {
+	switch msg.MsgType {
+	case saga.StartSaga:
+		// Starting a saga again replaces it, as with the in memory saga log.
+		if l.active[msg.SagaId] {
+			l.deadBytes += l.sagaBytes[msg.SagaId]
+		}
+		l.sagas[msg.SagaId] = []saga.SagaMessage{msg}
+		l.sagaBytes[msg.SagaId] = recordLen
+		l.active[msg.SagaId] = true
+	case saga.EndSaga:
+		l.sagas[msg.SagaId] = append(l.sagas[msg.SagaId], msg)
+		l.sagaBytes[msg.SagaId] += recordLen
+		if l.active[msg.SagaId] {
+			l.deadBytes += l.sagaBytes[msg.SagaId]
+		} else {
+			l.deadBytes += recordLen
+		}
+		delete(l.active, msg.SagaId)
+	default:
+		l.sagas[msg.SagaId] = append(l.sagas[msg.SagaId], msg)
+		l.sagaBytes[msg.SagaId] += recordLen
+		if !l.active[msg.SagaId] {
+			l.deadBytes += recordLen
+		}
+	}
+}
+
+// Log a Start Saga Message message to the log.
+// Returns an error if it fails.
+func (l *durableSagaLog) StartSaga(sagaId string, job []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.commit(saga.MakeStartSagaMessage(sagaId, job))
+}
+
+// Update the State of the Saga by Logging a message.
+// Returns an error if it fails.
+func (l *durableSagaLog) LogMessage(msg saga.SagaMessage) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.sagas[msg.SagaId]; !ok {
+		return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s is not Started yet.", msg.SagaId))
+	}
+	return l.commit(msg)
+}
+
+// Appends the message to the journal and replicates it, applying it once it's committed.
+// Must be called with the lock held, messages are committed one at a time so the journals stay identical.
+func (l *durableSagaLog) commit(msg saga.SagaMessage) error {
+	if l.fenced != nil {
+		return saga.NewInternalLogError(fmt.Sprintf("Saga log was failed over, not logging: %v", l.fenced))
+	}
+	record, err := encodeJournalRecord(msg)
+	if err != nil {
+		return err
+	}
+
+	offset := l.size
+	if _, err := l.file.WriteAt(record, offset); err != nil {
+		l.rollback(offset)
+		return saga.NewInternalLogError(fmt.Sprintf("Error writing to saga log journal: %v", err))
+	}
+	if err := l.file.Sync(); err != nil {
+		l.rollback(offset)
+		return saga.NewInternalLogError(fmt.Sprintf("Error syncing saga log journal: %v", err))
+	}
+
+	if acks := l.replicate(l.id, offset, record); l.fenced != nil || !l.isMajority(acks) {
+		l.rollback(offset)
+		if l.fenced != nil {
+			return saga.NewInternalLogError(fmt.Sprintf("Saga log was failed over, not logging: %v", l.fenced))
+		}
+		return saga.NewInternalLogError(fmt.Sprintf(
+			"Saga %s message replicated to %d of %d replicas, not enough to commit it", msg.SagaId, acks, len(l.replicas)))
+	}
+
+	l.size = offset + int64(len(record))
+	l.apply(msg, int64(len(record)))
+	l.maybeCompact()
+	return nil
+}
+
+// Returns true if the log and acks of its replicas are a majority.
+func (l *durableSagaLog) isMajority(acks int) bool {
+	return 2*(acks+1) > len(l.replicas)+1
+}
+
+// Writes the data at offset of the journal with the specified id to all replicas in parallel and returns
+// how many have it. Sets fenced if a replica has a newer epoch.
+func (l *durableSagaLog) replicate(id JournalID, offset int64, data []byte) int {
+	errs := make(chan error, len(l.replicas))
+	for _, r := range l.replicas {
+		go func(r SagaLogReplica) {
+			errs <- l.replicateTo(r, id, offset, data)
+		}(r)
+	}
+	n := 0
+	for range l.replicas {
+		err := <-errs
+		if err == nil {
+			n++
+		} else if fenced, ok := err.(SagaLogFencedError); ok {
+			log.Errorf("Saga log journal %s with epoch %d was failed over: %v", l.file.Name(), id.Epoch, err)
+			l.fenced = fenced
+		}
+	}
+	return n
+}
+
+// Writes the data to the replica, first catching it up with the rest of the journal if it's behind.
+func (l *durableSagaLog) replicateTo(r SagaLogReplica, id JournalID, offset int64, data []byte) error {
+	size, err := r.WriteAt(id, offset, data)
+	if err == nil && size < offset {
+		missing := make([]byte, offset-size)
+		if _, err = l.file.ReadAt(missing, size); err == nil {
+			log.Infof("Catching up saga log replica %v from offset %d to %d", r, size, offset)
+			size, err = r.WriteAt(id, size, append(missing, data...))
+		}
+	}
+	if err == nil && size != offset+int64(len(data)) {
+		err = fmt.Errorf("replica has %d bytes, expected %d", size, offset+int64(len(data)))
+	}
+	if err != nil {
+		log.Errorf("Error replicating saga log journal at offset %d to %v: %v", offset, r, err)
+	}
+	return err
+}
+
+// Compacts the journal once ended sagas take up enough of it, see DefaultDurableSagaLogCompactionBytes.
+// Must be called with the lock held.
+func (l *durableSagaLog) maybeCompact() {
+	if l.deadBytes < l.compactBytes || 2*l.deadBytes < l.size || time.Since(l.compactionFailedAt) < DurableSagaLogCompactionRetry {
+		return
+	}
+	if err := l.compact(); err != nil {
+		log.Errorf("Error compacting saga log journal %s, retrying in %v: %v", l.file.Name(), DurableSagaLogCompactionRetry, err)
+		l.compactionFailedAt = time.Now()
+	}
+}
+
+// Rewrites the journal with only the messages of active sagas, under the next generation, and evicts ended sagas.
+// The new journal is written to a majority of replicas before it replaces the log's, replicas that missed it
+// are sent all of it with the next message.
+func (l *durableSagaLog) compact() error {
+	id := JournalID{Epoch: l.id.Epoch, Generation: l.id.Generation + 1}
+	data := encodeJournalFileHeader(id)
+	ids := make([]string, 0, len(l.active))
+	for sagaId := range l.active {
+		ids = append(ids, sagaId)
+	}
+	sort.Strings(ids)
+	for _, sagaId := range ids {
+		for _, msg := range l.sagas[sagaId] {
+			record, err := encodeJournalRecord(msg)
+			if err != nil {
+				return err
+			}
+			data = append(data, record...)
+		}
+	}
+
+	if acks := l.replicate(id, 0, data); l.fenced != nil {
+		return l.fenced
+	} else if !l.isMajority(acks) {
+		return fmt.Errorf("compacted journal replicated to %d of %d replicas", acks, len(l.replicas))
+	}
+	file, err := replaceJournal(l.dirName, data)
+	if err != nil {
+		return err
+	}
+	log.Infof("Compacted saga log journal %s from %d to %d bytes", l.file.Name(), l.size, len(data))
+	l.file.Close()
+	l.file, l.id, l.size, l.deadBytes = file, id, int64(len(data)), 0
+	for sagaId := range l.sagas {
+		if !l.active[sagaId] {
+			delete(l.sagas, sagaId)
+			delete(l.sagaBytes, sagaId)
+		}
+	}
+	return nil
+}
+
+// Drops a record that failed to commit from the end of the journal.
+func (l *durableSagaLog) rollback(offset int64) {
+	if err := l.file.Truncate(offset); err != nil {
+		log.Errorf("Error truncating saga log journal %s to %d, the uncommitted message will be recovered on restart: %v",
+			l.file.Name(), offset, err)
+	}
+}
+
+// Returns all of the messages logged so far for the
+// specified saga, or none if it ended and was evicted by compaction.
+func (l *durableSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	msgs, ok := l.sagas[sagaId]
+	if !ok {
+		return nil, nil
+	}
+	return append([]saga.SagaMessage(nil), msgs...), nil
+}
+
+// Returns the saga's latest Checkpoint message and the messages after it,
+// or all of its messages if it has no checkpoint.
+func (l *durableSagaLog) GetMessagesSinceCheckpoint(sagaId string) ([]saga.SagaMessage, error) {
+	msgs, err := l.GetMessages(sagaId)
+	return saga.TrimToCheckpoint(msgs), err
+}
+
+// Returns the sagas that haven't ended.
+func (l *durableSagaLog) GetActiveSagas() ([]string, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	ids := make([]string, 0, len(l.active))
+	for id := range l.active {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (l *durableSagaLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
@@ -0,0 +1,387 @@
+package sagalogs
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// Replica that can be taken down to simulate a lost host.
+type downableReplica struct {
+	SagaLogReplica
+	down bool
+}
+
+func (r *downableReplica) WriteAt(id JournalID, offset int64, data []byte) (int64, error) {
+	if r.down {
+		return 0, errors.New("replica is down")
+	}
+	return r.SagaLogReplica.WriteAt(id, offset, data)
+}
+
+func makeTestDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "durable_saga_log_test")
+	if err != nil {
+		t.Fatalf("Error creating temp dir: %v", err)
+	}
+	return dir
+}
+
+func logTestSagas(t *testing.T, slog saga.SagaLog) map[string][]saga.SagaMessage {
+	expected := map[string][]saga.SagaMessage{
+		"ended": {
+			saga.MakeStartSagaMessage("ended", []byte("job")),
+			saga.MakeStartTaskMessage("ended", "task1", nil),
+			saga.MakeEndTaskMessage("ended", "task1", []byte{0, 1, 2}),
+			saga.MakeEndSagaMessage("ended"),
+		},
+		"active": {
+			saga.MakeStartSagaMessage("active", nil),
+			saga.MakeStartTaskMessage("active", "task1", []byte("attempt")),
+		},
+	}
+	for _, id := range []string{"ended", "active"} {
+		for i, msg := range expected[id] {
+			var err error
+			if i == 0 {
+				err = slog.StartSaga(id, msg.Data)
+			} else {
+				err = slog.LogMessage(msg)
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error logging %+v: %v", msg, err)
+			}
+		}
+	}
+	return expected
+}
+
+func checkTestSagas(t *testing.T, slog saga.SagaLog, expected map[string][]saga.SagaMessage) {
+	checkTestSagasActive(t, slog, expected, []string{"active"})
+}
+
+func checkTestSagasActive(t *testing.T, slog saga.SagaLog, expected map[string][]saga.SagaMessage, active []string) {
+	for id, msgs := range expected {
+		if actual, err := slog.GetMessages(id); err != nil || !reflect.DeepEqual(actual, msgs) {
+			t.Errorf("Expected messages %+v for %s, got %+v, %v", msgs, id, actual, err)
+		}
+	}
+	actual, err := slog.GetActiveSagas()
+	sort.Strings(actual)
+	if err != nil || !reflect.DeepEqual(actual, active) {
+		t.Errorf("Expected active sagas %v, got %v, %v", active, actual, err)
+	}
+}
+
+func TestDurableSagaLog_Recover(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	slog, err := MakeDurableSagaLog(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error making durable saga log: %v", err)
+	}
+	expected := logTestSagas(t, slog)
+	checkTestSagas(t, slog, expected)
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("unknown")); err == nil {
+		t.Errorf("Expected an error logging to a saga that wasn't started")
+	}
+	slog.Close()
+
+	// Simulate a crash in the middle of writing a message.
+	record, _ := encodeJournalRecord(saga.MakeEndSagaMessage("active"))
+	f, _ := os.OpenFile(path.Join(dir, journalFileName), os.O_APPEND|os.O_WRONLY, 0)
+	f.Write(record[:len(record)-1])
+	f.Close()
+
+	slog, err = MakeDurableSagaLog(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error recovering durable saga log: %v", err)
+	}
+	defer slog.Close()
+	checkTestSagas(t, slog, expected)
+
+	// The torn message is discarded so the journal can be appended to again.
+	if err := slog.LogMessage(saga.MakeEndTaskMessage("active", "task1", nil)); err != nil {
+		t.Fatalf("Unexpected error logging after recovery: %v", err)
+	}
+	expected["active"] = append(expected["active"], saga.MakeEndTaskMessage("active", "task1", nil))
+	slog.Close()
+	if slog, err = MakeDurableSagaLog(dir); err != nil {
+		t.Fatalf("Unexpected error recovering durable saga log: %v", err)
+	}
+	checkTestSagas(t, slog, expected)
+}
+
+func TestDurableSagaLog_Corrupted(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	slog, _ := MakeDurableSagaLog(dir)
+	logTestSagas(t, slog)
+	slog.Close()
+
+	journal := path.Join(dir, journalFileName)
+	data, _ := ioutil.ReadFile(journal)
+	data[journalFileHeaderLen+journalHeaderLen+1] ^= 0xff
+	ioutil.WriteFile(journal, data, os.ModePerm)
+
+	if _, err := MakeDurableSagaLog(dir); err == nil {
+		t.Fatalf("Expected an error opening a journal corrupted before its end")
+	} else if _, ok := err.(saga.CorruptedSagaLogError); !ok {
+		t.Errorf("Expected a CorruptedSagaLogError, got %v", err)
+	}
+}
+
+func TestDurableSagaLog_Replicas(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	var replicas []*downableReplica
+	var dirs []string
+	for _, name := range []string{"replica1", "replica2"} {
+		dirs = append(dirs, path.Join(dir, name))
+		r, err := MakeDirSagaLogReplica(dirs[len(dirs)-1])
+		if err != nil {
+			t.Fatalf("Unexpected error making replica: %v", err)
+		}
+		defer r.Close()
+		replicas = append(replicas, &downableReplica{SagaLogReplica: r})
+	}
+	slog, err := MakeDurableSagaLog(path.Join(dir, "primary"), replicas[0], replicas[1])
+	if err != nil {
+		t.Fatalf("Unexpected error making durable saga log: %v", err)
+	}
+	defer slog.Close()
+
+	// A majority is enough to commit, the replica that was down catches up on the next message.
+	replicas[1].down = true
+	expected := logTestSagas(t, slog)
+
+	replicas[0].down = true
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("active")); err == nil {
+		t.Fatalf("Expected an error logging without a majority")
+	} else if _, ok := err.(saga.InternalLogError); !ok {
+		t.Errorf("Expected an InternalLogError, got %v", err)
+	}
+	checkTestSagas(t, slog, expected)
+
+	replicas[1].down = false
+	msg := saga.MakeEndTaskMessage("active", "task1", nil)
+	if err := slog.LogMessage(msg); err != nil {
+		t.Fatalf("Unexpected error logging with a majority: %v", err)
+	}
+	expected["active"] = append(expected["active"], msg)
+
+	// Fail over to the replica that was down at first.
+	failover, err := MakeDurableSagaLog(dirs[1])
+	if err != nil {
+		t.Fatalf("Unexpected error failing over to replica: %v", err)
+	}
+	defer failover.Close()
+	checkTestSagas(t, failover, expected)
+}
+
+func TestDurableSagaLog_HTTPReplica(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	r, _ := MakeDirSagaLogReplica(path.Join(dir, "replica"))
+	defer r.Close()
+	server := httptest.NewServer(NewSagaLogReplicaHandler(r, []string{"127.0.0.1"}))
+	defer server.Close()
+
+	slog, err := MakeDurableSagaLog(path.Join(dir, "primary"), MakeHTTPSagaLogReplica(server.URL, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error making durable saga log: %v", err)
+	}
+	defer slog.Close()
+	expected := logTestSagas(t, slog)
+
+	primary, _ := ioutil.ReadFile(path.Join(dir, "primary", journalFileName))
+	replica, _ := ioutil.ReadFile(path.Join(dir, "replica", journalFileName))
+	if len(primary) == 0 || !reflect.DeepEqual(primary, replica) {
+		t.Errorf("Expected the replica's journal to match the primary's, got %d and %d bytes", len(primary), len(replica))
+	}
+
+	server.Close()
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("active")); err == nil {
+		t.Errorf("Expected an error logging without the only replica")
+	}
+	checkTestSagas(t, slog, expected)
+
+	// Writes from hosts that aren't peers are rejected.
+	server = httptest.NewServer(NewSagaLogReplicaHandler(r, []string{"192.0.2.1"}))
+	defer server.Close()
+	if _, err := MakeHTTPSagaLogReplica(server.URL, 0).WriteAt(slog.id, 0, primary); err == nil {
+		t.Errorf("Expected an error writing to a replica from a host that isn't a peer")
+	}
+}
+
+// A failed over durable saga log stops logging once a replica has the new epoch.
+func TestDurableSagaLog_Fencing(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	var replicas []*downableReplica
+	var dirs []string
+	for _, name := range []string{"replica1", "replica2"} {
+		dirs = append(dirs, path.Join(dir, name))
+		r, err := MakeDirSagaLogReplica(dirs[len(dirs)-1])
+		if err != nil {
+			t.Fatalf("Unexpected error making replica: %v", err)
+		}
+		defer r.Close()
+		replicas = append(replicas, &downableReplica{SagaLogReplica: r})
+	}
+	slog, err := MakeDurableSagaLog(path.Join(dir, "primary"), replicas[0], replicas[1])
+	if err != nil {
+		t.Fatalf("Unexpected error making durable saga log: %v", err)
+	}
+	defer slog.Close()
+	expected := logTestSagas(t, slog)
+
+	// Fail over to the first replica's host while the primary is unreachable.
+	replicas[0].down = true
+	failover, err := MakeDurableSagaLog(dirs[0], replicas[1])
+	if err != nil {
+		t.Fatalf("Unexpected error failing over to replica: %v", err)
+	}
+	defer failover.Close()
+	if failover.id.Epoch != slog.id.Epoch+1 {
+		t.Fatalf("Expected epoch %d after failing over, got %d", slog.id.Epoch+1, failover.id.Epoch)
+	}
+	msg := saga.MakeEndTaskMessage("active", "task1", nil)
+	if err := failover.LogMessage(msg); err != nil {
+		t.Fatalf("Unexpected error logging after failing over: %v", err)
+	}
+	expected["active"] = append(expected["active"], msg)
+	checkTestSagas(t, failover, expected)
+
+	// The old primary is rejected by the replica, and stays fenced off once the other replica is back.
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("active")); err == nil {
+		t.Fatalf("Expected an error logging to a failed over saga log")
+	}
+	replicas[0].down = false
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("active")); err == nil {
+		t.Fatalf("Expected an error logging to a failed over saga log")
+	}
+	if _, ok := slog.fenced.(SagaLogFencedError); !ok {
+		t.Errorf("Expected the failed over saga log to be fenced, got %v", slog.fenced)
+	}
+	checkTestSagas(t, failover, expected)
+}
+
+// The failed over durable saga log can't commit as soon as the new one is open, before it logs anything.
+func TestDurableSagaLog_FencedOnOpen(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	var replicas []*downableReplica
+	var dirs []string
+	for _, name := range []string{"replica1", "replica2"} {
+		dirs = append(dirs, path.Join(dir, name))
+		r, err := MakeDirSagaLogReplica(dirs[len(dirs)-1])
+		if err != nil {
+			t.Fatalf("Unexpected error making replica: %v", err)
+		}
+		defer r.Close()
+		replicas = append(replicas, &downableReplica{SagaLogReplica: r})
+	}
+	slog, err := MakeDurableSagaLog(path.Join(dir, "primary"), replicas[0], replicas[1])
+	if err != nil {
+		t.Fatalf("Unexpected error making durable saga log: %v", err)
+	}
+	defer slog.Close()
+	expected := logTestSagas(t, slog)
+
+	// Fail over to the first replica's host, whose replica stops serving, while the primary is unreachable.
+	replicas[0].down = true
+	replicas[1].down = true
+	if _, err := MakeDurableSagaLog(dirs[0], replicas[1]); err == nil {
+		t.Fatalf("Expected an error failing over without a majority for the new epoch")
+	}
+	replicas[1].down = false
+	failover, err := MakeDurableSagaLog(dirs[0], replicas[1])
+	if err != nil {
+		t.Fatalf("Unexpected error failing over to replica: %v", err)
+	}
+	defer failover.Close()
+
+	// The old primary would have a majority with the second replica if it didn't have the new epoch.
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("active")); err == nil {
+		t.Fatalf("Expected an error logging to a failed over saga log")
+	}
+	if _, ok := slog.fenced.(SagaLogFencedError); !ok {
+		t.Errorf("Expected the failed over saga log to be fenced, got %v", slog.fenced)
+	}
+	checkTestSagas(t, failover, expected)
+	checkTestSagas(t, slog, expected)
+}
+
+// Ended sagas are evicted and dropped from the journals of the log and its replicas.
+func TestDurableSagaLog_Compaction(t *testing.T) {
+	dir := makeTestDir(t)
+	defer os.RemoveAll(dir)
+
+	var replicas []*downableReplica
+	var dirs []string
+	for _, name := range []string{"replica1", "replica2"} {
+		dirs = append(dirs, path.Join(dir, name))
+		r, err := MakeDirSagaLogReplica(dirs[len(dirs)-1])
+		if err != nil {
+			t.Fatalf("Unexpected error making replica: %v", err)
+		}
+		defer r.Close()
+		replicas = append(replicas, &downableReplica{SagaLogReplica: r})
+	}
+	slog, err := MakeDurableSagaLog(path.Join(dir, "primary"), replicas[0], replicas[1])
+	if err != nil {
+		t.Fatalf("Unexpected error making durable saga log: %v", err)
+	}
+	defer slog.Close()
+	slog.compactBytes = 1
+
+	// The second replica misses the compaction when the first saga ends.
+	if err := slog.StartSaga("other", nil); err != nil {
+		t.Fatalf("Unexpected error logging: %v", err)
+	}
+	replicas[1].down = true
+	expected := logTestSagas(t, slog)
+	delete(expected, "ended")
+	checkTestSagasActive(t, slog, expected, []string{"active", "other"})
+	if msgs, err := slog.GetMessages("ended"); err != nil || msgs != nil {
+		t.Errorf("Expected the ended saga to be evicted, got %+v, %v", msgs, err)
+	}
+	if slog.id.Generation != 1 {
+		t.Errorf("Expected the journal to be compacted once, got generation %d", slog.id.Generation)
+	}
+
+	// It's sent the compacted journal with the next message.
+	replicas[1].down = false
+	msg := saga.MakeEndTaskMessage("active", "task1", nil)
+	if err := slog.LogMessage(msg); err != nil {
+		t.Fatalf("Unexpected error logging: %v", err)
+	}
+	expected["active"] = append(expected["active"], msg)
+	primary, _ := ioutil.ReadFile(path.Join(dir, "primary", journalFileName))
+	for _, d := range dirs {
+		if copied, _ := ioutil.ReadFile(path.Join(d, journalFileName)); !reflect.DeepEqual(primary, copied) {
+			t.Errorf("Expected the journal of %s to match the primary's, got %d and %d bytes", d, len(copied), len(primary))
+		}
+	}
+
+	failover, err := MakeDurableSagaLog(dirs[1])
+	if err != nil {
+		t.Fatalf("Unexpected error failing over to replica: %v", err)
+	}
+	defer failover.Close()
+	checkTestSagasActive(t, failover, expected, []string{"active", "other"})
+}
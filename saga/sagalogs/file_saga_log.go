@@ -2,10 +2,15 @@ package sagalogs
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/twitter/scoot/saga"
@@ -14,6 +19,7 @@ import (
 // Writes Saga Log to file system.  Not durable beyond machine failure
 // Sagas are stored in a directory.  Each saga has a corresponding directory
 // each saga directory contains a log file, and associated data files.
+// Sagas that haven't ended are indexed in the activeIndexDirName directory, see GetActiveSagas.
 
 // StartSaga Message
 // StartSaga \n
@@ -44,21 +50,107 @@ import (
 
 // EndSaga Message
 // EndSaga
+
+// Checkpoint Message
+// Checkpoint \n
+// state data filename \n
+// Only the latest checkpoint's data file is kept, older ones are deleted once it's logged.
+// Their messages are read without data, so a saga's directory grows linearly with its messages.
 type fileSagaLog struct {
 	dirName string
+
+	compactionMu   sync.Mutex
+	stopCompaction func() // set by StartCompaction
+
+	lockMu   sync.Mutex
+	lockFile *os.File // set by Lock
 }
 
+// Each saga that hasn't ended has an empty file named by its sagaId in this directory.
+const activeIndexDirName = ".active"
+
+// File in the saga log directory that the process using the log holds an exclusive flock on, see Lock.
+const lockFileName = ".lock"
+
+// Returned by Lock when another process, ex: a running scheduler, has the saga log locked.
+var ErrFileSagaLogLocked = errors.New("Saga log is locked by another process")
+
 // Creates a FileSagaLog with files stored at the specified directory
 // If the directory does not exist it will create it.
+// Logs written before the active saga index existed are indexed the first time they're locked,
+// until then GetActiveSagas reads every saga.
 func MakeFileSagaLog(dirName string) (*fileSagaLog, error) {
 
 	if err := os.MkdirAll(dirName, os.ModePerm); err != nil {
 		return nil, err
 	}
 
-	return &fileSagaLog{
+	log := &fileSagaLog{
 		dirName: dirName,
-	}, nil
+	}
+	if indexed, err := log.isIndexed(); err != nil {
+		return nil, err
+	} else if !indexed {
+		// A log without sagas has nothing to index, anything else is only indexed under the lock.
+		sagaIds, err := log.GetAllSagas()
+		if err != nil {
+			return nil, err
+		}
+		if len(sagaIds) == 0 {
+			if err := os.Mkdir(log.getActiveIndexDirectory(), os.ModePerm); err != nil && !os.IsExist(err) {
+				return nil, err
+			}
+		}
+	}
+
+	return log, nil
+}
+
+// Takes an exclusive lock on the log's directory, which is held until the log is closed or the process exits.
+// The scheduler holds it while running so that tools which change sagas outside of it, see ForceEndSaga,
+// can't run meanwhile. Returns ErrFileSagaLogLocked if another process holds the lock, and nil if this log does.
+// A log that isn't indexed yet has its active saga index built once the lock is taken.
+func (log *fileSagaLog) Lock() error {
+	log.lockMu.Lock()
+	defer log.lockMu.Unlock()
+	if log.lockFile != nil {
+		return nil
+	}
+	f, err := os.OpenFile(path.Join(log.dirName, lockFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if err == syscall.EWOULDBLOCK {
+			return ErrFileSagaLogLocked
+		}
+		return err
+	}
+
+	indexed, err := log.isIndexed()
+	if err == nil && !indexed {
+		err = log.RebuildActiveIndex()
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+	log.lockFile = f
+	return nil
+}
+
+// Releases the lock taken by Lock, if any.
+func (log *fileSagaLog) unlock() error {
+	log.lockMu.Lock()
+	defer log.lockMu.Unlock()
+	if log.lockFile == nil {
+		return nil
+	}
+	// Closing the file releases the flock.
+	err := log.lockFile.Close()
+	log.lockFile = nil
+	return err
 }
 
 // all files for a saga log are stored in a directory named
@@ -67,6 +159,41 @@ func (log *fileSagaLog) getSagaDirectory(sagaId string) string {
 	return path.Join(log.dirName, sagaId)
 }
 
+// Returns the name of the file that marks the specified saga as active.
+func (log *fileSagaLog) getActiveIndexFileName(sagaId string) string {
+	return path.Join(log.getActiveIndexDirectory(), sagaId)
+}
+
+func (log *fileSagaLog) getActiveIndexDirectory() string {
+	return path.Join(log.dirName, activeIndexDirName)
+}
+
+// Returns true if the log has an active saga index.
+func (log *fileSagaLog) isIndexed() (bool, error) {
+	if _, err := os.Stat(log.getActiveIndexDirectory()); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Returns true if the saga hasn't ended, according to the index if the log has one.
+func (log *fileSagaLog) isSagaActive(sagaId string) (bool, error) {
+	if indexed, err := log.isIndexed(); err != nil {
+		return false, err
+	} else if !indexed {
+		ended, err := log.isSagaEnded(sagaId)
+		return !ended, err
+	}
+	if _, err := os.Stat(log.getActiveIndexFileName(sagaId)); os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Returns the name of the sagalog for the specified file.
 func (log *fileSagaLog) getSagaLogFileName(sagaId string) string {
 	return path.Join(log.getSagaDirectory(sagaId), "log")
@@ -91,6 +218,26 @@ func (log *fileSagaLog) createTaskDataFileName(
 	return path.Join(log.getSagaDirectory(sagaId), fileName)
 }
 
+// Returns the name of the file to store a Checkpoint's state in
+// based on the SagaId.  Not deterministic.
+func (log *fileSagaLog) createCheckpointDataFileName(sagaId string) string {
+	//format sagaDir/CheckpointData_timestamp
+	fileName := fmt.Sprintf(
+		"CheckpointData_%v", time.Now().Format(time.StampNano))
+	return path.Join(log.getSagaDirectory(sagaId), fileName)
+}
+
+// Deletes the saga's checkpoint data files other than keep. Best effort, files that
+// couldn't be deleted are retried with the next checkpoint.
+func (log *fileSagaLog) removeCheckpointData(sagaId string, keep string) {
+	files, _ := filepath.Glob(path.Join(log.getSagaDirectory(sagaId), "CheckpointData_*"))
+	for _, f := range files {
+		if path.Base(f) != path.Base(keep) {
+			os.Remove(f)
+		}
+	}
+}
+
 // Returns the name of the file to store Job Data in based on the
 // SagaId.  Not deterministic.
 func (log *fileSagaLog) createJobDataFileName(sagaId string) string {
@@ -117,6 +264,16 @@ func (log *fileSagaLog) StartSaga(sagaId string, job []byte) error {
 		}
 	}
 
+	// Index the saga as active before logging it so recovery can't miss it.
+	// A log that isn't indexed yet finds it when the index is built.
+	if indexed, err := log.isIndexed(); err != nil {
+		return err
+	} else if indexed {
+		if err := ioutil.WriteFile(log.getActiveIndexFileName(sagaId), nil, os.ModePerm); err != nil {
+			return err
+		}
+	}
+
 	// Write Data File
 	dataFileName := log.createJobDataFileName(sagaId)
 	err := ioutil.WriteFile(dataFileName, job, os.ModePerm)
@@ -198,118 +355,199 @@ func (log *fileSagaLog) LogMessage(message saga.SagaMessage) error {
 				dataFileName))...)
 	}
 
+	// If its a Checkpoint Write the State
+	checkpointFileName := ""
+	if message.MsgType == saga.Checkpoint {
+		checkpointFileName = log.createCheckpointDataFileName(message.SagaId)
+		err = ioutil.WriteFile(checkpointFileName, message.Data, os.ModePerm)
+		if err != nil {
+			return err
+		}
+		msg = append(msg, []byte(fmt.Sprintf("%v\n", checkpointFileName))...)
+	}
+
 	_, err = logFile.Write(msg)
 	if err != nil {
 		return err
 	}
 
-	logFile.Sync()
+	syncErr := logFile.Sync()
+
+	// Once the new checkpoint is durable the older ones aren't needed for recovery.
+	if checkpointFileName != "" && syncErr == nil {
+		log.removeCheckpointData(message.SagaId, checkpointFileName)
+	}
+
+	// The saga has ended, remove it from the active index.
+	if message.MsgType == saga.EndSaga {
+		err = os.Remove(log.getActiveIndexFileName(message.SagaId))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	return nil
 }
 
 // Returns all of the messages logged so far for the
 // specified saga.
 func (log *fileSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	msgs, dataFileNames, err := log.readLog(sagaId)
+	if err != nil || msgs == nil {
+		return nil, err
+	}
+	if err := readMessageData(sagaId, log.getSagaDirectory(sagaId), msgs, dataFileNames); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// Returns the saga's latest Checkpoint message and the messages after it,
+// or all of its messages if it has no checkpoint.
+// Only the data files of the returned messages are read.
+func (log *fileSagaLog) GetMessagesSinceCheckpoint(sagaId string) ([]saga.SagaMessage, error) {
+	msgs, dataFileNames, err := log.readLog(sagaId)
+	if err != nil || msgs == nil {
+		return nil, err
+	}
+	skip := len(msgs) - len(saga.TrimToCheckpoint(msgs))
+	msgs, dataFileNames = msgs[skip:], dataFileNames[skip:]
+	if err := readMessageData(sagaId, log.getSagaDirectory(sagaId), msgs, dataFileNames); err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// Parses the saga's log file, returning its messages without their data and the name of each message's
+// data file, empty if it has none. Returns nil if the saga doesn't exist.
+func (log *fileSagaLog) readLog(sagaId string) ([]saga.SagaMessage, []string, error) {
 	fileName := log.getSagaLogFileName(sagaId)
 
 	// check if this saga actually exists
 	if _, err := os.Stat(fileName); err != nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	logFile, err := os.Open(fileName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer logFile.Close()
 
 	msgs := make([]saga.SagaMessage, 0)
+	dataFileNames := make([]string, 0)
 	scanner := bufio.NewScanner(logFile)
 	nextToken := scanner.Scan()
 
 	for nextToken == true {
-		msg, err := parseMessage(sagaId, scanner)
+		msg, dataFileName, err := parseMessage(sagaId, scanner)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		msgs = append(msgs, msg)
+		dataFileNames = append(dataFileNames, dataFileName)
 		nextToken = scanner.Scan()
 	}
 
-	return msgs, nil
+	return msgs, dataFileNames, nil
+}
+
+// Reads the data files of messages parsed by readLog into their Data.
+// SagaDir is the saga's current directory, which differs from the one in its data filenames if it was archived.
+// Checkpoints before the latest one are left without data if theirs was deleted, see removeCheckpointData.
+func readMessageData(sagaId string, sagaDir string, msgs []saga.SagaMessage, dataFileNames []string) error {
+	latestCheckpoint := len(msgs) - len(saga.TrimToCheckpoint(msgs))
+	for i, dataFileName := range dataFileNames {
+		if dataFileName == "" {
+			continue
+		}
+		data, err := readDataFile(sagaDir, dataFileName)
+		if os.IsNotExist(err) && msgs[i].MsgType == saga.Checkpoint && i < latestCheckpoint {
+			continue
+		}
+		if err != nil {
+			return saga.NewCorruptedSagaLogError(
+				sagaId,
+				fmt.Sprintf("Error Reading DataFile %v, Error: %v", dataFileName, err),
+			)
+		}
+		msgs[i].Data = data
+	}
+	return nil
 }
 
-// Helper Function that Parses a SagaMessage.  Returns a message if succesfully parsed
+// Helper Function that Parses a SagaMessage.  Returns a message without its data and
+// the name of its data file if succesfully parsed, see readMessageData.
 // Returns and error otherwise
-func parseMessage(sagaId string, scanner *bufio.Scanner) (saga.SagaMessage, error) {
+func parseMessage(sagaId string, scanner *bufio.Scanner) (saga.SagaMessage, string, error) {
 
 	switch scanner.Text() {
 
 	// Parse Start Saga Message
 	case saga.StartSaga.String():
 		if ok := scanner.Scan(); !ok {
-			return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(
+			return saga.SagaMessage{}, "", saga.NewCorruptedSagaLogError(
 				sagaId,
 				fmt.Sprintf("Error Parsing SagaLog expected Data after StartSaga message.  Error: %v",
 					createUnexpectedScanEndMsg(scanner)),
 			)
 		}
-		dataFileName := scanner.Text()
-		data, err := ioutil.ReadFile(dataFileName)
-		if err != nil {
-			return saga.SagaMessage{},
-				saga.NewCorruptedSagaLogError(
-					sagaId,
-					fmt.Sprintf("Error Reading DataFile %v, Error: %v", dataFileName, err),
-				)
-		}
-
-		return saga.MakeStartSagaMessage(sagaId, data), nil
+		return saga.MakeStartSagaMessage(sagaId, nil), scanner.Text(), nil
 
 		// Parse End Saga Message
 	case saga.EndSaga.String():
-		return saga.MakeEndSagaMessage(sagaId), nil
+		return saga.MakeEndSagaMessage(sagaId), "", nil
 
 		// Parse Abort Saga Message
 	case saga.AbortSaga.String():
-		return saga.MakeAbortSagaMessage(sagaId), nil
+		return saga.MakeAbortSagaMessage(sagaId), "", nil
 
 		// Parse Start Task Message
 	case saga.StartTask.String():
-		taskId, data, err := parseTask(sagaId, scanner)
+		taskId, dataFileName, err := parseTask(sagaId, scanner)
 		if err != nil {
-			return saga.SagaMessage{}, err
+			return saga.SagaMessage{}, "", err
 		}
-		return saga.MakeStartTaskMessage(sagaId, taskId, data), nil
+		return saga.MakeStartTaskMessage(sagaId, taskId, nil), dataFileName, nil
 
 		// Parse End Task Message
 	case saga.EndTask.String():
-		taskId, data, err := parseTask(sagaId, scanner)
+		taskId, dataFileName, err := parseTask(sagaId, scanner)
 		if err != nil {
-			return saga.SagaMessage{}, err
+			return saga.SagaMessage{}, "", err
 		}
-		return saga.MakeEndTaskMessage(sagaId, taskId, data), nil
+		return saga.MakeEndTaskMessage(sagaId, taskId, nil), dataFileName, nil
 
 		// Parse Start Comp Task Message
 	case saga.StartCompTask.String():
-		taskId, data, err := parseTask(sagaId, scanner)
+		taskId, dataFileName, err := parseTask(sagaId, scanner)
 		if err != nil {
-			return saga.SagaMessage{}, err
+			return saga.SagaMessage{}, "", err
 		}
-		return saga.MakeStartCompTaskMessage(sagaId, taskId, data), nil
+		return saga.MakeStartCompTaskMessage(sagaId, taskId, nil), dataFileName, nil
 
 		// Parse End Comp Task Message
 	case saga.EndCompTask.String():
-		taskId, data, err := parseTask(sagaId, scanner)
+		taskId, dataFileName, err := parseTask(sagaId, scanner)
 		if err != nil {
-			return saga.SagaMessage{}, err
+			return saga.SagaMessage{}, "", err
+		}
+		return saga.MakeEndCompTaskMessage(sagaId, taskId, nil), dataFileName, nil
+
+		// Parse Checkpoint Message
+	case saga.Checkpoint.String():
+		if ok := scanner.Scan(); !ok {
+			return saga.SagaMessage{}, "", saga.NewCorruptedSagaLogError(
+				sagaId,
+				fmt.Sprintf("Error Parsing SagaLog expected Data after Checkpoint message.  Error: %v",
+					createUnexpectedScanEndMsg(scanner)),
+			)
 		}
-		return saga.MakeEndCompTaskMessage(sagaId, taskId, data), nil
+		return saga.MakeCheckpointMessage(sagaId, nil), scanner.Text(), nil
 
 		// Unrecognized Message
 	default:
-		return saga.SagaMessage{}, saga.NewCorruptedSagaLogError(
+		return saga.SagaMessage{}, "", saga.NewCorruptedSagaLogError(
 			sagaId,
 			fmt.Sprintf("Error Parsing SagaLog unrecognized message type, %v", scanner.Text()),
 		)
@@ -321,11 +559,11 @@ func parseMessage(sagaId string, scanner *bufio.Scanner) (saga.SagaMessage, erro
 // line1: MessageType
 // line2: TaskId
 // line3: TaskData FileName
-// Returns a tuple of TaskId, TaskData, Error
-func parseTask(sagaId string, scanner *bufio.Scanner) (string, []byte, error) {
+// Returns a tuple of TaskId, TaskData FileName, Error
+func parseTask(sagaId string, scanner *bufio.Scanner) (string, string, error) {
 	// read taskId and datafileName
 	if ok := scanner.Scan(); !ok {
-		return "", nil,
+		return "", "",
 			saga.NewCorruptedSagaLogError(
 				sagaId,
 				fmt.Sprintf("Error Parsing SagaLog expected TaskId, Error: %v",
@@ -334,7 +572,7 @@ func parseTask(sagaId string, scanner *bufio.Scanner) (string, []byte, error) {
 	}
 	taskId := scanner.Text()
 	if ok := scanner.Scan(); !ok {
-		return "", nil,
+		return "", "",
 			saga.NewCorruptedSagaLogError(
 				sagaId,
 				fmt.Sprintf("Error Parsing SagaLog expected Data, Error: %v",
@@ -343,16 +581,18 @@ func parseTask(sagaId string, scanner *bufio.Scanner) (string, []byte, error) {
 	}
 	dataFileName := scanner.Text()
 
+	return taskId, dataFileName, nil
+}
+
+// Reads a data file, looking for it in sagaDir if the saga has been moved since it was logged.
+func readDataFile(sagaDir string, dataFileName string) ([]byte, error) {
 	data, err := ioutil.ReadFile(dataFileName)
-	if err != nil {
-		return "", nil,
-			saga.NewCorruptedSagaLogError(
-				sagaId,
-				fmt.Sprintf("Error Reading DataFile %v, Error: %v", dataFileName, err),
-			)
+	if os.IsNotExist(err) {
+		if moved, movedErr := ioutil.ReadFile(path.Join(sagaDir, path.Base(dataFileName))); movedErr == nil {
+			return moved, nil
+		}
 	}
-
-	return taskId, data, nil
+	return data, err
 }
 
 func createUnexpectedScanEndMsg(scanner *bufio.Scanner) string {
@@ -365,18 +605,14 @@ func createUnexpectedScanEndMsg(scanner *bufio.Scanner) string {
 	return errMsg
 }
 
-//
-// Returns a list of all in progress sagaIds.
-// This MUST include all not completed sagaIds.
-// It may also included completed sagas
+// Returns a list of all in progress sagaIds from the active saga index,
+// or by reading every saga if the log isn't indexed yet, see Lock.
 // Returns an error if it fails.
-//
-// This is a very dumb implementation currently just returns
-// a list of all sagas.  This can be improved with an index
-//
 func (log *fileSagaLog) GetActiveSagas() ([]string, error) {
-	files, err := ioutil.ReadDir(log.dirName)
-	if err != nil {
+	files, err := ioutil.ReadDir(log.getActiveIndexDirectory())
+	if os.IsNotExist(err) {
+		return log.readActiveSagas()
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -387,3 +623,71 @@ func (log *fileSagaLog) GetActiveSagas() ([]string, error) {
 
 	return sagaIds, nil
 }
+
+// Returns the ids of all sagas in the log, ended or not.
+func (log *fileSagaLog) GetAllSagas() ([]string, error) {
+	files, err := ioutil.ReadDir(log.dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	sagaIds := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
+			sagaIds = append(sagaIds, file.Name())
+		}
+	}
+
+	return sagaIds, nil
+}
+
+// Returns the sagas that haven't ended by reading every saga in the log, sagas that can't be read are considered active.
+func (log *fileSagaLog) readActiveSagas() ([]string, error) {
+	sagaIds, err := log.GetAllSagas()
+	if err != nil {
+		return nil, err
+	}
+	active := []string{}
+	for _, sagaId := range sagaIds {
+		if ended, _ := log.isSagaEnded(sagaId); !ended {
+			active = append(active, sagaId)
+		}
+	}
+	return active, nil
+}
+
+// Returns true if the saga's last message is an EndSaga.
+func (log *fileSagaLog) isSagaEnded(sagaId string) (bool, error) {
+	msgs, err := log.GetMessages(sagaId)
+	if err != nil {
+		return false, err
+	}
+	return len(msgs) > 0 && msgs[len(msgs)-1].MsgType == saga.EndSaga, nil
+}
+
+// Rebuilds the active saga index by reading every saga in the log, sagas that can't be read are considered active.
+// The index is built aside and then swapped in, so it's never partial. The log mustn't be in use meanwhile.
+func (log *fileSagaLog) RebuildActiveIndex() error {
+	sagaIds, err := log.readActiveSagas()
+	if err != nil {
+		return err
+	}
+
+	tmpDir := log.getActiveIndexDirectory() + ".tmp"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return err
+	}
+	if err := os.Mkdir(tmpDir, os.ModePerm); err != nil {
+		return err
+	}
+	for _, sagaId := range sagaIds {
+		if err := ioutil.WriteFile(path.Join(tmpDir, sagaId), nil, os.ModePerm); err != nil {
+			return err
+		}
+	}
+
+	if err := os.RemoveAll(log.getActiveIndexDirectory()); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, log.getActiveIndexDirectory())
+}
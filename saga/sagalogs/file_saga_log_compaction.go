@@ -0,0 +1,143 @@
+package sagalogs
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Returns the sagas that have ended and haven't been logged to for at least olderThan.
+func (log *fileSagaLog) GetCompletedSagas(olderThan time.Duration) ([]string, error) {
+	active, err := log.GetActiveSagas()
+	if err != nil {
+		return nil, err
+	}
+	isActive := make(map[string]bool, len(active))
+	for _, sagaId := range active {
+		isActive[sagaId] = true
+	}
+
+	sagaIds, err := log.GetAllSagas()
+	if err != nil {
+		return nil, err
+	}
+	completed := []string{}
+	cutoff := time.Now().Add(-olderThan)
+	for _, sagaId := range sagaIds {
+		if isActive[sagaId] {
+			continue
+		}
+		info, err := os.Stat(log.getSagaLogFileName(sagaId))
+		if err != nil || info.ModTime().After(cutoff) {
+			continue
+		}
+		// Double check the log rather than trusting the index with the saga's only copy.
+		if ended, err := log.isSagaEnded(sagaId); err != nil || !ended {
+			continue
+		}
+		completed = append(completed, sagaId)
+	}
+	return completed, nil
+}
+
+// Moves the specified sagas to archiveDir, which must be on the same filesystem, or deletes them if it's empty.
+// An archived saga can still be read by a FileSagaLog made on archiveDir. Active sagas are never removed.
+func (log *fileSagaLog) RemoveSagas(sagaIds []string, archiveDir string) error {
+	if archiveDir != "" {
+		if err := os.MkdirAll(archiveDir, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	for _, sagaId := range sagaIds {
+		if active, err := log.isSagaActive(sagaId); err != nil {
+			return err
+		} else if active {
+			return fmt.Errorf("Saga %s is active and can't be removed", sagaId)
+		}
+		var err error
+		if archiveDir != "" {
+			err = os.Rename(log.getSagaDirectory(sagaId), path.Join(archiveDir, sagaId))
+		} else {
+			err = os.RemoveAll(log.getSagaDirectory(sagaId))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Archives or deletes the sagas that ended at least olderThan ago, see RemoveSagas.
+// Returns the ids of the sagas that were removed.
+func (log *fileSagaLog) Compact(olderThan time.Duration, archiveDir string) ([]string, error) {
+	sagaIds, err := log.GetCompletedSagas(olderThan)
+	if err != nil {
+		return nil, err
+	}
+	if err := log.RemoveSagas(sagaIds, archiveDir); err != nil {
+		return nil, err
+	}
+	return sagaIds, nil
+}
+
+// Compacts the log every interval until the returned func or Close is called, which wait for a running compaction.
+// Replaces any compaction that was already started.
+func (log *fileSagaLog) StartCompaction(interval, olderThan time.Duration, archiveDir string) func() {
+	doneCh, exitedCh := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	stop := func() {
+		once.Do(func() { close(doneCh) })
+		<-exitedCh
+	}
+
+	log.compactionMu.Lock()
+	if log.stopCompaction != nil {
+		log.stopCompaction()
+	}
+	log.stopCompaction = stop
+	log.compactionMu.Unlock()
+
+	go func() {
+		compactEvery(log, interval, olderThan, archiveDir, doneCh)
+		close(exitedCh)
+	}()
+	return stop
+}
+
+// Stops compaction, if it was started. The log itself holds no open files.
+func (log *fileSagaLog) Close() error {
+	log.compactionMu.Lock()
+	defer log.compactionMu.Unlock()
+	if log.stopCompaction != nil {
+		log.stopCompaction()
+		log.stopCompaction = nil
+	}
+	return log.unlock()
+}
+
+func compactEvery(slog *fileSagaLog, interval, olderThan time.Duration, archiveDir string, doneCh chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-doneCh:
+			return
+		case <-ticker.C:
+			select {
+			case <-doneCh:
+				return
+			default:
+			}
+			sagaIds, err := slog.Compact(olderThan, archiveDir)
+			if err != nil {
+				log.Errorf("Error compacting saga log %s: %v", slog.dirName, err)
+			} else if len(sagaIds) > 0 {
+				log.Infof("Compacted %d sagas older than %s from saga log %s", len(sagaIds), olderThan, slog.dirName)
+			}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package sagalogs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// File in the saga log directory where sagas that were forced to end are recorded, one JSON SagaAuditRecord per line.
+const auditLogFileName = ".audit"
+
+// Records who forced a saga to end, why, and the messages that were logged to do it.
+type SagaAuditRecord struct {
+	Time     time.Time
+	User     string
+	Host     string
+	SagaId   string
+	Action   string // "complete" or "abort"
+	Reason   string
+	Messages []string // ex: "End Task task1"
+}
+
+// Validates the saga's messages, see saga.ValidateSagaMessages.
+// Returns the saga's state, or nil if it doesn't exist.
+func (log *fileSagaLog) ValidateSaga(sagaId string) (*saga.SagaState, error) {
+	msgs, err := log.GetMessages(sagaId)
+	if err != nil {
+		return nil, err
+	}
+	return saga.ValidateSagaMessages(sagaId, msgs)
+}
+
+// Ends a stuck saga by logging the messages that complete it, or that abort and roll it back if abort is set,
+// see saga.MakeForceCompleteMessages and saga.MakeForceAbortMessages. The saga must be valid.
+// The change is recorded in the audit log before any messages are logged.
+// The log is locked first so this fails with ErrFileSagaLogLocked while a scheduler is using it, see Lock.
+// Returns the messages that were logged.
+func (log *fileSagaLog) ForceEndSaga(sagaId string, abort bool, reason string) ([]saga.SagaMessage, error) {
+	if err := log.Lock(); err != nil {
+		return nil, err
+	}
+	state, err := log.ValidateSaga(sagaId)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, fmt.Errorf("Saga %s doesn't exist", sagaId)
+	}
+
+	action, makeMessages := "complete", saga.MakeForceCompleteMessages
+	if abort {
+		action, makeMessages = "abort", saga.MakeForceAbortMessages
+	}
+	msgs, err := makeMessages(state)
+	if err != nil {
+		return nil, err
+	}
+
+	record := SagaAuditRecord{Time: time.Now(), SagaId: sagaId, Action: action, Reason: reason}
+	if u, err := user.Current(); err == nil {
+		record.User = u.Username
+	}
+	record.Host, _ = os.Hostname()
+	for _, msg := range msgs {
+		record.Messages = append(record.Messages, strings.TrimSpace(fmt.Sprintf("%v %s", msg.MsgType, msg.TaskId)))
+	}
+	if err := log.appendAuditRecord(record); err != nil {
+		return nil, err
+	}
+
+	for i, msg := range msgs {
+		if err := log.LogMessage(msg); err != nil {
+			return msgs[:i], err
+		}
+	}
+	return msgs, nil
+}
+
+// Returns the sagas that were forced to end, oldest first.
+func (log *fileSagaLog) GetAuditRecords() ([]SagaAuditRecord, error) {
+	f, err := os.Open(log.getAuditLogFileName())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := []SagaAuditRecord{}
+	decoder := json.NewDecoder(f)
+	for decoder.More() {
+		var record SagaAuditRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (log *fileSagaLog) getAuditLogFileName() string {
+	return path.Join(log.dirName, auditLogFileName)
+}
+
+func (log *fileSagaLog) appendAuditRecord(record SagaAuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(log.getAuditLogFileName(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return f.Sync()
+}
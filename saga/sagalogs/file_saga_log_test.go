@@ -2,10 +2,14 @@ package sagalogs
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/twitter/scoot/saga"
 )
@@ -171,3 +175,285 @@ func TestGetMessages_SagaDoesNotExist(t *testing.T) {
 		t.Errorf("Expeceted no messages to be returned %+v", msgs)
 	}
 }
+
+func TestGetActiveSagas_Index(t *testing.T) {
+	defer testCleanup(t)
+
+	dirName := getDirName()
+	slog, _ := MakeFileSagaLog(dirName)
+	slog.StartSaga("ended", nil)
+	slog.StartSaga("active", nil)
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("ended")); err != nil {
+		t.Fatalf("Unexpected Error Logging EndSaga %v", err)
+	}
+
+	if active, err := slog.GetActiveSagas(); err != nil || !reflect.DeepEqual(active, []string{"active"}) {
+		t.Errorf("Expected only the active saga, got %v, %v", active, err)
+	}
+
+	// Logs written before the index existed are read without being indexed until they're locked.
+	indexDir := path.Join(dirName, activeIndexDirName)
+	if err := os.RemoveAll(indexDir); err != nil {
+		t.Fatalf("Unexpected Error Removing Index %v", err)
+	}
+	slog, err := MakeFileSagaLog(dirName)
+	if err != nil {
+		t.Fatalf("Unexpected Error Opening Log %v", err)
+	}
+	if active, err := slog.GetActiveSagas(); err != nil || !reflect.DeepEqual(active, []string{"active"}) {
+		t.Errorf("Expected only the active saga without an index, got %v, %v", active, err)
+	}
+	if _, err := os.Stat(indexDir); !os.IsNotExist(err) {
+		t.Fatalf("Expected the index not to be rebuilt without the lock, got %v", err)
+	}
+
+	if err := slog.Lock(); err != nil {
+		t.Fatalf("Unexpected Error Locking Saga Log %v", err)
+	}
+	defer slog.Close()
+	if _, err := os.Stat(indexDir); err != nil {
+		t.Fatalf("Expected the index to be rebuilt once locked, got %v", err)
+	}
+	if active, err := slog.GetActiveSagas(); err != nil || !reflect.DeepEqual(active, []string{"active"}) {
+		t.Errorf("Expected only the active saga after rebuilding the index, got %v, %v", active, err)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	defer testCleanup(t)
+
+	dirName := getDirName()
+	archiveDir := path.Join(dirName, ".archive")
+	slog, _ := MakeFileSagaLog(dirName)
+	for _, sagaId := range []string{"old", "recent", "active"} {
+		slog.StartSaga(sagaId, []byte(sagaId))
+		slog.LogMessage(saga.MakeStartTaskMessage(sagaId, "task1", []byte("data")))
+		if sagaId != "active" {
+			slog.LogMessage(saga.MakeEndSagaMessage(sagaId))
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(slog.getSagaLogFileName("old"), old, old)
+	os.Chtimes(slog.getSagaLogFileName("active"), old, old)
+	oldMsgs, _ := slog.GetMessages("old")
+
+	removed, err := slog.Compact(time.Hour, archiveDir)
+	if err != nil || !reflect.DeepEqual(removed, []string{"old"}) {
+		t.Fatalf("Expected only the old saga to be compacted, got %v, %v", removed, err)
+	}
+	if msgs, _ := slog.GetMessages("old"); msgs != nil {
+		t.Errorf("Expected the old saga to be gone from the log, got %+v", msgs)
+	}
+	for _, sagaId := range []string{"recent", "active"} {
+		if msgs, _ := slog.GetMessages(sagaId); len(msgs) == 0 {
+			t.Errorf("Expected %s to be kept", sagaId)
+		}
+	}
+
+	// Archived sagas can still be read.
+	archive, _ := MakeFileSagaLog(archiveDir)
+	if msgs, err := archive.GetMessages("old"); err != nil || !reflect.DeepEqual(msgs, oldMsgs) {
+		t.Errorf("Expected archived messages %+v, got %+v, %v", oldMsgs, msgs, err)
+	}
+
+	if err := slog.RemoveSagas([]string{"active"}, ""); err == nil {
+		t.Errorf("Expected an error removing an active saga")
+	}
+}
+
+func TestStartCompaction_Close(t *testing.T) {
+	defer testCleanup(t)
+
+	slog, _ := MakeFileSagaLog(getDirName())
+	slog.StartSaga("old", nil)
+	slog.LogMessage(saga.MakeEndSagaMessage("old"))
+	old := time.Now().Add(-2 * time.Hour)
+	os.Chtimes(slog.getSagaLogFileName("old"), old, old)
+
+	slog.StartCompaction(time.Millisecond, time.Hour, "")
+	for i := 0; i < 1000; i++ {
+		if msgs, _ := slog.GetMessages("old"); msgs == nil {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if msgs, _ := slog.GetMessages("old"); msgs != nil {
+		t.Fatalf("Expected the old saga to be compacted")
+	}
+
+	if err := slog.Close(); err != nil {
+		t.Fatalf("Unexpected error closing the log: %v", err)
+	}
+	slog.StartSaga("closed", nil)
+	slog.LogMessage(saga.MakeEndSagaMessage("closed"))
+	os.Chtimes(slog.getSagaLogFileName("closed"), old, old)
+	time.Sleep(20 * time.Millisecond)
+	if msgs, _ := slog.GetMessages("closed"); msgs == nil {
+		t.Errorf("Expected compaction to stop when the log is closed")
+	}
+	if err := slog.Close(); err != nil {
+		t.Errorf("Unexpected error closing the log twice: %v", err)
+	}
+}
+
+func TestGetMessagesSinceCheckpoint(t *testing.T) {
+	defer testCleanup(t)
+
+	slog, _ := MakeFileSagaLog(getDirName())
+	sc := saga.MakeSagaCoordinator(slog).WithCheckpointInterval(2)
+	s, _ := sc.MakeSaga("saga1", []byte("job"))
+	s.StartTask("task1", []byte("start1"))
+	s.EndTask("task1", []byte("end1"))
+	s.StartTask("task2", []byte("start2"))
+
+	msgs, err := slog.GetMessages("saga1")
+	if err != nil || len(msgs) != 5 || msgs[3].MsgType != saga.Checkpoint {
+		t.Fatalf("Expected a checkpoint after two messages, got %+v, %v", msgs, err)
+	}
+	msgs, err = slog.GetMessagesSinceCheckpoint("saga1")
+	if err != nil || len(msgs) != 2 || msgs[0].MsgType != saga.Checkpoint || msgs[1].TaskId != "task2" {
+		t.Fatalf("Expected the checkpoint and the message after it, got %+v, %v", msgs, err)
+	}
+
+	// Data logged before the checkpoint isn't needed to recover the saga.
+	files, _ := filepath.Glob(path.Join(slog.getSagaDirectory("saga1"), "*Task*_data_*"))
+	if len(files) != 3 {
+		t.Fatalf("Expected data files for 3 task messages, got %v", files)
+	}
+	for _, f := range files {
+		if !strings.Contains(f, "task2") {
+			os.Remove(f)
+		}
+	}
+	state, err := sc.GetSagaState("saga1")
+	if err != nil {
+		t.Fatalf("Unexpected Error Recovering Saga %v", err)
+	}
+	if !state.IsTaskCompleted("task1") || string(state.GetEndTaskData("task1")) != "end1" ||
+		!state.IsTaskStarted("task2") || string(state.GetStartTaskData("task2")) != "start2" {
+		t.Errorf("Expected task1 completed and task2 started, got %v", state)
+	}
+}
+
+func TestCheckpointDataRemoved(t *testing.T) {
+	defer testCleanup(t)
+
+	slog, _ := MakeFileSagaLog(getDirName())
+	sc := saga.MakeSagaCoordinator(slog).WithCheckpointInterval(2)
+	s, _ := sc.MakeSaga("saga1", []byte("job"))
+	for i := 0; i < 10; i++ {
+		taskId := fmt.Sprintf("task%d", i)
+		s.StartTask(taskId, nil)
+		s.EndTask(taskId, []byte(taskId))
+	}
+
+	// Only the latest checkpoint's state is kept, earlier checkpoints are read without it.
+	files, _ := filepath.Glob(path.Join(slog.getSagaDirectory("saga1"), "CheckpointData_*"))
+	if len(files) != 1 {
+		t.Fatalf("Expected only the latest checkpoint's data file, got %v", files)
+	}
+	msgs, err := slog.GetMessages("saga1")
+	if err != nil {
+		t.Fatalf("Unexpected Error Getting Messages %v", err)
+	}
+	checkpoints := 0
+	for i, msg := range msgs {
+		if msg.MsgType != saga.Checkpoint {
+			continue
+		}
+		checkpoints++
+		if latest := len(saga.TrimToCheckpoint(msgs)) == len(msgs)-i; latest != (len(msg.Data) > 0) {
+			t.Errorf("Expected only the latest checkpoint to have data, got %d bytes for message %d", len(msg.Data), i)
+		}
+	}
+	if checkpoints != 10 {
+		t.Fatalf("Expected a checkpoint every two messages, got %d in %+v", checkpoints, msgs)
+	}
+
+	if _, err := slog.ValidateSaga("saga1"); err != nil {
+		t.Errorf("Unexpected Error Validating Saga %v", err)
+	}
+	state, err := sc.GetSagaState("saga1")
+	if err != nil || !state.IsTaskCompleted("task9") || string(state.GetEndTaskData("task0")) != "task0" {
+		t.Errorf("Expected every task completed after recovery, got %v, %v", state, err)
+	}
+}
+
+func TestForceEndSaga(t *testing.T) {
+	defer testCleanup(t)
+
+	slog, _ := MakeFileSagaLog(getDirName())
+	for _, sagaId := range []string{"stuck", "rollingBack"} {
+		slog.StartSaga(sagaId, nil)
+		slog.LogMessage(saga.MakeStartTaskMessage(sagaId, "task1", nil))
+	}
+	slog.LogMessage(saga.MakeAbortSagaMessage("rollingBack"))
+
+	if msgs, err := slog.ForceEndSaga("stuck", false, "worker lost"); err != nil || len(msgs) != 2 {
+		t.Fatalf("Expected to complete the stuck saga, got %v, %v", msgs, err)
+	}
+	if state, err := slog.ValidateSaga("stuck"); err != nil || !state.IsSagaCompleted() || state.IsSagaAborted() {
+		t.Errorf("Expected the stuck saga to be completed, got %v, %v", state, err)
+	}
+	if _, err := slog.ForceEndSaga("rollingBack", false, "can't complete"); err == nil {
+		t.Errorf("Expected an error completing an aborted saga")
+	}
+	if _, err := slog.ForceEndSaga("rollingBack", true, "bad job"); err != nil {
+		t.Fatalf("Unexpected Error Aborting Saga %v", err)
+	}
+	if state, err := slog.ValidateSaga("rollingBack"); err != nil || !state.IsSagaCompleted() || !state.IsCompTaskCompleted("task1") {
+		t.Errorf("Expected the aborted saga to be rolled back, got %v, %v", state, err)
+	}
+	if active, _ := slog.GetActiveSagas(); len(active) != 0 {
+		t.Errorf("Expected no active sagas, got %v", active)
+	}
+	if _, err := slog.ForceEndSaga("unknown", true, "typo"); err == nil {
+		t.Errorf("Expected an error ending a saga that doesn't exist")
+	}
+
+	// Only the changes that were made are in the audit trail.
+	records, err := slog.GetAuditRecords()
+	if err != nil || len(records) != 2 {
+		t.Fatalf("Expected 2 audit records, got %+v, %v", records, err)
+	}
+	if r := records[0]; r.SagaId != "stuck" || r.Action != "complete" || r.Reason != "worker lost" ||
+		!reflect.DeepEqual(r.Messages, []string{"End Task task1", "End Saga"}) {
+		t.Errorf("Unexpected audit record %+v", r)
+	}
+	if r := records[1]; r.SagaId != "rollingBack" || r.Action != "abort" || len(r.Messages) != 3 {
+		t.Errorf("Unexpected audit record %+v", r)
+	}
+	if sagaIds, _ := slog.GetAllSagas(); len(sagaIds) != 2 {
+		t.Errorf("Expected the audit trail not to be listed as a saga, got %v", sagaIds)
+	}
+}
+
+func TestForceEndSagaLocked(t *testing.T) {
+	defer testCleanup(t)
+
+	// The scheduler's log holds the lock while it's open.
+	scheduler, _ := MakeFileSagaLog(getDirName())
+	if err := scheduler.Lock(); err != nil {
+		t.Fatalf("Unexpected Error Locking Saga Log %v", err)
+	}
+	scheduler.StartSaga("stuck", nil)
+
+	slog, _ := MakeFileSagaLog(getDirName())
+	if _, err := slog.ForceEndSaga("stuck", false, "worker lost"); err != ErrFileSagaLogLocked {
+		t.Fatalf("Expected the locked log to refuse to end the saga, got %v", err)
+	}
+	if records, _ := slog.GetAuditRecords(); len(records) != 0 {
+		t.Errorf("Expected no audit records, got %+v", records)
+	}
+
+	scheduler.Close()
+	if _, err := slog.ForceEndSaga("stuck", false, "worker lost"); err != nil {
+		t.Fatalf("Expected to end the saga once the log was closed, got %v", err)
+	}
+	slog.Close()
+
+	info, err := os.Stat(path.Join(getDirName(), auditLogFileName))
+	if err != nil || info.Mode().Perm()&0133 != 0 {
+		t.Errorf("Expected the audit trail to be created with mode 0644, got %v, %v", info, err)
+	}
+}
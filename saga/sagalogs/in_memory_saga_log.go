@@ -73,6 +73,15 @@ func (log *inMemorySagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, erro
 	}
 }
 
+/*
+ * Returns the saga's latest Checkpoint message and the messages after it,
+ * or all of its messages if it has no checkpoint.
+ */
+func (log *inMemorySagaLog) GetMessagesSinceCheckpoint(sagaId string) ([]saga.SagaMessage, error) {
+	msgs, err := log.GetMessages(sagaId)
+	return saga.TrimToCheckpoint(msgs), err
+}
+
 /*
  * Returns all Sagas Started since this InMemory Saga was created
  */
@@ -0,0 +1,181 @@
+package sagalogs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// The journal is a file header followed by an append only sequence of records, one per SagaMessage.
+// The file header identifies the journal, see JournalID:
+//   epoch (8 bytes, big endian)
+//   generation (8 bytes, big endian)
+// Each record is
+//   payload length (4 bytes, big endian)
+//   crc32 of the payload (4 bytes, big endian)
+//   payload: message type (1 byte), then the sagaId, taskId and data, each as a uvarint length and bytes
+//
+// A crash can leave a partially written record at the end of the journal, which is detected by its
+// length or checksum and discarded since it was never acknowledged.
+
+const journalFileHeaderLen = 16
+const journalHeaderLen = 8
+
+// Identifies the contents of a journal. The Epoch is that of the durable saga log that wrote it, it's
+// incremented each time a durable saga log is opened so that replicas can fence off the previous one.
+// The Generation is incremented each time the journal is compacted, which rewrites it from the start.
+type JournalID struct {
+	Epoch      uint64
+	Generation uint64
+}
+
+func encodeJournalFileHeader(id JournalID) []byte {
+	header := make([]byte, journalFileHeaderLen)
+	binary.BigEndian.PutUint64(header[0:8], id.Epoch)
+	binary.BigEndian.PutUint64(header[8:16], id.Generation)
+	return header
+}
+
+func decodeJournalFileHeader(header []byte) JournalID {
+	return JournalID{
+		Epoch:      binary.BigEndian.Uint64(header[0:8]),
+		Generation: binary.BigEndian.Uint64(header[8:16]),
+	}
+}
+
+// Reads the ID of the journal in file, returning a zero ID for an empty journal, or one whose header is torn.
+func readJournalID(file *os.File) (JournalID, int64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return JournalID{}, 0, err
+	}
+	if info.Size() < journalFileHeaderLen {
+		return JournalID{}, info.Size(), nil
+	}
+	header := make([]byte, journalFileHeaderLen)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return JournalID{}, info.Size(), err
+	}
+	return decodeJournalFileHeader(header), info.Size(), nil
+}
+
+// Replaces the journal in dirName with data, returning the new journal file opened for read and write.
+// The data is written to a temporary file that's renamed over the journal, so it's never partially replaced.
+func replaceJournal(dirName string, data []byte) (*os.File, error) {
+	tmp, err := ioutil.TempFile(dirName, journalFileName+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	if err = tmp.Chmod(0644); err == nil {
+		_, err = tmp.Write(data)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path.Join(dirName, journalFileName))
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	// Sync the directory so the rename survives a crash.
+	if dir, err := os.Open(dirName); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return tmp, nil
+}
+
+// Messages bigger than this are rejected, it's also a sanity check on the lengths read back from disk.
+const maxJournalRecordLen = 64 * 1024 * 1024
+
+var errTornRecord = errors.New("incomplete journal record")
+
+func encodeJournalRecord(msg saga.SagaMessage) ([]byte, error) {
+	payload := []byte{byte(msg.MsgType)}
+	for _, field := range [][]byte{[]byte(msg.SagaId), []byte(msg.TaskId), msg.Data} {
+		var lenBuf [binary.MaxVarintLen64]byte
+		payload = append(payload, lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(field)))]...)
+		payload = append(payload, field...)
+	}
+	if len(payload) > maxJournalRecordLen {
+		return nil, saga.NewInvalidRequestError(
+			fmt.Sprintf("Message for saga %s is too large to log: %d bytes", msg.SagaId, len(payload)))
+	}
+
+	record := make([]byte, journalHeaderLen, journalHeaderLen+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	return append(record, payload...), nil
+}
+
+// Reads the next record from r, returning the message and the record's length.
+// Returns io.EOF at the end of the journal and errTornRecord if the journal ends mid record.
+func decodeJournalRecord(r io.Reader) (saga.SagaMessage, int64, error) {
+	var header [journalHeaderLen]byte
+	if n, err := io.ReadFull(r, header[:]); err == io.EOF {
+		return saga.SagaMessage{}, 0, io.EOF
+	} else if err != nil {
+		return saga.SagaMessage{}, int64(n), errTornRecord
+	}
+	payloadLen := binary.BigEndian.Uint32(header[0:4])
+	if payloadLen == 0 || payloadLen > maxJournalRecordLen {
+		return saga.SagaMessage{}, journalHeaderLen, fmt.Errorf("invalid journal record length %d", payloadLen)
+	}
+	payload := make([]byte, payloadLen)
+	if n, err := io.ReadFull(r, payload); err != nil {
+		return saga.SagaMessage{}, journalHeaderLen + int64(n), errTornRecord
+	}
+	recordLen := journalHeaderLen + int64(payloadLen)
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+		return saga.SagaMessage{}, recordLen, fmt.Errorf("journal record checksum mismatch")
+	}
+
+	msg := saga.SagaMessage{MsgType: saga.SagaMessageType(payload[0])}
+	fields := make([][]byte, 3)
+	rest := payload[1:]
+	for i := range fields {
+		fieldLen, n := binary.Uvarint(rest)
+		if n <= 0 || fieldLen > uint64(len(rest)-n) {
+			return saga.SagaMessage{}, recordLen, fmt.Errorf("invalid journal record field %d", i)
+		}
+		fields[i] = rest[n : n+int(fieldLen)]
+		rest = rest[n+int(fieldLen):]
+	}
+	msg.SagaId, msg.TaskId = string(fields[0]), string(fields[1])
+	if len(fields[2]) > 0 {
+		msg.Data = fields[2]
+	}
+	return msg, recordLen, nil
+}
+
+// Reads every message in the journal's records, which start after its file header, calling fn for each in
+// order with the message's record length.
+// Returns the length of the valid part of the journal, which is shorter than the journal if it ends with a
+// torn record. A bad record anywhere else means the journal is corrupted and returns an error.
+func replayJournal(r io.Reader, size int64, fn func(saga.SagaMessage, int64)) (int64, error) {
+	offset := int64(journalFileHeaderLen)
+	for {
+		msg, n, err := decodeJournalRecord(r)
+		switch {
+		case err == io.EOF, err == errTornRecord:
+			return offset, nil
+		case err != nil && offset+n >= size:
+			// The last record was sized but its contents never made it to disk.
+			return offset, nil
+		case err != nil:
+			return offset, saga.NewCorruptedSagaLogError("", fmt.Sprintf("Error reading journal at offset %d: %v", offset, err))
+		}
+		fn(msg, n)
+		offset += n
+	}
+}
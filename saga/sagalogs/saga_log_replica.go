@@ -0,0 +1,252 @@
+package sagalogs
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// HTTP path the SagaLogReplicaHandler is served at.
+const SagaLogReplicaPath = "/sagalog/replica"
+
+// A SagaLogReplica keeps a byte for byte copy of a durable saga log's journal, typically on another host.
+// If the durable saga log's host is lost, a durable saga log opened on the replica's directory takes over.
+type SagaLogReplica interface {
+	// Writes data at offset of the replica's journal with the specified id, first discarding anything past
+	// offset, which the durable saga log failed to commit. Writing at offset 0 replaces the journal.
+	// Returns the size of the replica's journal, which is less than offset if the replica is missing earlier
+	// data that has to be written first, or 0 if the replica's journal has a different id.
+	// Returns a SagaLogFencedError if the replica's journal is from a newer epoch.
+	WriteAt(id JournalID, offset int64, data []byte) (int64, error)
+}
+
+// Returned by a SagaLogReplica whose journal was written by a newer durable saga log than the one writing to it,
+// which means that the writer was failed over and must stop logging.
+type SagaLogFencedError struct {
+	Epoch uint64
+}
+
+func (e SagaLogFencedError) Error() string {
+	return fmt.Sprintf("saga log replica has a journal from the newer epoch %d", e.Epoch)
+}
+
+// Replica that writes the journal to a local directory, ex: on another disk or a network mount.
+// It's also what the SagaLogReplicaHandler serves for remote durable saga logs.
+type dirSagaLogReplica struct {
+	dirName string
+	file    *os.File
+	id      JournalID
+	size    int64
+	mu      sync.Mutex
+}
+
+// Creates a replica with its journal stored in the specified directory, creating it if needed.
+func MakeDirSagaLogReplica(dirName string) (*dirSagaLogReplica, error) {
+	if err := os.MkdirAll(dirName, os.ModePerm); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path.Join(dirName, journalFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	id, size, err := readJournalID(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if size < journalFileHeaderLen {
+		// Never written to, or torn while first written, which is rewritten from the start.
+		size = 0
+	}
+	return &dirSagaLogReplica{dirName: dirName, file: file, id: id, size: size}, nil
+}
+
+func (r *dirSagaLogReplica) WriteAt(id JournalID, offset int64, data []byte) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id.Epoch < r.id.Epoch {
+		return r.size, SagaLogFencedError{Epoch: r.id.Epoch}
+	}
+	if offset == 0 {
+		return r.replace(id, data)
+	}
+	if id != r.id || r.size == 0 {
+		return 0, nil
+	}
+	if offset > r.size {
+		return r.size, nil
+	}
+	if offset < r.size {
+		if err := r.file.Truncate(offset); err != nil {
+			return r.size, err
+		}
+		r.size = offset
+	}
+	if _, err := r.file.WriteAt(data, offset); err != nil {
+		return r.size, err
+	}
+	if err := r.file.Sync(); err != nil {
+		return r.size, err
+	}
+	r.size = offset + int64(len(data))
+	return r.size, nil
+}
+
+// Replaces the journal with data, which starts with the file header of the journal with the specified id.
+func (r *dirSagaLogReplica) replace(id JournalID, data []byte) (int64, error) {
+	if len(data) < journalFileHeaderLen || decodeJournalFileHeader(data) != id {
+		return r.size, fmt.Errorf("data written at offset 0 doesn't start with the header of journal %+v", id)
+	}
+	file, err := replaceJournal(r.dirName, data)
+	if err != nil {
+		return r.size, err
+	}
+	r.file.Close()
+	r.file, r.id, r.size = file, id, int64(len(data))
+	return r.size, nil
+}
+
+func (r *dirSagaLogReplica) String() string {
+	return r.file.Name()
+}
+
+func (r *dirSagaLogReplica) Close() error {
+	return r.file.Close()
+}
+
+// Replica on another host that serves a SagaLogReplicaHandler, ex: binaries/sagalog-replica.
+type httpSagaLogReplica struct {
+	url    string
+	client *http.Client
+}
+
+// Creates a replica that writes to the SagaLogReplicaHandler at addr, ex: "host:9095".
+// Requests that take longer than timeout fail, zero means no timeout.
+func MakeHTTPSagaLogReplica(addr string, timeout time.Duration) *httpSagaLogReplica {
+	if !strings.HasPrefix(addr, "http://") && !strings.HasPrefix(addr, "https://") {
+		addr = "http://" + addr
+	}
+	return &httpSagaLogReplica{
+		url:    strings.TrimSuffix(addr, "/") + SagaLogReplicaPath,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (r *httpSagaLogReplica) WriteAt(id JournalID, offset int64, data []byte) (int64, error) {
+	url := fmt.Sprintf("%s?epoch=%d&generation=%d&offset=%d", r.url, id.Epoch, id.Generation, offset)
+	resp, err := r.client.Post(url, "application/octet-stream", bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode == http.StatusConflict {
+		epoch, err := strconv.ParseUint(strings.TrimSpace(string(body)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("replica %s returned %s with invalid epoch: %v", r.url, resp.Status, err)
+		}
+		return 0, SagaLogFencedError{Epoch: epoch}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("replica %s returned %s: %s", r.url, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+}
+
+func (r *httpSagaLogReplica) String() string {
+	return r.url
+}
+
+// Serves WriteAt requests from remote durable saga logs, responding with the replica's journal size.
+// Only the configured peers, the hosts of the durable saga logs, are allowed to write.
+type SagaLogReplicaHandler struct {
+	replica SagaLogReplica
+	peers   []string
+}
+
+// Creates a handler that accepts writes from peers, a list of host names or IP addresses.
+func NewSagaLogReplicaHandler(replica SagaLogReplica, peers []string) *SagaLogReplicaHandler {
+	return &SagaLogReplicaHandler{replica: replica, peers: peers}
+}
+
+func (h *SagaLogReplicaHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "Expected a POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.isPeer(req.RemoteAddr) {
+		log.Warnf("Rejecting saga log replica write from %s, which isn't a configured peer", req.RemoteAddr)
+		http.Error(w, "Not a configured peer", http.StatusForbidden)
+		return
+	}
+	query := req.URL.Query()
+	offset, err := strconv.ParseInt(query.Get("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		http.Error(w, fmt.Sprintf("Invalid offset: %q", query.Get("offset")), http.StatusBadRequest)
+		return
+	}
+	var id JournalID
+	if id.Epoch, err = strconv.ParseUint(query.Get("epoch"), 10, 64); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid epoch: %q", query.Get("epoch")), http.StatusBadRequest)
+		return
+	}
+	if id.Generation, err = strconv.ParseUint(query.Get("generation"), 10, 64); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid generation: %q", query.Get("generation")), http.StatusBadRequest)
+		return
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	size, err := h.replica.WriteAt(id, offset, data)
+	if fenced, ok := err.(SagaLogFencedError); ok {
+		log.Warnf("Rejecting saga log replica write from %s with epoch %d: %v", req.RemoteAddr, id.Epoch, err)
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, "%d", fenced.Epoch)
+		return
+	} else if err != nil {
+		log.Errorf("Failed to write %d bytes at offset %d of the saga log replica: %v", len(data), offset, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "%d", size)
+}
+
+// Returns true if the request's remote address is one of the peers, or one of the addresses they resolve to.
+func (h *SagaLogReplicaHandler) isPeer(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	for _, peer := range h.peers {
+		if peer == host {
+			return true
+		}
+		addrs, err := net.LookupHost(peer)
+		if err != nil {
+			log.Errorf("Failed to resolve saga log replica peer %s: %v", peer, err)
+			continue
+		}
+		for _, addr := range addrs {
+			if peerIP := net.ParseIP(addr); peerIP != nil && peerIP.Equal(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
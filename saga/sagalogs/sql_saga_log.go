@@ -0,0 +1,305 @@
+package sagalogs
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// Saga Log stored in a SQL database through database/sql, one row per saga and one row per message.
+// The schema and statements work with SQLite ("sqlite3") for local use and tests and with Postgres
+// ("postgres") for production, the binary has to link in the driver, ex: import _ "github.com/lib/pq".
+//
+// Alongside the messages each saga's row records its status and who ran it, see SagaHistory.
+type sqlSagaLog struct {
+	db      *sql.DB
+	jobInfo JobInfoFunc
+}
+
+// Describes the job a saga runs, for job history queries.
+type SagaJobInfo struct {
+	Requestor string
+	Tag       string
+	JobType   string
+}
+
+// Extracts the SagaJobInfo from the data logged with StartSaga.
+type JobInfoFunc func(job []byte) (SagaJobInfo, error)
+
+type SagaStatus string
+
+const (
+	SagaInProgress SagaStatus = "InProgress"
+	SagaCompleted  SagaStatus = "Completed"
+	SagaAborted    SagaStatus = "Aborted" // Rolling back if the saga hasn't ended, rolled back if it has.
+)
+
+// A saga in the job history.
+type SagaSummary struct {
+	SagaId string
+	SagaJobInfo
+	Status  SagaStatus
+	Started time.Time
+	Ended   time.Time // Zero if the saga hasn't ended.
+}
+
+// Filters for job history queries, zero values match everything.
+type SagaHistoryQuery struct {
+	Requestor     string
+	Tag           string
+	Status        SagaStatus
+	StartedAfter  time.Time
+	StartedBefore time.Time
+	Limit         int // DefaultSagaHistoryLimit if zero.
+}
+
+const DefaultSagaHistoryLimit = 100
+
+// SagaHistory is implemented by saga logs that can answer job history queries.
+type SagaHistory interface {
+	// Returns the sagas matching the query, most recently started first.
+	GetSagaHistory(q SagaHistoryQuery) ([]SagaSummary, error)
+}
+
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS sagas (
+		saga_id      VARCHAR(255) PRIMARY KEY,
+		requestor    VARCHAR(255) NOT NULL DEFAULT '',
+		tag          VARCHAR(255) NOT NULL DEFAULT '',
+		job_type     VARCHAR(255) NOT NULL DEFAULT '',
+		status       VARCHAR(32) NOT NULL,
+		started_at   BIGINT NOT NULL,
+		ended_at     BIGINT NOT NULL DEFAULT 0,
+		num_messages BIGINT NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS saga_messages (
+		saga_id   VARCHAR(255) NOT NULL,
+		seq       BIGINT NOT NULL,
+		msg_type  INTEGER NOT NULL,
+		task_id   VARCHAR(255) NOT NULL DEFAULT '',
+		data      BYTEA,
+		logged_at BIGINT NOT NULL,
+		PRIMARY KEY (saga_id, seq)
+	)`,
+	`CREATE INDEX IF NOT EXISTS sagas_by_requestor ON sagas (requestor, started_at)`,
+	`CREATE INDEX IF NOT EXISTS sagas_by_tag ON sagas (tag, started_at)`,
+	`CREATE INDEX IF NOT EXISTS sagas_by_status ON sagas (status, started_at)`,
+	`CREATE INDEX IF NOT EXISTS sagas_by_ended_at ON sagas (ended_at)`,
+}
+
+// Times are stored as unix nanoseconds, which compare the same way in every database.
+// SQLite numbers $N parameters in the order they first appear, so each statement must use them in order.
+const (
+	sqlDeleteMessages = `DELETE FROM saga_messages WHERE saga_id = $1`
+	sqlDeleteSaga     = `DELETE FROM sagas WHERE saga_id = $1`
+	sqlInsertSaga     = `INSERT INTO sagas (saga_id, requestor, tag, job_type, status, started_at, ended_at, num_messages)
+		VALUES ($1, $2, $3, $4, $5, $6, 0, 1)`
+	sqlInsertMessage = `INSERT INTO saga_messages (saga_id, seq, msg_type, task_id, data, logged_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`
+	// Incrementing first locks the saga's row, so concurrent messages for a saga get distinct sequence numbers.
+	sqlIncrementMessages = `UPDATE sagas SET num_messages = num_messages + 1 WHERE saga_id = $1`
+	sqlSelectSaga        = `SELECT num_messages, status FROM sagas WHERE saga_id = $1`
+	sqlUpdateStatus      = `UPDATE sagas SET status = $1, ended_at = $2 WHERE saga_id = $3`
+	sqlSelectMessages    = `SELECT msg_type, task_id, data FROM saga_messages WHERE saga_id = $1 ORDER BY seq`
+	sqlSelectActive      = `SELECT saga_id FROM sagas WHERE ended_at = 0`
+	sqlSelectHistory     = `SELECT saga_id, requestor, tag, job_type, status, started_at, ended_at FROM sagas
+		WHERE ($1 = '' OR requestor = $1) AND ($2 = '' OR tag = $2) AND ($3 = '' OR status = $3)
+		AND started_at >= $4 AND started_at < $5
+		ORDER BY started_at DESC LIMIT $6`
+)
+
+// Opens the database with the named driver and makes a SQL saga log on it, see MakeSQLSagaLog.
+// SQLite databases are limited to one connection, since SQLite allows one writer at a time
+// and each connection to ":memory:" is a separate database.
+func OpenSQLSagaLog(driver, dataSource string, jobInfo JobInfoFunc) (*sqlSagaLog, error) {
+	db, err := sql.Open(driver, dataSource)
+	if err != nil {
+		return nil, err
+	}
+	if driver == "sqlite3" {
+		db.SetMaxOpenConns(1)
+	}
+	slog, err := MakeSQLSagaLog(db, jobInfo)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return slog, nil
+}
+
+// Creates a SQL saga log on the given database, creating its tables if they don't exist.
+// JobInfo is optional, without it job history queries can only filter by status and time.
+func MakeSQLSagaLog(db *sql.DB, jobInfo JobInfoFunc) (*sqlSagaLog, error) {
+	for _, stmt := range sqlSchema {
+		if _, err := db.Exec(stmt); err != nil {
+			return nil, err
+		}
+	}
+	return &sqlSagaLog{db: db, jobInfo: jobInfo}, nil
+}
+
+// Log a Start Saga Message message to the log.
+// Starting a saga again replaces it, as with the in memory saga log.
+// Returns an error if it fails.
+func (l *sqlSagaLog) StartSaga(sagaId string, job []byte) error {
+	var info SagaJobInfo
+	if l.jobInfo != nil {
+		// The job history is best effort, the saga is logged regardless.
+		info, _ = l.jobInfo(job)
+	}
+	now := time.Now().UnixNano()
+	return l.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(sqlDeleteMessages, sagaId); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqlDeleteSaga, sagaId); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqlInsertSaga, sagaId, info.Requestor, info.Tag, info.JobType, string(SagaInProgress), now); err != nil {
+			return err
+		}
+		_, err := tx.Exec(sqlInsertMessage, sagaId, 0, int64(saga.StartSaga), "", job, now)
+		return err
+	})
+}
+
+// Update the State of the Saga by Logging a message.
+// Returns an error if it fails.
+func (l *sqlSagaLog) LogMessage(msg saga.SagaMessage) error {
+	now := time.Now().UnixNano()
+	return l.inTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec(sqlIncrementMessages, msg.SagaId)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil {
+			return err
+		} else if n == 0 {
+			return saga.NewInvalidRequestError(fmt.Sprintf("Saga: %s is not Started yet.", msg.SagaId))
+		}
+
+		var numMessages int64
+		var status string
+		if err := tx.QueryRow(sqlSelectSaga, msg.SagaId).Scan(&numMessages, &status); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(sqlInsertMessage, msg.SagaId, numMessages-1, int64(msg.MsgType), msg.TaskId, msg.Data, now); err != nil {
+			return err
+		}
+
+		switch msg.MsgType {
+		case saga.AbortSaga:
+			_, err = tx.Exec(sqlUpdateStatus, string(SagaAborted), 0, msg.SagaId)
+		case saga.EndSaga:
+			if SagaStatus(status) != SagaAborted {
+				status = string(SagaCompleted)
+			}
+			_, err = tx.Exec(sqlUpdateStatus, status, now, msg.SagaId)
+		}
+		return err
+	})
+}
+
+// Returns all of the messages logged so far for the
+// specified saga.
+func (l *sqlSagaLog) GetMessages(sagaId string) ([]saga.SagaMessage, error) {
+	rows, err := l.db.Query(sqlSelectMessages, sagaId)
+	if err != nil {
+		return nil, saga.NewInternalLogError(err.Error())
+	}
+	defer rows.Close()
+
+	var msgs []saga.SagaMessage
+	for rows.Next() {
+		var msgType int64
+		var taskId string
+		var data []byte
+		if err := rows.Scan(&msgType, &taskId, &data); err != nil {
+			return nil, saga.NewCorruptedSagaLogError(sagaId, err.Error())
+		}
+		msgs = append(msgs, saga.SagaMessage{SagaId: sagaId, MsgType: saga.SagaMessageType(msgType), TaskId: taskId, Data: data})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, saga.NewInternalLogError(err.Error())
+	}
+	return msgs, nil
+}
+
+// Returns the sagas that haven't ended.
+func (l *sqlSagaLog) GetActiveSagas() ([]string, error) {
+	rows, err := l.db.Query(sqlSelectActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sagaIds := []string{}
+	for rows.Next() {
+		var sagaId string
+		if err := rows.Scan(&sagaId); err != nil {
+			return nil, err
+		}
+		sagaIds = append(sagaIds, sagaId)
+	}
+	return sagaIds, rows.Err()
+}
+
+// Returns the sagas matching the query, most recently started first.
+func (l *sqlSagaLog) GetSagaHistory(q SagaHistoryQuery) ([]SagaSummary, error) {
+	after, before := int64(0), int64(1<<63-1)
+	if !q.StartedAfter.IsZero() {
+		after = q.StartedAfter.UnixNano()
+	}
+	if !q.StartedBefore.IsZero() {
+		before = q.StartedBefore.UnixNano()
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultSagaHistoryLimit
+	}
+
+	rows, err := l.db.Query(sqlSelectHistory, q.Requestor, q.Tag, string(q.Status), after, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sagas := []SagaSummary{}
+	for rows.Next() {
+		var s SagaSummary
+		var status string
+		var started, ended int64
+		if err := rows.Scan(&s.SagaId, &s.Requestor, &s.Tag, &s.JobType, &status, &started, &ended); err != nil {
+			return nil, err
+		}
+		s.Status = SagaStatus(status)
+		s.Started = time.Unix(0, started)
+		if ended != 0 {
+			s.Ended = time.Unix(0, ended)
+		}
+		sagas = append(sagas, s)
+	}
+	return sagas, rows.Err()
+}
+
+// Runs fn in a transaction, committing it if fn succeeds.
+// Database errors are returned as InternalLogErrors since the request may succeed on retry.
+func (l *sqlSagaLog) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := l.db.Begin()
+	if err != nil {
+		return saga.NewInternalLogError(err.Error())
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		if _, ok := err.(saga.InvalidRequestError); ok {
+			return err
+		}
+		return saga.NewInternalLogError(err.Error())
+	}
+	if err := tx.Commit(); err != nil {
+		return saga.NewInternalLogError(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package sagalogs
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/twitter/scoot/saga"
+)
+
+// Runs the tests against an in memory SQLite database.
+func makeTestSQLSagaLog(t *testing.T, jobInfo JobInfoFunc) *sqlSagaLog {
+	slog, err := OpenSQLSagaLog("sqlite3", ":memory:", jobInfo)
+	if err != nil {
+		t.Fatalf("Unexpected error making sql saga log: %v", err)
+	}
+	return slog
+}
+
+func TestSQLSagaLog(t *testing.T) {
+	slog := makeTestSQLSagaLog(t, nil)
+	expected := logTestSagas(t, slog)
+	checkTestSagas(t, slog, expected)
+
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("unknown")); err == nil {
+		t.Errorf("Expected an error logging to a saga that wasn't started")
+	} else if _, ok := err.(saga.InvalidRequestError); !ok {
+		t.Errorf("Expected an InvalidRequestError, got %v", err)
+	}
+	if msgs, err := slog.GetMessages("unknown"); err != nil || msgs != nil {
+		t.Errorf("Expected no messages for a saga that wasn't started, got %v, %v", msgs, err)
+	}
+
+	// Starting a saga again replaces it.
+	if err := slog.StartSaga("ended", []byte("job2")); err != nil {
+		t.Fatalf("Unexpected error restarting saga: %v", err)
+	}
+	expected["ended"] = []saga.SagaMessage{saga.MakeStartSagaMessage("ended", []byte("job2"))}
+	expected["active"] = append(expected["active"], saga.MakeEndSagaMessage("active"))
+	if err := slog.LogMessage(saga.MakeEndSagaMessage("active")); err != nil {
+		t.Fatalf("Unexpected error ending saga: %v", err)
+	}
+	for id, msgs := range expected {
+		if actual, err := slog.GetMessages(id); err != nil || !reflect.DeepEqual(actual, msgs) {
+			t.Errorf("Expected messages %+v for %s, got %+v, %v", msgs, id, actual, err)
+		}
+	}
+	if active, err := slog.GetActiveSagas(); err != nil || !reflect.DeepEqual(active, []string{"ended"}) {
+		t.Errorf("Expected only the restarted saga to be active, got %v, %v", active, err)
+	}
+}
+
+func TestSQLSagaLog_History(t *testing.T) {
+	slog := makeTestSQLSagaLog(t, func(job []byte) (SagaJobInfo, error) {
+		parts := strings.Split(string(job), "/")
+		return SagaJobInfo{Requestor: parts[0], Tag: parts[1], JobType: parts[2]}, nil
+	})
+
+	jobs := []struct {
+		id, job string
+		end     []saga.SagaMessageType
+	}{
+		{"completed", "alice/nightly/build", []saga.SagaMessageType{saga.EndSaga}},
+		{"aborted", "bob/nightly/test", []saga.SagaMessageType{saga.AbortSaga, saga.EndSaga}},
+		{"rollingBack", "alice/presubmit/test", []saga.SagaMessageType{saga.AbortSaga}},
+		{"running", "bob/presubmit/build", nil},
+	}
+	var startedAfter time.Time
+	for i, j := range jobs {
+		if err := slog.StartSaga(j.id, []byte(j.job)); err != nil {
+			t.Fatalf("Unexpected error starting saga: %v", err)
+		}
+		for _, msgType := range j.end {
+			if err := slog.LogMessage(saga.SagaMessage{SagaId: j.id, MsgType: msgType}); err != nil {
+				t.Fatalf("Unexpected error logging %s to saga: %v", msgType, err)
+			}
+		}
+		if i == 1 {
+			startedAfter = time.Now()
+		}
+	}
+
+	for _, tc := range []struct {
+		query    SagaHistoryQuery
+		expected []string
+	}{
+		{SagaHistoryQuery{}, []string{"running", "rollingBack", "aborted", "completed"}},
+		{SagaHistoryQuery{Requestor: "alice"}, []string{"rollingBack", "completed"}},
+		{SagaHistoryQuery{Tag: "nightly", Requestor: "bob"}, []string{"aborted"}},
+		{SagaHistoryQuery{Status: SagaAborted}, []string{"rollingBack", "aborted"}},
+		{SagaHistoryQuery{Status: SagaInProgress}, []string{"running"}},
+		{SagaHistoryQuery{StartedAfter: startedAfter}, []string{"running", "rollingBack"}},
+		{SagaHistoryQuery{StartedBefore: startedAfter, Status: SagaCompleted}, []string{"completed"}},
+		{SagaHistoryQuery{Limit: 1}, []string{"running"}},
+	} {
+		sagas, err := slog.GetSagaHistory(tc.query)
+		if err != nil {
+			t.Fatalf("Unexpected error querying history %+v: %v", tc.query, err)
+		}
+		ids := []string{}
+		for _, s := range sagas {
+			ids = append(ids, s.SagaId)
+		}
+		if !reflect.DeepEqual(ids, tc.expected) {
+			t.Errorf("Expected sagas %v for query %+v, got %v", tc.expected, tc.query, ids)
+		}
+	}
+
+	sagas, _ := slog.GetSagaHistory(SagaHistoryQuery{Requestor: "bob", Tag: "nightly"})
+	if len(sagas) != 1 || sagas[0].JobType != "test" || sagas[0].Status != SagaAborted || sagas[0].Ended.IsZero() {
+		t.Errorf("Expected an ended, aborted test job, got %+v", sagas)
+	}
+}
+
+// Reopening a database keeps its sagas, the schema is only created if it doesn't exist.
+func TestSQLSagaLog_Reopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sql_saga_log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	dataSource := path.Join(dir, "sagas.db")
+
+	slog, err := OpenSQLSagaLog("sqlite3", dataSource, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error making sql saga log: %v", err)
+	}
+	expected := logTestSagas(t, slog)
+	slog.db.Close()
+
+	if slog, err = OpenSQLSagaLog("sqlite3", dataSource, nil); err != nil {
+		t.Fatalf("Unexpected error reopening sql saga log: %v", err)
+	}
+	defer slog.db.Close()
+	checkTestSagas(t, slog, expected)
+}
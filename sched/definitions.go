@@ -3,6 +3,7 @@ package sched
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/twitter/scoot/bazel/execution/bazelapi"
@@ -50,11 +51,29 @@ type JobDefinition struct {
 	Tag       string
 	Priority  Priority
 	Tasks     []TaskDefinition
+	// Retry policy for the job's tasks, nil to use the scheduler's default.
+	RetryPolicy *RetryPolicy
 }
 
 // Task is one task to run
 type TaskDefinition struct {
 	runner.Command
+	// TaskIDs within the same job that must complete successfully before this task is scheduled.
+	DependsOn []string
+	// TaskID within the same job whose output snapshot is used as this task's snapshot, resolved by the scheduler.
+	SnapshotFromTask string
+	// If true, the output of SnapshotFromTask is merged over SnapshotID rather than replacing it.
+	MergeSnapshot bool
+}
+
+// Returns true if taskID is one of the task's DependsOn.
+func (t TaskDefinition) DependsOnTask(taskID string) bool {
+	for _, dep := range t.DependsOn {
+		if dep == taskID {
+			return true
+		}
+	}
+	return false
 }
 
 type OfflineWorkerReq struct {
@@ -67,6 +86,24 @@ type ReinstateWorkerReq struct {
 	Requestor string
 }
 
+// Filters for the scheduler's flaky tasks, the zero value matches all of them.
+type FlakyTasksReq struct {
+	Tag      string
+	MinScore float64
+}
+
+// FlakyTask is a task, identified by its job's Tag and its TaskID, that has succeeded only after a retry.
+type FlakyTask struct {
+	Tag    string
+	TaskID string
+	// Rolling fraction of the task's recent successful runs that needed a retry, in [0,1].
+	Score float64
+	// Successful runs since the task was first flaky, and how many of those were flaky.
+	NumRuns      int
+	NumFlakyRuns int
+	LastFlaky    time.Time
+}
+
 // Status for Job & Tasks
 type Status int
 
@@ -139,9 +176,20 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 					Tag:    thriftJobDef.GetTag(),
 				},
 				ExecuteRequest: execReq,
+				Resources: runner.Resources{
+					Memory: task.GetMemoryBytes(),
+					CPUs:   task.GetCpus(),
+					Disk:   task.GetDiskBytes(),
+				},
+				AllowNetwork: task.GetAllowNetwork(),
 			}
 
-			domainTasks = append(domainTasks, TaskDefinition{command})
+			domainTasks = append(domainTasks, TaskDefinition{
+				Command:          command,
+				DependsOn:        task.GetDependsOn(),
+				SnapshotFromTask: task.GetSnapshotFromTask(),
+				MergeSnapshot:    task.GetMergeSnapshot(),
+			})
 		}
 
 		jobType = thriftJobDef.GetJobType()
@@ -159,6 +207,9 @@ func makeDomainJobFromThriftJob(thriftJob *schedthrift.Job) *Job {
 		Requestor: requestor,
 		Tag:       tag,
 	}
+	if thriftJobDef != nil {
+		domainJobDef.RetryPolicy = retryPolicyFromThrift(thriftJobDef)
+	}
 
 	return &Job{
 		Id:  jobID,
@@ -184,7 +235,27 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 		taskId := domainTask.TaskID
 		execReq := bazelapi.MakeExecReqThriftFromDomain(domainTask.ExecuteRequest)
 
-		thriftTask := schedthrift.TaskDefinition{Command: &cmd, TaskId: &taskId, BazelRequest: execReq}
+		thriftTask := schedthrift.TaskDefinition{
+			Command:      &cmd,
+			TaskId:       &taskId,
+			BazelRequest: execReq,
+			DependsOn:    domainTask.DependsOn,
+		}
+		if domainTask.SnapshotFromTask != "" {
+			snapshotFrom := domainTask.SnapshotFromTask
+			merge := domainTask.MergeSnapshot
+			thriftTask.SnapshotFromTask = &snapshotFrom
+			thriftTask.MergeSnapshot = &merge
+		}
+		if resources := domainTask.Resources; !resources.IsZero() {
+			thriftTask.MemoryBytes = &resources.Memory
+			thriftTask.Cpus = &resources.CPUs
+			thriftTask.DiskBytes = &resources.Disk
+		}
+		if domainTask.AllowNetwork {
+			allowNetwork := true
+			thriftTask.AllowNetwork = &allowNetwork
+		}
 		thriftTasks = append(thriftTasks, &thriftTask)
 	}
 
@@ -197,6 +268,7 @@ func makeThriftJobFromDomainJob(domainJob *Job) (*schedthrift.Job, error) {
 		Basis:     &(domainJob).Def.Basis,
 		Requestor: &(domainJob).Def.Requestor,
 	}
+	retryPolicyToThrift(domainJob.Def.RetryPolicy, &thriftJobDefinition)
 
 	thriftJob := schedthrift.Job{
 		ID:            domainJob.Id,
@@ -211,6 +283,9 @@ func ValidateJob(job JobDefinition) error {
 	if len(job.Tasks) == 0 {
 		return fmt.Errorf("invalid job. Must have at least 1 task; was empty")
 	}
+	if err := validateRetryPolicy(job.RetryPolicy); err != nil {
+		return err
+	}
 	for _, task := range job.Tasks {
 		if task.TaskID == "" {
 			return fmt.Errorf("invalid task id \"\".")
@@ -218,6 +293,62 @@ func ValidateJob(job JobDefinition) error {
 		if len(task.Command.Argv) == 0 {
 			return fmt.Errorf("invalid task.Command.Argv. Must have at least one argument; was empty")
 		}
+		if r := task.Resources; r.Memory < 0 || r.CPUs < 0 || r.Disk < 0 {
+			return fmt.Errorf("invalid task %s. Requested resources must not be negative: %s", task.TaskID, r)
+		}
+	}
+	return validateTaskDependencies(job.Tasks)
+}
+
+// Verifies that every dependency refers to another task in the job and that the dependencies form a DAG.
+func validateTaskDependencies(tasks []TaskDefinition) error {
+	deps := map[string][]string{}
+	for _, task := range tasks {
+		deps[task.TaskID] = task.DependsOn
+	}
+	for _, task := range tasks {
+		for _, dep := range task.DependsOn {
+			if dep == task.TaskID {
+				return fmt.Errorf("invalid task %s. Cannot depend on itself", task.TaskID)
+			}
+			if _, ok := deps[dep]; !ok {
+				return fmt.Errorf("invalid task %s. Depends on unknown task %s", task.TaskID, dep)
+			}
+		}
+		if task.SnapshotFromTask != "" && !task.DependsOnTask(task.SnapshotFromTask) {
+			return fmt.Errorf("invalid task %s. Must depend on snapshotFromTask %s", task.TaskID, task.SnapshotFromTask)
+		}
+	}
+
+	// Depth first search, a task that's revisited while still on the stack indicates a cycle.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := map[string]int{}
+	var visit func(taskID string, path []string) error
+	visit = func(taskID string, path []string) error {
+		switch state[taskID] {
+		case visiting:
+			return fmt.Errorf("invalid job. Task dependencies contain a cycle: %s -> %s",
+				strings.Join(path, " -> "), taskID)
+		case visited:
+			return nil
+		}
+		state[taskID] = visiting
+		for _, dep := range deps[taskID] {
+			if err := visit(dep, append(path, taskID)); err != nil {
+				return err
+			}
+		}
+		state[taskID] = visited
+		return nil
+	}
+	for _, task := range tasks {
+		if err := visit(task.TaskID, nil); err != nil {
+			return err
+		}
 	}
 	return nil
 }
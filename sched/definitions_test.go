@@ -1,9 +1,12 @@
 package sched
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/twitter/scoot/common/thrifthelpers"
+	"github.com/twitter/scoot/runner"
 	schedthrift "github.com/twitter/scoot/sched/gen-go/sched"
 )
 
@@ -35,3 +38,113 @@ func Test_DeserializeJob_MinThrift(t *testing.T) {
 		t.Errorf("unexpected error converting to Scheduler Job %+v", err)
 	}
 }
+
+func Test_ValidateJob_Dependencies(t *testing.T) {
+	makeJob := func(deps map[string][]string) JobDefinition {
+		job := JobDefinition{}
+		for _, id := range []string{"a", "b", "c"} {
+			task := TaskDefinition{DependsOn: deps[id]}
+			task.TaskID = id
+			task.Argv = []string{"true"}
+			job.Tasks = append(job.Tasks, task)
+		}
+		return job
+	}
+
+	valid := map[string][]string{"b": {"a"}, "c": {"a", "b"}}
+	if err := ValidateJob(makeJob(valid)); err != nil {
+		t.Errorf("Expected valid dependencies, got %v", err)
+	}
+
+	invalid := []map[string][]string{
+		{"a": {"a"}},
+		{"a": {"d"}},
+		{"a": {"b"}, "b": {"a"}},
+		{"a": {"c"}, "b": {"a"}, "c": {"b"}},
+	}
+	for _, deps := range invalid {
+		if err := ValidateJob(makeJob(deps)); err == nil {
+			t.Errorf("Expected invalid dependencies %v to be rejected", deps)
+		}
+	}
+
+	job := makeJob(map[string][]string{"b": {"a"}})
+	job.Tasks[1].SnapshotFromTask = "a"
+	if err := ValidateJob(job); err != nil {
+		t.Errorf("Expected snapshotFromTask on a dependency to be valid, got %v", err)
+	}
+	job.Tasks[2].SnapshotFromTask = "a"
+	if err := ValidateJob(job); err == nil {
+		t.Errorf("Expected snapshotFromTask without a dependency to be rejected")
+	}
+}
+
+func Test_SerializeDeserializeJob_Resources(t *testing.T) {
+	job := GenJob("job1", 2)
+	job.Def.Tasks[0].Resources = runner.Resources{Memory: 1 << 30, CPUs: 0.5, Disk: 1 << 20}
+	job.Def.Tasks[1].AllowNetwork = true
+
+	binaryJob, err := job.Serialize()
+	if err != nil {
+		t.Fatalf("unexpected error serializing job %+v", err)
+	}
+	deserialized, err := DeserializeJob(binaryJob)
+	if err != nil {
+		t.Fatalf("unexpected error deserializing job %+v", err)
+	}
+	for i, task := range deserialized.Def.Tasks {
+		if task.Resources != job.Def.Tasks[i].Resources {
+			t.Errorf("Expected task %d resources %v, got %v", i, job.Def.Tasks[i].Resources, task.Resources)
+		}
+		if task.AllowNetwork != job.Def.Tasks[i].AllowNetwork {
+			t.Errorf("Expected task %d allowNetwork %t, got %t", i, job.Def.Tasks[i].AllowNetwork, task.AllowNetwork)
+		}
+	}
+
+	def := job.Def
+	def.Tasks[1].Resources = runner.Resources{Memory: -1}
+	if err := ValidateJob(def); err == nil {
+		t.Errorf("Expected negative resources to be rejected")
+	}
+}
+
+func Test_SerializeDeserializeJob_RetryPolicy(t *testing.T) {
+	job := GenJob("job1", 1)
+	binaryJob, _ := job.Serialize()
+	if deserialized, err := DeserializeJob(binaryJob); err != nil || deserialized.Def.RetryPolicy != nil {
+		t.Fatalf("Expected no retry policy, got %v, err: %v", deserialized.Def.RetryPolicy, err)
+	}
+
+	job.Def.RetryPolicy = &RetryPolicy{
+		MaxAttempts:      3,
+		RetryOnExitCodes: []int{75},
+		Backoff:          time.Second,
+		MaxBackoff:       3 * time.Second,
+	}
+	binaryJob, err := job.Serialize()
+	if err != nil {
+		t.Fatalf("unexpected error serializing job %+v", err)
+	}
+	deserialized, err := DeserializeJob(binaryJob)
+	if err != nil {
+		t.Fatalf("unexpected error deserializing job %+v", err)
+	}
+	if !reflect.DeepEqual(deserialized.Def.RetryPolicy, job.Def.RetryPolicy) {
+		t.Errorf("Expected retry policy %s, got %s", job.Def.RetryPolicy, deserialized.Def.RetryPolicy)
+	}
+
+	policy := *job.Def.RetryPolicy
+	if !policy.RetriesExitCode(75) || policy.RetriesExitCode(1) || policy.RetriesExitCode(0) {
+		t.Errorf("Expected only exit code 75 to be retried by %s", policy)
+	}
+	for attempt, expected := range []time.Duration{0, 0, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		if backoff := policy.BackoffBefore(attempt); backoff != expected {
+			t.Errorf("Expected backoff %s before attempt %d, got %s", expected, attempt, backoff)
+		}
+	}
+
+	job.Def.RetryPolicy.MaxAttempts = -1
+	if err := ValidateJob(job.Def); err == nil {
+		t.Errorf("Expected negative max attempts to be rejected")
+	}
+}
@@ -299,10 +299,24 @@ func (p *Command) String() string {
 //  - Command
 //  - TaskId
 //  - BazelRequest
+//  - DependsOn
+//  - SnapshotFromTask
+//  - MergeSnapshot
+//  - MemoryBytes
+//  - Cpus
+//  - DiskBytes
+//  - AllowNetwork
 type TaskDefinition struct {
-	Command      *Command              `thrift:"command,1,required" json:"command"`
-	TaskId       *string               `thrift:"taskId,2" json:"taskId,omitempty"`
-	BazelRequest *bazel.ExecuteRequest `thrift:"bazelRequest,3" json:"bazelRequest,omitempty"`
+	Command          *Command              `thrift:"command,1,required" json:"command"`
+	TaskId           *string               `thrift:"taskId,2" json:"taskId,omitempty"`
+	BazelRequest     *bazel.ExecuteRequest `thrift:"bazelRequest,3" json:"bazelRequest,omitempty"`
+	DependsOn        []string              `thrift:"dependsOn,4" json:"dependsOn,omitempty"`
+	SnapshotFromTask *string               `thrift:"snapshotFromTask,5" json:"snapshotFromTask,omitempty"`
+	MergeSnapshot    *bool                 `thrift:"mergeSnapshot,6" json:"mergeSnapshot,omitempty"`
+	MemoryBytes      *int64                `thrift:"memoryBytes,7" json:"memoryBytes,omitempty"`
+	Cpus             *float64              `thrift:"cpus,8" json:"cpus,omitempty"`
+	DiskBytes        *int64                `thrift:"diskBytes,9" json:"diskBytes,omitempty"`
+	AllowNetwork     *bool                 `thrift:"allowNetwork,10" json:"allowNetwork,omitempty"`
 }
 
 func NewTaskDefinition() *TaskDefinition {
@@ -335,6 +349,66 @@ func (p *TaskDefinition) GetBazelRequest() *bazel.ExecuteRequest {
 	}
 	return p.BazelRequest
 }
+
+var TaskDefinition_DependsOn_DEFAULT []string
+
+func (p *TaskDefinition) GetDependsOn() []string {
+	return p.DependsOn
+}
+
+var TaskDefinition_SnapshotFromTask_DEFAULT string
+
+func (p *TaskDefinition) GetSnapshotFromTask() string {
+	if !p.IsSetSnapshotFromTask() {
+		return TaskDefinition_SnapshotFromTask_DEFAULT
+	}
+	return *p.SnapshotFromTask
+}
+
+var TaskDefinition_MergeSnapshot_DEFAULT bool
+
+func (p *TaskDefinition) GetMergeSnapshot() bool {
+	if !p.IsSetMergeSnapshot() {
+		return TaskDefinition_MergeSnapshot_DEFAULT
+	}
+	return *p.MergeSnapshot
+}
+
+var TaskDefinition_MemoryBytes_DEFAULT int64
+
+func (p *TaskDefinition) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return TaskDefinition_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+
+var TaskDefinition_Cpus_DEFAULT float64
+
+func (p *TaskDefinition) GetCpus() float64 {
+	if !p.IsSetCpus() {
+		return TaskDefinition_Cpus_DEFAULT
+	}
+	return *p.Cpus
+}
+
+var TaskDefinition_DiskBytes_DEFAULT int64
+
+func (p *TaskDefinition) GetDiskBytes() int64 {
+	if !p.IsSetDiskBytes() {
+		return TaskDefinition_DiskBytes_DEFAULT
+	}
+	return *p.DiskBytes
+}
+
+var TaskDefinition_AllowNetwork_DEFAULT bool
+
+func (p *TaskDefinition) GetAllowNetwork() bool {
+	if !p.IsSetAllowNetwork() {
+		return TaskDefinition_AllowNetwork_DEFAULT
+	}
+	return *p.AllowNetwork
+}
 func (p *TaskDefinition) IsSetCommand() bool {
 	return p.Command != nil
 }
@@ -347,6 +421,34 @@ func (p *TaskDefinition) IsSetBazelRequest() bool {
 	return p.BazelRequest != nil
 }
 
+func (p *TaskDefinition) IsSetDependsOn() bool {
+	return p.DependsOn != nil
+}
+
+func (p *TaskDefinition) IsSetSnapshotFromTask() bool {
+	return p.SnapshotFromTask != nil
+}
+
+func (p *TaskDefinition) IsSetMergeSnapshot() bool {
+	return p.MergeSnapshot != nil
+}
+
+func (p *TaskDefinition) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *TaskDefinition) IsSetCpus() bool {
+	return p.Cpus != nil
+}
+
+func (p *TaskDefinition) IsSetDiskBytes() bool {
+	return p.DiskBytes != nil
+}
+
+func (p *TaskDefinition) IsSetAllowNetwork() bool {
+	return p.AllowNetwork != nil
+}
+
 func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -376,6 +478,34 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField3(iprot); err != nil {
 				return err
 			}
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -419,6 +549,82 @@ func (p *TaskDefinition) readField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.DependsOn = tSlice
+	for i := 0; i < size; i++ {
+		var _elem3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem3 = v
+		}
+		p.DependsOn = append(p.DependsOn, _elem3)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.SnapshotFromTask = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.MergeSnapshot = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.Cpus = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.DiskBytes = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.AllowNetwork = &v
+	}
+	return nil
+}
+
 func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -432,6 +638,27 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField3(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -484,6 +711,119 @@ func (p *TaskDefinition) writeField3(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDependsOn() {
+		if err := oprot.WriteFieldBegin("dependsOn", thrift.LIST, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:dependsOn: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.DependsOn)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.DependsOn {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:dependsOn: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotFromTask() {
+		if err := oprot.WriteFieldBegin("snapshotFromTask", thrift.STRING, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:snapshotFromTask: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotFromTask)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotFromTask (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:snapshotFromTask: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMergeSnapshot() {
+		if err := oprot.WriteFieldBegin("mergeSnapshot", thrift.BOOL, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:mergeSnapshot: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.MergeSnapshot)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.mergeSnapshot (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:mergeSnapshot: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpus() {
+		if err := oprot.WriteFieldBegin("cpus", thrift.DOUBLE, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:cpus: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.Cpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpus (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:cpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDiskBytes() {
+		if err := oprot.WriteFieldBegin("diskBytes", thrift.I64, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:diskBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.DiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.diskBytes (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:diskBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAllowNetwork() {
+		if err := oprot.WriteFieldBegin("allowNetwork", thrift.BOOL, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:allowNetwork: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.AllowNetwork)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.allowNetwork (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:allowNetwork: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *TaskDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -498,13 +838,27 @@ func (p *TaskDefinition) String() string {
 //  - Tag
 //  - Basis
 //  - Requestor
+//  - MaxTaskAttempts
+//  - RetryOnWorkerFailure
+//  - RetryOnTimeout
+//  - RetryOnNonZeroExit
+//  - RetryOnExitCodes
+//  - RetryBackoff
+//  - MaxRetryBackoff
 type JobDefinition struct {
-	JobType   *string           `thrift:"jobType,1" json:"jobType,omitempty"`
-	Tasks     []*TaskDefinition `thrift:"tasks,2" json:"tasks,omitempty"`
-	Priority  *int32            `thrift:"priority,3" json:"priority,omitempty"`
-	Tag       *string           `thrift:"tag,4" json:"tag,omitempty"`
-	Basis     *string           `thrift:"basis,5" json:"basis,omitempty"`
-	Requestor *string           `thrift:"requestor,6" json:"requestor,omitempty"`
+	JobType              *string           `thrift:"jobType,1" json:"jobType,omitempty"`
+	Tasks                []*TaskDefinition `thrift:"tasks,2" json:"tasks,omitempty"`
+	Priority             *int32            `thrift:"priority,3" json:"priority,omitempty"`
+	Tag                  *string           `thrift:"tag,4" json:"tag,omitempty"`
+	Basis                *string           `thrift:"basis,5" json:"basis,omitempty"`
+	Requestor            *string           `thrift:"requestor,6" json:"requestor,omitempty"`
+	MaxTaskAttempts      *int32            `thrift:"maxTaskAttempts,7" json:"maxTaskAttempts,omitempty"`
+	RetryOnWorkerFailure *bool             `thrift:"retryOnWorkerFailure,8" json:"retryOnWorkerFailure,omitempty"`
+	RetryOnTimeout       *bool             `thrift:"retryOnTimeout,9" json:"retryOnTimeout,omitempty"`
+	RetryOnNonZeroExit   *bool             `thrift:"retryOnNonZeroExit,10" json:"retryOnNonZeroExit,omitempty"`
+	RetryOnExitCodes     []int32           `thrift:"retryOnExitCodes,11" json:"retryOnExitCodes,omitempty"`
+	RetryBackoff         *int64            `thrift:"retryBackoff,12" json:"retryBackoff,omitempty"`
+	MaxRetryBackoff      *int64            `thrift:"maxRetryBackoff,13" json:"maxRetryBackoff,omitempty"`
 }
 
 func NewJobDefinition() *JobDefinition {
@@ -561,6 +915,66 @@ func (p *JobDefinition) GetRequestor() string {
 	}
 	return *p.Requestor
 }
+
+var JobDefinition_MaxTaskAttempts_DEFAULT int32
+
+func (p *JobDefinition) GetMaxTaskAttempts() int32 {
+	if !p.IsSetMaxTaskAttempts() {
+		return JobDefinition_MaxTaskAttempts_DEFAULT
+	}
+	return *p.MaxTaskAttempts
+}
+
+var JobDefinition_RetryOnWorkerFailure_DEFAULT bool
+
+func (p *JobDefinition) GetRetryOnWorkerFailure() bool {
+	if !p.IsSetRetryOnWorkerFailure() {
+		return JobDefinition_RetryOnWorkerFailure_DEFAULT
+	}
+	return *p.RetryOnWorkerFailure
+}
+
+var JobDefinition_RetryOnTimeout_DEFAULT bool
+
+func (p *JobDefinition) GetRetryOnTimeout() bool {
+	if !p.IsSetRetryOnTimeout() {
+		return JobDefinition_RetryOnTimeout_DEFAULT
+	}
+	return *p.RetryOnTimeout
+}
+
+var JobDefinition_RetryOnNonZeroExit_DEFAULT bool
+
+func (p *JobDefinition) GetRetryOnNonZeroExit() bool {
+	if !p.IsSetRetryOnNonZeroExit() {
+		return JobDefinition_RetryOnNonZeroExit_DEFAULT
+	}
+	return *p.RetryOnNonZeroExit
+}
+
+var JobDefinition_RetryOnExitCodes_DEFAULT []int32
+
+func (p *JobDefinition) GetRetryOnExitCodes() []int32 {
+	return p.RetryOnExitCodes
+}
+
+var JobDefinition_RetryBackoff_DEFAULT int64
+
+func (p *JobDefinition) GetRetryBackoff() int64 {
+	if !p.IsSetRetryBackoff() {
+		return JobDefinition_RetryBackoff_DEFAULT
+	}
+	return *p.RetryBackoff
+}
+
+var JobDefinition_MaxRetryBackoff_DEFAULT int64
+
+func (p *JobDefinition) GetMaxRetryBackoff() int64 {
+	if !p.IsSetMaxRetryBackoff() {
+		return JobDefinition_MaxRetryBackoff_DEFAULT
+	}
+	return *p.MaxRetryBackoff
+}
 func (p *JobDefinition) IsSetJobType() bool {
 	return p.JobType != nil
 }
@@ -585,6 +999,34 @@ func (p *JobDefinition) IsSetRequestor() bool {
 	return p.Requestor != nil
 }
 
+func (p *JobDefinition) IsSetMaxTaskAttempts() bool {
+	return p.MaxTaskAttempts != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnWorkerFailure() bool {
+	return p.RetryOnWorkerFailure != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnTimeout() bool {
+	return p.RetryOnTimeout != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnNonZeroExit() bool {
+	return p.RetryOnNonZeroExit != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnExitCodes() bool {
+	return p.RetryOnExitCodes != nil
+}
+
+func (p *JobDefinition) IsSetRetryBackoff() bool {
+	return p.RetryBackoff != nil
+}
+
+func (p *JobDefinition) IsSetMaxRetryBackoff() bool {
+	return p.MaxRetryBackoff != nil
+}
+
 func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -623,6 +1065,34 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField6(iprot); err != nil {
 				return err
 			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+		case 11:
+			if err := p.readField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.readField13(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -655,11 +1125,11 @@ func (p *JobDefinition) readField2(iprot thrift.TProtocol) error {
 	tSlice := make([]*TaskDefinition, 0, size)
 	p.Tasks = tSlice
 	for i := 0; i < size; i++ {
-		_elem3 := &TaskDefinition{}
-		if err := _elem3.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem3), err)
+		_elem4 := &TaskDefinition{}
+		if err := _elem4.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem4), err)
 		}
-		p.Tasks = append(p.Tasks, _elem3)
+		p.Tasks = append(p.Tasks, _elem4)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -703,6 +1173,82 @@ func (p *JobDefinition) readField6(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *JobDefinition) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.MaxTaskAttempts = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.RetryOnWorkerFailure = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.RetryOnTimeout = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.RetryOnNonZeroExit = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField11(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]int32, 0, size)
+	p.RetryOnExitCodes = tSlice
+	for i := 0; i < size; i++ {
+		var _elem int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem = v
+		}
+		p.RetryOnExitCodes = append(p.RetryOnExitCodes, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.RetryBackoff = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField13(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 13: ", err)
+	} else {
+		p.MaxRetryBackoff = &v
+	}
+	return nil
+}
+
 func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -725,6 +1271,27 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField6(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField13(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -832,6 +1399,119 @@ func (p *JobDefinition) writeField6(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *JobDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxTaskAttempts() {
+		if err := oprot.WriteFieldBegin("maxTaskAttempts", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:maxTaskAttempts: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.MaxTaskAttempts)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxTaskAttempts (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:maxTaskAttempts: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnWorkerFailure() {
+		if err := oprot.WriteFieldBegin("retryOnWorkerFailure", thrift.BOOL, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:retryOnWorkerFailure: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.RetryOnWorkerFailure)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryOnWorkerFailure (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:retryOnWorkerFailure: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnTimeout() {
+		if err := oprot.WriteFieldBegin("retryOnTimeout", thrift.BOOL, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:retryOnTimeout: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.RetryOnTimeout)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryOnTimeout (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:retryOnTimeout: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnNonZeroExit() {
+		if err := oprot.WriteFieldBegin("retryOnNonZeroExit", thrift.BOOL, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:retryOnNonZeroExit: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.RetryOnNonZeroExit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryOnNonZeroExit (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:retryOnNonZeroExit: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnExitCodes() {
+		if err := oprot.WriteFieldBegin("retryOnExitCodes", thrift.LIST, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:retryOnExitCodes: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(p.RetryOnExitCodes)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.RetryOnExitCodes {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:retryOnExitCodes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryBackoff() {
+		if err := oprot.WriteFieldBegin("retryBackoff", thrift.I64, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:retryBackoff: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.RetryBackoff)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryBackoff (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:retryBackoff: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxRetryBackoff() {
+		if err := oprot.WriteFieldBegin("maxRetryBackoff", thrift.I64, 13); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:maxRetryBackoff: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MaxRetryBackoff)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxRetryBackoff (13) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 13:maxRetryBackoff: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *JobDefinition) String() string {
 	if p == nil {
 		return "<nil>"
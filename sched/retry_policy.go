@@ -0,0 +1,119 @@
+package sched
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	schedthrift "github.com/twitter/scoot/sched/gen-go/sched"
+)
+
+// RetryPolicy determines which unsuccessful attempts to run a task are retried, how many times, and how soon.
+// Attempts aborted because their job was killed are never retried.
+type RetryPolicy struct {
+	// Total number of times a task may be run, including the first attempt. Zero uses the scheduler's default.
+	MaxAttempts int
+	// Retry when the worker fails to run the task or can't be reached.
+	RetryOnWorkerFailure bool
+	// Retry when the task exceeds its timeout.
+	RetryOnTimeout bool
+	// Retry when the task's command exits with any non-zero code.
+	// Only jobs that retry non-zero exits record them as flaky runs when a retry passes, see scheduler.IsFlakyRun.
+	RetryOnNonZeroExit bool
+	// Retry when the task's command exits with one of these codes.
+	RetryOnExitCodes []int
+	// Wait this long before the first retry, doubling for each subsequent retry up to MaxBackoff if it's non-zero.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy retries the failures that are likely transient, those of workers and timeouts, without backoff.
+// It's used for jobs that don't specify a policy and as the base for the fields a job leaves unset.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{RetryOnWorkerFailure: true, RetryOnTimeout: true}
+}
+
+// RetriesExitCode returns true if an attempt whose command exited with code should be retried.
+func (p RetryPolicy) RetriesExitCode(code int) bool {
+	if code == 0 {
+		return false
+	}
+	if p.RetryOnNonZeroExit {
+		return true
+	}
+	for _, c := range p.RetryOnExitCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// BackoffBefore returns how long to wait before starting the given attempt, the first attempt being 1.
+func (p RetryPolicy) BackoffBefore(attempt int) time.Duration {
+	if attempt <= 1 || p.Backoff <= 0 {
+		return 0
+	}
+	backoff := p.Backoff
+	for i := 2; i < attempt && backoff < math.MaxInt64/2; i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+func (p RetryPolicy) String() string {
+	return fmt.Sprintf("RetryPolicy{MaxAttempts: %d, WorkerFailure: %t, Timeout: %t, NonZeroExit: %t, ExitCodes: %v, Backoff: %s, MaxBackoff: %s}",
+		p.MaxAttempts, p.RetryOnWorkerFailure, p.RetryOnTimeout, p.RetryOnNonZeroExit, p.RetryOnExitCodes, p.Backoff, p.MaxBackoff)
+}
+
+func validateRetryPolicy(p *RetryPolicy) error {
+	if p == nil {
+		return nil
+	}
+	if p.MaxAttempts < 0 || p.Backoff < 0 || p.MaxBackoff < 0 {
+		return fmt.Errorf("invalid retry policy. Attempts and backoff must not be negative: %s", p)
+	}
+	return nil
+}
+
+// Sets the retry policy fields of the thrift job definition, which are all set if the job has a policy.
+func retryPolicyToThrift(p *RetryPolicy, def *schedthrift.JobDefinition) {
+	if p == nil {
+		return
+	}
+	maxAttempts := int32(p.MaxAttempts)
+	workerFailure, timeout, nonZeroExit := p.RetryOnWorkerFailure, p.RetryOnTimeout, p.RetryOnNonZeroExit
+	backoff, maxBackoff := int64(p.Backoff), int64(p.MaxBackoff)
+	def.MaxTaskAttempts = &maxAttempts
+	def.RetryOnWorkerFailure = &workerFailure
+	def.RetryOnTimeout = &timeout
+	def.RetryOnNonZeroExit = &nonZeroExit
+	def.RetryOnExitCodes = make([]int32, 0, len(p.RetryOnExitCodes))
+	for _, c := range p.RetryOnExitCodes {
+		def.RetryOnExitCodes = append(def.RetryOnExitCodes, int32(c))
+	}
+	def.RetryBackoff = &backoff
+	def.MaxRetryBackoff = &maxBackoff
+}
+
+// Returns the retry policy of the thrift job definition, or nil if it doesn't have one.
+func retryPolicyFromThrift(def *schedthrift.JobDefinition) *RetryPolicy {
+	if !def.IsSetMaxTaskAttempts() {
+		return nil
+	}
+	p := &RetryPolicy{
+		MaxAttempts:          int(def.GetMaxTaskAttempts()),
+		RetryOnWorkerFailure: def.GetRetryOnWorkerFailure(),
+		RetryOnTimeout:       def.GetRetryOnTimeout(),
+		RetryOnNonZeroExit:   def.GetRetryOnNonZeroExit(),
+		Backoff:              time.Duration(def.GetRetryBackoff()),
+		MaxBackoff:           time.Duration(def.GetMaxRetryBackoff()),
+	}
+	for _, c := range def.GetRetryOnExitCodes() {
+		p.RetryOnExitCodes = append(p.RetryOnExitCodes, int(c))
+	}
+	return p
+}
@@ -20,6 +20,13 @@ struct TaskDefinition {
   1: required Command command
   2: optional string taskId
   3: optional bazel.ExecuteRequest bazelRequest
+  4: optional list<string> dependsOn
+  5: optional string snapshotFromTask
+  6: optional bool mergeSnapshot
+  7: optional i64 memoryBytes
+  8: optional double cpus
+  9: optional i64 diskBytes
+  10: optional bool allowNetwork
 }
 
 struct JobDefinition {
@@ -29,6 +36,14 @@ struct JobDefinition {
   4: optional string tag
   5: optional string basis
   6: optional string requestor
+  7: optional i32 maxTaskAttempts
+  8: optional bool retryOnWorkerFailure
+  9: optional bool retryOnTimeout
+  10: optional bool retryOnNonZeroExit
+  11: optional list<i32> retryOnExitCodes
+  # Nanoseconds
+  12: optional i64 retryBackoff
+  13: optional i64 maxRetryBackoff
 }
 
 struct Job {
@@ -9,17 +9,23 @@ import (
 
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 )
 
-const noJob = ""
-const noTask = ""
 const defaultMaxLostDuration = time.Minute
 const defaultMaxFlakyDuration = 15 * time.Minute
 
 var nilTime = time.Time{}
 
-// Cluster will use this function to determine if newly added nodes are ready to be used.
-type ReadyFn func(cluster.Node) (ready bool, backoffDuration time.Duration)
+// Cluster will use this function to determine if newly added nodes are ready to be used,
+// and what a ready node offers to tasks.
+type ReadyFn func(cluster.Node) (ready bool, capacity NodeCapacity, backoffDuration time.Duration)
+
+// The number of tasks a node runs concurrently and the resources shared by those tasks.
+type NodeCapacity struct {
+	Slots     int              // Zero is treated as a single slot.
+	Resources runner.Resources // Zero values mean unlimited.
+}
 
 // clusterState maintains a cluster of nodes and information about what task is running on each node.
 // nodeGroups is for node affinity where we want to remember which node last ran with what snapshot.
@@ -33,10 +39,11 @@ type clusterState struct {
 	maxLostDuration  time.Duration                 // after which we remove a node from the cluster entirely
 	maxFlakyDuration time.Duration                 // after which we mark it not flaky and put it back in rotation.
 	readyFn          ReadyFn                       // If provided, new nodes will be suspended until this returns true.
-	numRunning       int                           // Number of running nodes. running + free + suspended ~= allNodes (may lag)
+	numRunning       int                           // Number of running tasks. running + free ~= slots of allNodes (may lag)
 	stats            stats.StatsReceiver           // for collecting stats about node availability
 }
 
+// Nodes with at least one free slot are idle, nodes with all slots in use are busy.
 type nodeGroup struct {
 	idle map[cluster.NodeId]*nodeState
 	busy map[cluster.NodeId]*nodeState
@@ -46,21 +53,51 @@ func newNodeGroup() *nodeGroup {
 	return &nodeGroup{idle: map[cluster.NodeId]*nodeState{}, busy: map[cluster.NodeId]*nodeState{}}
 }
 
+// Identifies a task running on a node
+type runningTask struct {
+	jobId  string
+	taskId string
+}
+
+func (t runningTask) String() string {
+	return t.jobId + "/" + t.taskId
+}
+
 // The State of A Node in the Cluster
 type nodeState struct {
-	node        cluster.Node
-	runningJob  string
-	runningTask string
-	snapshotId  string
-	timeLost    time.Time        // Time when node was marked lost, if set (lost and flaky are mutually exclusive).
-	timeFlaky   time.Time        // Time when node was marked flaky, if set (lost and flaky are mutually exclusive).
-	readyCh     chan interface{} // We create goroutines for each new node which will close this channel once the node is ready.
-	removedCh   chan interface{} // We send nil when a node has been removed and we want the above goroutine to exit.
+	node       cluster.Node
+	running    map[runningTask]runner.Resources // Tasks running on this node and the resources each requested.
+	reserved   runner.Resources                 // Sum of the resources requested by running tasks.
+	snapshotId string                           // Snapshot of the most recently scheduled task.
+	timeLost   time.Time                        // Time when node was marked lost, if set (lost and flaky are mutually exclusive).
+	timeFlaky  time.Time                        // Time when node was marked flaky, if set (lost and flaky are mutually exclusive).
+	readyCh    chan interface{}                 // We create goroutines for each new node which will close this channel once the node is ready.
+	removedCh  chan interface{}                 // We send nil when a node has been removed and we want the above goroutine to exit.
+	capacity   NodeCapacity                     // As reported by ReadyFn, set before readyCh is closed.
 }
 
 func (n *nodeState) String() string {
-	return fmt.Sprintf("{node:%s, jobId:%s, taskId:%s, snapshotId:%s, timeLost:%v, timeFlaky:%v, ready:%t}",
-		spew.Sdump(n.node), n.runningJob, n.runningTask, n.snapshotId, n.timeLost, n.timeFlaky, (n.readyCh == nil))
+	return fmt.Sprintf("{node:%s, running:%v, snapshotId:%s, timeLost:%v, timeFlaky:%v, ready:%t}",
+		spew.Sdump(n.node), n.runningTasks(), n.snapshotId, n.timeLost, n.timeFlaky, (n.readyCh == nil))
+}
+
+// Returns the jobId/taskId of each task running on this node.
+func (ns *nodeState) runningTasks() []string {
+	tasks := []string{}
+	for t := range ns.running {
+		tasks = append(tasks, t.String())
+	}
+	return tasks
+}
+
+// Number of tasks this node can run concurrently.
+func (ns *nodeState) numSlots() int {
+	return max(1, ns.capacity.Slots)
+}
+
+// Number of slots not occupied by a running task.
+func (ns *nodeState) freeSlots() int {
+	return max(0, ns.numSlots()-len(ns.running))
 }
 
 // This node was either reported lost by a NodeUpdate and we keep it around for a bit in case it revives,
@@ -83,13 +120,27 @@ func (ns *nodeState) ready() bool {
 	return ready
 }
 
+// Returns true if this node has a free slot and enough unreserved capacity for a task requesting the given resources,
+// taking into account any pending tasks that have been assigned to this node but aren't running yet.
+func (ns *nodeState) fits(resources runner.Resources, pending []runner.Resources) bool {
+	if ns.freeSlots() <= len(pending) {
+		return false
+	}
+	reserved := ns.reserved.Add(resources)
+	for _, r := range pending {
+		reserved = reserved.Add(r)
+	}
+	return reserved.FitsIn(ns.capacity.Resources)
+}
+
 // Starts a goroutine loop checking node readiness, waiting 'backoff' time between checks, and exiting if node is fully removed.
 func (ns *nodeState) startReadyLoop(rfn ReadyFn) {
 	ns.readyCh = make(chan interface{})
 	go func() {
 		done := false
 		for !done {
-			if ready, backoff := rfn(ns.node); ready {
+			if ready, capacity, backoff := rfn(ns.node); ready {
+				ns.capacity = capacity
 				close(ns.readyCh)
 				done = true
 			} else if backoff == 0 {
@@ -109,14 +160,13 @@ func (ns *nodeState) startReadyLoop(rfn ReadyFn) {
 // Initializes a Node State for the specified Node
 func newNodeState(node cluster.Node) *nodeState {
 	return &nodeState{
-		node:        node,
-		runningJob:  noJob,
-		runningTask: noTask,
-		snapshotId:  "",
-		timeLost:    nilTime,
-		timeFlaky:   nilTime,
-		readyCh:     nil,
-		removedCh:   make(chan interface{}),
+		node:       node,
+		running:    map[runningTask]runner.Resources{},
+		snapshotId: "",
+		timeLost:   nilTime,
+		timeFlaky:  nilTime,
+		readyCh:    nil,
+		removedCh:  make(chan interface{}),
 	}
 }
 
@@ -143,38 +193,78 @@ func newClusterState(initial []cluster.Node, updateCh chan []cluster.NodeUpdate,
 	return cs
 }
 
-// Number of free nodes that are not in a suspended state.
+// Number of free slots on nodes that are not in a suspended state.
 func (c *clusterState) numFree() int {
+	slots := 0
+	for _, ns := range c.nodes {
+		slots += ns.numSlots()
+	}
 	// This can go negative due to lost nodes, set lower bound at zero.
-	return max(0, len(c.nodes)-c.numRunning)
+	return max(0, slots-c.numRunning)
+}
+
+// The largest capacity offered by any known node that has reported it, including suspended and offlined nodes
+// which may come back. A resource is zero (unlimited) if any such node doesn't limit it.
+// Returns false if no node has reported its capacity yet.
+func (c *clusterState) maxCapacity() (runner.Resources, bool) {
+	capacity := runner.Resources{}
+	found := false
+	for _, nodes := range []map[cluster.NodeId]*nodeState{c.nodes, c.suspendedNodes, c.offlinedNodes} {
+		for _, ns := range nodes {
+			if !ns.ready() {
+				continue
+			}
+			r := ns.capacity.Resources
+			if !found {
+				capacity, found = r, true
+				continue
+			}
+			if r.Memory == 0 || (capacity.Memory != 0 && r.Memory > capacity.Memory) {
+				capacity.Memory = r.Memory
+			}
+			if r.CPUs == 0 || (capacity.CPUs != 0 && r.CPUs > capacity.CPUs) {
+				capacity.CPUs = r.CPUs
+			}
+			if r.Disk == 0 || (capacity.Disk != 0 && r.Disk > capacity.Disk) {
+				capacity.Disk = r.Disk
+			}
+		}
+	}
+	return capacity, found
 }
 
 // Update ClusterState to reflect that a task has been scheduled on a particular node
-// SnapshotId should be the value from the task definition associated with the given taskId.
-func (c *clusterState) taskScheduled(nodeId cluster.NodeId, jobId, taskId, snapshotId string) {
+// SnapshotId and resources should be the values from the task definition associated with the given taskId.
+func (c *clusterState) taskScheduled(nodeId cluster.NodeId, jobId, taskId, snapshotId string, resources runner.Resources) {
 	ns := c.nodes[nodeId]
 
-	delete(c.nodeGroups[ns.snapshotId].idle, nodeId)
-	empty := len(c.nodeGroups[ns.snapshotId].idle) == 0 && len(c.nodeGroups[ns.snapshotId].busy) == 0
-	if ns.snapshotId != "" && empty {
-		delete(c.nodeGroups, ns.snapshotId)
+	if group, ok := c.nodeGroups[ns.snapshotId]; ok {
+		delete(group.idle, nodeId)
+		delete(group.busy, nodeId)
+		if ns.snapshotId != "" && len(group.idle) == 0 && len(group.busy) == 0 {
+			delete(c.nodeGroups, ns.snapshotId)
+		}
 	}
 
+	ns.running[runningTask{jobId, taskId}] = resources
+	ns.reserved = ns.reserved.Add(resources)
+	ns.snapshotId = snapshotId
+	c.numRunning++
+
 	if _, ok := c.nodeGroups[snapshotId]; !ok {
 		c.nodeGroups[snapshotId] = newNodeGroup()
 	}
-	c.nodeGroups[snapshotId].busy[nodeId] = ns
-
-	ns.runningJob = jobId
-	ns.runningTask = taskId
-	ns.snapshotId = snapshotId
-	c.numRunning++
+	if ns.freeSlots() > 0 {
+		c.nodeGroups[snapshotId].idle[nodeId] = ns
+	} else {
+		c.nodeGroups[snapshotId].busy[nodeId] = ns
+	}
 }
 
 // Update ClusterState to reflect that a task has finished running on
-// a particular node, whether successfully or unsuccessfully.
+// a particular node, whether successfully or unsuccessfully, freeing its slot.
 // If the node isn't found then the node was already suspended and deleted, just decrement numRunning.
-func (c *clusterState) taskCompleted(nodeId cluster.NodeId, flaky bool) {
+func (c *clusterState) taskCompleted(nodeId cluster.NodeId, jobId, taskId string, flaky bool) {
 	var ns *nodeState
 	var ok bool
 	if ns, ok = c.nodes[nodeId]; !ok {
@@ -187,8 +277,14 @@ func (c *clusterState) taskCompleted(nodeId cluster.NodeId, flaky bool) {
 			c.suspendedNodes[nodeId] = ns
 			ns.timeFlaky = time.Now()
 		}
-		ns.runningJob = noJob
-		ns.runningTask = noTask
+		task := runningTask{jobId, taskId}
+		if resources, ok := ns.running[task]; ok {
+			delete(ns.running, task)
+			ns.reserved = ns.reserved.Sub(resources)
+		}
+		if _, ok := c.nodeGroups[ns.snapshotId]; !ok {
+			c.nodeGroups[ns.snapshotId] = newNodeGroup()
+		}
 		delete(c.nodeGroups[ns.snapshotId].busy, nodeId)
 		c.nodeGroups[ns.snapshotId].idle[nodeId] = ns
 	} else {
@@ -197,6 +293,28 @@ func (c *clusterState) taskCompleted(nodeId cluster.NodeId, flaky bool) {
 	c.numRunning--
 }
 
+// Returns an idle node with room for a task requesting the given resources, other than the excluded node,
+// preferring nodes whose most recent snapshot is snapshotId. Returns nil if there's none.
+func (c *clusterState) idleNode(resources runner.Resources, snapshotId string, exclude cluster.NodeId) *nodeState {
+	groups := []*nodeGroup{c.nodeGroups[snapshotId]}
+	for id, group := range c.nodeGroups {
+		if id != snapshotId {
+			groups = append(groups, group)
+		}
+	}
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for nodeId, ns := range group.idle {
+			if _, ok := c.nodes[nodeId]; ok && nodeId != exclude && !ns.suspended() && ns.fits(resources, nil) {
+				return ns
+			}
+		}
+	}
+	return nil
+}
+
 func (c *clusterState) getNodeState(nodeId cluster.NodeId) (*nodeState, bool) {
 	ns, ok := c.nodes[nodeId]
 	return ns, ok
@@ -8,6 +8,7 @@ import (
 	"github.com/davecgh/go-spew/spew"
 	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 )
 
 // ensures nodes can be added and removed
@@ -29,7 +30,7 @@ func Test_ClusterState_UpdateCluster(t *testing.T) {
 	}
 
 	ns, _ := cs.getNodeState(cluster.NodeId("node1"))
-	if ns.runningTask != noTask {
+	if len(ns.running) != 0 {
 		t.Errorf("expected newly added node to have no tasks")
 	}
 
@@ -59,7 +60,7 @@ func Test_ClusterState_RemoveNotTrackedNode(t *testing.T) {
 func Test_ClusterState_DuplicateNodeAdd(t *testing.T) {
 	cs, cl, _ := setupTestCluster(nil, "node1")
 
-	cs.taskScheduled("node1", "job1", "task1", "")
+	cs.taskScheduled("node1", "job1", "task1", "", runner.Resources{})
 
 	// readd node to cluster
 	cl.add("node1")
@@ -72,8 +73,8 @@ func Test_ClusterState_DuplicateNodeAdd(t *testing.T) {
 
 	ns, _ := cs.getNodeState("node1")
 	// verify that the state wasn't modified
-	if ns.runningTask != "task1" {
-		t.Errorf("Expected adding an already tracked node to not modify state %v", cs.nodes[cluster.NodeId("node1")].runningTask)
+	if _, ok := ns.running[runningTask{"job1", "task1"}]; !ok {
+		t.Errorf("Expected adding an already tracked node to not modify state %v", cs.nodes[cluster.NodeId("node1")].runningTasks())
 	}
 }
 
@@ -197,10 +198,10 @@ func Test_ClusterState_ReinstateNodeAlreadyReinstated(t *testing.T) {
 func Test_ClusterState_TaskStarted(t *testing.T) {
 	cs, _, _ := setupTestCluster(nil, "node1")
 
-	cs.taskScheduled("node1", "job1", "task1", "")
+	cs.taskScheduled("node1", "job1", "task1", "", runner.Resources{})
 	ns, _ := cs.getNodeState("node1")
 
-	if ns.runningTask != "task1" {
+	if _, ok := ns.running[runningTask{"job1", "task1"}]; !ok {
 		t.Errorf("Expected Node1 to be running task1")
 	}
 }
@@ -208,14 +209,53 @@ func Test_ClusterState_TaskStarted(t *testing.T) {
 func Test_ClusterState_TaskCompleted(t *testing.T) {
 	cs, _, _ := setupTestCluster(nil, "node1")
 
-	cs.taskScheduled("node1", "job1", "task1", "")
+	cs.taskScheduled("node1", "job1", "task1", "", runner.Resources{})
 	ns, _ := cs.getNodeState("node1")
 
-	cs.taskCompleted("node1", false)
-	if ns.runningTask != noTask {
-		t.Errorf("Expected Node1 to be running task1")
+	cs.taskCompleted("node1", "job1", "task1", false)
+	if len(ns.running) != 0 {
+		t.Errorf("Expected Node1 to not be running any tasks")
+	}
+
+}
+
+// verify that a node with several slots stays idle until all of its slots are in use.
+func Test_ClusterState_Slots(t *testing.T) {
+	readyFn := func(node cluster.Node) (bool, NodeCapacity, time.Duration) {
+		return true, NodeCapacity{Slots: 2, Resources: runner.Resources{Memory: 4 << 30}}, time.Duration(0)
+	}
+	cs, _, _ := setupTestCluster(readyFn, "node1")
+	// Sleeping so nodeState goroutines can pick up readiness changes.
+	time.Sleep(10 * time.Millisecond)
+	cs.updateCluster()
+	ns, ok := cs.getNodeState("node1")
+	if !ok || cs.numFree() != 2 {
+		t.Fatalf("Expected node1 with 2 free slots, got: %d", cs.numFree())
 	}
 
+	cs.taskScheduled("node1", "job1", "task1", "snapA", runner.Resources{Memory: 1 << 30})
+	if _, ok := cs.nodeGroups["snapA"].idle["node1"]; !ok || cs.numFree() != 1 {
+		t.Fatalf("Expected node1 to be idle with 1 free slot, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+	if !ns.fits(runner.Resources{Memory: 3 << 30}, nil) || ns.fits(runner.Resources{Memory: 4 << 30}, nil) {
+		t.Fatalf("Expected node1 to have 3GB unreserved, reserved: %v", ns.reserved)
+	}
+
+	cs.taskScheduled("node1", "job1", "task2", "snapA", runner.Resources{Memory: 1 << 30})
+	if _, ok := cs.nodeGroups["snapA"].busy["node1"]; !ok || cs.numFree() != 0 {
+		t.Fatalf("Expected node1 to be busy with no free slots, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+	if ns.fits(runner.Resources{}, nil) {
+		t.Fatalf("Expected node1 to have no room for another task")
+	}
+
+	cs.taskCompleted("node1", "job1", "task1", false)
+	if _, ok := cs.nodeGroups["snapA"].idle["node1"]; !ok || cs.numFree() != 1 {
+		t.Fatalf("Expected node1 to be idle with 1 free slot, got: %s", spew.Sdump(cs.nodeGroups))
+	}
+	if _, ok := ns.running[runningTask{"job1", "task2"}]; !ok || len(ns.running) != 1 || ns.reserved.Memory != 1<<30 {
+		t.Fatalf("Expected node1 to only be running task2, got: %v, reserved: %v", ns.runningTasks(), ns.reserved)
+	}
 }
 
 // verify that idle and busy maps are populated correctly and that flaky/lost/init'd status are as well.
@@ -224,12 +264,12 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 		"node1": make(chan interface{}), "node2": make(chan interface{}),
 		"node3": make(chan interface{}), "node4": make(chan interface{}),
 	}
-	readyFn := func(node cluster.Node) (bool, time.Duration) {
+	readyFn := func(node cluster.Node) (bool, NodeCapacity, time.Duration) {
 		select {
 		case <-ready[string(node.Id())]:
-			return true, time.Duration(0)
+			return true, NodeCapacity{}, time.Duration(0)
 		default:
-			return false, time.Millisecond
+			return false, NodeCapacity{}, time.Millisecond
 		}
 	}
 	setReady := func(node string) {
@@ -315,9 +355,9 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 		t.Fatal("stats check did not pass.")
 	}
 	// Test the the right idle/busy maps are filled out for each snapshotId.
-	cs.taskScheduled("node1", "job1", "task1", "snapA")
-	cs.taskScheduled("node2", "job1", "task2", "snapA")
-	cs.taskScheduled("node3", "job1", "task3", "snapB")
+	cs.taskScheduled("node1", "job1", "task1", "snapA", runner.Resources{})
+	cs.taskScheduled("node2", "job1", "task2", "snapA", runner.Resources{})
+	cs.taskScheduled("node3", "job1", "task3", "snapB", runner.Resources{})
 	expectedGroups := map[string]*nodeGroup{
 		"": &nodeGroup{
 			idle: map[cluster.NodeId]*nodeState{
@@ -344,7 +384,7 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 	}
 
 	// Test that finishing a jobs moves it to the idle list for its snapshotId.
-	cs.taskCompleted("node1", false)
+	cs.taskCompleted("node1", "job1", "task1", false)
 	expectedGroups["snapA"].idle["node1"] = cs.nodes["node1"]
 	delete(expectedGroups["snapA"].busy, "node1")
 	if !reflect.DeepEqual(cs.nodeGroups, expectedGroups) {
@@ -352,7 +392,7 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 	}
 
 	// Test the rescheduling a task moves it correctly from an idle list to a busy one.
-	cs.taskScheduled("node1", "job1", "task1", "snapB")
+	cs.taskScheduled("node1", "job1", "task1", "snapB", runner.Resources{})
 	expectedGroups["snapB"].busy["node1"] = cs.nodes["node1"]
 	delete(expectedGroups["snapA"].idle, "node1")
 	if !reflect.DeepEqual(cs.nodeGroups, expectedGroups) {
@@ -360,7 +400,7 @@ func Test_ClusterState_NodeGroups(t *testing.T) {
 	}
 
 	// Task finished and is marked as flaky
-	cs.taskCompleted("node1", true)
+	cs.taskCompleted("node1", "job1", "task1", true)
 	if _, ok := cs.nodes["node1"]; ok {
 		t.Fatalf("Flaky node was not moved out of cs.nodes")
 	} else if _, ok := cs.suspendedNodes["node1"]; !ok {
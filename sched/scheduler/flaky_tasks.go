@@ -0,0 +1,210 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/workerapi"
+)
+
+// Weight of the latest run in a task's rolling flakiness score, the rest is the score of previous runs.
+const flakyScoreWeight = 0.2
+
+// Tasks whose score decays below this are forgotten, ex: a task that was flaky once and then passed 14 times in a row.
+const minFlakyScore = 0.01
+
+// How often changed scores are saved to FlakyTasksFile, scores recorded since the last save are lost on restart.
+const flakyTasksSaveInterval = 10 * time.Second
+
+// Tasks are identified by their job's tag and their taskId, which are stable across jobs unlike jobIds.
+type flakyTaskKey struct {
+	tag    string
+	taskId string
+}
+
+// Tracks the tasks that have needed a retry to succeed, see IsFlakyRun().
+// It's updated by the scheduler loop and read by the API, so it has its own lock.
+type flakyTasks struct {
+	tasks map[flakyTaskKey]*sched.FlakyTask
+	mu    sync.RWMutex
+
+	// Set when tasks changed since they were last saved, only used by the scheduler loop and record().
+	dirty     bool
+	lastSaved time.Time
+}
+
+func newFlakyTasks() *flakyTasks {
+	return &flakyTasks{tasks: make(map[flakyTaskKey]*sched.FlakyTask)}
+}
+
+// Records a successful run of the task, which was flaky if it needed a retry.
+// Tasks aren't tracked until their first flaky run since most tasks never have one.
+func (f *flakyTasks) record(tag, taskId string, flaky bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := flakyTaskKey{tag, taskId}
+	t, ok := f.tasks[key]
+	if !ok {
+		if !flaky {
+			return
+		}
+		t = &sched.FlakyTask{Tag: tag, TaskID: taskId}
+		f.tasks[key] = t
+	}
+	f.dirty = true
+
+	t.NumRuns++
+	t.Score *= (1 - flakyScoreWeight)
+	if flaky {
+		t.NumFlakyRuns++
+		t.LastFlaky = time.Now()
+		t.Score += flakyScoreWeight
+	}
+	if t.Score < minFlakyScore {
+		delete(f.tasks, key)
+	}
+}
+
+// Returns the task's flakiness score, zero if it isn't tracked.
+func (f *flakyTasks) score(tag, taskId string) float64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if t, ok := f.tasks[flakyTaskKey{tag, taskId}]; ok {
+		return t.Score
+	}
+	return 0
+}
+
+// Returns the tracked tasks that match the request, ordered by descending score.
+func (f *flakyTasks) list(req sched.FlakyTasksReq) []sched.FlakyTask {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	tasks := []sched.FlakyTask{}
+	for _, t := range f.tasks {
+		if (req.Tag == "" || req.Tag == t.Tag) && t.Score >= req.MinScore {
+			tasks = append(tasks, *t)
+		}
+	}
+	sort.Sort(flakyTasksByScore(tasks))
+	return tasks
+}
+
+// Replaces the tracked tasks with the ones saved to the file, a missing file leaves them empty.
+func (f *flakyTasks) load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	saved := []sched.FlakyTask{}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tasks = make(map[flakyTaskKey]*sched.FlakyTask)
+	for i := range saved {
+		t := saved[i]
+		f.tasks[flakyTaskKey{t.Tag, t.TaskID}] = &t
+	}
+	return nil
+}
+
+// Saves the tracked tasks to the file if they changed and flakyTasksSaveInterval has passed since the last save.
+// The file is replaced atomically so a crash mid save leaves the previous scores.
+func (f *flakyTasks) save(path string) error {
+	f.mu.Lock()
+	if !f.dirty || time.Since(f.lastSaved) < flakyTasksSaveInterval {
+		f.mu.Unlock()
+		return nil
+	}
+	tasks := make([]sched.FlakyTask, 0, len(f.tasks))
+	for _, t := range f.tasks {
+		tasks = append(tasks, *t)
+	}
+	f.dirty = false
+	f.lastSaved = time.Now()
+	f.mu.Unlock()
+
+	data, err := json.Marshal(tasks)
+	if err == nil {
+		err = writeFileAtomic(path, data)
+	}
+	if err != nil {
+		// Try again on the next save.
+		f.mu.Lock()
+		f.dirty = true
+		f.mu.Unlock()
+	}
+	return err
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+type flakyTasksByScore []sched.FlakyTask
+
+func (s flakyTasksByScore) Len() int {
+	return len(s)
+}
+func (s flakyTasksByScore) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+func (s flakyTasksByScore) Less(i, j int) bool {
+	if s[i].Score != s[j].Score {
+		return s[i].Score > s[j].Score
+	}
+	return s[i].Tag+s[i].TaskID < s[j].Tag+s[j].TaskID
+}
+
+// Returns true if the task succeeded after one or more of its attempts exited non-zero or timed out.
+// Attempts that failed because of the worker they ran on don't count, that's the node being flaky rather than the task.
+// Only retries within the run count, so non-zero exits only make a run flaky for jobs whose RetryPolicy
+// retries them. The default policy only retries timeouts, so a task that exits non-zero in such a job fails
+// and a later pass in another job isn't flaky: the task may have been fixed in between.
+func IsFlakyRun(state *saga.SagaState, taskId string) bool {
+	if !state.IsTaskCompleted(taskId) {
+		return false
+	}
+	st, err := workerapi.DeserializeProcessStatus(state.GetEndTaskData(taskId))
+	if err != nil || st.State != runner.COMPLETE || st.ExitCode != 0 {
+		return false
+	}
+	for _, attempt := range retriedAttempts(state, taskId) {
+		if attempt.State == runner.TIMEDOUT || (attempt.State == runner.COMPLETE && attempt.ExitCode != 0) {
+			return true
+		}
+	}
+	return false
+}
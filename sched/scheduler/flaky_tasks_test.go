@@ -0,0 +1,223 @@
+package scheduler
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/workerapi"
+)
+
+func Test_FlakyTasks_Record(t *testing.T) {
+	f := newFlakyTasks()
+
+	f.record("tag", "stable", false)
+	if tasks := f.list(sched.FlakyTasksReq{}); len(tasks) != 0 {
+		t.Fatalf("Expected tasks that were never flaky to be untracked, got %+v", tasks)
+	}
+
+	f.record("tag", "flaky", true)
+	f.record("tag", "flaky", true)
+	f.record("tag", "flaky", false)
+	f.record("other", "flaky", true)
+	tasks := f.list(sched.FlakyTasksReq{})
+	if len(tasks) != 2 || tasks[0].Tag != "tag" || tasks[1].Tag != "other" {
+		t.Fatalf("Expected both flaky tasks ordered by score, got %+v", tasks)
+	}
+	if tasks[0].NumRuns != 3 || tasks[0].NumFlakyRuns != 2 || tasks[0].LastFlaky.IsZero() {
+		t.Errorf("Expected 2 of 3 runs to be flaky, got %+v", tasks[0])
+	}
+	if score := f.score("tag", "flaky"); score != tasks[0].Score || score <= f.score("other", "flaky") {
+		t.Errorf("Expected score %v to match the listed one and exceed that of a single flaky run", score)
+	}
+	if tasks := f.list(sched.FlakyTasksReq{Tag: "other"}); len(tasks) != 1 || tasks[0].Tag != "other" {
+		t.Errorf("Expected only the task with the other tag, got %+v", tasks)
+	}
+	if tasks := f.list(sched.FlakyTasksReq{MinScore: 0.25}); len(tasks) != 1 || tasks[0].Tag != "tag" {
+		t.Errorf("Expected only the flakier task, got %+v", tasks)
+	}
+
+	// A task that keeps passing is eventually forgotten.
+	for i := 0; i < 50; i++ {
+		f.record("other", "flaky", false)
+	}
+	if score := f.score("other", "flaky"); score != 0 {
+		t.Errorf("Expected the task to be forgotten, got score %v", score)
+	}
+}
+
+func Test_IsFlakyRun(t *testing.T) {
+	job := sched.GenJob("job1", 3)
+	jobAsBytes, _ := job.Serialize()
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+
+	serialize := func(st runner.RunStatus) []byte {
+		b, _ := workerapi.SerializeProcessStatus(st)
+		return b
+	}
+	success := serialize(runner.CompleteStatus("run", "", 0, tags.LogTags{}))
+	nonZeroExit := serialize(runner.CompleteStatus("run", "", 1, tags.LogTags{}))
+	workerFailure := serialize(runner.FailedStatus("run", errors.New("worker failure"), tags.LogTags{}))
+
+	// Passed after exiting non-zero.
+	flaky := job.Def.Tasks[0].TaskID
+	saga.StartTask(flaky, nil)
+	saga.StartTask(flaky, nonZeroExit)
+	saga.StartTask(flaky, nil)
+	saga.EndTask(flaky, success)
+	// Passed after its worker failed.
+	retried := job.Def.Tasks[1].TaskID
+	saga.StartTask(retried, nil)
+	saga.StartTask(retried, workerFailure)
+	saga.StartTask(retried, nil)
+	saga.EndTask(retried, success)
+	// Exited non-zero twice.
+	failed := job.Def.Tasks[2].TaskID
+	saga.StartTask(failed, nil)
+	saga.StartTask(failed, nonZeroExit)
+	saga.StartTask(failed, nil)
+	saga.EndTask(failed, nonZeroExit)
+
+	for taskId, expected := range map[string]bool{flaky: true, retried: false, failed: false} {
+		if IsFlakyRun(saga.GetState(), taskId) != expected {
+			t.Errorf("Expected IsFlakyRun(%s) to be %t", taskId, expected)
+		}
+	}
+}
+
+func Test_GetRetryPolicy_FlakyTask(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	s.config.FlakyTaskScore = 0.2
+	s.config.FlakyTaskAttempts = 3
+	job := sched.GenJob("job1", 2)
+	flaky, stable := job.Def.Tasks[0].TaskID, job.Def.Tasks[1].TaskID
+	s.flakyTasks.record(job.Def.Tag, flaky, true)
+
+	if p := s.getRetryPolicy(&job, stable); p.MaxAttempts != 1 || p.RetryOnNonZeroExit {
+		t.Errorf("Expected the default policy for a task that isn't flaky, got %s", p)
+	}
+	if p := s.getRetryPolicy(&job, flaky); p.MaxAttempts != 3 || !p.RetryOnNonZeroExit {
+		t.Errorf("Expected known flaky tasks to be retried when they exit non-zero, got %s", p)
+	}
+}
+
+// Non-zero exits only make runs flaky for jobs whose retry policy retries them, timeouts also count by default.
+func Test_FlakyTasks_RetryPolicy(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	s.config.MaxRetriesPerTask = 1
+	s.config.FlakyTaskScore = 0.2
+	s.config.FlakyTaskAttempts = 3
+
+	serialize := func(st runner.RunStatus) []byte {
+		b, _ := workerapi.SerializeProcessStatus(st)
+		return b
+	}
+	nonZeroExit := runner.CompleteStatus("run", "", 1, tags.LogTags{})
+	timeout := runner.TimeoutStatus("run", tags.LogTags{})
+
+	makeJob := func(tag string, policy *sched.RetryPolicy) *sched.Job {
+		job := sched.GenJob("job", 1)
+		job.Def.Tag = tag
+		job.Def.RetryPolicy = policy
+		job.Def.Tasks[0].TaskID = "task"
+		return &job
+	}
+	// Runs the task in a new job whose first attempt ends with first, which is retried if the job's policy
+	// retries it, like the taskRunner does. Passing runs are recorded like the scheduler does.
+	run := func(tag string, policy *sched.RetryPolicy, first *runner.RunStatus) {
+		job := makeJob(tag, policy)
+		jobAsBytes, _ := job.Serialize()
+		sa, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+		sa.StartTask("task", nil)
+		if first != nil {
+			p := s.getRetryPolicy(job, "task")
+			retried := p.RetriesExitCode(first.ExitCode) || (first.State == runner.TIMEDOUT && p.RetryOnTimeout)
+			if !retried || p.MaxAttempts < 2 {
+				sa.EndTask("task", serialize(*first))
+				return
+			}
+			sa.StartTask("task", serialize(*first))
+			sa.StartTask("task", nil)
+		}
+		sa.EndTask("task", serialize(runner.CompleteStatus("run", "", 0, tags.LogTags{})))
+		s.flakyTasks.record(tag, "task", IsFlakyRun(sa.GetState(), "task"))
+	}
+
+	// With the default policy a non-zero exit fails the job, and passing in the next job isn't flaky.
+	run("default", nil, &nonZeroExit)
+	run("default", nil, nil)
+	if score := s.flakyTasks.score("default", "task"); score != 0 {
+		t.Errorf("Expected no score without retries of non-zero exits, got %v", score)
+	}
+	if p := s.getRetryPolicy(makeJob("default", nil), "task"); p.RetryOnNonZeroExit {
+		t.Errorf("Expected the default policy for a task that isn't known to be flaky, got %s", p)
+	}
+
+	// Timeouts are retried by default.
+	run("timeout", nil, &timeout)
+	if score := s.flakyTasks.score("timeout", "task"); score != flakyScoreWeight {
+		t.Errorf("Expected a retried timeout to be flaky, got score %v", score)
+	}
+
+	// Jobs that opt in to retrying non-zero exits record them, after which the task is retried in every job.
+	run("optin", &sched.RetryPolicy{MaxAttempts: 2, RetryOnNonZeroExit: true}, &nonZeroExit)
+	if score := s.flakyTasks.score("optin", "task"); score != flakyScoreWeight {
+		t.Errorf("Expected a retried non-zero exit to be flaky, got score %v", score)
+	}
+	if p := s.getRetryPolicy(makeJob("optin", nil), "task"); !p.RetryOnNonZeroExit || p.MaxAttempts != 3 {
+		t.Errorf("Expected the known flaky task to be retried with the default policy, got %s", p)
+	}
+}
+
+func Test_FlakyTasks_SaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "flaky_tasks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "flaky_tasks.json")
+
+	f := newFlakyTasks()
+	if err := f.load(path); err != nil {
+		t.Fatalf("Expected a missing file to load no tasks, got %v", err)
+	}
+	f.record("tag", "flaky", true)
+	f.record("tag", "flaky", false)
+	if err := f.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Unchanged scores aren't saved again.
+	f.lastSaved = time.Time{}
+	if err := ioutil.WriteFile(path, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.save(path); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := ioutil.ReadFile(path); string(data) != "[]" {
+		t.Fatalf("Expected unchanged tasks not to be saved, got %s", data)
+	}
+	f.record("tag", "flaky", true)
+	if err := f.save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newFlakyTasks()
+	if err := loaded.load(path); err != nil {
+		t.Fatal(err)
+	}
+	expected := f.list(sched.FlakyTasksReq{})
+	tasks := loaded.list(sched.FlakyTasksReq{})
+	if len(tasks) != 1 || tasks[0].Score != expected[0].Score || tasks[0].NumRuns != 3 || tasks[0].NumFlakyRuns != 2 ||
+		!tasks[0].LastFlaky.Equal(expected[0].LastFlaky) {
+		t.Errorf("Expected loaded tasks %+v to match saved ones %+v", tasks, expected)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"math"
 	"time"
 
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/workerapi"
 )
 
 // Contains all the information for a job in progress
@@ -24,14 +26,19 @@ type jobState struct {
 
 // Contains all the information for a specified task
 type taskState struct {
-	JobId         string
-	TaskId        string
-	Def           sched.TaskDefinition
-	Status        sched.Status
-	TimeStarted   time.Time
-	NumTimesTried int
-	TaskRunner    *taskRunner
-	AvgDuration   time.Duration //average duration for previous runs with this taskId, if any.
+	JobId             string
+	TaskId            string
+	Def               sched.TaskDefinition
+	Status            sched.Status
+	TimeStarted       time.Time
+	NumTimesTried     int
+	TaskRunner        *taskRunner
+	SpeculativeRunner *taskRunner   //second attempt of a straggling task, see speculateTasks().
+	Speculated        bool          //true if the current attempt has had a speculative attempt.
+	AvgDuration       time.Duration //average duration for previous runs with this taskId, if any.
+	Failed            bool          //true if the task completed without succeeding, dependent tasks will be skipped.
+	SnapshotMissing   bool          //true if SnapshotFromTask succeeded without an output snapshot, the task will be skipped.
+	RetryAfter        time.Time     //the task isn't rescheduled before this time, per its job's retry backoff.
 }
 
 type taskStatesByDuration []*taskState
@@ -49,7 +56,7 @@ func (s taskStatesByDuration) Less(i, j int) bool {
 // Creates a New Job State based on the specified Job and Saga
 // The jobState will reflect any previous progress made on this job and logged to the Sagalog
 // Note: taskDurations is optional and only used to enable sorts using taskStatesByDuration above.
-func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]averageDuration) *jobState {
+func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]*averageDuration) *jobState {
 	j := &jobState{
 		Job:            job,
 		Saga:           saga,
@@ -63,7 +70,10 @@ func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]avera
 	}
 
 	for _, taskDef := range job.Def.Tasks {
-		duration := taskDurations[taskDef.TaskID].duration // This is safe since the map value is not a pointer.
+		duration := time.Duration(0)
+		if ad, ok := taskDurations[taskDef.TaskID]; ok {
+			duration = ad.duration
+		}
 		if duration == 0 {
 			duration = math.MaxInt64 // Set max duration if we don't have the average duration.
 		}
@@ -86,13 +96,29 @@ func newJobState(job *sched.Job, saga *saga.Saga, taskDurations map[string]avera
 	for _, taskId := range saga.GetState().GetTaskIds() {
 		if saga.GetState().IsTaskCompleted(taskId) {
 			j.getTask(taskId).Status = sched.Completed
+			j.taskEnded(j.getTask(taskId), saga.GetState().GetEndTaskData(taskId))
 			j.TasksCompleted++
+		} else {
+			// Attempts that were retried before recovery still count towards the task's max attempts.
+			j.getTask(taskId).NumTimesTried = len(retriedAttempts(saga.GetState(), taskId))
 		}
 	}
 
 	return j
 }
 
+// Returns the statuses of the task's attempts that were retried, oldest first.
+// The taskRunner logs each of them as a StartTask, along with the status of the attempt in progress, if any.
+func retriedAttempts(state *saga.SagaState, taskId string) []runner.RunStatus {
+	var attempts []runner.RunStatus
+	for _, data := range state.GetStartTaskDataHistory(taskId) {
+		if st, err := workerapi.DeserializeProcessStatus(data); err == nil && st.State.IsDone() {
+			attempts = append(attempts, st)
+		}
+	}
+	return attempts
+}
+
 // Helper, assumes that taskId is present given a consistent jobState.
 func (j *jobState) getTask(taskId string) *taskState {
 	for _, task := range j.Tasks {
@@ -104,12 +130,13 @@ func (j *jobState) getTask(taskId string) *taskState {
 }
 
 // Returns a list of taskIds that can be scheduled currently.
+// Tasks are only schedulable once all the tasks they depend on have completed successfully.
 func (j *jobState) getUnScheduledTasks() []*taskState {
 
 	var tasksToRun []*taskState
 
 	for _, state := range j.Tasks {
-		if state.Status == sched.NotStarted {
+		if state.Status == sched.NotStarted && !time.Now().Before(state.RetryAfter) && !state.SnapshotMissing && j.dependenciesSucceeded(state) {
 			tasksToRun = append(tasksToRun, state)
 		}
 	}
@@ -117,12 +144,49 @@ func (j *jobState) getUnScheduledTasks() []*taskState {
 	return tasksToRun
 }
 
+// Returns a list of tasks that haven't started and never will because a task they depend on failed,
+// or because the task they take their snapshot from didn't produce one.
+func (j *jobState) getSkippableTasks() []*taskState {
+
+	var tasksToSkip []*taskState
+
+	for _, state := range j.Tasks {
+		if state.Status == sched.NotStarted && (state.SnapshotMissing || j.failedDependency(state) != "") {
+			tasksToSkip = append(tasksToSkip, state)
+		}
+	}
+
+	return tasksToSkip
+}
+
+// Returns true if every task that this task depends on has completed successfully.
+func (j *jobState) dependenciesSucceeded(task *taskState) bool {
+	for _, dep := range task.Def.DependsOn {
+		depState := j.getTask(dep)
+		if depState == nil || depState.Status != sched.Completed || depState.Failed {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the id of the first task this task depends on that has failed, or "" if there's none.
+func (j *jobState) failedDependency(task *taskState) string {
+	for _, dep := range task.Def.DependsOn {
+		if depState := j.getTask(dep); depState != nil && depState.Failed {
+			return dep
+		}
+	}
+	return ""
+}
+
 // Update JobState to reflect that a Task has been started
 func (j *jobState) taskStarted(taskId string, tr *taskRunner) {
 	taskState := j.getTask(taskId)
 	taskState.Status = sched.InProgress
 	taskState.TimeStarted = time.Now()
 	taskState.TaskRunner = tr
+	taskState.Speculated = false
 	taskState.NumTimesTried++
 	j.TasksRunning++
 }
@@ -134,6 +198,9 @@ func (j *jobState) taskCompleted(taskId string, running bool) {
 	taskState.Status = sched.Completed
 	taskState.TimeStarted = nilTime
 	taskState.TaskRunner = nil
+	if j.Saga != nil {
+		j.taskEnded(taskState, j.Saga.GetState().GetEndTaskData(taskId))
+	}
 	j.TasksCompleted++
 	if running {
 		j.TasksRunning--
@@ -141,7 +208,8 @@ func (j *jobState) taskCompleted(taskId string, running bool) {
 }
 
 // Update JobState to reflect that an error has occurred running this Task
-func (j *jobState) errorRunningTask(taskId string, err error, preempted bool) {
+// The task won't be rescheduled until backoff has passed.
+func (j *jobState) errorRunningTask(taskId string, err error, preempted bool, backoff time.Duration) {
 	taskState := j.getTask(taskId)
 	taskState.Status = sched.NotStarted
 	taskState.TimeStarted = nilTime
@@ -149,6 +217,8 @@ func (j *jobState) errorRunningTask(taskId string, err error, preempted bool) {
 	j.TasksRunning--
 	if preempted {
 		taskState.NumTimesTried--
+	} else {
+		taskState.RetryAfter = time.Now().Add(backoff)
 	}
 }
 
@@ -159,3 +229,35 @@ func (j *jobState) getJobStatus() sched.Status {
 	}
 	return sched.InProgress
 }
+
+// Records the outcome of a task using the data logged with its EndTask message.
+// If the task succeeded, tasks that consume its output snapshot get their snapshot inputs resolved.
+// If it succeeded without an output snapshot, those tasks are left unchanged and marked to be skipped
+// rather than run on an empty checkout.
+func (j *jobState) taskEnded(task *taskState, endTaskData []byte) {
+	// Tasks ended without any data are considered successful, but have no output to pass on.
+	if endTaskData == nil {
+		task.Failed = false
+		return
+	}
+	st, err := workerapi.DeserializeProcessStatus(endTaskData)
+	task.Failed = (err != nil || st.State != runner.COMPLETE || st.ExitCode != 0)
+	if task.Failed {
+		return
+	}
+
+	for _, t := range j.Tasks {
+		if t.Def.SnapshotFromTask != task.TaskId {
+			continue
+		}
+		if st.SnapshotID == "" {
+			t.SnapshotMissing = true
+			continue
+		}
+		if t.Def.MergeSnapshot && t.Def.SnapshotID != "" {
+			t.Def.MergeSnapshotID = st.SnapshotID
+		} else {
+			t.Def.SnapshotID = st.SnapshotID
+		}
+	}
+}
@@ -3,9 +3,12 @@ package scheduler
 import (
 	"testing"
 
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga/sagalogs"
 	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/tests/testhelpers"
+	"github.com/twitter/scoot/workerapi"
 )
 
 func Test_GetUnscheduledTasks_ReturnsAllUnscheduledTasks(t *testing.T) {
@@ -56,3 +59,116 @@ func Test_NewJobState_PreviousProgress_CompletedTasks(t *testing.T) {
 		t.Errorf("Expected all Tasks to be completed")
 	}
 }
+
+func Test_GetUnscheduledTasks_WaitsForDependencies(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 3)
+	job.Def.Tasks[1].DependsOn = []string{job.Def.Tasks[0].TaskID}
+	job.Def.Tasks[2].DependsOn = []string{job.Def.Tasks[0].TaskID, job.Def.Tasks[1].TaskID}
+	jobAsBytes, _ := job.Serialize()
+
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	jobState := newJobState(&job, saga, nil)
+
+	tasks := jobState.getUnScheduledTasks()
+	if len(tasks) != 1 || tasks[0].TaskId != job.Def.Tasks[0].TaskID {
+		t.Fatalf("Expected only the task without dependencies to be schedulable, got %v", tasks)
+	}
+
+	saga.StartTask(job.Def.Tasks[0].TaskID, nil)
+	saga.EndTask(job.Def.Tasks[0].TaskID, nil)
+	jobState.taskCompleted(job.Def.Tasks[0].TaskID, false)
+
+	tasks = jobState.getUnScheduledTasks()
+	if len(tasks) != 1 || tasks[0].TaskId != job.Def.Tasks[1].TaskID {
+		t.Fatalf("Expected only the second task to be schedulable, got %v", tasks)
+	}
+}
+
+func Test_NewJobState_PreviousProgress_FailedDependency(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 2)
+	job.Def.Tasks[1].DependsOn = []string{job.Def.Tasks[0].TaskID}
+	jobAsBytes, _ := job.Serialize()
+
+	// Mark the first task as completed with a nonzero exit code, then recreate the jobState as recovery would.
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	st := runner.CompleteStatus("run1", "", 1, tags.LogTags{JobID: job.Id, TaskID: job.Def.Tasks[0].TaskID})
+	stAsBytes, _ := workerapi.SerializeProcessStatus(st)
+	saga.StartTask(job.Def.Tasks[0].TaskID, nil)
+	saga.EndTask(job.Def.Tasks[0].TaskID, stAsBytes)
+
+	recovered, _ := sched.DeserializeJob(saga.GetState().Job())
+	jobState := newJobState(recovered, saga, nil)
+
+	if tasks := jobState.getUnScheduledTasks(); len(tasks) != 0 {
+		t.Errorf("Expected no schedulable tasks, got %v", tasks)
+	}
+	tasks := jobState.getSkippableTasks()
+	if len(tasks) != 1 || tasks[0].TaskId != job.Def.Tasks[1].TaskID {
+		t.Errorf("Expected dependent task to be skippable, got %v", tasks)
+	}
+}
+
+func Test_TaskCompleted_ResolvesSnapshotInputs(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 3)
+	upstream := job.Def.Tasks[0].TaskID
+	job.Def.Tasks[1].DependsOn = []string{upstream}
+	job.Def.Tasks[1].SnapshotFromTask = upstream
+	job.Def.Tasks[2].DependsOn = []string{upstream}
+	job.Def.Tasks[2].SnapshotFromTask = upstream
+	job.Def.Tasks[2].MergeSnapshot = true
+	jobAsBytes, _ := job.Serialize()
+
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	jobState := newJobState(&job, saga, nil)
+
+	st := runner.CompleteStatus("run1", "upstreamOutput", 0, tags.LogTags{JobID: job.Id, TaskID: upstream})
+	stAsBytes, _ := workerapi.SerializeProcessStatus(st)
+	saga.StartTask(upstream, nil)
+	saga.EndTask(upstream, stAsBytes)
+	jobState.taskCompleted(upstream, false)
+
+	replaced := jobState.getTask(job.Def.Tasks[1].TaskID).Def
+	if replaced.SnapshotID != "upstreamOutput" || replaced.MergeSnapshotID != "" {
+		t.Errorf("Expected upstream output to replace snapshotID, got %v", replaced.Command)
+	}
+	merged := jobState.getTask(job.Def.Tasks[2].TaskID).Def
+	if merged.SnapshotID != job.Def.Tasks[2].SnapshotID || merged.MergeSnapshotID != "upstreamOutput" {
+		t.Errorf("Expected upstream output to be merged over snapshotID, got %v", merged.Command)
+	}
+
+	// Recovery should resolve the same inputs from the sagalog.
+	recovered, _ := sched.DeserializeJob(saga.GetState().Job())
+	jobState = newJobState(recovered, saga, nil)
+	if jobState.getTask(job.Def.Tasks[1].TaskID).Def.SnapshotID != "upstreamOutput" ||
+		jobState.getTask(job.Def.Tasks[2].TaskID).Def.MergeSnapshotID != "upstreamOutput" {
+		t.Errorf("Expected recovered job to resolve snapshot inputs")
+	}
+}
+
+func Test_TaskCompleted_SkipsTasksWhenSnapshotMissing(t *testing.T) {
+	job := sched.GenJob(testhelpers.GenJobId(testhelpers.NewRand()), 2)
+	upstream := job.Def.Tasks[0].TaskID
+	job.Def.Tasks[1].DependsOn = []string{upstream}
+	job.Def.Tasks[1].SnapshotFromTask = upstream
+	jobAsBytes, _ := job.Serialize()
+
+	saga, _ := sagalogs.MakeInMemorySagaCoordinator().MakeSaga(job.Id, jobAsBytes)
+	jobState := newJobState(&job, saga, nil)
+
+	st := runner.CompleteStatus("run1", "", 0, tags.LogTags{JobID: job.Id, TaskID: upstream})
+	stAsBytes, _ := workerapi.SerializeProcessStatus(st)
+	saga.StartTask(upstream, nil)
+	saga.EndTask(upstream, stAsBytes)
+	jobState.taskCompleted(upstream, false)
+
+	downstream := jobState.getTask(job.Def.Tasks[1].TaskID)
+	if downstream.Def.SnapshotID != job.Def.Tasks[1].SnapshotID {
+		t.Errorf("Expected snapshotID to be left unchanged, got %v", downstream.Def.SnapshotID)
+	}
+	if tasks := jobState.getUnScheduledTasks(); len(tasks) != 0 {
+		t.Errorf("Expected no schedulable tasks, got %v", tasks)
+	}
+	if tasks := jobState.getSkippableTasks(); len(tasks) != 1 || tasks[0] != downstream {
+		t.Errorf("Expected dependent task to be skippable, got %v", tasks)
+	}
+}
@@ -4,6 +4,7 @@ package scheduler
 //go:generate mockgen -source=scheduler.go -package=scheduler -destination=scheduler_mock.go
 
 import (
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
 )
@@ -13,9 +14,21 @@ type Scheduler interface {
 
 	KillJob(jobId string) error
 
+	// Returns the ids of jobs that have been accepted by the scheduler and haven't completed yet.
+	GetInProgressJobIds() []string
+
+	// Returns the ids of a bounded number of the most recently completed jobs, oldest first.
+	GetCompletedJobIds() []string
+
+	// Returns the runner of the worker that's currently running the given task, false if the task isn't running.
+	GetTaskRunner(jobId, taskId string) (runner.Service, bool)
+
 	GetSagaCoord() saga.SagaCoordinator
 
 	OfflineWorker(req sched.OfflineWorkerReq) error
 
 	ReinstateWorker(req sched.ReinstateWorkerReq) error
+
+	// Returns the tasks that have needed a retry to succeed, most flaky first.
+	GetFlakyTasks(req sched.FlakyTasksReq) []sched.FlakyTask
 }
@@ -5,6 +5,7 @@ package scheduler
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	runner "github.com/twitter/scoot/runner"
 	saga "github.com/twitter/scoot/saga"
 	sched "github.com/twitter/scoot/sched"
 )
@@ -51,6 +52,37 @@ func (_mr *_MockSchedulerRecorder) KillJob(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "KillJob", arg0)
 }
 
+func (_m *MockScheduler) GetInProgressJobIds() []string {
+	ret := _m.ctrl.Call(_m, "GetInProgressJobIds")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+func (_mr *_MockSchedulerRecorder) GetInProgressJobIds() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetInProgressJobIds")
+}
+
+func (_m *MockScheduler) GetCompletedJobIds() []string {
+	ret := _m.ctrl.Call(_m, "GetCompletedJobIds")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+func (_mr *_MockSchedulerRecorder) GetCompletedJobIds() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetCompletedJobIds")
+}
+
+func (_m *MockScheduler) GetTaskRunner(jobId string, taskId string) (runner.Service, bool) {
+	ret := _m.ctrl.Call(_m, "GetTaskRunner", jobId, taskId)
+	ret0, _ := ret[0].(runner.Service)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+func (_mr *_MockSchedulerRecorder) GetTaskRunner(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetTaskRunner", arg0, arg1)
+}
+
 func (_m *MockScheduler) GetSagaCoord() saga.SagaCoordinator {
 	ret := _m.ctrl.Call(_m, "GetSagaCoord")
 	ret0, _ := ret[0].(saga.SagaCoordinator)
@@ -80,3 +112,13 @@ func (_m *MockScheduler) ReinstateWorker(req sched.ReinstateWorkerReq) error {
 func (_mr *_MockSchedulerRecorder) ReinstateWorker(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ReinstateWorker", arg0)
 }
+
+func (_m *MockScheduler) GetFlakyTasks(req sched.FlakyTasksReq) []sched.FlakyTask {
+	ret := _m.ctrl.Call(_m, "GetFlakyTasks", req)
+	ret0, _ := ret[0].([]sched.FlakyTask)
+	return ret0
+}
+
+func (_mr *_MockSchedulerRecorder) GetFlakyTasks(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetFlakyTasks", arg0)
+}
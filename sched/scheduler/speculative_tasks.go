@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
+)
+
+// Error given to the attempt of a speculatively run task that didn't end it.
+const SpeculativeAttemptLostErrStr = "SpeculativeAttemptLost"
+
+// Starts a second attempt of each straggling task on an idle node, so that a task stuck on a bad node doesn't
+// hold up its job. Whichever attempt ends the task first wins and the other is aborted, see attemptOwnsTask().
+// Runs after scheduleTasks() so that waiting tasks get the idle nodes first.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) speculateTasks() {
+	if s.config.SpeculativeTaskMultiple <= 0 {
+		return
+	}
+	for _, jobState := range s.inProgressJobs {
+		if jobState.JobKilled {
+			continue
+		}
+		for _, task := range jobState.Tasks {
+			if !s.isStraggler(task) {
+				continue
+			}
+			nodeSt := s.clusterState.idleNode(task.Def.Resources, task.Def.SnapshotID, task.TaskRunner.nodeSt.node.Id())
+			if nodeSt == nil {
+				continue
+			}
+			log.WithFields(
+				log.Fields{
+					"jobID":       task.JobId,
+					"taskID":      task.TaskId,
+					"running":     time.Since(task.TimeStarted),
+					"avgDuration": s.taskDurations[task.TaskId].duration,
+					"node":        task.TaskRunner.nodeSt.node,
+					"tag":         jobState.Job.Def.Tag,
+				}).Info("Task is straggling, starting a speculative attempt")
+			s.stat.Counter(stats.SchedSpeculativeTaskCounter).Inc(1)
+			s.runTask(task, nodeSt, true)
+		}
+	}
+}
+
+// Returns true if the task's current attempt has run SpeculativeTaskMultiple times longer than the task's
+// average duration and hasn't been speculatively run yet. Tasks that have never finished aren't stragglers.
+func (s *statefulScheduler) isStraggler(task *taskState) bool {
+	if task.Status != sched.InProgress || task.TaskRunner == nil || task.Speculated {
+		return false
+	}
+	ad, ok := s.taskDurations[task.TaskId]
+	if !ok || ad.count == 0 {
+		return false
+	}
+	return time.Since(task.TimeStarted) > time.Duration(float64(ad.duration)*s.config.SpeculativeTaskMultiple)
+}
+
+// Called from the callback of each attempt, returns true if the attempt's outcome is the task's to act on.
+// When a task has a speculative attempt, the first attempt to end the task wins and the other is aborted.
+// An attempt that fails and would've been retried leaves the task to the other attempt, which is still running.
+func (s *statefulScheduler) attemptOwnsTask(jobState *jobState, task *taskState, tr *taskRunner, err error) bool {
+	if task.SpeculativeRunner == nil {
+		// The task's only attempt, unless another attempt already ended it.
+		return task.TaskRunner == tr
+	}
+	other := task.SpeculativeRunner
+	if other == tr {
+		other = task.TaskRunner
+	}
+	task.SpeculativeRunner = nil
+
+	if taskErr, ok := err.(*taskError); !ok || taskErr.ended {
+		task.TaskRunner = tr
+		// Attempts of killed jobs were both aborted already.
+		if !jobState.JobKilled {
+			other.Abort(false, SpeculativeAttemptLostErrStr)
+		}
+		return true
+	}
+	task.TaskRunner = other
+	return false
+}
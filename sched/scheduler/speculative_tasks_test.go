@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/cloud/cluster"
+	"github.com/twitter/scoot/os/temp"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/runner/execer/execers"
+	"github.com/twitter/scoot/runner/runners"
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/snapshot"
+	"github.com/twitter/scoot/snapshot/snapshots"
+	"github.com/twitter/scoot/workerapi"
+)
+
+func Test_StatefulScheduler_SpeculativeTask(t *testing.T) {
+	for _, resumeSpeculative := range []bool{true, false} {
+		// Keep the execer of each node's latest run so the test can pick which attempt finishes first.
+		tmp, _ := temp.NewTempDir("", "speculative_tasks_test")
+		execs := map[cluster.NodeId]*execers.SimExecer{}
+		deps := getDefaultSchedDeps()
+		deps.config.SpeculativeTaskMultiple = 2
+		deps.rf = func(n cluster.Node) runner.Service {
+			ex := execers.NewSimExecer()
+			execs[n.Id()] = ex
+			filerMap := runner.MakeRunTypeMap()
+			filerMap[runner.RunTypeScoot] = snapshot.FilerAndInitDoneCh{Filer: snapshots.MakeInvalidFiler(), IDC: nil}
+			return runners.NewSingleRunner(ex, filerMap, runners.NewNullOutputCreator(), tmp, nil)
+		}
+		s := makeStatefulSchedulerDeps(deps)
+
+		jobDef := sched.GenJobDef(1)
+		jobDef.Tasks[0].Argv = []string{"pause", "complete 0"}
+		taskId := jobDef.Tasks[0].TaskID
+		s.taskDurations[taskId] = &averageDuration{count: 1, duration: time.Millisecond}
+		go func() {
+			checkJobMsg := <-s.checkJobCh
+			checkJobMsg.resultCh <- nil
+		}()
+		jobId, _ := s.ScheduleJob(jobDef)
+
+		// The task's first attempt straggles past twice its average, so it should get a second attempt on another node.
+		for s.getJob(jobId) == nil || s.getJob(jobId).getTask(taskId).SpeculativeRunner == nil {
+			s.step()
+		}
+		job := s.getJob(jobId)
+		task := job.getTask(taskId)
+		primary, speculative := task.TaskRunner.nodeSt.node.Id(), task.SpeculativeRunner.nodeSt.node.Id()
+		if primary == speculative || s.clusterState.numRunning != 2 || task.NumTimesTried != 1 {
+			t.Fatalf("Expected a single attempt on each of two nodes, got %s and %s, %d running, %d tries",
+				primary, speculative, s.clusterState.numRunning, task.NumTimesTried)
+		}
+
+		winner := primary
+		if resumeSpeculative {
+			winner = speculative
+		}
+		go execs[winner].Resume()
+
+		// The winner ends the task and the loser is aborted, freeing both nodes.
+		for task.Status != sched.Completed || s.clusterState.numRunning != 0 {
+			s.step()
+		}
+		if job.getJobStatus() != sched.Completed || task.Failed || task.TaskRunner != nil || task.SpeculativeRunner != nil {
+			t.Fatalf("resumeSpeculative=%t: Expected the task to succeed, got %+v", resumeSpeculative, task)
+		}
+		st, err := workerapi.DeserializeProcessStatus(job.Saga.GetState().GetEndTaskData(taskId))
+		if err != nil || st.State != runner.COMPLETE || st.ExitCode != 0 {
+			t.Errorf("resumeSpeculative=%t: Expected the winner's status to be logged, got %+v, %v", resumeSpeculative, st, err)
+		}
+		for _, nodeId := range []cluster.NodeId{primary, speculative} {
+			if ns, ok := s.clusterState.getNodeState(nodeId); !ok || len(ns.running) != 0 || ns.suspended() {
+				t.Errorf("resumeSpeculative=%t: Expected node %s to be free, got %v", resumeSpeculative, nodeId, ns)
+			}
+		}
+	}
+}
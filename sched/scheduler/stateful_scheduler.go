@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	uuid "github.com/nu7hatch/gouuid"
@@ -47,6 +48,12 @@ func stringInSlice(a string, list []string) bool {
 // Clients will check for this string to differentiate between scoot and user initiated actions.
 const UserRequestedErrStr = "UserRequested"
 
+// Prefix of the error given to tasks that are never run because a task they depend on failed.
+const DependencyFailedErrStr = "DependencyFailed"
+
+// Prefix of the error given to tasks that are never run because the task they take their snapshot from produced none.
+const SnapshotMissingErrStr = "SnapshotMissing"
+
 // Provide defaults for config settings that should never be uninitialized/zero.
 // These are reasonable defaults for a small cluster of around a couple dozen nodes.
 
@@ -69,6 +76,9 @@ const DefaultSoftMaxSchedulableTasks = 1
 //
 const LongJobDuration = 4 * time.Hour
 
+// Number of completed job ids kept for GetCompletedJobIds, oldest are dropped first.
+const MaxCompletedJobIds = 1000
+
 // The max job priority we respect (higher priority is untested and disabled)
 const MaxPriority = sched.P2
 
@@ -78,7 +88,7 @@ var NodeScaleAdjustment = []float32{.15, .3, .55}
 
 // Scheduler Config variables read at initialization
 // MaxRetriesPerTask - the number of times to retry a failing task before
-//     marking it as completed.
+//     marking it as completed, for jobs whose retry policy doesn't set MaxAttempts.
 // DebugMode - if true, starts the scheduler up but does not start
 //     the update loop.  Instead the loop must be advanced manually
 //     by calling step()
@@ -94,6 +104,16 @@ var NodeScaleAdjustment = []float32{.15, .3, .55}
 //     how long to sleep between runner req retries.
 // ReadyFnBackoff -
 //     how long to wait between runner status queries to determine [init] status.
+// FlakyTaskScore -
+//     tasks with at least this flakiness score are known to be flaky, zero disables FlakyTaskAttempts.
+//     Scores only accrue from retries within a job, so non-zero exits only count for jobs that retry them.
+// FlakyTaskAttempts -
+//     known flaky tasks get at least this many attempts and are also retried when they exit non-zero.
+// FlakyTasksFile -
+//     file the flakiness scores are saved to and loaded from on startup, empty keeps them in memory only.
+// SpeculativeTaskMultiple -
+//     a task that has run this many times longer than its average duration gets a second attempt
+//     on an idle node, the first attempt to finish wins and the other is aborted. Zero disables it.
 type SchedulerConfig struct {
 	MaxRetriesPerTask       int
 	DebugMode               bool
@@ -107,6 +127,10 @@ type SchedulerConfig struct {
 	MaxJobsPerRequestor     int
 	SoftMaxSchedulableTasks int
 	Admins                  []string
+	FlakyTaskScore          float64
+	FlakyTaskAttempts       int
+	FlakyTasksFile          string
+	SpeculativeTaskMultiple float64
 }
 
 // Used to calculate how many tasks a job can run without adversely affecting other jobs.
@@ -122,7 +146,7 @@ type averageDuration struct {
 	duration time.Duration
 }
 
-func (ad averageDuration) update(d time.Duration) {
+func (ad *averageDuration) update(d time.Duration) {
 	ad.count++
 	ad.duration = ad.duration + time.Duration(int64(d-ad.duration)/ad.count)
 }
@@ -152,12 +176,25 @@ type statefulScheduler struct {
 	clusterState   *clusterState
 	inProgressJobs []*jobState // ordered list (by jobId) of jobs being scheduled.  Note: it might be
 	// no tasks have started yet.
-	requestorMap     map[string][]*jobState     // map of requestor to all its jobs. Default requestor="" is ok.
-	requestorHistory map[string][]string        // map of join(requestor, basis) to new tags in the order received.
-	taskDurations    map[string]averageDuration // map of taskId to averageDuration (note: we unconditionally dereference this).
+	requestorMap     map[string][]*jobState      // map of requestor to all its jobs. Default requestor="" is ok.
+	requestorHistory map[string][]string         // map of join(requestor, basis) to new tags in the order received.
+	taskDurations    map[string]*averageDuration // map of taskId to averageDuration, nil until the task first finishes.
 
 	requestorsCounts map[string]map[string]int // map of requestor to job and task stats counts
 
+	// Ids of inProgressJobs as of the end of the last scheduler loop, and of the most recently
+	// completed jobs, oldest first. Safe to read outside the loop.
+	inProgressJobIds   []string
+	completedJobIds    []string
+	inProgressJobIdsMu sync.RWMutex
+
+	// Runners of the workers running each task, safe to read outside the loop.
+	taskRunners   map[runningTask]runner.Service
+	taskRunnersMu sync.RWMutex
+
+	// Tasks that have needed a retry to succeed, safe to read outside the loop.
+	flakyTasks *flakyTasks
+
 	// stats
 	stat stats.StatsReceiver
 }
@@ -207,7 +244,7 @@ func NewStatefulScheduler(
 	stat stats.StatsReceiver,
 ) *statefulScheduler {
 
-	nodeReadyFn := func(node cluster.Node) (bool, time.Duration) {
+	nodeReadyFn := func(node cluster.Node) (bool, NodeCapacity, time.Duration) {
 		run := rf(node)
 		st, svc, err := run.StatusAll()
 		if err != nil || !svc.Initialized {
@@ -217,9 +254,9 @@ func NewStatefulScheduler(
 						"node": node,
 						"err":  svc.Error,
 					}).Info("received service err during init of new node")
-				return false, 0
+				return false, NodeCapacity{}, 0
 			}
-			return false, config.ReadyFnBackoff
+			return false, NodeCapacity{}, config.ReadyFnBackoff
 		}
 		for _, s := range st {
 			log.WithFields(
@@ -238,7 +275,7 @@ func NewStatefulScheduler(
 				}).Info("Aborting existing run on new node")
 			run.Abort(s.RunID)
 		}
-		return true, 0
+		return true, NodeCapacity{Slots: svc.Slots, Resources: svc.Capacity}, 0
 	}
 	if config.ReadyFnBackoff == 0 {
 		nodeReadyFn = nil
@@ -273,11 +310,19 @@ func NewStatefulScheduler(
 		inProgressJobs:   make([]*jobState, 0),
 		requestorMap:     make(map[string][]*jobState),
 		requestorHistory: make(map[string][]string),
-		taskDurations:    make(map[string]averageDuration),
+		taskDurations:    make(map[string]*averageDuration),
 		requestorsCounts: make(map[string]map[string]int),
+		taskRunners:      make(map[runningTask]runner.Service),
+		flakyTasks:       newFlakyTasks(),
 		stat:             stat,
 	}
 
+	if config.FlakyTasksFile != "" {
+		if err := sched.flakyTasks.load(config.FlakyTasksFile); err != nil {
+			log.Errorf("Couldn't load flaky tasks from %s, starting without them: %v", config.FlakyTasksFile, err)
+		}
+	}
+
 	if !config.DebugMode {
 		// start the scheduler loop
 		log.Info("Starting scheduler loop")
@@ -445,9 +490,45 @@ func (s *statefulScheduler) step() {
 
 	s.checkForCompletedJobs()
 	s.killJobs()
+	s.skipTasks()
 	s.scheduleTasks()
+	s.speculateTasks()
 
 	s.updateStats()
+	s.updateInProgressJobIds()
+	s.saveFlakyTasks()
+}
+
+// Saves the flakiness scores so they survive a restart, see FlakyTasksFile.
+func (s *statefulScheduler) saveFlakyTasks() {
+	if s.config.FlakyTasksFile == "" {
+		return
+	}
+	if err := s.flakyTasks.save(s.config.FlakyTasksFile); err != nil {
+		log.Errorf("Couldn't save flaky tasks to %s: %v", s.config.FlakyTasksFile, err)
+	}
+}
+
+// Publishes the ids of the current inProgressJobs for GetInProgressJobIds
+func (s *statefulScheduler) updateInProgressJobIds() {
+	ids := make([]string, 0, len(s.inProgressJobs))
+	for _, job := range s.inProgressJobs {
+		ids = append(ids, job.Job.Id)
+	}
+
+	s.inProgressJobIdsMu.Lock()
+	defer s.inProgressJobIdsMu.Unlock()
+	s.inProgressJobIds = ids
+}
+
+// Records a job that's no longer in progress for GetCompletedJobIds, keeping at most MaxCompletedJobIds.
+func (s *statefulScheduler) addCompletedJobId(jobId string) {
+	s.inProgressJobIdsMu.Lock()
+	defer s.inProgressJobIdsMu.Unlock()
+	s.completedJobIds = append(s.completedJobIds, jobId)
+	if len(s.completedJobIds) > MaxCompletedJobIds {
+		s.completedJobIds = append([]string(nil), s.completedJobIds[len(s.completedJobIds)-MaxCompletedJobIds:]...)
+	}
 }
 
 //update the stats monitoring values:
@@ -547,8 +628,8 @@ func (s *statefulScheduler) updateStats() {
 // them to the jobs the scheduler is handling
 func (s *statefulScheduler) addJobs() {
 	// For all new job requests (on the check job channel) that have come in since the last iteration of the step() loop,
-	// verify the job request: it doesn't exceed the requestor's limits or number of requestors, has a valid priority and
-	// doesn't duplicate tasks in another new job request.
+	// verify the job request: it doesn't exceed the requestor's limits or number of requestors, has a valid priority,
+	// doesn't duplicate tasks in another new job request and doesn't request more resources than any known node offers.
 	//
 	// If the job fails the validation, put an error on the job's callback channel, otherwise put nil on the job's callback
 	// channel.
@@ -579,6 +660,18 @@ checkLoop:
 					seenTasks[t.TaskID] = true
 				}
 			}
+			if err == nil {
+				// Reject tasks that no known node could ever run rather than leaving them unassigned forever.
+				if capacity, ok := s.clusterState.maxCapacity(); ok {
+					for _, t := range checkJobMsg.jobDef.Tasks {
+						if !t.Resources.FitsIn(capacity) {
+							err = fmt.Errorf("Invalid task %s, requested resources exceed the capacity of every node. Requested: %s, largest: %s",
+								t.TaskID, t.Resources, capacity)
+							break
+						}
+					}
+				}
+			}
 			if err == nil && checkJobMsg.jobDef.Basis != "" {
 				// Check if the given tag is expired for the given requestor & basis.
 				rb := checkJobMsg.jobDef.Requestor + checkJobMsg.jobDef.Basis
@@ -694,6 +787,38 @@ func (s *statefulScheduler) deleteJob(jobId string) {
 	if len(s.requestorMap[requestor]) == 0 {
 		delete(s.requestorMap, requestor)
 	}
+	s.addCompletedJobId(jobId)
+}
+
+// Returns the retry policy for the job's task, which is the job's policy or the default one if it doesn't have one.
+// A policy that doesn't limit attempts gets the limit from MaxRetriesPerTask.
+// Known flaky tasks are also retried when they exit non-zero or time out, see FlakyTaskAttempts.
+func (s *statefulScheduler) getRetryPolicy(job *sched.Job, taskId string) sched.RetryPolicy {
+	policy := sched.DefaultRetryPolicy()
+	if job.Def.RetryPolicy != nil {
+		policy = *job.Def.RetryPolicy
+	}
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = s.config.MaxRetriesPerTask + 1
+	}
+	if s.isFlakyTask(job.Def.Tag, taskId) && s.config.FlakyTaskAttempts > 0 {
+		policy.RetryOnNonZeroExit = true
+		policy.RetryOnTimeout = true
+		if policy.MaxAttempts < s.config.FlakyTaskAttempts {
+			policy.MaxAttempts = s.config.FlakyTaskAttempts
+		}
+	}
+	return policy
+}
+
+// Returns true if the task's flakiness score has reached FlakyTaskScore.
+func (s *statefulScheduler) isFlakyTask(tag, taskId string) bool {
+	return s.config.FlakyTaskScore > 0 && s.flakyTasks.score(tag, taskId) >= s.config.FlakyTaskScore
+}
+
+// Returns the tasks that have needed a retry to succeed, most flaky first.
+func (s *statefulScheduler) GetFlakyTasks(req sched.FlakyTasksReq) []sched.FlakyTask {
+	return s.flakyTasks.list(req)
 }
 
 func (s *statefulScheduler) getJob(jobId string) *jobState {
@@ -763,216 +888,269 @@ func (s *statefulScheduler) scheduleTasks() {
 		s.clusterState.nodeGroups = nodeGroups
 	}
 	for _, ta := range taskAssignments {
-		// Set up variables for async functions & callback
-		task := ta.task
-		nodeSt := ta.nodeSt
-		jobID := task.JobId
-		taskID := task.TaskId
-		requestor := s.getJob(jobID).Job.Def.Requestor
-		jobType := s.getJob(jobID).Job.Def.JobType
-		tag := s.getJob(jobID).Job.Def.Tag
-		taskDef := task.Def
-		taskDef.JobID = jobID
-		taskDef.Tag = tag
-		jobState := s.getJob(jobID)
-		sa := jobState.Saga
-		rs := s.runnerFactory(nodeSt.node)
-
-		preventRetries := bool(task.NumTimesTried >= s.config.MaxRetriesPerTask)
-
-		// Mark Task as Started in the cluster
-		s.clusterState.taskScheduled(nodeSt.node.Id(), jobID, taskID, taskDef.SnapshotID)
-		log.WithFields(
-			log.Fields{
-				"jobID":     jobID,
-				"taskID":    taskID,
-				"node":      nodeSt.node,
-				"requestor": requestor,
-				"jobType":   jobType,
-				"tag":       tag,
-				"taskDef":   taskDef,
-			}).Info("Task scheduled")
-
-		tRunner := &taskRunner{
-			saga:   sa,
-			runner: rs,
-			stat:   s.stat,
-
-			defaultTaskTimeout:    s.config.DefaultTaskTimeout,
-			taskTimeoutOverhead:   s.config.TaskTimeoutOverhead,
-			runnerRetryTimeout:    s.config.RunnerRetryTimeout,
-			runnerRetryInterval:   s.config.RunnerRetryInterval,
-			markCompleteOnFailure: preventRetries,
-
-			LogTags: tags.LogTags{
-				JobID:  jobID,
-				TaskID: taskID,
-				Tag:    tag,
-			},
-
-			task:   taskDef,
-			nodeSt: nodeSt,
-
-			abortCh:      make(chan abortReq, 1),
-			queryAbortCh: make(chan interface{}, 1),
-
-			startTime: time.Now(),
-		}
+		s.runTask(ta.task, ta.nodeSt, false)
+	}
+}
 
-		// mark the task as started in the jobState and record its taskRunner
-		jobState.taskStarted(taskID, tRunner)
+// Runs the task on the given node and handles the outcome once it's done. A speculative run is a second
+// attempt of a task that's already running, see speculateTasks().
+func (s *statefulScheduler) runTask(task *taskState, nodeSt *nodeState, speculative bool) {
+	// Set up variables for async functions & callback
+	jobID := task.JobId
+	taskID := task.TaskId
+	requestor := s.getJob(jobID).Job.Def.Requestor
+	jobType := s.getJob(jobID).Job.Def.JobType
+	tag := s.getJob(jobID).Job.Def.Tag
+	taskDef := task.Def
+	taskDef.JobID = jobID
+	taskDef.Tag = tag
+	jobState := s.getJob(jobID)
+	sa := jobState.Saga
+	rs := s.runnerFactory(nodeSt.node)
+
+	// A speculative attempt doesn't count towards the max attempts, if it's the last one standing and fails it's retried.
+	retryPolicy := s.getRetryPolicy(jobState.Job, taskID)
+	preventRetries := !speculative && bool(task.NumTimesTried+1 >= retryPolicy.MaxAttempts)
+
+	// The attempts of a task that run at the same time share this, only the first to end the task gets to log it.
+	taskEnded := new(int32)
+	scheduledMsg := "Task scheduled"
+	if speculative {
+		taskEnded = task.TaskRunner.taskEnded
+		scheduledMsg = "Speculative task scheduled"
+	}
 
-		s.asyncRunner.RunAsync(
-			tRunner.run,
-			func(err error) {
-				defer rs.Release()
-				// Update the average duration for this task so, for new jobs, we can schedule the likely long running tasks first.
-				if err == nil || err.(*taskError).st.State == runner.TIMEDOUT ||
-					(err.(*taskError).st.State == runner.COMPLETE && err.(*taskError).st.ExitCode == 0) {
-					s.taskDurations[taskID].update(time.Now().Sub(tRunner.startTime))
-				}
+	// Mark Task as Started in the cluster
+	s.clusterState.taskScheduled(nodeSt.node.Id(), jobID, taskID, taskDef.SnapshotID, taskDef.Resources)
+	log.WithFields(
+		log.Fields{
+			"jobID":     jobID,
+			"taskID":    taskID,
+			"node":      nodeSt.node,
+			"requestor": requestor,
+			"jobType":   jobType,
+			"tag":       tag,
+			"taskDef":   taskDef,
+		}).Info(scheduledMsg)
+
+	tRunner := &taskRunner{
+		saga:   sa,
+		runner: rs,
+		stat:   s.stat,
+
+		defaultTaskTimeout:    s.config.DefaultTaskTimeout,
+		taskTimeoutOverhead:   s.config.TaskTimeoutOverhead,
+		runnerRetryTimeout:    s.config.RunnerRetryTimeout,
+		runnerRetryInterval:   s.config.RunnerRetryInterval,
+		markCompleteOnFailure: preventRetries,
+		retryPolicy:           retryPolicy,
+
+		LogTags: tags.LogTags{
+			JobID:  jobID,
+			TaskID: taskID,
+			Tag:    tag,
+		},
+
+		task:   taskDef,
+		nodeSt: nodeSt,
+
+		abortCh:      make(chan abortReq, 1),
+		queryAbortCh: make(chan interface{}, 1),
+		taskEnded:    taskEnded,
+
+		startTime: time.Now(),
+	}
 
-				// If the node is absent, or was deleted then re-added, then we need to selectively clean up.
-				// The job update is normal but we update the cluster with a dummy value which denotes abnormal cleanup.
-				// We need the dummy value so we don't clobber any new job assignments to that nodeId.
-				nodeId := nodeSt.node.Id()
-				nodeStInstance, ok := s.clusterState.getNodeState(nodeId)
-				nodeAbsent := !ok
-				nodeReAdded := false
-				if !nodeAbsent {
-					nodeReAdded = (&nodeStInstance.readyCh != &nodeSt.readyCh)
-				}
-				nodeStChanged := nodeAbsent || nodeReAdded
-				preempted := false
+	// mark the task as started in the jobState and record its taskRunner
+	if speculative {
+		task.SpeculativeRunner = tRunner
+		task.Speculated = true
+	} else {
+		jobState.taskStarted(taskID, tRunner)
+		s.setTaskRunner(jobID, taskID, rs)
+	}
 
-				if nodeStChanged {
-					nodeId = nodeId + ":ERROR"
-					log.WithFields(
-						log.Fields{
-							"node":        nodeSt.node,
-							"jobID":       jobID,
-							"taskID":      taskID,
-							"runningJob":  nodeSt.runningJob,
-							"runningTask": nodeSt.runningTask,
-							"requestor":   requestor,
-							"jobType":     jobType,
-							"tag":         tag,
-						}).Info("Task *node* lost, cleaning up.")
-				}
-				if nodeReAdded {
-					preempted = true
+	s.asyncRunner.RunAsync(
+		tRunner.run,
+		func(err error) {
+			defer rs.Release()
+			// Update the average duration for this task so, for new jobs, we can schedule the likely long running tasks first.
+			if err == nil || err.(*taskError).st.State == runner.TIMEDOUT ||
+				(err.(*taskError).st.State == runner.COMPLETE && err.(*taskError).st.ExitCode == 0) {
+				if _, ok := s.taskDurations[taskID]; !ok {
+					s.taskDurations[taskID] = &averageDuration{}
 				}
+				s.taskDurations[taskID].update(time.Now().Sub(tRunner.startTime))
+			}
 
-				flaky := false
-				aborted := (err != nil && err.(*taskError).st.State == runner.ABORTED)
-				if err != nil {
-					// Get the type of error. Currently we only care to distinguish runner (ex: thrift) errors to mark flaky nodes.
-					taskErr := err.(*taskError)
-					flaky = (taskErr.runnerErr != nil)
+			// If the node is absent, or was deleted then re-added, then we need to selectively clean up.
+			// The job update is normal but we update the cluster with a dummy value which denotes abnormal cleanup.
+			// We need the dummy value so we don't clobber any new job assignments to that nodeId.
+			nodeId := nodeSt.node.Id()
+			nodeStInstance, ok := s.clusterState.getNodeState(nodeId)
+			nodeAbsent := !ok
+			nodeReAdded := false
+			if !nodeAbsent {
+				nodeReAdded = (&nodeStInstance.readyCh != &nodeSt.readyCh)
+			}
+			nodeStChanged := nodeAbsent || nodeReAdded
+			preempted := false
 
-					msg := "Error running job (will be retried):"
-					if aborted {
-						msg = "Error running task, but job kill request received, (will not retry):"
-						err = nil
-					} else {
-						if preventRetries {
-							msg = fmt.Sprintf("Error running task (quitting, hit max retries of %d):", s.config.MaxRetriesPerTask)
-							err = nil
-						} else {
-							jobState.errorRunningTask(taskID, err, preempted)
-						}
-					}
-					log.WithFields(
-						log.Fields{
-							"jobId":     jobID,
-							"taskId":    taskID,
-							"err":       taskErr,
-							"cmd":       strings.Join(taskDef.Argv, " "),
-							"requestor": requestor,
-							"jobType":   jobType,
-							"tag":       tag,
-						}).Info(msg)
-
-					// If the task completed succesfully but sagalog failed, start a goroutine to retry until it succeeds.
-					if taskErr.sagaErr != nil && taskErr.st.RunID != "" && taskErr.runnerErr == nil && taskErr.resultErr == nil {
-						log.WithFields(
-							log.Fields{
-								"jobId":  jobID,
-								"taskId": taskID,
-							}).Info(msg, " -> starting goroutine to handle failed saga.EndTask. ")
-						//TODO -this may results in closed channel panic due to sending endSaga to sagalog (below) before endTask
-						go func() {
-							for err := errors.New(""); err != nil; err = tRunner.logTaskStatus(&taskErr.st, saga.EndTask) {
-								time.Sleep(time.Second)
-							}
-							log.WithFields(
-								log.Fields{
-									"jobId":     jobID,
-									"taskId":    taskID,
-									"requestor": requestor,
-									"jobType":   jobType,
-									"tag":       tag,
-								}).Info(msg, " -> finished goroutine to handle failed saga.EndTask. ")
-						}()
-					}
-				}
-				if err == nil || aborted {
-					log.WithFields(
-						log.Fields{
-							"jobId":     jobID,
-							"taskId":    taskID,
-							"command":   strings.Join(taskDef.Argv, " "),
-							"requestor": requestor,
-							"jobType":   jobType,
-							"tag":       tag,
-						}).Info("Ending task.")
-					jobState.taskCompleted(taskID, true)
-				}
+			if nodeStChanged {
+				nodeId = nodeId + ":ERROR"
+				log.WithFields(
+					log.Fields{
+						"node":         nodeSt.node,
+						"jobID":        jobID,
+						"taskID":       taskID,
+						"runningTasks": nodeSt.runningTasks(),
+						"requestor":    requestor,
+						"jobType":      jobType,
+						"tag":          tag,
+					}).Info("Task *node* lost, cleaning up.")
+			}
+			if nodeReAdded {
+				preempted = true
+			}
 
-				// update cluster state that this node is now free and if we consider the runner to be flaky.
+			// Only one of the attempts of a speculatively run task gets to end or retry it.
+			if !s.attemptOwnsTask(jobState, task, tRunner, err) {
+				flaky := (err != nil && err.(*taskError).runnerErr != nil)
 				log.WithFields(
 					log.Fields{
 						"jobId":     jobID,
 						"taskId":    taskID,
 						"node":      nodeSt.node,
 						"flaky":     flaky,
+						"err":       err,
 						"requestor": requestor,
 						"jobType":   jobType,
 						"tag":       tag,
-					}).Info("Freeing node, removed job.")
-				s.clusterState.taskCompleted(nodeId, flaky)
-
-				total := 0
-				completed := 0
-				running := 0
-				for _, job := range s.inProgressJobs {
-					total += len(job.Tasks)
-					completed += job.TasksCompleted
-					running += job.TasksRunning
+					}).Info("Freeing node, task was left to its other attempt.")
+				s.clusterState.taskCompleted(nodeId, jobID, taskID, flaky)
+				if task.TaskRunner != nil {
+					s.setTaskRunner(jobID, taskID, task.TaskRunner.runner)
+				}
+				return
+			}
+
+			flaky := false
+			aborted := (err != nil && err.(*taskError).st.State == runner.ABORTED)
+			if err != nil {
+				// Get the type of error. Currently we only care to distinguish runner (ex: thrift) errors to mark flaky nodes.
+				taskErr := err.(*taskError)
+				flaky = (taskErr.runnerErr != nil)
+
+				msg := "Error running job (will be retried):"
+				if aborted {
+					msg = "Error running task, but job kill request received, (will not retry):"
+					err = nil
+				} else {
+					if preventRetries {
+						msg = fmt.Sprintf("Error running task (quitting, hit max attempts of %d):", retryPolicy.MaxAttempts)
+						err = nil
+					} else if taskErr.deadLettered {
+						msg = fmt.Sprintf("Error running task (quitting, not retried by %s):", retryPolicy)
+						err = nil
+					} else {
+						backoff := retryPolicy.BackoffBefore(task.NumTimesTried + 1)
+						jobState.errorRunningTask(taskID, err, preempted, backoff)
+					}
 				}
 				log.WithFields(
 					log.Fields{
 						"jobId":     jobID,
-						"running":   jobState.TasksRunning,
-						"completed": jobState.TasksCompleted,
-						"total":     len(jobState.Tasks),
-						"isdone":    jobState.TasksCompleted == len(jobState.Tasks),
+						"taskId":    taskID,
+						"err":       taskErr,
+						"cmd":       strings.Join(taskDef.Argv, " "),
 						"requestor": requestor,
 						"jobType":   jobType,
 						"tag":       tag,
-					}).Info()
+					}).Info(msg)
+
+				// If the task completed succesfully but sagalog failed, start a goroutine to retry until it succeeds.
+				if taskErr.sagaErr != nil && taskErr.st.RunID != "" && taskErr.runnerErr == nil && taskErr.resultErr == nil {
+					log.WithFields(
+						log.Fields{
+							"jobId":  jobID,
+							"taskId": taskID,
+						}).Info(msg, " -> starting goroutine to handle failed saga.EndTask. ")
+					//TODO -this may results in closed channel panic due to sending endSaga to sagalog (below) before endTask
+					go func() {
+						for err := errors.New(""); err != nil; err = tRunner.logTaskStatus(&taskErr.st, saga.EndTask) {
+							time.Sleep(time.Second)
+						}
+						log.WithFields(
+							log.Fields{
+								"jobId":     jobID,
+								"taskId":    taskID,
+								"requestor": requestor,
+								"jobType":   jobType,
+								"tag":       tag,
+							}).Info(msg, " -> finished goroutine to handle failed saga.EndTask. ")
+					}()
+				}
+			}
+			if err == nil || aborted {
 				log.WithFields(
 					log.Fields{
-						"running":   running,
-						"completed": completed,
-						"total":     total,
-						"alldone":   completed == total,
+						"jobId":     jobID,
+						"taskId":    taskID,
+						"command":   strings.Join(taskDef.Argv, " "),
 						"requestor": requestor,
 						"jobType":   jobType,
 						"tag":       tag,
-					}).Info("Jobs task summary")
-			})
-	}
+					}).Info("Ending task.")
+				jobState.taskCompleted(taskID, true)
+				if !aborted && !task.Failed {
+					s.flakyTasks.record(tag, taskID, IsFlakyRun(sa.GetState(), taskID))
+				}
+			}
+
+			// update cluster state that this node is now free and if we consider the runner to be flaky.
+			log.WithFields(
+				log.Fields{
+					"jobId":     jobID,
+					"taskId":    taskID,
+					"node":      nodeSt.node,
+					"flaky":     flaky,
+					"requestor": requestor,
+					"jobType":   jobType,
+					"tag":       tag,
+				}).Info("Freeing node, removed job.")
+			s.clusterState.taskCompleted(nodeId, jobID, taskID, flaky)
+			s.setTaskRunner(jobID, taskID, nil)
+
+			total := 0
+			completed := 0
+			running := 0
+			for _, job := range s.inProgressJobs {
+				total += len(job.Tasks)
+				completed += job.TasksCompleted
+				running += job.TasksRunning
+			}
+			log.WithFields(
+				log.Fields{
+					"jobId":     jobID,
+					"running":   jobState.TasksRunning,
+					"completed": jobState.TasksCompleted,
+					"total":     len(jobState.Tasks),
+					"isdone":    jobState.TasksCompleted == len(jobState.Tasks),
+					"requestor": requestor,
+					"jobType":   jobType,
+					"tag":       tag,
+				}).Info()
+			log.WithFields(
+				log.Fields{
+					"running":   running,
+					"completed": completed,
+					"total":     total,
+					"alldone":   completed == total,
+					"requestor": requestor,
+					"jobType":   jobType,
+					"tag":       tag,
+				}).Info("Jobs task summary")
+		})
 }
 
 //Put the kill request on channel that is processed by the main
@@ -990,6 +1168,46 @@ func (s *statefulScheduler) KillJob(jobID string) error {
 	return <-req.responseCh
 }
 
+// Returns the in progress job ids as of the last completed scheduler loop.
+// Jobs accepted or completed since then may not be reflected yet.
+func (s *statefulScheduler) GetInProgressJobIds() []string {
+	s.inProgressJobIdsMu.RLock()
+	defer s.inProgressJobIdsMu.RUnlock()
+	ids := make([]string, len(s.inProgressJobIds))
+	copy(ids, s.inProgressJobIds)
+	return ids
+}
+
+// Returns the ids of the most recently completed jobs, oldest first, see MaxCompletedJobIds.
+// Jobs are listed once their EndSaga has been logged, and may still be listed as in progress
+// until the end of the scheduler loop.
+func (s *statefulScheduler) GetCompletedJobIds() []string {
+	s.inProgressJobIdsMu.RLock()
+	defer s.inProgressJobIdsMu.RUnlock()
+	ids := make([]string, len(s.completedJobIds))
+	copy(ids, s.completedJobIds)
+	return ids
+}
+
+// Returns the runner of the worker that's currently running the given task, false if the task isn't running.
+func (s *statefulScheduler) GetTaskRunner(jobId, taskId string) (runner.Service, bool) {
+	s.taskRunnersMu.RLock()
+	defer s.taskRunnersMu.RUnlock()
+	rs, ok := s.taskRunners[runningTask{jobId, taskId}]
+	return rs, ok
+}
+
+// Records the runner of the worker running the given task, or that it's no longer running if rs is nil.
+func (s *statefulScheduler) setTaskRunner(jobId, taskId string, rs runner.Service) {
+	s.taskRunnersMu.Lock()
+	defer s.taskRunnersMu.Unlock()
+	if rs == nil {
+		delete(s.taskRunners, runningTask{jobId, taskId})
+	} else {
+		s.taskRunners[runningTask{jobId, taskId}] = rs
+	}
+}
+
 func (s *statefulScheduler) GetSagaCoord() saga.SagaCoordinator {
 	return s.sagaCoord
 }
@@ -1068,6 +1286,9 @@ func (s *statefulScheduler) killJobs() {
 			logFields["taskID"] = task.TaskId
 			if task.Status == sched.InProgress {
 				task.TaskRunner.Abort(true, UserRequestedErrStr)
+				if task.SpeculativeRunner != nil {
+					task.SpeculativeRunner.Abort(true, UserRequestedErrStr)
+				}
 				inProgress++
 			} else if task.Status == sched.NotStarted {
 				st := runner.AbortStatus("", tags.LogTags{JobID: jobState.Job.Id, TaskID: task.TaskId})
@@ -1096,3 +1317,68 @@ func (s *statefulScheduler) killJobs() {
 		req.responseCh <- nil
 	}
 }
+
+// Serializes an aborted task's status for the sagalog, falling back to a minimal status with only the state
+// and error if the full status can't be serialized.
+func (s *statefulScheduler) serializeAbortStatus(st runner.RunStatus) ([]byte, error) {
+	statusAsBytes, err := workerapi.SerializeProcessStatus(st)
+	if err == nil {
+		return statusAsBytes, nil
+	}
+	s.stat.Counter(stats.SchedFailedTaskSerializeCounter).Inc(1) // TODO errata metric - remove if unused
+	log.Errorf("Failed to serialize abort status %v, logging a minimal status instead: %v", st, err)
+	return workerapi.SerializeProcessStatus(runner.RunStatus{RunID: st.RunID, State: runner.ABORTED, Error: st.Error})
+}
+
+// Ends any unstarted tasks that depend on a failed task, or whose SnapshotFromTask produced no snapshot,
+// logging an aborted status for them to the sagalog.
+// Skipped tasks count as failed, so this repeats until all transitive dependents have been skipped.
+//
+// this function is part of the main scheduler loop
+func (s *statefulScheduler) skipTasks() {
+	for _, jobState := range s.inProgressJobs {
+		if jobState.JobKilled {
+			continue
+		}
+		for tasks := jobState.getSkippableTasks(); len(tasks) > 0; tasks = jobState.getSkippableTasks() {
+			for _, task := range tasks {
+				dep := jobState.failedDependency(task)
+				reason := fmt.Sprintf("%s: %s", DependencyFailedErrStr, dep)
+				if dep == "" {
+					dep = task.Def.SnapshotFromTask
+					reason = fmt.Sprintf("%s: %s", SnapshotMissingErrStr, dep)
+				}
+				logFields := log.Fields{
+					"jobID":      jobState.Job.Id,
+					"taskID":     task.TaskId,
+					"dependency": dep,
+					"requestor":  jobState.Job.Def.Requestor,
+					"jobType":    jobState.Job.Def.JobType,
+					"tag":        jobState.Job.Def.Tag,
+				}
+				st := runner.AbortStatus("", tags.LogTags{JobID: jobState.Job.Id, TaskID: task.TaskId, Tag: jobState.Job.Def.Tag})
+				st.Error = reason
+				statusAsBytes, err := s.serializeAbortStatus(st)
+				s.stat.Counter(stats.SchedSkippedTaskCounter).Inc(1)
+				if err := jobState.Saga.StartTask(task.TaskId, nil); err != nil {
+					logFields["err"] = err
+					log.WithFields(logFields).Info("skipTasks saga.StartTask failure.")
+				}
+				// Recovery treats an ended task without a status as successful, so never end it with nil data.
+				if err != nil {
+					logFields["err"] = err
+					log.WithFields(logFields).Error("skipTasks failed to serialize abort status, not ending task in sagalog.")
+				} else if err := jobState.Saga.EndTask(task.TaskId, statusAsBytes); err != nil {
+					logFields["err"] = err
+					log.WithFields(logFields).Info("skipTasks saga.EndTask failure.")
+				}
+				// Mark as failed even if the sagalog write failed so we don't loop on this task.
+				jobState.taskCompleted(task.TaskId, false)
+				task.Failed = true
+				delete(logFields, "err")
+				logFields["reason"] = reason
+				log.WithFields(logFields).Info("Skipped task")
+			}
+		}
+	}
+}
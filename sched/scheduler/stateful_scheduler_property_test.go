@@ -27,8 +27,8 @@ func Test_StatefulScheduler_TasksDistributedEvenly(t *testing.T) {
 
 	for len(s.inProgressJobs) > 0 {
 		for nodeId, state := range s.clusterState.nodes {
-			if state.runningTask != noTask {
-				taskMap[state.runningTask] = nodeId
+			for task := range state.running {
+				taskMap[task.taskId] = nodeId
 			}
 		}
 		s.step()
@@ -22,6 +22,7 @@ import (
 	"github.com/twitter/scoot/sched/worker/workers"
 	"github.com/twitter/scoot/snapshot"
 	"github.com/twitter/scoot/snapshot/snapshots"
+	"github.com/twitter/scoot/workerapi"
 )
 
 //Mocks sometimes hang without useful output, this allows early exit with err msg.
@@ -186,6 +187,10 @@ func Test_StatefulScheduler_AddJob(t *testing.T) {
 		t.Errorf("Expected the %v to be an inProgressJobs", id)
 	}
 
+	if ids := s.GetInProgressJobIds(); len(ids) != 1 || ids[0] != id {
+		t.Errorf("Expected In Progress Job Ids to be [%v] not %v", id, ids)
+	}
+
 	if !stats.StatsOk("", statsRegistry, t,
 		map[string]stats.Rule{
 			stats.SchedAcceptedJobsGauge:    {Checker: stats.Int64EqTest, Value: 1},
@@ -196,6 +201,43 @@ func Test_StatefulScheduler_AddJob(t *testing.T) {
 	}
 }
 
+// A job with a task requesting more resources than any node offers should be rejected instead of never being assigned.
+func Test_StatefulScheduler_AddJobExceedsCapacity(t *testing.T) {
+	s := makeDefaultStatefulScheduler()
+	checkJob := func(resources runner.Resources) error {
+		jobDef := sched.GenJobDef(2)
+		jobDef.Tasks[1].Resources = resources
+		resultCh := make(chan error, 1)
+		s.checkJobCh <- jobCheckMsg{jobDef: &jobDef, resultCh: resultCh}
+		s.addJobs()
+		return <-resultCh
+	}
+
+	// Nodes that don't report a capacity don't limit resources.
+	if err := checkJob(runner.Resources{CPUs: 16}); err != nil {
+		t.Fatalf("Expected job to be accepted by unlimited nodes, got: %v", err)
+	}
+
+	s.clusterState.nodes["node1"].capacity.Resources = runner.Resources{Memory: 1 << 30, CPUs: 1}
+	s.clusterState.nodes["node2"].capacity.Resources = runner.Resources{Memory: 8 << 30, CPUs: 8}
+	for _, id := range []cluster.NodeId{"node3", "node4", "node5"} {
+		s.clusterState.nodes[id].capacity.Resources = runner.Resources{CPUs: 4}
+	}
+	if err := checkJob(runner.Resources{Memory: 16 << 30, CPUs: 8}); err != nil {
+		t.Fatalf("Expected job to be accepted by a node that doesn't limit memory, got: %v", err)
+	}
+	if err := checkJob(runner.Resources{CPUs: 16}); err == nil {
+		t.Fatalf("Expected job requesting more cpus than any node to be rejected")
+	}
+
+	// Capacity of suspended nodes still counts since they may come back.
+	s.clusterState.suspendedNodes["node2"] = s.clusterState.nodes["node2"]
+	delete(s.clusterState.nodes, "node2")
+	if err := checkJob(runner.Resources{CPUs: 8}); err != nil {
+		t.Fatalf("Expected job to be accepted by a suspended node, got: %v", err)
+	}
+}
+
 // verifies that task gets retried maxRetryTimes and then marked as completed
 func Test_StatefulScheduler_TaskGetsMarkedCompletedAfterMaxRetriesFailedStarts(t *testing.T) {
 	jobDef := sched.GenJobDef(1)
@@ -296,6 +338,60 @@ func Test_StatefulScheduler_TaskGetsMarkedCompletedAfterMaxRetriesFailedRuns(t *
 	}
 }
 
+// verifies that a job's retry policy overrides MaxRetriesPerTask and that retried attempts are recorded
+func Test_StatefulScheduler_JobRetryPolicy(t *testing.T) {
+	deps := getDefaultSchedDeps()
+	deps.config.MaxRetriesPerTask = 3
+
+	// create a runner factory that returns a runner that returns an error
+	deps.rf = func(cluster.Node) runner.Service {
+		chaos := runners.NewChaosRunner(nil)
+
+		chaos.SetError(fmt.Errorf("starting error"))
+		return chaos
+	}
+
+	for _, test := range []struct {
+		policy           sched.RetryPolicy
+		expectedAttempts int
+	}{
+		{sched.RetryPolicy{MaxAttempts: 2, RetryOnWorkerFailure: true}, 2},
+		{sched.RetryPolicy{RetryOnWorkerFailure: true}, deps.config.MaxRetriesPerTask + 1},
+		{sched.RetryPolicy{MaxAttempts: 5, RetryOnTimeout: true}, 1},
+	} {
+		jobDef := sched.GenJobDef(1)
+		jobDef.RetryPolicy = &test.policy
+		taskId := jobDef.Tasks[0].TaskID
+
+		// Failed nodes are marked flaky, so start each job with a fresh cluster.
+		s := makeStatefulSchedulerDeps(deps)
+		go func() {
+			checkJobMsg := <-s.checkJobCh
+			checkJobMsg.resultCh <- nil
+		}()
+		jobId, _ := s.ScheduleJob(jobDef)
+
+		// advance scheduler until job gets scheduled & marked completed
+		for s.getJob(jobId) == nil || s.getJob(jobId).getJobStatus() != sched.Completed {
+			s.step()
+		}
+
+		job := s.getJob(jobId)
+		if job.getTask(taskId).NumTimesTried != test.expectedAttempts {
+			t.Errorf("%s: Expected Tries: %v times, Actual Tries: %v",
+				test.policy, test.expectedAttempts, job.getTask(taskId).NumTimesTried)
+		}
+		if retried := retriedAttempts(job.Saga.GetState(), taskId); len(retried) != test.expectedAttempts-1 {
+			t.Errorf("%s: Expected %d retried attempts to be recorded, got %+v", test.policy, test.expectedAttempts-1, retried)
+		}
+
+		// advance scheduler until job gets marked completed
+		for len(s.inProgressJobs) > 0 {
+			s.step()
+		}
+	}
+}
+
 // Ensure a single job with one task runs to completion, updates
 // state correctly, and makes the expected calls to the SagaLog
 func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
@@ -346,7 +442,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 	}
 
 	// verify scheduler state updated appropriately
-	if s.clusterState.nodes["node1"].runningTask != taskId {
+	if _, ok := s.clusterState.nodes["node1"].running[runningTask{jobId, taskId}]; !ok {
 		t.Errorf("Expected %v to be scheduled on node1.  nodestate: %+v", taskId, s.clusterState.nodes["node1"])
 	}
 
@@ -356,7 +452,7 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 	}
 
 	// verify state changed appropriately
-	if s.clusterState.nodes["node1"].runningTask != noTask {
+	if len(s.clusterState.nodes["node1"].running) != 0 {
 		t.Errorf("Expected node1 to not have any running tasks")
 	}
 
@@ -374,6 +470,12 @@ func Test_StatefulScheduler_JobRunsToCompletion(t *testing.T) {
 		s.step()
 	}
 
+	if ids := s.GetInProgressJobIds(); len(ids) != 0 {
+		t.Errorf("Expected no In Progress Job Ids once the job completed, got %v", ids)
+	}
+	if ids := s.GetCompletedJobIds(); len(ids) != 1 || ids[0] != jobId {
+		t.Errorf("Expected the job to be listed as completed, got %v", ids)
+	}
 }
 
 func Test_StatefulScheduler_KillStartedJob(t *testing.T) {
@@ -430,7 +532,7 @@ func Test_StatefulScheduler_KillFinishedJob(t *testing.T) {
 
 	// verify state changed appropriately
 	for i := 0; i < 5; i++ {
-		if s.clusterState.nodes[cluster.NodeId(fmt.Sprintf("node%d", i+1))].runningTask != noTask {
+		if len(s.clusterState.nodes[cluster.NodeId(fmt.Sprintf("node%d", i+1))].running) != 0 {
 			t.Errorf("Expected nodes to not have any running tasks")
 		}
 	}
@@ -690,3 +792,85 @@ func getDepsWithSimWorker() (*schedulerDeps, []*execers.SimExecer) {
 	}, nil
 
 }
+
+func Test_StatefulScheduler_DependentTasksWaitForDependencies(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+	s, _, _ := initializeServices(sc, false)
+
+	jobDef := sched.GenJobDef(2)
+	jobDef.Tasks[0].Argv = []string{"sleep 100", "complete 0"}
+	jobDef.Tasks[1].Argv = []string{"complete 0"}
+	jobDef.Tasks[1].DependsOn = []string{jobDef.Tasks[0].TaskID}
+	upstream, downstream := jobDef.Tasks[0].TaskID, jobDef.Tasks[1].TaskID
+
+	go func() {
+		checkJobMsg := <-s.checkJobCh
+		checkJobMsg.resultCh <- nil
+	}()
+	jobId, _ := s.ScheduleJob(jobDef)
+
+	for len(s.inProgressJobs) == 0 || s.getJob(jobId).getTask(upstream).Status != sched.Completed {
+		s.step()
+		if s.getJob(jobId) != nil && s.getJob(jobId).getTask(upstream).Status != sched.Completed &&
+			s.getJob(jobId).getTask(downstream).Status != sched.NotStarted {
+			t.Fatalf("Expected %s not to start before %s completed", downstream, upstream)
+		}
+	}
+
+	for s.getJob(jobId).getJobStatus() != sched.Completed {
+		s.step()
+	}
+	if s.getJob(jobId).getTask(downstream).Failed {
+		t.Errorf("Expected %s to succeed", downstream)
+	}
+	st, _ := workerapi.DeserializeProcessStatus(s.getJob(jobId).Saga.GetState().GetEndTaskData(downstream))
+	if st.State != runner.COMPLETE {
+		t.Errorf("Expected %s to run to completion, got %v", downstream, st)
+	}
+}
+
+func Test_StatefulScheduler_DependentTasksSkippedOnFailure(t *testing.T) {
+	sc := sagalogs.MakeInMemorySagaCoordinator()
+	s, _, statsRegistry := initializeServices(sc, false)
+
+	jobDef := sched.GenJobDef(3)
+	jobDef.Tasks[0].Argv = []string{"complete 1"}
+	jobDef.Tasks[1].Argv = []string{"complete 0"}
+	jobDef.Tasks[1].DependsOn = []string{jobDef.Tasks[0].TaskID}
+	jobDef.Tasks[2].Argv = []string{"complete 0"}
+	jobDef.Tasks[2].DependsOn = []string{jobDef.Tasks[1].TaskID}
+
+	go func() {
+		checkJobMsg := <-s.checkJobCh
+		checkJobMsg.resultCh <- nil
+	}()
+	jobId, _ := s.ScheduleJob(jobDef)
+
+	for len(s.inProgressJobs) == 0 || s.getJob(jobId).getJobStatus() != sched.Completed {
+		s.step()
+	}
+
+	for i, task := range jobDef.Tasks {
+		if !s.getJob(jobId).getTask(task.TaskID).Failed {
+			t.Errorf("Expected task %d to be marked failed", i)
+		}
+		if i == 0 {
+			continue
+		}
+		st, err := workerapi.DeserializeProcessStatus(s.getJob(jobId).Saga.GetState().GetEndTaskData(task.TaskID))
+		if err != nil || st.State != runner.ABORTED || !strings.HasPrefix(st.Error, DependencyFailedErrStr) {
+			t.Errorf("Expected task %d to be skipped, got %v, %v", i, st, err)
+		}
+	}
+
+	if !stats.StatsOk("", statsRegistry, t,
+		map[string]stats.Rule{
+			stats.SchedSkippedTaskCounter: {Checker: stats.Int64EqTest, Value: 2},
+		}) {
+		t.Fatal("stats check did not pass.")
+	}
+
+	for len(s.inProgressJobs) > 0 {
+		s.step()
+	}
+}
@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -31,10 +32,11 @@ type taskRunner struct {
 	stat   stats.StatsReceiver
 
 	markCompleteOnFailure bool
-	taskTimeoutOverhead   time.Duration // How long to wait for a response after the task has timed out.
-	defaultTaskTimeout    time.Duration // Use this timeout as the default for any cmds that don't have one.
-	runnerRetryTimeout    time.Duration // How long to keep retrying a runner req
-	runnerRetryInterval   time.Duration // How long to sleep between runner req retries.
+	retryPolicy           sched.RetryPolicy // Which errors are worth running the task again for.
+	taskTimeoutOverhead   time.Duration     // How long to wait for a response after the task has timed out.
+	defaultTaskTimeout    time.Duration     // Use this timeout as the default for any cmds that don't have one.
+	runnerRetryTimeout    time.Duration     // How long to keep retrying a runner req
+	runnerRetryInterval   time.Duration     // How long to sleep between runner req retries.
 
 	tags.LogTags
 	task   sched.TaskDefinition
@@ -42,6 +44,7 @@ type taskRunner struct {
 
 	abortCh      chan abortReq    // Primary channel to check for aborts
 	queryAbortCh chan interface{} // Secondary channel to pass to blocking query.
+	taskEnded    *int32           // Shared with any other attempt running at the same time, set by the first to end the task.
 
 	startTime time.Time
 }
@@ -52,6 +55,9 @@ type taskError struct {
 	runnerErr error
 	resultErr error // Note: resultErr is the error from trying to get the results of the command, not an error from the command
 	st        runner.RunStatus
+
+	deadLettered bool // The task was ended despite the error and shouldn't be retried.
+	ended        bool // This attempt logged, or tried to log, the task's EndTask.
 }
 
 func (t *taskError) Error() string {
@@ -100,10 +106,16 @@ func (r *taskRunner) run() error {
 			taskErr.resultErr = err
 		}
 	}
+	// A non-zero exit is the command's result rather than an error, unless the retry policy says to retry it.
+	if err == nil && completed && r.retryPolicy.RetriesExitCode(st.ExitCode) {
+		err = fmt.Errorf("exit code %d", st.ExitCode)
+		taskErr.resultErr = err
+	}
 
 	// We should write to sagalog if there's no error, or there's an error but the caller won't be retrying.
-	shouldDeadLetter := (err != nil && (end || r.markCompleteOnFailure))
+	shouldDeadLetter := (err != nil && (end || r.markCompleteOnFailure || !r.retryable(taskErr)))
 	shouldLog := (err == nil) || shouldDeadLetter
+	taskErr.deadLettered = shouldDeadLetter
 
 	// Update taskErr state if it's empty or if we're doing deadletter..
 	if taskErr.st.State == runner.UNKNOWN {
@@ -124,27 +136,55 @@ func (r *taskRunner) run() error {
 
 	log.WithFields(
 		log.Fields{
-			"node":       r.nodeSt.node,
-			"log":        shouldLog,
-			"runID":      taskErr.st.RunID,
-			"state":      taskErr.st.State,
-			"stdout":     taskErr.st.StdoutRef,
-			"stderr":     taskErr.st.StderrRef,
-			"snapshotID": taskErr.st.SnapshotID,
-			"exitCode":   taskErr.st.ExitCode,
-			"error":      taskErr.st.Error,
-			"jobID":      taskErr.st.JobID,
-			"taskID":     taskErr.st.TaskID,
-			"tag":        taskErr.st.Tag,
-			"err":        taskErr,
+			"node":          r.nodeSt.node,
+			"log":           shouldLog,
+			"runID":         taskErr.st.RunID,
+			"state":         taskErr.st.State,
+			"stdout":        taskErr.st.StdoutRef,
+			"stderr":        taskErr.st.StderrRef,
+			"snapshotID":    taskErr.st.SnapshotID,
+			"exitCode":      taskErr.st.ExitCode,
+			"error":         taskErr.st.Error,
+			"failureReason": taskErr.st.FailureReason,
+			"jobID":         taskErr.st.JobID,
+			"taskID":        taskErr.st.TaskID,
+			"tag":           taskErr.st.Tag,
+			"err":           taskErr,
 		}).Info("End task")
 	if !shouldLog {
 		if taskErr != nil {
 			r.stat.Counter(stats.SchedFailedTaskCounter).Inc(1)
 		}
+		// Record the attempt that's about to be retried. The task isn't done, so this is another StartTask.
+		if taskErr.st.State != runner.ABORTED {
+			if err := r.logTaskStatus(&taskErr.st, saga.StartTask); err != nil {
+				log.WithFields(
+					log.Fields{
+						"jobID":  r.JobID,
+						"taskID": r.TaskID,
+						"tag":    r.Tag,
+						"err":    err,
+					}).Info("Failed to record the attempt, it'll be missing from the task's history")
+			}
+		}
 		return taskErr
 	}
 
+	// A speculative attempt races the attempt it duplicates, the loser leaves the task to the winner.
+	if r.taskEnded != nil && !atomic.CompareAndSwapInt32(r.taskEnded, 0, 1) {
+		log.WithFields(
+			log.Fields{
+				"jobID":  r.JobID,
+				"taskID": r.TaskID,
+				"runID":  taskErr.st.RunID,
+				"tag":    r.Tag,
+			}).Info("Another attempt already ended the task")
+		taskErr.st = runner.AbortStatus(taskErr.st.RunID, r.LogTags)
+		taskErr.st.Error = SpeculativeAttemptLostErrStr
+		return taskErr
+	}
+	taskErr.ended = true
+
 	err = r.logTaskStatus(&taskErr.st, saga.EndTask)
 	taskErr.sagaErr = err
 	if taskErr.sagaErr == nil && taskErr.runnerErr == nil && taskErr.resultErr == nil {
@@ -156,6 +196,20 @@ func (r *taskRunner) run() error {
 	}
 }
 
+// Returns true if the retry policy retries the outcome of the attempt.
+func (r *taskRunner) retryable(taskErr *taskError) bool {
+	switch {
+	case taskErr.runnerErr != nil:
+		return r.retryPolicy.RetryOnWorkerFailure
+	case taskErr.st.State == runner.TIMEDOUT:
+		return r.retryPolicy.RetryOnTimeout
+	case taskErr.st.State == runner.COMPLETE:
+		return r.retryPolicy.RetriesExitCode(taskErr.st.ExitCode)
+	}
+	// Aborts are left to the scheduler, which doesn't retry the tasks of killed jobs.
+	return true
+}
+
 // Run cmd and if there's a runner error (ex: thrift) re-run/re-query until completion, retry timeout, or cmd timeout.
 func (r *taskRunner) runAndWait() (runner.RunStatus, bool, error) {
 	cmd := &r.task.Command
@@ -40,6 +40,7 @@ func get_testTaskRunner(s *saga.Saga, r runner.Service, jobId, taskId string,
 		stat:   stat,
 
 		markCompleteOnFailure: markCompleteOnFailure,
+		retryPolicy:           sched.DefaultRetryPolicy(),
 		defaultTaskTimeout:    30 * time.Second,
 		taskTimeoutOverhead:   1 * time.Second,
 		runnerRetryTimeout:    0,
@@ -149,6 +150,12 @@ func Test_runTaskAndLog_TaskFailsToRun(t *testing.T) {
 	sagaLogMock := saga.NewMockSagaLog(mockCtrl)
 	sagaLogMock.EXPECT().StartSaga("job1", nil)
 	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", nil))
+	// The failed attempt is recorded since it'll be retried.
+	var retStatus runner.RunStatus
+	retStatus.State = runner.FAILED
+	retStatus.Error = emptyStatusError("job1", "task1", fmt.Errorf("starting error"))
+	attemptStatus, _ := workerapi.SerializeProcessStatus(retStatus)
+	sagaLogMock.EXPECT().LogMessage(saga.MakeStartTaskMessage("job1", "task1", attemptStatus))
 	sagaCoord := saga.MakeSagaCoordinator(sagaLogMock)
 	s, _ := sagaCoord.MakeSaga("job1", nil)
 
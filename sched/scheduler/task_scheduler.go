@@ -5,7 +5,9 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/twitter/scoot/cloud/cluster"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/sched"
 )
 
@@ -271,6 +273,8 @@ func assign(
 	snapIds []string,
 	stat stats.StatsReceiver,
 ) (assignments []taskAssignment) {
+	// Resources of the tasks assigned to each node so far, a node stays idle until all its slots are assigned.
+	pending := map[cluster.NodeId][]runner.Resources{}
 	for _, task := range tasks {
 		var snapshotId string
 		var nodeSt *nodeState
@@ -278,7 +282,7 @@ func assign(
 		for _, snapId := range append([]string{task.Def.SnapshotID}, snapIds...) {
 			if groups, ok := nodeGroups[snapId]; ok {
 				for _, ns := range groups.idle {
-					if ns.suspended() {
+					if ns.suspended() || !ns.fits(task.Def.Resources, pending[ns.node.Id()]) {
 						continue
 					}
 					snapshotId = snapId
@@ -302,8 +306,11 @@ func assign(
 			nodeGroups[snapshotId] = newNodeGroup()
 		}
 		nodeId := nodeSt.node.Id()
-		nodeGroups[snapshotId].busy[nodeId] = nodeSt
-		delete(nodeGroups[snapshotId].idle, nodeId)
+		pending[nodeId] = append(pending[nodeId], task.Def.Resources)
+		if nodeSt.freeSlots() <= len(pending[nodeId]) {
+			nodeGroups[snapshotId].busy[nodeId] = nodeSt
+			delete(nodeGroups[snapshotId].idle, nodeId)
+		}
 		log.WithFields(
 			log.Fields{
 				"jobID":          task.JobId,
@@ -2,8 +2,10 @@ package scheduler
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/luci/go-render/render"
@@ -89,10 +91,10 @@ func Test_TaskAssignment_Affinity(t *testing.T) {
 	taskNodes := map[string]cluster.NodeId{}
 	for _, as := range assignments {
 		taskNodes[as.task.TaskId] = as.nodeSt.node.Id()
-		cs.taskScheduled(as.nodeSt.node.Id(), "job1", as.task.TaskId, as.task.Def.SnapshotID)
+		cs.taskScheduled(as.nodeSt.node.Id(), "job1", as.task.TaskId, as.task.Def.SnapshotID, as.task.Def.Resources)
 		js.taskStarted(as.task.TaskId, &taskRunner{})
 		if as.task.TaskId != "task1" {
-			cs.taskCompleted(as.nodeSt.node.Id(), false)
+			cs.taskCompleted(as.nodeSt.node.Id(), "job1", as.task.TaskId, false)
 			js.taskCompleted(as.task.TaskId, true)
 		}
 	}
@@ -115,6 +117,81 @@ func Test_TaskAssignment_Affinity(t *testing.T) {
 	}
 }
 
+// Tasks should only be assigned to nodes with enough capacity for the resources they request.
+func Test_TaskAssignment_Resources(t *testing.T) {
+	capacity := map[cluster.NodeId]runner.Resources{
+		"small": runner.Resources{Memory: 1 << 30, CPUs: 1},
+		"large": runner.Resources{Memory: 8 << 30, CPUs: 8},
+	}
+	readyFn := func(node cluster.Node) (bool, NodeCapacity, time.Duration) {
+		return true, NodeCapacity{Resources: capacity[node.Id()]}, 0
+	}
+	testCluster := makeTestCluster("small", "large")
+	cs := newClusterState(testCluster.nodes, testCluster.ch, readyFn, stats.NilStatsReceiver())
+	// Sleeping so nodeState goroutines can report readiness.
+	time.Sleep(10 * time.Millisecond)
+	cs.update(nil)
+
+	tasks := []*taskState{
+		&taskState{TaskId: "big", Def: sched.TaskDefinition{Command: runner.Command{Resources: runner.Resources{Memory: 4 << 30}}}},
+		&taskState{TaskId: "huge", Def: sched.TaskDefinition{Command: runner.Command{Resources: runner.Resources{CPUs: 16}}}},
+	}
+	js := &jobState{Job: &sched.Job{}, Tasks: tasks}
+	req := map[string][]*jobState{"": []*jobState{js}}
+	assignments, _ := getTaskAssignments(cs, []*jobState{js}, req, nil, stats.NilStatsReceiver())
+
+	assigned := map[string]cluster.NodeId{}
+	for _, as := range assignments {
+		assigned[as.task.TaskId] = as.nodeSt.node.Id()
+	}
+	expected := map[string]cluster.NodeId{"big": "large"}
+	if !reflect.DeepEqual(assigned, expected) {
+		t.Fatalf("Expected assignments %v, got: %v", expected, assigned)
+	}
+
+	// Jobs with tasks like "huge" are rejected by the scheduler against the largest capacity.
+	if largest, ok := cs.maxCapacity(); !ok || largest != capacity["large"] {
+		t.Fatalf("Expected max capacity %v, got: %v", capacity["large"], largest)
+	}
+}
+
+// A node with several slots should be assigned tasks until its slots are all in use.
+func Test_TaskAssignment_Slots(t *testing.T) {
+	readyFn := func(node cluster.Node) (bool, NodeCapacity, time.Duration) {
+		return true, NodeCapacity{Slots: 3}, 0
+	}
+	testCluster := makeTestCluster("node1")
+	cs := newClusterState(testCluster.nodes, testCluster.ch, readyFn, stats.NilStatsReceiver())
+	// Sleeping so nodeState goroutines can report readiness.
+	time.Sleep(10 * time.Millisecond)
+	cs.update(nil)
+	if cs.numFree() != 3 {
+		t.Fatalf("Expected 3 free slots, got: %d", cs.numFree())
+	}
+
+	tasks := []*taskState{
+		&taskState{TaskId: "task1", Def: sched.TaskDefinition{}},
+		&taskState{TaskId: "task2", Def: sched.TaskDefinition{}},
+		&taskState{TaskId: "task3", Def: sched.TaskDefinition{}},
+		&taskState{TaskId: "task4", Def: sched.TaskDefinition{}},
+	}
+	js := &jobState{Job: &sched.Job{}, Tasks: tasks}
+	req := map[string][]*jobState{"": []*jobState{js}}
+	assignments, nodeGroups := getTaskAssignments(cs, []*jobState{js}, req, nil, stats.NilStatsReceiver())
+
+	if len(assignments) != 3 {
+		t.Fatalf("Expected 3 assignments, got: %v", assignments)
+	}
+	for _, as := range assignments {
+		if as.nodeSt.node.Id() != "node1" {
+			t.Fatalf("Expected all tasks on node1, got: %v", as.nodeSt.node.Id())
+		}
+	}
+	if _, ok := nodeGroups[""].busy["node1"]; !ok || len(nodeGroups[""].idle) != 0 {
+		t.Fatalf("Expected node1 to be busy once all its slots are assigned, got: %s", spew.Sdump(nodeGroups))
+	}
+}
+
 // We want to see three tasks with TagX scheduled first, followed by one TagY, then the final TagX
 func Test_TaskAssignments_RequestorBatching(t *testing.T) {
 	js := []*jobState{
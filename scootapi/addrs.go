@@ -10,6 +10,8 @@ const DefaultWorker_HTTP string = "localhost:9093"
 const DefaultApiBundlestore_HTTP string = "localhost:9094"
 const DefaultApiBundlestore_GRPC string = "localhost:9098"
 
+const DefaultSagaLogReplica_HTTP string = "localhost:9095"
+
 // Port ranges to make setup of multiple workerServer/apiServer more repeatable.
 const WorkerPorts = 10100
 const ApiBundlestorePorts = 11100
@@ -129,6 +129,23 @@ func (c *CloudScootClient) ReinstateWorker(req *scoot.ReinstateWorkerReq) error
 	return err
 }
 
+func (c *CloudScootClient) GetFlakyTasks(req *scoot.GetFlakyTasksReq) (*scoot.FlakyTasks, error) {
+	err := c.checkForClient()
+	if err != nil {
+		return nil, err
+	}
+	flakyTasks, err := c.client.GetFlakyTasks(req)
+	// if an error occurred reset the connection, could be a broken pipe or other
+	// unrecoverable error.  reset connection so a new clean one gets created
+	// on the next request
+	if err != nil {
+		// this could cause an error when closing transport
+		// but we don't care do our best effort and move on
+		c.closeConnection()
+	}
+	return flakyTasks, err
+}
+
 // helper method to check for a non-nil client / create one
 func (c *CloudScootClient) checkForClient() (err error) {
 	if c.client == nil {
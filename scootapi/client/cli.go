@@ -48,6 +48,7 @@ func NewSimpleCLIClient(d dialer.Dialer) (CLIClient, error) {
 	c.addCmd(&killJobCmd{})
 	c.addCmd(&offlineWorkerCmd{})
 	c.addCmd(&reinstateWorkerCmd{})
+	c.addCmd(&getFlakyTasksCmd{})
 
 	return c, nil
 }
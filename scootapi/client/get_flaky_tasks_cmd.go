@@ -0,0 +1,65 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+type getFlakyTasksCmd struct {
+	tag         string
+	minScore    float64
+	printAsJson bool
+}
+
+func (c *getFlakyTasksCmd) registerFlags() *cobra.Command {
+	r := &cobra.Command{
+		Use:   "get_flaky_tasks",
+		Short: "GetFlakyTasks",
+	}
+	r.Flags().StringVar(&c.tag, "tag", "", "Only list tasks of jobs with this tag")
+	r.Flags().Float64Var(&c.minScore, "min_score", 0, "Only list tasks with at least this flakiness score, between 0 and 1")
+	r.Flags().BoolVar(&c.printAsJson, "json", false, "Print out tasks as JSON")
+	return r
+}
+
+func (c *getFlakyTasksCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
+	log.Info("Getting flaky tasks")
+
+	req := scoot.NewGetFlakyTasksReq()
+	if c.tag != "" {
+		req.Tag = &c.tag
+	}
+	req.MinScore = &c.minScore
+	flakyTasks, err := cl.scootClient.GetFlakyTasks(req)
+	if err != nil {
+		switch err := err.(type) {
+		case *scoot.InvalidRequest:
+			return fmt.Errorf("Invalid Request: %v", err.GetMessage())
+		case *scoot.ScootServerError:
+			return fmt.Errorf("Scoot server error: %v", err.Error())
+		default:
+			return fmt.Errorf("Error getting flaky tasks: %v", err.Error())
+		}
+	}
+
+	if c.printAsJson {
+		asJson, err := json.Marshal(flakyTasks)
+		if err != nil {
+			return fmt.Errorf("Error converting flaky tasks to JSON: %v", err.Error())
+		}
+		fmt.Printf("%s\n", asJson)
+		return nil
+	}
+	for _, t := range flakyTasks.Tasks {
+		lastFlaky := time.Unix(0, t.LastFlakyEpochMs*int64(time.Millisecond))
+		fmt.Printf("%.2f\t%d/%d runs flaky, last at %s\t%s\t%s\n",
+			t.Score, t.NumFlakyRuns, t.NumRuns, lastFlaky.Format(time.RFC3339), t.Tag, t.TaskId)
+	}
+	return nil
+}
@@ -43,14 +43,30 @@ type CLIJobDef struct {
 	Basis                string
 	JobType              string
 	Requestor            string
+
+	// Retry policy, where unset fields take the scheduler's defaults.
+	MaxTaskAttempts      int32
+	RetryOnWorkerFailure *bool
+	RetryOnTimeout       *bool
+	RetryOnNonZeroExit   bool
+	RetryOnExitCodes     []int32
+	RetryBackoffMs       int32
+	MaxRetryBackoffMs    int32
 }
 
 type TaskDef struct {
-	Args       []string
-	EnvVars    map[string]string
-	SnapshotID string
-	TimeoutMs  int32
-	TaskID     string
+	Args             []string
+	EnvVars          map[string]string
+	SnapshotID       string
+	TimeoutMs        int32
+	TaskID           string
+	DependsOn        []string
+	SnapshotFromTask string
+	MergeSnapshot    bool
+	MemoryBytes      int64
+	CPUs             float64
+	DiskBytes        int64
+	AllowNetwork     bool
 }
 
 func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string) error {
@@ -99,6 +115,21 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 		jobDef.JobType = &jsonJob.JobType
 		jobDef.Requestor = &jsonJob.Requestor
 		jobDef.Priority = &jsonJob.Priority
+		if jsonJob.MaxTaskAttempts > 0 {
+			jobDef.MaxTaskAttempts = &jsonJob.MaxTaskAttempts
+		}
+		jobDef.RetryOnWorkerFailure = jsonJob.RetryOnWorkerFailure
+		jobDef.RetryOnTimeout = jsonJob.RetryOnTimeout
+		if jsonJob.RetryOnNonZeroExit {
+			jobDef.RetryOnNonZeroExit = &jsonJob.RetryOnNonZeroExit
+		}
+		jobDef.RetryOnExitCodes = jsonJob.RetryOnExitCodes
+		if jsonJob.RetryBackoffMs > 0 {
+			jobDef.RetryBackoffMs = &jsonJob.RetryBackoffMs
+		}
+		if jsonJob.MaxRetryBackoffMs > 0 {
+			jobDef.MaxRetryBackoffMs = &jsonJob.MaxRetryBackoffMs
+		}
 		jobDef.Tasks = []*scoot.TaskDefinition{}
 		for _, jsonTask := range jsonJob.Tasks {
 			jt := jsonTask
@@ -111,10 +142,27 @@ func (c *runJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string)
 			}
 			taskDef.SnapshotId = &jt.SnapshotID
 			taskDef.TaskId = &jt.TaskID
+			taskDef.DependsOn = jt.DependsOn
+			if jt.SnapshotFromTask != "" {
+				taskDef.SnapshotFromTask = &jt.SnapshotFromTask
+				taskDef.MergeSnapshot = &jt.MergeSnapshot
+			}
 			jobDef.Tasks = append(jobDef.Tasks, taskDef)
 			if jt.TimeoutMs > 0 {
 				taskDef.TimeoutMs = &jt.TimeoutMs
 			}
+			if jt.MemoryBytes != 0 {
+				taskDef.MemoryBytes = &jt.MemoryBytes
+			}
+			if jt.CPUs != 0 {
+				taskDef.Cpus = &jt.CPUs
+			}
+			if jt.DiskBytes != 0 {
+				taskDef.DiskBytes = &jt.DiskBytes
+			}
+			if jt.AllowNetwork {
+				taskDef.AllowNetwork = &jt.AllowNetwork
+			}
 		}
 	}
 
@@ -1,12 +1,20 @@
 package client
 
 import (
+	"bufio"
 	"fmt"
-	log "github.com/sirupsen/logrus"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
 	"time"
 
+	log "github.com/sirupsen/logrus"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/twitter/scoot/scootapi"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 )
 
@@ -15,7 +23,9 @@ const (
 )
 
 type watchJobCmd struct {
-	jobId string
+	jobId      string
+	followLogs bool
+	httpAddr   string
 }
 
 func (c *watchJobCmd) registerFlags() *cobra.Command {
@@ -23,7 +33,8 @@ func (c *watchJobCmd) registerFlags() *cobra.Command {
 		Use:   "watch_job",
 		Short: "Watch job",
 	}
-
+	r.Flags().BoolVar(&c.followLogs, "follow-logs", false, "Print the stdout and stderr of running tasks as it's produced")
+	r.Flags().StringVar(&c.httpAddr, "http_addr", "", "'host:port' of the scheduler's http server, defaults to the host of addr with the default http port")
 	return r
 }
 
@@ -37,13 +48,24 @@ func (c *watchJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string
 
 	jobId := args[0]
 
+	logs := &taskLogsFollower{httpAddr: c.logsAddr(cl.addr), client: scootapi.NewTaskLogsClient(), following: map[string]bool{}}
 	for {
-		jobStatus, err := GetAndPrintStatus(jobId, cl.scootClient)
+		jobStatus, err := getAndPrintJobStatus(jobId, cl.scootClient)
 		if err != nil {
 			return err
 		}
 
-		if *jobStatus == scoot.Status_COMPLETED || *jobStatus == scoot.Status_ROLLED_BACK {
+		if c.followLogs {
+			for taskId, taskStatus := range jobStatus.TaskStatus {
+				if taskStatus == scoot.Status_IN_PROGRESS {
+					logs.follow(jobId, taskId)
+				}
+			}
+		}
+
+		if jobStatus.Status == scoot.Status_COMPLETED || jobStatus.Status == scoot.Status_ROLLED_BACK {
+			// Print whatever output is still on its way
+			logs.wait()
 			return nil
 		}
 
@@ -52,7 +74,80 @@ func (c *watchJobCmd) run(cl *simpleCLIClient, cmd *cobra.Command, args []string
 
 }
 
+// The scheduler's http server is expected on the same host as its thrift server, unless http_addr is given.
+func (c *watchJobCmd) logsAddr(addr string) string {
+	if c.httpAddr != "" {
+		return c.httpAddr
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return scootapi.DefaultSched_HTTP
+	}
+	_, port, _ := net.SplitHostPort(scootapi.DefaultSched_HTTP)
+	return net.JoinHostPort(host, port)
+}
+
+// Streams the output of running tasks from the scheduler, prefixing each line with its task id.
+// Requests give up if the scheduler doesn't respond within scootapi.TaskLogsResponseTimeout, so that
+// a hung request doesn't stop the job from being watched, and are retried the next time follow is called.
+type taskLogsFollower struct {
+	httpAddr  string
+	client    *http.Client
+	following map[string]bool // Keyed by taskId/stream, ex: "task1/stdout"
+	wg        sync.WaitGroup
+	mu        sync.Mutex // Keeps lines from different tasks from interleaving
+}
+
+// Starts streaming the stdout and stderr of the task, unless they're already being streamed.
+// If the worker isn't serving a stream yet, it's retried the next time follow is called for the task.
+func (f *taskLogsFollower) follow(jobId, taskId string) {
+	for _, stream := range []struct {
+		name string
+		out  io.Writer
+	}{{"stdout", os.Stdout}, {"stderr", os.Stderr}} {
+		key := taskId + "/" + stream.name
+		if f.following[key] {
+			continue
+		}
+		url := fmt.Sprintf("http://%s%s%s/%s/%s", f.httpAddr, scootapi.TaskLogsPath, jobId, taskId, stream.name)
+		resp, err := f.client.Get(url)
+		if err == nil && resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			err = fmt.Errorf(resp.Status)
+		}
+		if err != nil {
+			log.Infof("%s of task %s isn't available yet: %v", stream.name, taskId, err)
+			continue
+		}
+		f.following[key] = true
+		f.wg.Add(1)
+		go func(body io.ReadCloser, out io.Writer) {
+			defer f.wg.Done()
+			defer body.Close()
+			scanner := bufio.NewScanner(body)
+			for scanner.Scan() {
+				f.mu.Lock()
+				fmt.Fprintf(out, "%s: %s\n", taskId, scanner.Text())
+				f.mu.Unlock()
+			}
+		}(resp.Body, stream.out)
+	}
+}
+
+// Blocks until all streamed output has been printed.
+func (f *taskLogsFollower) wait() {
+	f.wg.Wait()
+}
+
 func GetAndPrintStatus(jobId string, thriftClient scoot.CloudScoot) (*scoot.Status, error) {
+	status, err := getAndPrintJobStatus(jobId, thriftClient)
+	if err != nil {
+		return nil, err
+	}
+	return &status.Status, nil
+}
+
+func getAndPrintJobStatus(jobId string, thriftClient scoot.CloudScoot) (*scoot.JobStatus, error) {
 
 	status, err := thriftClient.GetStatus(jobId)
 	if err != nil {
@@ -65,7 +160,7 @@ func GetAndPrintStatus(jobId string, thriftClient scoot.CloudScoot) (*scoot.Stat
 	}
 	PrintJobStatus(status)
 
-	return &status.Status, nil
+	return status, nil
 
 }
 
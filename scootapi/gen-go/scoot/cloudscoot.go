@@ -33,6 +33,9 @@ type CloudScoot interface {
 	// Parameters:
 	//  - Req
 	ReinstateWorker(req *ReinstateWorkerReq) (err error)
+	// Parameters:
+	//  - Req
+	GetFlakyTasks(req *GetFlakyTasksReq) (r *FlakyTasks, err error)
 }
 
 type CloudScootClient struct {
@@ -111,16 +114,16 @@ func (p *CloudScootClient) recvRunJob() (value *JobId, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error8 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error9 error
-		error9, err = error8.Read(iprot)
+		error12 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error13 error
+		error13, err = error12.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error9
+		err = error13
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -195,16 +198,16 @@ func (p *CloudScootClient) recvGetStatus() (value *JobStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error10 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error11 error
-		error11, err = error10.Read(iprot)
+		error14 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error15 error
+		error15, err = error14.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error11
+		err = error15
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -279,16 +282,16 @@ func (p *CloudScootClient) recvKillJob() (value *JobStatus, err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error12 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error13 error
-		error13, err = error12.Read(iprot)
+		error16 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error17 error
+		error17, err = error16.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error13
+		err = error17
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -363,16 +366,16 @@ func (p *CloudScootClient) recvOfflineWorker() (err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error14 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error15 error
-		error15, err = error14.Read(iprot)
+		error18 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error19 error
+		error19, err = error18.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error15
+		err = error19
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -446,16 +449,16 @@ func (p *CloudScootClient) recvReinstateWorker() (err error) {
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error16 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error17 error
-		error17, err = error16.Read(iprot)
+		error20 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error21 error
+		error21, err = error20.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error17
+		err = error21
 		return
 	}
 	if mTypeId != thrift.REPLY {
@@ -479,6 +482,90 @@ func (p *CloudScootClient) recvReinstateWorker() (err error) {
 	return
 }
 
+// Parameters:
+//  - Req
+func (p *CloudScootClient) GetFlakyTasks(req *GetFlakyTasksReq) (r *FlakyTasks, err error) {
+	if err = p.sendGetFlakyTasks(req); err != nil {
+		return
+	}
+	return p.recvGetFlakyTasks()
+}
+
+func (p *CloudScootClient) sendGetFlakyTasks(req *GetFlakyTasksReq) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("GetFlakyTasks", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := CloudScootGetFlakyTasksArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *CloudScootClient) recvGetFlakyTasks() (value *FlakyTasks, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "GetFlakyTasks" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "GetFlakyTasks failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "GetFlakyTasks failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error22 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error23 error
+		error23, err = error22.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error23
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "GetFlakyTasks failed: invalid message type")
+		return
+	}
+	result := CloudScootGetFlakyTasksResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Ir != nil {
+		err = result.Ir
+		return
+	} else if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
 type CloudScootProcessor struct {
 	processorMap map[string]thrift.TProcessorFunction
 	handler      CloudScoot
@@ -499,13 +586,14 @@ func (p *CloudScootProcessor) ProcessorMap() map[string]thrift.TProcessorFunctio
 
 func NewCloudScootProcessor(handler CloudScoot) *CloudScootProcessor {
 
-	self18 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self18.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
-	self18.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
-	self18.processorMap["KillJob"] = &cloudScootProcessorKillJob{handler: handler}
-	self18.processorMap["OfflineWorker"] = &cloudScootProcessorOfflineWorker{handler: handler}
-	self18.processorMap["ReinstateWorker"] = &cloudScootProcessorReinstateWorker{handler: handler}
-	return self18
+	self24 := &CloudScootProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self24.processorMap["RunJob"] = &cloudScootProcessorRunJob{handler: handler}
+	self24.processorMap["GetStatus"] = &cloudScootProcessorGetStatus{handler: handler}
+	self24.processorMap["KillJob"] = &cloudScootProcessorKillJob{handler: handler}
+	self24.processorMap["OfflineWorker"] = &cloudScootProcessorOfflineWorker{handler: handler}
+	self24.processorMap["ReinstateWorker"] = &cloudScootProcessorReinstateWorker{handler: handler}
+	self24.processorMap["GetFlakyTasks"] = &cloudScootProcessorGetFlakyTasks{handler: handler}
+	return self24
 }
 
 func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
@@ -518,12 +606,12 @@ func (p *CloudScootProcessor) Process(iprot, oprot thrift.TProtocol) (success bo
 	}
 	iprot.Skip(thrift.STRUCT)
 	iprot.ReadMessageEnd()
-	x19 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	x23 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
 	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-	x19.Write(oprot)
+	x23.Write(oprot)
 	oprot.WriteMessageEnd()
 	oprot.Flush()
-	return false, x19
+	return false, x23
 
 }
 
@@ -796,6 +884,61 @@ func (p *cloudScootProcessorReinstateWorker) Process(seqId int32, iprot, oprot t
 	return true, err
 }
 
+type cloudScootProcessorGetFlakyTasks struct {
+	handler CloudScoot
+}
+
+func (p *cloudScootProcessorGetFlakyTasks) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := CloudScootGetFlakyTasksArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("GetFlakyTasks", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := CloudScootGetFlakyTasksResult{}
+	var retval *FlakyTasks
+	var err2 error
+	if retval, err2 = p.handler.GetFlakyTasks(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *InvalidRequest:
+			result.Ir = v
+		case *ScootServerError:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing GetFlakyTasks: "+err2.Error())
+			oprot.WriteMessageBegin("GetFlakyTasks", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("GetFlakyTasks", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
+}
+
 // HELPER FUNCTIONS AND STRUCTURES
 
 // Attributes:
@@ -2141,3 +2284,293 @@ func (p *CloudScootReinstateWorkerResult) String() string {
 	}
 	return fmt.Sprintf("CloudScootReinstateWorkerResult(%+v)", *p)
 }
+
+// Attributes:
+//  - Req
+type CloudScootGetFlakyTasksArgs struct {
+	Req *GetFlakyTasksReq `thrift:"req,1" json:"req"`
+}
+
+func NewCloudScootGetFlakyTasksArgs() *CloudScootGetFlakyTasksArgs {
+	return &CloudScootGetFlakyTasksArgs{}
+}
+
+var CloudScootGetFlakyTasksArgs_Req_DEFAULT *GetFlakyTasksReq
+
+func (p *CloudScootGetFlakyTasksArgs) GetReq() *GetFlakyTasksReq {
+	if !p.IsSetReq() {
+		return CloudScootGetFlakyTasksArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *CloudScootGetFlakyTasksArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *CloudScootGetFlakyTasksArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksArgs) readField1(iprot thrift.TProtocol) error {
+	p.Req = &GetFlakyTasksReq{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetFlakyTasks_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *CloudScootGetFlakyTasksArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetFlakyTasksArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Ir
+//  - Err
+type CloudScootGetFlakyTasksResult struct {
+	Success *FlakyTasks        `thrift:"success,0" json:"success,omitempty"`
+	Ir      *InvalidRequest   `thrift:"ir,1" json:"ir,omitempty"`
+	Err     *ScootServerError `thrift:"err,2" json:"err,omitempty"`
+}
+
+func NewCloudScootGetFlakyTasksResult() *CloudScootGetFlakyTasksResult {
+	return &CloudScootGetFlakyTasksResult{}
+}
+
+var CloudScootGetFlakyTasksResult_Success_DEFAULT *FlakyTasks
+
+func (p *CloudScootGetFlakyTasksResult) GetSuccess() *FlakyTasks {
+	if !p.IsSetSuccess() {
+		return CloudScootGetFlakyTasksResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var CloudScootGetFlakyTasksResult_Ir_DEFAULT *InvalidRequest
+
+func (p *CloudScootGetFlakyTasksResult) GetIr() *InvalidRequest {
+	if !p.IsSetIr() {
+		return CloudScootGetFlakyTasksResult_Ir_DEFAULT
+	}
+	return p.Ir
+}
+
+var CloudScootGetFlakyTasksResult_Err_DEFAULT *ScootServerError
+
+func (p *CloudScootGetFlakyTasksResult) GetErr() *ScootServerError {
+	if !p.IsSetErr() {
+		return CloudScootGetFlakyTasksResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *CloudScootGetFlakyTasksResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) IsSetIr() bool {
+	return p.Ir != nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.readField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) readField0(iprot thrift.TProtocol) error {
+	p.Success = &FlakyTasks{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) readField1(iprot thrift.TProtocol) error {
+	p.Ir = &InvalidRequest{}
+	if err := p.Ir.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Ir), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) readField2(iprot thrift.TProtocol) error {
+	p.Err = &ScootServerError{}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetFlakyTasks_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField0(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *CloudScootGetFlakyTasksResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetFlakyTasksResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetIr() {
+		if err := oprot.WriteFieldBegin("ir", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ir: ", p), err)
+		}
+		if err := p.Ir.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Ir), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ir: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetFlakyTasksResult) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:err: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *CloudScootGetFlakyTasksResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("CloudScootGetFlakyTasksResult(%+v)", *p)
+}
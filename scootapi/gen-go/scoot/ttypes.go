@@ -519,18 +519,20 @@ func (p *ScootServerError) Error() string {
 //  - TaskId
 //  - Tag
 //  - BazelResult_
+//  - FailureReason
 type RunStatus struct {
-	Status       RunStatusState       `thrift:"status,1,required" json:"status"`
-	RunId        string               `thrift:"runId,2,required" json:"runId"`
-	OutUri       *string              `thrift:"outUri,3" json:"outUri,omitempty"`
-	ErrUri       *string              `thrift:"errUri,4" json:"errUri,omitempty"`
-	Error        *string              `thrift:"error,5" json:"error,omitempty"`
-	ExitCode     *int32               `thrift:"exitCode,6" json:"exitCode,omitempty"`
-	SnapshotId   *string              `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
-	JobId        *string              `thrift:"jobId,8" json:"jobId,omitempty"`
-	TaskId       *string              `thrift:"taskId,9" json:"taskId,omitempty"`
-	Tag          *string              `thrift:"tag,10" json:"tag,omitempty"`
-	BazelResult_ *bazel.ActionResult_ `thrift:"bazelResult,11" json:"bazelResult,omitempty"`
+	Status        RunStatusState       `thrift:"status,1,required" json:"status"`
+	RunId         string               `thrift:"runId,2,required" json:"runId"`
+	OutUri        *string              `thrift:"outUri,3" json:"outUri,omitempty"`
+	ErrUri        *string              `thrift:"errUri,4" json:"errUri,omitempty"`
+	Error         *string              `thrift:"error,5" json:"error,omitempty"`
+	ExitCode      *int32               `thrift:"exitCode,6" json:"exitCode,omitempty"`
+	SnapshotId    *string              `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
+	JobId         *string              `thrift:"jobId,8" json:"jobId,omitempty"`
+	TaskId        *string              `thrift:"taskId,9" json:"taskId,omitempty"`
+	Tag           *string              `thrift:"tag,10" json:"tag,omitempty"`
+	BazelResult_  *bazel.ActionResult_ `thrift:"bazelResult,11" json:"bazelResult,omitempty"`
+	FailureReason *string              `thrift:"failureReason,12" json:"failureReason,omitempty"`
 }
 
 func NewRunStatus() *RunStatus {
@@ -625,6 +627,15 @@ func (p *RunStatus) GetBazelResult_() *bazel.ActionResult_ {
 	}
 	return p.BazelResult_
 }
+
+var RunStatus_FailureReason_DEFAULT string
+
+func (p *RunStatus) GetFailureReason() string {
+	if !p.IsSetFailureReason() {
+		return RunStatus_FailureReason_DEFAULT
+	}
+	return *p.FailureReason
+}
 func (p *RunStatus) IsSetOutUri() bool {
 	return p.OutUri != nil
 }
@@ -661,6 +672,10 @@ func (p *RunStatus) IsSetBazelResult_() bool {
 	return p.BazelResult_ != nil
 }
 
+func (p *RunStatus) IsSetFailureReason() bool {
+	return p.FailureReason != nil
+}
+
 func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -724,6 +739,10 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField11(iprot); err != nil {
 				return err
 			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -844,6 +863,15 @@ func (p *RunStatus) readField11(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RunStatus) readField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.FailureReason = &v
+	}
+	return nil
+}
+
 func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -881,6 +909,9 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField11(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1051,6 +1082,21 @@ func (p *RunStatus) writeField11(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunStatus) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFailureReason() {
+		if err := oprot.WriteFieldBegin("failureReason", thrift.STRING, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:failureReason: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.FailureReason)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.failureReason (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:failureReason: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1248,11 +1294,25 @@ func (p *Command) String() string {
 //  - SnapshotId
 //  - TaskId
 //  - TimeoutMs
+//  - DependsOn
+//  - SnapshotFromTask
+//  - MergeSnapshot
+//  - MemoryBytes
+//  - Cpus
+//  - DiskBytes
+//  - AllowNetwork
 type TaskDefinition struct {
-	Command    *Command `thrift:"command,1,required" json:"command"`
-	SnapshotId *string  `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
-	TaskId     *string  `thrift:"taskId,3" json:"taskId,omitempty"`
-	TimeoutMs  *int32   `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	Command          *Command `thrift:"command,1,required" json:"command"`
+	SnapshotId       *string  `thrift:"snapshotId,2" json:"snapshotId,omitempty"`
+	TaskId           *string  `thrift:"taskId,3" json:"taskId,omitempty"`
+	TimeoutMs        *int32   `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	DependsOn        []string `thrift:"dependsOn,5" json:"dependsOn,omitempty"`
+	SnapshotFromTask *string  `thrift:"snapshotFromTask,6" json:"snapshotFromTask,omitempty"`
+	MergeSnapshot    *bool    `thrift:"mergeSnapshot,7" json:"mergeSnapshot,omitempty"`
+	MemoryBytes      *int64   `thrift:"memoryBytes,8" json:"memoryBytes,omitempty"`
+	Cpus             *float64 `thrift:"cpus,9" json:"cpus,omitempty"`
+	DiskBytes        *int64   `thrift:"diskBytes,10" json:"diskBytes,omitempty"`
+	AllowNetwork     *bool    `thrift:"allowNetwork,11" json:"allowNetwork,omitempty"`
 }
 
 func NewTaskDefinition() *TaskDefinition {
@@ -1294,6 +1354,66 @@ func (p *TaskDefinition) GetTimeoutMs() int32 {
 	}
 	return *p.TimeoutMs
 }
+
+var TaskDefinition_DependsOn_DEFAULT []string
+
+func (p *TaskDefinition) GetDependsOn() []string {
+	return p.DependsOn
+}
+
+var TaskDefinition_SnapshotFromTask_DEFAULT string
+
+func (p *TaskDefinition) GetSnapshotFromTask() string {
+	if !p.IsSetSnapshotFromTask() {
+		return TaskDefinition_SnapshotFromTask_DEFAULT
+	}
+	return *p.SnapshotFromTask
+}
+
+var TaskDefinition_MergeSnapshot_DEFAULT bool
+
+func (p *TaskDefinition) GetMergeSnapshot() bool {
+	if !p.IsSetMergeSnapshot() {
+		return TaskDefinition_MergeSnapshot_DEFAULT
+	}
+	return *p.MergeSnapshot
+}
+
+var TaskDefinition_MemoryBytes_DEFAULT int64
+
+func (p *TaskDefinition) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return TaskDefinition_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+
+var TaskDefinition_Cpus_DEFAULT float64
+
+func (p *TaskDefinition) GetCpus() float64 {
+	if !p.IsSetCpus() {
+		return TaskDefinition_Cpus_DEFAULT
+	}
+	return *p.Cpus
+}
+
+var TaskDefinition_DiskBytes_DEFAULT int64
+
+func (p *TaskDefinition) GetDiskBytes() int64 {
+	if !p.IsSetDiskBytes() {
+		return TaskDefinition_DiskBytes_DEFAULT
+	}
+	return *p.DiskBytes
+}
+
+var TaskDefinition_AllowNetwork_DEFAULT bool
+
+func (p *TaskDefinition) GetAllowNetwork() bool {
+	if !p.IsSetAllowNetwork() {
+		return TaskDefinition_AllowNetwork_DEFAULT
+	}
+	return *p.AllowNetwork
+}
 func (p *TaskDefinition) IsSetCommand() bool {
 	return p.Command != nil
 }
@@ -1310,6 +1430,34 @@ func (p *TaskDefinition) IsSetTimeoutMs() bool {
 	return p.TimeoutMs != nil
 }
 
+func (p *TaskDefinition) IsSetDependsOn() bool {
+	return p.DependsOn != nil
+}
+
+func (p *TaskDefinition) IsSetSnapshotFromTask() bool {
+	return p.SnapshotFromTask != nil
+}
+
+func (p *TaskDefinition) IsSetMergeSnapshot() bool {
+	return p.MergeSnapshot != nil
+}
+
+func (p *TaskDefinition) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *TaskDefinition) IsSetCpus() bool {
+	return p.Cpus != nil
+}
+
+func (p *TaskDefinition) IsSetDiskBytes() bool {
+	return p.DiskBytes != nil
+}
+
+func (p *TaskDefinition) IsSetAllowNetwork() bool {
+	return p.AllowNetwork != nil
+}
+
 func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -1343,6 +1491,34 @@ func (p *TaskDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField4(iprot); err != nil {
 				return err
 			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+		case 11:
+			if err := p.readField11(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1396,6 +1572,82 @@ func (p *TaskDefinition) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *TaskDefinition) readField5(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.DependsOn = tSlice
+	for i := 0; i < size; i++ {
+		var _elem3 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem3 = v
+		}
+		p.DependsOn = append(p.DependsOn, _elem3)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.SnapshotFromTask = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.MergeSnapshot = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.Cpus = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.DiskBytes = &v
+	}
+	return nil
+}
+
+func (p *TaskDefinition) readField11(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 11: ", err)
+	} else {
+		p.AllowNetwork = &v
+	}
+	return nil
+}
+
 func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("TaskDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1412,6 +1664,27 @@ func (p *TaskDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField4(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1479,6 +1752,119 @@ func (p *TaskDefinition) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *TaskDefinition) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDependsOn() {
+		if err := oprot.WriteFieldBegin("dependsOn", thrift.LIST, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:dependsOn: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.DependsOn)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.DependsOn {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:dependsOn: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSnapshotFromTask() {
+		if err := oprot.WriteFieldBegin("snapshotFromTask", thrift.STRING, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:snapshotFromTask: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.SnapshotFromTask)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.snapshotFromTask (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:snapshotFromTask: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMergeSnapshot() {
+		if err := oprot.WriteFieldBegin("mergeSnapshot", thrift.BOOL, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:mergeSnapshot: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.MergeSnapshot)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.mergeSnapshot (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:mergeSnapshot: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpus() {
+		if err := oprot.WriteFieldBegin("cpus", thrift.DOUBLE, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:cpus: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.Cpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpus (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:cpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDiskBytes() {
+		if err := oprot.WriteFieldBegin("diskBytes", thrift.I64, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:diskBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.DiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.diskBytes (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:diskBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *TaskDefinition) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAllowNetwork() {
+		if err := oprot.WriteFieldBegin("allowNetwork", thrift.BOOL, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:allowNetwork: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.AllowNetwork)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.allowNetwork (11) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:allowNetwork: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *TaskDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -1495,6 +1881,13 @@ func (p *TaskDefinition) String() string {
 //  - Basis
 //  - Requestor
 //  - JobType
+//  - MaxTaskAttempts
+//  - RetryOnWorkerFailure
+//  - RetryOnTimeout
+//  - RetryOnNonZeroExit
+//  - RetryOnExitCodes
+//  - RetryBackoffMs
+//  - MaxRetryBackoffMs
 type JobDefinition struct {
 	Tasks                []*TaskDefinition `thrift:"tasks,1,required" json:"tasks"`
 	DEPRECATEDJobType    *JobType          `thrift:"DEPRECATED_jobType,2" json:"DEPRECATED_jobType,omitempty"`
@@ -1504,6 +1897,13 @@ type JobDefinition struct {
 	Basis                *string           `thrift:"basis,6" json:"basis,omitempty"`
 	Requestor            *string           `thrift:"requestor,7" json:"requestor,omitempty"`
 	JobType              *string           `thrift:"jobType,8" json:"jobType,omitempty"`
+	MaxTaskAttempts      *int32            `thrift:"maxTaskAttempts,9" json:"maxTaskAttempts,omitempty"`
+	RetryOnWorkerFailure *bool             `thrift:"retryOnWorkerFailure,10" json:"retryOnWorkerFailure,omitempty"`
+	RetryOnTimeout       *bool             `thrift:"retryOnTimeout,11" json:"retryOnTimeout,omitempty"`
+	RetryOnNonZeroExit   *bool             `thrift:"retryOnNonZeroExit,12" json:"retryOnNonZeroExit,omitempty"`
+	RetryOnExitCodes     []int32           `thrift:"retryOnExitCodes,13" json:"retryOnExitCodes,omitempty"`
+	RetryBackoffMs       *int32            `thrift:"retryBackoffMs,14" json:"retryBackoffMs,omitempty"`
+	MaxRetryBackoffMs    *int32            `thrift:"maxRetryBackoffMs,15" json:"maxRetryBackoffMs,omitempty"`
 }
 
 func NewJobDefinition() *JobDefinition {
@@ -1576,32 +1976,120 @@ func (p *JobDefinition) GetJobType() string {
 	}
 	return *p.JobType
 }
-func (p *JobDefinition) IsSetDEPRECATEDJobType() bool {
-	return p.DEPRECATEDJobType != nil
-}
 
-func (p *JobDefinition) IsSetDefaultTaskTimeoutMs() bool {
-	return p.DefaultTaskTimeoutMs != nil
+var JobDefinition_MaxTaskAttempts_DEFAULT int32
+
+func (p *JobDefinition) GetMaxTaskAttempts() int32 {
+	if !p.IsSetMaxTaskAttempts() {
+		return JobDefinition_MaxTaskAttempts_DEFAULT
+	}
+	return *p.MaxTaskAttempts
 }
 
-func (p *JobDefinition) IsSetPriority() bool {
-	return p.Priority != nil
+var JobDefinition_RetryOnWorkerFailure_DEFAULT bool
+
+func (p *JobDefinition) GetRetryOnWorkerFailure() bool {
+	if !p.IsSetRetryOnWorkerFailure() {
+		return JobDefinition_RetryOnWorkerFailure_DEFAULT
+	}
+	return *p.RetryOnWorkerFailure
 }
 
-func (p *JobDefinition) IsSetTag() bool {
-	return p.Tag != nil
+var JobDefinition_RetryOnTimeout_DEFAULT bool
+
+func (p *JobDefinition) GetRetryOnTimeout() bool {
+	if !p.IsSetRetryOnTimeout() {
+		return JobDefinition_RetryOnTimeout_DEFAULT
+	}
+	return *p.RetryOnTimeout
 }
 
-func (p *JobDefinition) IsSetBasis() bool {
-	return p.Basis != nil
+var JobDefinition_RetryOnNonZeroExit_DEFAULT bool
+
+func (p *JobDefinition) GetRetryOnNonZeroExit() bool {
+	if !p.IsSetRetryOnNonZeroExit() {
+		return JobDefinition_RetryOnNonZeroExit_DEFAULT
+	}
+	return *p.RetryOnNonZeroExit
 }
 
-func (p *JobDefinition) IsSetRequestor() bool {
-	return p.Requestor != nil
+var JobDefinition_RetryOnExitCodes_DEFAULT []int32
+
+func (p *JobDefinition) GetRetryOnExitCodes() []int32 {
+	return p.RetryOnExitCodes
 }
 
-func (p *JobDefinition) IsSetJobType() bool {
-	return p.JobType != nil
+var JobDefinition_RetryBackoffMs_DEFAULT int32
+
+func (p *JobDefinition) GetRetryBackoffMs() int32 {
+	if !p.IsSetRetryBackoffMs() {
+		return JobDefinition_RetryBackoffMs_DEFAULT
+	}
+	return *p.RetryBackoffMs
+}
+
+var JobDefinition_MaxRetryBackoffMs_DEFAULT int32
+
+func (p *JobDefinition) GetMaxRetryBackoffMs() int32 {
+	if !p.IsSetMaxRetryBackoffMs() {
+		return JobDefinition_MaxRetryBackoffMs_DEFAULT
+	}
+	return *p.MaxRetryBackoffMs
+}
+func (p *JobDefinition) IsSetDEPRECATEDJobType() bool {
+	return p.DEPRECATEDJobType != nil
+}
+
+func (p *JobDefinition) IsSetDefaultTaskTimeoutMs() bool {
+	return p.DefaultTaskTimeoutMs != nil
+}
+
+func (p *JobDefinition) IsSetPriority() bool {
+	return p.Priority != nil
+}
+
+func (p *JobDefinition) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *JobDefinition) IsSetBasis() bool {
+	return p.Basis != nil
+}
+
+func (p *JobDefinition) IsSetRequestor() bool {
+	return p.Requestor != nil
+}
+
+func (p *JobDefinition) IsSetJobType() bool {
+	return p.JobType != nil
+}
+
+func (p *JobDefinition) IsSetMaxTaskAttempts() bool {
+	return p.MaxTaskAttempts != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnWorkerFailure() bool {
+	return p.RetryOnWorkerFailure != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnTimeout() bool {
+	return p.RetryOnTimeout != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnNonZeroExit() bool {
+	return p.RetryOnNonZeroExit != nil
+}
+
+func (p *JobDefinition) IsSetRetryOnExitCodes() bool {
+	return p.RetryOnExitCodes != nil
+}
+
+func (p *JobDefinition) IsSetRetryBackoffMs() bool {
+	return p.RetryBackoffMs != nil
+}
+
+func (p *JobDefinition) IsSetMaxRetryBackoffMs() bool {
+	return p.MaxRetryBackoffMs != nil
 }
 
 func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
@@ -1653,6 +2141,34 @@ func (p *JobDefinition) Read(iprot thrift.TProtocol) error {
 			if err := p.readField8(iprot); err != nil {
 				return err
 			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+		case 11:
+			if err := p.readField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.readField13(iprot); err != nil {
+				return err
+			}
+		case 14:
+			if err := p.readField14(iprot); err != nil {
+				return err
+			}
+		case 15:
+			if err := p.readField15(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1679,11 +2195,11 @@ func (p *JobDefinition) readField1(iprot thrift.TProtocol) error {
 	tSlice := make([]*TaskDefinition, 0, size)
 	p.Tasks = tSlice
 	for i := 0; i < size; i++ {
-		_elem3 := &TaskDefinition{}
-		if err := _elem3.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem3), err)
+		_elem4 := &TaskDefinition{}
+		if err := _elem4.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem4), err)
 		}
-		p.Tasks = append(p.Tasks, _elem3)
+		p.Tasks = append(p.Tasks, _elem4)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -1755,6 +2271,82 @@ func (p *JobDefinition) readField8(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *JobDefinition) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.MaxTaskAttempts = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.RetryOnWorkerFailure = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField11(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 11: ", err)
+	} else {
+		p.RetryOnTimeout = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.RetryOnNonZeroExit = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField13(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]int32, 0, size)
+	p.RetryOnExitCodes = tSlice
+	for i := 0; i < size; i++ {
+		var _elem int32
+		if v, err := iprot.ReadI32(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem = v
+		}
+		p.RetryOnExitCodes = append(p.RetryOnExitCodes, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField14(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 14: ", err)
+	} else {
+		p.RetryBackoffMs = &v
+	}
+	return nil
+}
+
+func (p *JobDefinition) readField15(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 15: ", err)
+	} else {
+		p.MaxRetryBackoffMs = &v
+	}
+	return nil
+}
+
 func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("JobDefinition"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1783,6 +2375,27 @@ func (p *JobDefinition) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField8(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField13(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField14(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField15(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1918,6 +2531,119 @@ func (p *JobDefinition) writeField8(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *JobDefinition) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxTaskAttempts() {
+		if err := oprot.WriteFieldBegin("maxTaskAttempts", thrift.I32, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:maxTaskAttempts: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.MaxTaskAttempts)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxTaskAttempts (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:maxTaskAttempts: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnWorkerFailure() {
+		if err := oprot.WriteFieldBegin("retryOnWorkerFailure", thrift.BOOL, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:retryOnWorkerFailure: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.RetryOnWorkerFailure)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryOnWorkerFailure (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:retryOnWorkerFailure: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnTimeout() {
+		if err := oprot.WriteFieldBegin("retryOnTimeout", thrift.BOOL, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:retryOnTimeout: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.RetryOnTimeout)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryOnTimeout (11) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:retryOnTimeout: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnNonZeroExit() {
+		if err := oprot.WriteFieldBegin("retryOnNonZeroExit", thrift.BOOL, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:retryOnNonZeroExit: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.RetryOnNonZeroExit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryOnNonZeroExit (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:retryOnNonZeroExit: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryOnExitCodes() {
+		if err := oprot.WriteFieldBegin("retryOnExitCodes", thrift.LIST, 13); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:retryOnExitCodes: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.I32, len(p.RetryOnExitCodes)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.RetryOnExitCodes {
+			if err := oprot.WriteI32(int32(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 13:retryOnExitCodes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField14(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRetryBackoffMs() {
+		if err := oprot.WriteFieldBegin("retryBackoffMs", thrift.I32, 14); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 14:retryBackoffMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.RetryBackoffMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.retryBackoffMs (14) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 14:retryBackoffMs: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobDefinition) writeField15(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMaxRetryBackoffMs() {
+		if err := oprot.WriteFieldBegin("maxRetryBackoffMs", thrift.I32, 15); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 15:maxRetryBackoffMs: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.MaxRetryBackoffMs)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.maxRetryBackoffMs (15) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 15:maxRetryBackoffMs: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *JobDefinition) String() string {
 	if p == nil {
 		return "<nil>"
@@ -2027,11 +2753,17 @@ func (p *JobId) String() string {
 //  - Status
 //  - TaskStatus
 //  - TaskData
+//  - TaskDependencies
+//  - TaskAttempts
+//  - FlakyTasks
 type JobStatus struct {
-	ID         string                `thrift:"id,1,required" json:"id"`
-	Status     Status                `thrift:"status,2,required" json:"status"`
-	TaskStatus map[string]Status     `thrift:"taskStatus,3" json:"taskStatus,omitempty"`
-	TaskData   map[string]*RunStatus `thrift:"taskData,4" json:"taskData,omitempty"`
+	ID               string                  `thrift:"id,1,required" json:"id"`
+	Status           Status                  `thrift:"status,2,required" json:"status"`
+	TaskStatus       map[string]Status       `thrift:"taskStatus,3" json:"taskStatus,omitempty"`
+	TaskData         map[string]*RunStatus   `thrift:"taskData,4" json:"taskData,omitempty"`
+	TaskDependencies map[string][]string     `thrift:"taskDependencies,5" json:"taskDependencies,omitempty"`
+	TaskAttempts     map[string][]*RunStatus `thrift:"taskAttempts,6" json:"taskAttempts,omitempty"`
+	FlakyTasks       []string                `thrift:"flakyTasks,7" json:"flakyTasks,omitempty"`
 }
 
 func NewJobStatus() *JobStatus {
@@ -2057,6 +2789,24 @@ var JobStatus_TaskData_DEFAULT map[string]*RunStatus
 func (p *JobStatus) GetTaskData() map[string]*RunStatus {
 	return p.TaskData
 }
+
+var JobStatus_TaskDependencies_DEFAULT map[string][]string
+
+func (p *JobStatus) GetTaskDependencies() map[string][]string {
+	return p.TaskDependencies
+}
+
+var JobStatus_TaskAttempts_DEFAULT map[string][]*RunStatus
+
+func (p *JobStatus) GetTaskAttempts() map[string][]*RunStatus {
+	return p.TaskAttempts
+}
+
+var JobStatus_FlakyTasks_DEFAULT []string
+
+func (p *JobStatus) GetFlakyTasks() []string {
+	return p.FlakyTasks
+}
 func (p *JobStatus) IsSetTaskStatus() bool {
 	return p.TaskStatus != nil
 }
@@ -2065,6 +2815,18 @@ func (p *JobStatus) IsSetTaskData() bool {
 	return p.TaskData != nil
 }
 
+func (p *JobStatus) IsSetTaskDependencies() bool {
+	return p.TaskDependencies != nil
+}
+
+func (p *JobStatus) IsSetTaskAttempts() bool {
+	return p.TaskAttempts != nil
+}
+
+func (p *JobStatus) IsSetFlakyTasks() bool {
+	return p.FlakyTasks != nil
+}
+
 func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -2100,6 +2862,18 @@ func (p *JobStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField4(iprot); err != nil {
 				return err
 			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -2148,20 +2922,20 @@ func (p *JobStatus) readField3(iprot thrift.TProtocol) error {
 	tMap := make(map[string]Status, size)
 	p.TaskStatus = tMap
 	for i := 0; i < size; i++ {
-		var _key4 string
+		var _key5 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key4 = v
+			_key5 = v
 		}
-		var _val5 Status
+		var _val6 Status
 		if v, err := iprot.ReadI32(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
 			temp := Status(v)
-			_val5 = temp
+			_val6 = temp
 		}
-		p.TaskStatus[_key4] = _val5
+		p.TaskStatus[_key5] = _val6
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -2177,17 +2951,17 @@ func (p *JobStatus) readField4(iprot thrift.TProtocol) error {
 	tMap := make(map[string]*RunStatus, size)
 	p.TaskData = tMap
 	for i := 0; i < size; i++ {
-		var _key6 string
+		var _key7 string
 		if v, err := iprot.ReadString(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_key6 = v
+			_key7 = v
 		}
-		_val7 := &RunStatus{}
-		if err := _val7.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val7), err)
+		_val8 := &RunStatus{}
+		if err := _val8.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _val8), err)
 		}
-		p.TaskData[_key6] = _val7
+		p.TaskData[_key7] = _val8
 	}
 	if err := iprot.ReadMapEnd(); err != nil {
 		return thrift.PrependError("error reading map end: ", err)
@@ -2195,33 +2969,142 @@ func (p *JobStatus) readField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *JobStatus) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("JobStatus"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if err := p.writeField1(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField2(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField3(oprot); err != nil {
-		return err
-	}
-	if err := p.writeField4(oprot); err != nil {
-		return err
+func (p *JobStatus) readField5(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
 	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
+	tMap := make(map[string][]string, size)
+	p.TaskDependencies = tMap
+	for i := 0; i < size; i++ {
+		var _key9 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key9 = v
+		}
+		_, size, err := iprot.ReadListBegin()
+		if err != nil {
+			return thrift.PrependError("error reading list begin: ", err)
+		}
+		tSlice := make([]string, 0, size)
+		_val10 := tSlice
+		for i := 0; i < size; i++ {
+			var _elem11 string
+			if v, err := iprot.ReadString(); err != nil {
+				return thrift.PrependError("error reading field 0: ", err)
+			} else {
+				_elem11 = v
+			}
+			_val10 = append(_val10, _elem11)
+		}
+		if err := iprot.ReadListEnd(); err != nil {
+			return thrift.PrependError("error reading list end: ", err)
+		}
+		p.TaskDependencies[_key9] = _val10
 	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
 	}
 	return nil
 }
 
-func (p *JobStatus) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+func (p *JobStatus) readField6(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
+	}
+	tMap := make(map[string][]*RunStatus, size)
+	p.TaskAttempts = tMap
+	for i := 0; i < size; i++ {
+		var _key string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key = v
+		}
+		_, size, err := iprot.ReadListBegin()
+		if err != nil {
+			return thrift.PrependError("error reading list begin: ", err)
+		}
+		tSlice := make([]*RunStatus, 0, size)
+		_val := tSlice
+		for i := 0; i < size; i++ {
+			_elem := &RunStatus{}
+			if err := _elem.Read(iprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem), err)
+			}
+			_val = append(_val, _elem)
+		}
+		if err := iprot.ReadListEnd(); err != nil {
+			return thrift.PrependError("error reading list end: ", err)
+		}
+		p.TaskAttempts[_key] = _val
+	}
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) readField7(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.FlakyTasks = tSlice
+	for i := 0; i < size; i++ {
+		var _elem string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem = v
+		}
+		p.FlakyTasks = append(p.FlakyTasks, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("JobStatus"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *JobStatus) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
 	}
 	if err := oprot.WriteString(string(p.ID)); err != nil {
@@ -2298,6 +3181,97 @@ func (p *JobStatus) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *JobStatus) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskDependencies() {
+		if err := oprot.WriteFieldBegin("taskDependencies", thrift.MAP, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:taskDependencies: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.LIST, len(p.TaskDependencies)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.TaskDependencies {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteListBegin(thrift.STRING, len(v)); err != nil {
+				return thrift.PrependError("error writing list begin: ", err)
+			}
+			for _, v := range v {
+				if err := oprot.WriteString(string(v)); err != nil {
+					return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+				}
+			}
+			if err := oprot.WriteListEnd(); err != nil {
+				return thrift.PrependError("error writing list end: ", err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:taskDependencies: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTaskAttempts() {
+		if err := oprot.WriteFieldBegin("taskAttempts", thrift.MAP, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:taskAttempts: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.LIST, len(p.TaskAttempts)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.TaskAttempts {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteListBegin(thrift.STRUCT, len(v)); err != nil {
+				return thrift.PrependError("error writing list begin: ", err)
+			}
+			for _, v := range v {
+				if err := v.Write(oprot); err != nil {
+					return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+				}
+			}
+			if err := oprot.WriteListEnd(); err != nil {
+				return thrift.PrependError("error writing list end: ", err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:taskAttempts: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *JobStatus) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFlakyTasks() {
+		if err := oprot.WriteFieldBegin("flakyTasks", thrift.LIST, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:flakyTasks: ", p), err)
+		}
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.FlakyTasks)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.FlakyTasks {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:flakyTasks: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *JobStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -2578,3 +3552,564 @@ func (p *ReinstateWorkerReq) String() string {
 	}
 	return fmt.Sprintf("ReinstateWorkerReq(%+v)", *p)
 }
+
+// Attributes:
+//  - Tag
+//  - MinScore
+type GetFlakyTasksReq struct {
+	Tag      *string  `thrift:"tag,1" json:"tag,omitempty"`
+	MinScore *float64 `thrift:"minScore,2" json:"minScore,omitempty"`
+}
+
+func NewGetFlakyTasksReq() *GetFlakyTasksReq {
+	return &GetFlakyTasksReq{}
+}
+
+var GetFlakyTasksReq_Tag_DEFAULT string
+
+func (p *GetFlakyTasksReq) GetTag() string {
+	if !p.IsSetTag() {
+		return GetFlakyTasksReq_Tag_DEFAULT
+	}
+	return *p.Tag
+}
+
+var GetFlakyTasksReq_MinScore_DEFAULT float64
+
+func (p *GetFlakyTasksReq) GetMinScore() float64 {
+	if !p.IsSetMinScore() {
+		return GetFlakyTasksReq_MinScore_DEFAULT
+	}
+	return *p.MinScore
+}
+func (p *GetFlakyTasksReq) IsSetTag() bool {
+	return p.Tag != nil
+}
+
+func (p *GetFlakyTasksReq) IsSetMinScore() bool {
+	return p.MinScore != nil
+}
+
+func (p *GetFlakyTasksReq) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *GetFlakyTasksReq) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Tag = &v
+	}
+	return nil
+}
+
+func (p *GetFlakyTasksReq) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.MinScore = &v
+	}
+	return nil
+}
+
+func (p *GetFlakyTasksReq) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("GetFlakyTasksReq"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *GetFlakyTasksReq) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTag() {
+		if err := oprot.WriteFieldBegin("tag", thrift.STRING, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tag: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Tag)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.tag (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tag: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *GetFlakyTasksReq) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMinScore() {
+		if err := oprot.WriteFieldBegin("minScore", thrift.DOUBLE, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:minScore: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.MinScore)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.minScore (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:minScore: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *GetFlakyTasksReq) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GetFlakyTasksReq(%+v)", *p)
+}
+
+// Attributes:
+//  - Tag
+//  - TaskId
+//  - Score
+//  - NumRuns
+//  - NumFlakyRuns
+//  - LastFlakyEpochMs
+type FlakyTask struct {
+	Tag              string  `thrift:"tag,1,required" json:"tag"`
+	TaskId           string  `thrift:"taskId,2,required" json:"taskId"`
+	Score            float64 `thrift:"score,3,required" json:"score"`
+	NumRuns          int32   `thrift:"numRuns,4,required" json:"numRuns"`
+	NumFlakyRuns     int32   `thrift:"numFlakyRuns,5,required" json:"numFlakyRuns"`
+	LastFlakyEpochMs int64   `thrift:"lastFlakyEpochMs,6,required" json:"lastFlakyEpochMs"`
+}
+
+func NewFlakyTask() *FlakyTask {
+	return &FlakyTask{}
+}
+
+func (p *FlakyTask) GetTag() string {
+	return p.Tag
+}
+
+func (p *FlakyTask) GetTaskId() string {
+	return p.TaskId
+}
+
+func (p *FlakyTask) GetScore() float64 {
+	return p.Score
+}
+
+func (p *FlakyTask) GetNumRuns() int32 {
+	return p.NumRuns
+}
+
+func (p *FlakyTask) GetNumFlakyRuns() int32 {
+	return p.NumFlakyRuns
+}
+
+func (p *FlakyTask) GetLastFlakyEpochMs() int64 {
+	return p.LastFlakyEpochMs
+}
+func (p *FlakyTask) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTag bool = false
+	var issetTaskId bool = false
+	var issetScore bool = false
+	var issetNumRuns bool = false
+	var issetNumFlakyRuns bool = false
+	var issetLastFlakyEpochMs bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTag = true
+		case 2:
+			if err := p.readField2(iprot); err != nil {
+				return err
+			}
+			issetTaskId = true
+		case 3:
+			if err := p.readField3(iprot); err != nil {
+				return err
+			}
+			issetScore = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+			issetNumRuns = true
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+			issetNumFlakyRuns = true
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+			issetLastFlakyEpochMs = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTag {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tag is not set"))
+	}
+	if !issetTaskId {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TaskId is not set"))
+	}
+	if !issetScore {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Score is not set"))
+	}
+	if !issetNumRuns {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumRuns is not set"))
+	}
+	if !issetNumFlakyRuns {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumFlakyRuns is not set"))
+	}
+	if !issetLastFlakyEpochMs {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LastFlakyEpochMs is not set"))
+	}
+	return nil
+}
+
+func (p *FlakyTask) readField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Tag = v
+	}
+	return nil
+}
+
+func (p *FlakyTask) readField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.TaskId = v
+	}
+	return nil
+}
+
+func (p *FlakyTask) readField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Score = v
+	}
+	return nil
+}
+
+func (p *FlakyTask) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.NumRuns = v
+	}
+	return nil
+}
+
+func (p *FlakyTask) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.NumFlakyRuns = v
+	}
+	return nil
+}
+
+func (p *FlakyTask) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.LastFlakyEpochMs = v
+	}
+	return nil
+}
+
+func (p *FlakyTask) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("FlakyTask"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField2(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField3(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *FlakyTask) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tag", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tag: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Tag)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.tag (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tag: ", p), err)
+	}
+	return err
+}
+
+func (p *FlakyTask) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("taskId", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:taskId: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.TaskId)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.taskId (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:taskId: ", p), err)
+	}
+	return err
+}
+
+func (p *FlakyTask) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("score", thrift.DOUBLE, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:score: ", p), err)
+	}
+	if err := oprot.WriteDouble(float64(p.Score)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.score (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:score: ", p), err)
+	}
+	return err
+}
+
+func (p *FlakyTask) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numRuns", thrift.I32, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:numRuns: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NumRuns)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numRuns (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:numRuns: ", p), err)
+	}
+	return err
+}
+
+func (p *FlakyTask) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numFlakyRuns", thrift.I32, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:numFlakyRuns: ", p), err)
+	}
+	if err := oprot.WriteI32(int32(p.NumFlakyRuns)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numFlakyRuns (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:numFlakyRuns: ", p), err)
+	}
+	return err
+}
+
+func (p *FlakyTask) writeField6(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("lastFlakyEpochMs", thrift.I64, 6); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:lastFlakyEpochMs: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LastFlakyEpochMs)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.lastFlakyEpochMs (6) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 6:lastFlakyEpochMs: ", p), err)
+	}
+	return err
+}
+
+func (p *FlakyTask) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("FlakyTask(%+v)", *p)
+}
+
+// Attributes:
+//  - Tasks
+type FlakyTasks struct {
+	Tasks []*FlakyTask `thrift:"tasks,1,required" json:"tasks"`
+}
+
+func NewFlakyTasks() *FlakyTasks {
+	return &FlakyTasks{}
+}
+
+func (p *FlakyTasks) GetTasks() []*FlakyTask {
+	return p.Tasks
+}
+func (p *FlakyTasks) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetTasks bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.readField1(iprot); err != nil {
+				return err
+			}
+			issetTasks = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetTasks {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tasks is not set"))
+	}
+	return nil
+}
+
+func (p *FlakyTasks) readField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*FlakyTask, 0, size)
+	p.Tasks = tSlice
+	for i := 0; i < size; i++ {
+		_elem := &FlakyTask{}
+		if err := _elem.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem), err)
+		}
+		p.Tasks = append(p.Tasks, _elem)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *FlakyTasks) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("FlakyTasks"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if err := p.writeField1(oprot); err != nil {
+		return err
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *FlakyTasks) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tasks", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tasks: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tasks)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Tasks {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tasks: ", p), err)
+	}
+	return err
+}
+
+func (p *FlakyTasks) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("FlakyTasks(%+v)", *p)
+}
@@ -52,6 +52,7 @@ struct RunStatus {
   9: optional string taskId
   10: optional string tag
   11: optional bazel.ActionResult bazelResult
+  12: optional string failureReason  # Why the worker failed the run, ex: "OOMKilled". Unset if the run failed on its own.
 }
 
 
@@ -66,6 +67,20 @@ struct TaskDefinition {
   # TaskId should generally be unique, otherwise previous tasks with the same Requestor and Tag will be stomped.
   3: optional string taskId
   4: optional i32 timeoutMs
+  # TaskIds within this job that must complete successfully before this task is scheduled.
+  # If any of them fail, this task is skipped.
+  5: optional list<string> dependsOn
+  # TaskId within this job whose output snapshot is used as this task's snapshot (implies a dependency).
+  6: optional string snapshotFromTask
+  # If true, the output of snapshotFromTask is copied over snapshotId rather than replacing it.
+  7: optional bool mergeSnapshot
+  # Resources the task needs. It's only scheduled on a worker with enough unreserved capacity,
+  # and the worker limits it to the requested memory and cpus. Unset means no specific requirement.
+  8: optional i64 memoryBytes
+  9: optional double cpus
+  10: optional i64 diskBytes
+  # If true, the task has network access when the worker runs tasks in a sandbox. Unset means no network.
+  11: optional bool allowNetwork
 }
 
 struct JobDefinition {
@@ -83,6 +98,20 @@ struct JobDefinition {
   7: optional string requestor
   # JobType is used for stats and does not affect scheduling.
   8: optional string jobType
+  # Retry policy for the job's tasks, unset fields use the scheduler's defaults.
+  # Total number of times a task may be run, including the first attempt.
+  9: optional i32 maxTaskAttempts
+  # Retry when the worker fails to run the task or can't be reached.
+  10: optional bool retryOnWorkerFailure
+  # Retry when the task exceeds its timeout.
+  11: optional bool retryOnTimeout
+  # Retry when the task's command exits with any non-zero code.
+  12: optional bool retryOnNonZeroExit
+  # Retry when the task's command exits with one of these codes.
+  13: optional list<i32> retryOnExitCodes
+  # Wait this long before the first retry, doubling for each subsequent retry up to maxRetryBackoffMs.
+  14: optional i32 retryBackoffMs
+  15: optional i32 maxRetryBackoffMs
 }
 
 struct JobId {
@@ -114,6 +143,12 @@ struct JobStatus {
   2: required Status status
   3: optional map<string, Status> taskStatus
   4: optional map<string, RunStatus> taskData
+  # Map of taskId to the taskIds it depends on, omitted if no task has dependencies.
+  5: optional map<string, list<string>> taskDependencies
+  # Map of taskId to the unsuccessful attempts that were retried, oldest first, omitted for tasks that weren't retried.
+  6: optional map<string, list<RunStatus>> taskAttempts
+  # Tasks that succeeded after an attempt that failed or timed out, omitted if there are none.
+  7: optional list<string> flakyTasks
 }
 
 struct OfflineWorkerReq {
@@ -126,6 +161,29 @@ struct ReinstateWorkerReq {
   2: required string requestor
 }
 
+# Optional filters, by default all tasks that have been flaky are returned.
+struct GetFlakyTasksReq {
+  1: optional string tag       # Only tasks of jobs with this tag.
+  2: optional double minScore  # Only tasks whose flakiness score is at least this.
+}
+
+# A task that succeeded after an attempt that failed or timed out, as tracked by the scheduler since it started.
+# Tasks are identified by their job's tag and their taskId, which are stable across jobs.
+# Only retries within a job count, so failures are only tracked for jobs whose retry policy retries them,
+# ex: non-zero exits need retryOnNonZeroExit.
+struct FlakyTask {
+  1: required string tag
+  2: required string taskId
+  3: required double score             # Rolling fraction of recent successful runs that needed a retry, in [0,1].
+  4: required i32 numRuns              # Successful runs since the task was first flaky.
+  5: required i32 numFlakyRuns         # Successful runs that needed a retry.
+  6: required i64 lastFlakyEpochMs
+}
+
+struct FlakyTasks {
+  1: required list<FlakyTask> tasks   # Ordered by descending score.
+}
+
 service CloudScoot {
    JobId RunJob(1: JobDefinition job) throws (
     1: InvalidRequest ir
@@ -147,4 +205,8 @@ service CloudScoot {
     1: InvalidRequest ir
     2: ScootServerError err
   )
+  FlakyTasks GetFlakyTasks(1: GetFlakyTasksReq req) throws (
+    1: InvalidRequest ir
+    2: ScootServerError err
+  )
 }
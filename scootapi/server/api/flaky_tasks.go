@@ -0,0 +1,38 @@
+package api
+
+import (
+	"time"
+
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+// Implementation of the GetFlakyTasks API
+func GetFlakyTasks(req *scoot.GetFlakyTasksReq, scheduler scheduler.Scheduler) (*scoot.FlakyTasks, error) {
+	var schedReq sched.FlakyTasksReq
+	if req != nil {
+		schedReq.Tag = req.GetTag()
+		schedReq.MinScore = req.GetMinScore()
+	}
+	if schedReq.MinScore < 0 || schedReq.MinScore > 1 {
+		ir := scoot.NewInvalidRequest()
+		msg := "minScore must be between 0 and 1"
+		ir.Message = &msg
+		return nil, ir
+	}
+
+	result := scoot.NewFlakyTasks()
+	result.Tasks = []*scoot.FlakyTask{}
+	for _, t := range scheduler.GetFlakyTasks(schedReq) {
+		result.Tasks = append(result.Tasks, &scoot.FlakyTask{
+			Tag:              t.Tag,
+			TaskId:           t.TaskID,
+			Score:            t.Score,
+			NumRuns:          int32(t.NumRuns),
+			NumFlakyRuns:     int32(t.NumFlakyRuns),
+			LastFlakyEpochMs: t.LastFlaky.UnixNano() / int64(time.Millisecond),
+		})
+	}
+	return result, nil
+}
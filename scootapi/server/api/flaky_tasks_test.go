@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+)
+
+func Test_GetFlakyTasks(t *testing.T) {
+	scheduler := CreateSchedulerMock(t)
+	lastFlaky := time.Unix(1500000000, 0)
+	scheduler.EXPECT().GetFlakyTasks(sched.FlakyTasksReq{Tag: "tag", MinScore: 0.5}).Return([]sched.FlakyTask{
+		{Tag: "tag", TaskID: "task1", Score: 0.6, NumRuns: 3, NumFlakyRuns: 2, LastFlaky: lastFlaky},
+	})
+
+	tag, minScore := "tag", 0.5
+	flakyTasks, err := GetFlakyTasks(&scoot.GetFlakyTasksReq{Tag: &tag, MinScore: &minScore}, scheduler)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(flakyTasks.Tasks) != 1 {
+		t.Fatalf("Expected one flaky task, got %v", flakyTasks.Tasks)
+	}
+	if task := flakyTasks.Tasks[0]; task.TaskId != "task1" || task.NumFlakyRuns != 2 || task.LastFlakyEpochMs != 1500000000000 {
+		t.Errorf("Unexpected flaky task %v", task)
+	}
+
+	minScore = 2
+	if _, err := GetFlakyTasks(&scoot.GetFlakyTasksReq{MinScore: &minScore}, scheduler); err == nil {
+		t.Errorf("Expected a minScore above 1 to be rejected")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/twitter/scoot/common/thrifthelpers"
 	s "github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/sched"
+	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
@@ -37,11 +38,11 @@ func GetJobStatus(jobId string, sc s.SagaCoordinator) (*scoot.JobStatus, error)
 		return js, nil
 	}
 
-	return convertSagaStateToJobStatus(state), nil
+	return ConvertSagaStateToJobStatus(state), nil
 }
 
 // Converts a SagaState to a corresponding JobStatus
-func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
+func ConvertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 
 	js := scoot.NewJobStatus()
 
@@ -52,12 +53,28 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 	if job, err := sched.DeserializeJob(sagaState.Job()); err == nil {
 		for i, _ := range job.Def.Tasks {
 			js.TaskStatus[job.Def.Tasks[i].TaskID] = scoot.Status_NOT_STARTED
+			if len(job.Def.Tasks[i].DependsOn) > 0 {
+				if js.TaskDependencies == nil {
+					js.TaskDependencies = make(map[string][]string)
+				}
+				js.TaskDependencies[job.Def.Tasks[i].TaskID] = job.Def.Tasks[i].DependsOn
+			}
 		}
 	}
 
 	// NotStarted Tasks will not have a logged value
 	for _, id := range sagaState.GetTaskIds() {
 
+		// Attempts that were retried are logged as StartTask data, after which the task was started again.
+		for _, data := range sagaState.GetStartTaskDataHistory(id) {
+			if attempt, err := workerRunStatusToScootRunStatus(data); err == nil && attempt != nil && isDoneStatus(attempt.Status) {
+				if js.TaskAttempts == nil {
+					js.TaskAttempts = make(map[string][]*scoot.RunStatus)
+				}
+				js.TaskAttempts[id] = append(js.TaskAttempts[id], attempt)
+			}
+		}
+
 		taskStatus := scoot.Status_NOT_STARTED
 
 		if sagaState.IsSagaAborted() {
@@ -72,6 +89,9 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 				if thriftJobStatus, err := workerRunStatusToScootRunStatus(sagaState.GetEndTaskData(id)); err == nil {
 					js.TaskData[id] = thriftJobStatus
 				}
+				if scheduler.IsFlakyRun(sagaState, id) {
+					js.FlakyTasks = append(js.FlakyTasks, id)
+				}
 			} else if sagaState.IsTaskStarted(id) {
 				taskStatus = scoot.Status_IN_PROGRESS
 				if startData := sagaState.GetStartTaskData(id); startData != nil {
@@ -106,6 +126,15 @@ func convertSagaStateToJobStatus(sagaState *s.SagaState) *scoot.JobStatus {
 	return js
 }
 
+func isDoneStatus(st scoot.RunStatusState) bool {
+	switch st {
+	case scoot.RunStatusState_COMPLETE, scoot.RunStatusState_FAILED, scoot.RunStatusState_ABORTED,
+		scoot.RunStatusState_TIMEDOUT, scoot.RunStatusState_BADREQUEST:
+		return true
+	}
+	return false
+}
+
 // this is a thrift to thrift structure translation.  We are doing this because we get invalid
 // import statements in the generated code when we use thrift import statements (this issue is supposed
 // to be fixed in thrift 10.0
@@ -123,14 +152,15 @@ func workerRunStatusToScootRunStatus(resultsFromSaga []byte) (*scoot.RunStatus,
 	}
 
 	scootRunStatus := scoot.RunStatus{
-		RunId:        workerRunStatus.RunId,
-		Status:       status,
-		OutUri:       workerRunStatus.OutUri,
-		ErrUri:       workerRunStatus.ErrUri,
-		ExitCode:     workerRunStatus.ExitCode,
-		Error:        workerRunStatus.Error,
-		SnapshotId:   workerRunStatus.SnapshotId,
-		BazelResult_: workerRunStatus.BazelResult_,
+		RunId:         workerRunStatus.RunId,
+		Status:        status,
+		OutUri:        workerRunStatus.OutUri,
+		ErrUri:        workerRunStatus.ErrUri,
+		ExitCode:      workerRunStatus.ExitCode,
+		Error:         workerRunStatus.Error,
+		SnapshotId:    workerRunStatus.SnapshotId,
+		BazelResult_:  workerRunStatus.BazelResult_,
+		FailureReason: workerRunStatus.FailureReason,
 	}
 
 	return &scootRunStatus, nil
@@ -23,7 +23,7 @@ func Test_ConvertSagaStateToJobStatus(t *testing.T) {
 	properties.Property("SagaState Converted To Job Status Correctly", prop.ForAll(
 		func(state *s.SagaState) bool {
 
-			jobStatus := convertSagaStateToJobStatus(state)
+			jobStatus := ConvertSagaStateToJobStatus(state)
 
 			// Verify JobId Set Correctly
 			if state.SagaId() != jobStatus.ID {
@@ -1,15 +1,18 @@
 package api
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	log "github.com/sirupsen/logrus"
+	"github.com/twitter/scoot/common/log/tags"
 	"github.com/twitter/scoot/common/thrifthelpers"
 	"github.com/twitter/scoot/runner"
 	s "github.com/twitter/scoot/saga"
 	"github.com/twitter/scoot/saga/sagalogs"
+	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/workerapi"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
@@ -83,6 +86,68 @@ func Test_GetJobStatus_NoSagaMessages(t *testing.T) {
 	}
 }
 
+func Test_GetJobStatus_TaskDependencies(t *testing.T) {
+	job := sched.GenJob("job1", 2)
+	job.Def.Tasks[1].DependsOn = []string{job.Def.Tasks[0].TaskID}
+	jobAsBytes, _ := job.Serialize()
+
+	sagaCoord := sagalogs.MakeInMemorySagaCoordinator()
+	sagaCoord.MakeSaga(job.Id, jobAsBytes)
+
+	status, err := GetJobStatus(job.Id, sagaCoord)
+	if err != nil {
+		t.Fatal("Unexpected error returned", err)
+	}
+
+	if len(status.TaskDependencies) != 1 {
+		t.Fatalf("Expected dependencies for exactly one task, got %v", status.TaskDependencies)
+	}
+	deps := status.TaskDependencies[job.Def.Tasks[1].TaskID]
+	if len(deps) != 1 || deps[0] != job.Def.Tasks[0].TaskID {
+		t.Errorf("Expected %s to depend on %s, got %v", job.Def.Tasks[1].TaskID, job.Def.Tasks[0].TaskID, deps)
+	}
+}
+
+func Test_GetJobStatus_TaskAttempts(t *testing.T) {
+	job := sched.GenJob("job1", 1)
+	taskId := job.Def.Tasks[0].TaskID
+	jobAsBytes, _ := job.Serialize()
+
+	sagaCoord := sagalogs.MakeInMemorySagaCoordinator()
+	saga, _ := sagaCoord.MakeSaga(job.Id, jobAsBytes)
+
+	// Each attempt is started without data, may log its running status, and is logged with its result if retried.
+	running, _ := workerapi.SerializeProcessStatus(runner.RunningStatus("run1", "", "", tags.LogTags{}))
+	failed, _ := workerapi.SerializeProcessStatus(runner.FailedStatus("run1", errors.New("worker failure"), tags.LogTags{}))
+	timedOut, _ := workerapi.SerializeProcessStatus(runner.TimeoutStatus("run2", tags.LogTags{}))
+	for _, data := range [][]byte{nil, running, failed, nil, timedOut, nil} {
+		saga.StartTask(taskId, data)
+	}
+
+	status, err := GetJobStatus(job.Id, sagaCoord)
+	if err != nil {
+		t.Fatal("Unexpected error returned", err)
+	}
+	attempts := status.TaskAttempts[taskId]
+	if len(attempts) != 2 || attempts[0].Status != scoot.RunStatusState_FAILED || attempts[1].Status != scoot.RunStatusState_TIMEDOUT {
+		t.Errorf("Expected a failed and a timed out attempt, got %+v", attempts)
+	}
+	if status.TaskStatus[taskId] != scoot.Status_IN_PROGRESS {
+		t.Errorf("Expected the task to still be in progress, got %v", status.TaskStatus[taskId])
+	}
+	if len(status.FlakyTasks) != 0 {
+		t.Errorf("Expected no flaky tasks before the task succeeds, got %v", status.FlakyTasks)
+	}
+
+	// Succeeding after a timed out attempt makes the task flaky.
+	success, _ := workerapi.SerializeProcessStatus(runner.CompleteStatus("run3", "", 0, tags.LogTags{}))
+	saga.EndTask(taskId, success)
+	status, _ = GetJobStatus(job.Id, sagaCoord)
+	if len(status.FlakyTasks) != 1 || status.FlakyTasks[0] != taskId {
+		t.Errorf("Expected %s to be flaky, got %v", taskId, status.FlakyTasks)
+	}
+}
+
 func Test_RunStatusThriftConversion(t *testing.T) {
 	// test with non-empty structure
 	var outURI = "outURI"
@@ -229,3 +294,32 @@ func TestRunStatusRoundTrip(t *testing.T) {
 		t.Fatalf("runStatus.OutUri: %v (expected %v)", *runStatus.OutUri, stdoutRef)
 	}
 }
+
+func TestRunStatusFailureReason(t *testing.T) {
+	sagaCoord := s.MakeSagaCoordinator(sagalogs.MakeInMemorySagaLog())
+	saga, err := sagaCoord.MakeSaga("foo", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saga.StartTask("t", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	st := runner.FailedStatus(runner.RunID("2"), errors.New("killed"), tags.LogTags{})
+	st.FailureReason = "OOMKilled"
+	statusAsBytes, err := workerapi.SerializeProcessStatus(st)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = saga.EndTask("t", statusAsBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	jobStatus, err := GetJobStatus("foo", sagaCoord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason := jobStatus.TaskData["t"].GetFailureReason(); reason != st.FailureReason {
+		t.Fatalf("runStatus.FailureReason: %v (expected %v)", reason, st.FailureReason)
+	}
+}
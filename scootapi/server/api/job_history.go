@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/saga/sagalogs"
+)
+
+// Http path under which the job history is served, ex: /jobs/history?requestor=alice&status=Aborted
+const JobHistoryPath = "/jobs/history"
+
+// Serves the jobs recorded by a saga log that supports history queries as JSON, most recently started first.
+// Jobs can be filtered by the requestor, tag and status query params, and by started_after and started_before,
+// RFC3339 times ex: 2018-01-02T15:04:05Z. At most limit jobs are returned, sagalogs.DefaultSagaHistoryLimit by default.
+type JobHistoryHandler struct {
+	history sagalogs.SagaHistory
+	stat    stats.StatsReceiver
+}
+
+func NewJobHistoryHandler(history sagalogs.SagaHistory, stat stats.StatsReceiver) *JobHistoryHandler {
+	return &JobHistoryHandler{history: history, stat: stat}
+}
+
+type jobHistoryEntry struct {
+	JobId     string     `json:"jobId"`
+	Requestor string     `json:"requestor"`
+	Tag       string     `json:"tag"`
+	JobType   string     `json:"jobType"`
+	Status    string     `json:"status"`
+	Started   time.Time  `json:"started"`
+	Ended     *time.Time `json:"ended,omitempty"` // Nil if the job hasn't ended.
+}
+
+func (h *JobHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.stat.Counter(stats.SchedServerJobHistoryCounter).Inc(1)
+	q, err := parseJobHistoryQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sagas, err := h.history.GetSagaHistory(q)
+	if err != nil {
+		log.WithFields(
+			log.Fields{
+				"query": fmt.Sprintf("%+v", q),
+				"err":   err,
+			}).Error("Failed to query job history")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	jobs := make([]jobHistoryEntry, 0, len(sagas))
+	for _, s := range sagas {
+		job := jobHistoryEntry{
+			JobId:     s.SagaId,
+			Requestor: s.Requestor,
+			Tag:       s.Tag,
+			JobType:   s.JobType,
+			Status:    string(s.Status),
+			Started:   s.Started,
+		}
+		if !s.Ended.IsZero() {
+			ended := s.Ended
+			job.Ended = &ended
+		}
+		jobs = append(jobs, job)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(jobs)
+}
+
+func parseJobHistoryQuery(r *http.Request) (sagalogs.SagaHistoryQuery, error) {
+	params := r.URL.Query()
+	q := sagalogs.SagaHistoryQuery{
+		Requestor: params.Get("requestor"),
+		Tag:       params.Get("tag"),
+		Status:    sagalogs.SagaStatus(params.Get("status")),
+	}
+	switch q.Status {
+	case "", sagalogs.SagaInProgress, sagalogs.SagaCompleted, sagalogs.SagaAborted:
+	default:
+		return q, fmt.Errorf("Invalid status %q, expected %s, %s or %s",
+			q.Status, sagalogs.SagaInProgress, sagalogs.SagaCompleted, sagalogs.SagaAborted)
+	}
+
+	var err error
+	if v := params.Get("started_after"); v != "" {
+		if q.StartedAfter, err = time.Parse(time.RFC3339, v); err != nil {
+			return q, fmt.Errorf("Invalid started_after %q: %v", v, err)
+		}
+	}
+	if v := params.Get("started_before"); v != "" {
+		if q.StartedBefore, err = time.Parse(time.RFC3339, v); err != nil {
+			return q, fmt.Errorf("Invalid started_before %q: %v", v, err)
+		}
+	}
+	if v := params.Get("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil || q.Limit < 0 {
+			return q, fmt.Errorf("Invalid limit %q", v)
+		}
+	}
+	return q, nil
+}
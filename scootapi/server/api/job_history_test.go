@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/saga/sagalogs"
+)
+
+type fakeSagaHistory struct {
+	query sagalogs.SagaHistoryQuery
+	sagas []sagalogs.SagaSummary
+}
+
+func (h *fakeSagaHistory) GetSagaHistory(q sagalogs.SagaHistoryQuery) ([]sagalogs.SagaSummary, error) {
+	h.query = q
+	return h.sagas, nil
+}
+
+func Test_JobHistory(t *testing.T) {
+	started := time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC)
+	history := &fakeSagaHistory{sagas: []sagalogs.SagaSummary{
+		{SagaId: "job1", SagaJobInfo: sagalogs.SagaJobInfo{Requestor: "alice", Tag: "nightly"},
+			Status: sagalogs.SagaInProgress, Started: started},
+		{SagaId: "job2", Status: sagalogs.SagaCompleted, Started: started, Ended: started.Add(time.Minute)},
+	}}
+	server := httptest.NewServer(NewJobHistoryHandler(history, stats.NilStatsReceiver()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + JobHistoryPath +
+		"?requestor=alice&tag=nightly&status=InProgress&started_after=2018-01-02T15:04:05Z&limit=10")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer resp.Body.Close()
+	var jobs []map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&jobs); err != nil {
+		t.Fatalf("Couldn't decode job history: %v", err)
+	}
+
+	expected := sagalogs.SagaHistoryQuery{
+		Requestor: "alice", Tag: "nightly", Status: sagalogs.SagaInProgress, StartedAfter: started, Limit: 10}
+	if !reflect.DeepEqual(history.query, expected) {
+		t.Errorf("Expected query %+v, got %+v", expected, history.query)
+	}
+	if len(jobs) != 2 || jobs[0]["jobId"] != "job1" || jobs[0]["requestor"] != "alice" || jobs[0]["ended"] != nil ||
+		jobs[1]["status"] != "Completed" || jobs[1]["ended"] != "2018-01-02T15:05:05Z" {
+		t.Errorf("Unexpected job history %v", jobs)
+	}
+
+	for _, params := range []string{"?status=Done", "?started_before=yesterday", "?limit=-1"} {
+		if resp, err := http.Get(server.URL + JobHistoryPath + params); err != nil || resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected a bad request for %s, got %v, %v", params, resp, err)
+		}
+	}
+}
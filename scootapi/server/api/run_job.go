@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
@@ -65,6 +66,21 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 			return result, fmt.Errorf("nil taskId")
 		}
 		task.TaskID = *t.TaskId
+		task.Resources = runner.Resources{
+			Memory: t.GetMemoryBytes(),
+			CPUs:   t.GetCpus(),
+			Disk:   t.GetDiskBytes(),
+		}
+		task.AllowNetwork = t.GetAllowNetwork()
+		task.DependsOn = t.DependsOn
+		if t.SnapshotFromTask != nil && *t.SnapshotFromTask != "" {
+			task.SnapshotFromTask = *t.SnapshotFromTask
+			task.MergeSnapshot = t.GetMergeSnapshot()
+			// Consuming another task's output implies a dependency on it.
+			if !task.DependsOnTask(task.SnapshotFromTask) {
+				task.DependsOn = append(task.DependsOn, task.SnapshotFromTask)
+			}
+		}
 
 		result.Tasks = append(result.Tasks, task)
 	}
@@ -84,6 +100,32 @@ func thriftJobToScoot(def *scoot.JobDefinition) (result sched.JobDefinition, err
 	if def.Priority != nil {
 		result.Priority = sched.Priority(*def.Priority)
 	}
+	result.RetryPolicy = thriftRetryPolicyToScoot(def)
 
 	return result, nil
 }
+
+// Returns the job's retry policy, or nil if it doesn't set any of its fields.
+// Unset fields take their values from the default policy.
+func thriftRetryPolicyToScoot(def *scoot.JobDefinition) *sched.RetryPolicy {
+	if !def.IsSetMaxTaskAttempts() && !def.IsSetRetryOnWorkerFailure() && !def.IsSetRetryOnTimeout() &&
+		!def.IsSetRetryOnNonZeroExit() && !def.IsSetRetryOnExitCodes() &&
+		!def.IsSetRetryBackoffMs() && !def.IsSetMaxRetryBackoffMs() {
+		return nil
+	}
+	policy := sched.DefaultRetryPolicy()
+	policy.MaxAttempts = int(def.GetMaxTaskAttempts())
+	if def.RetryOnWorkerFailure != nil {
+		policy.RetryOnWorkerFailure = *def.RetryOnWorkerFailure
+	}
+	if def.RetryOnTimeout != nil {
+		policy.RetryOnTimeout = *def.RetryOnTimeout
+	}
+	policy.RetryOnNonZeroExit = def.GetRetryOnNonZeroExit()
+	for _, c := range def.RetryOnExitCodes {
+		policy.RetryOnExitCodes = append(policy.RetryOnExitCodes, int(c))
+	}
+	policy.Backoff = time.Duration(def.GetRetryBackoffMs()) * time.Millisecond
+	policy.MaxBackoff = time.Duration(def.GetMaxRetryBackoffMs()) * time.Millisecond
+	return &policy
+}
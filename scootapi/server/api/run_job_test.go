@@ -4,9 +4,11 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/sched"
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
 	"github.com/twitter/scoot/tests/testhelpers"
@@ -113,3 +115,52 @@ func Test_RunJob_SchedulerError(t *testing.T) {
 		t.Errorf("expected job Id to be nil when error occurs not %v", jobId)
 	}
 }
+
+func Test_RunJob_SnapshotFromTaskImpliesDependency(t *testing.T) {
+	jobDef := scoot.NewJobDefinition()
+	upstream := testhelpers.GenTask(testhelpers.NewRand(), "upstream", "")
+	downstream := testhelpers.GenTask(testhelpers.NewRand(), "downstream", "")
+	snapshotFrom := "upstream"
+	downstream.SnapshotFromTask = &snapshotFrom
+	jobDef.Tasks = []*scoot.TaskDefinition{upstream, downstream}
+
+	def, err := thriftJobToScoot(jobDef)
+	if err != nil {
+		t.Fatalf("unexpected error translating job: %v", err)
+	}
+	if deps := def.Tasks[1].DependsOn; len(deps) != 1 || deps[0] != "upstream" {
+		t.Errorf("expected downstream to depend on upstream, got %v", deps)
+	}
+	if def.Tasks[1].SnapshotFromTask != "upstream" || def.Tasks[1].MergeSnapshot {
+		t.Errorf("expected snapshotFromTask=upstream without merge, got %+v", def.Tasks[1])
+	}
+}
+
+func Test_RunJob_RetryPolicy(t *testing.T) {
+	jobDef := scoot.NewJobDefinition()
+	jobDef.Tasks = []*scoot.TaskDefinition{testhelpers.GenTask(testhelpers.NewRand(), "1", "")}
+
+	def, err := thriftJobToScoot(jobDef)
+	if err != nil || def.RetryPolicy != nil {
+		t.Fatalf("expected no retry policy, got %v, err: %v", def.RetryPolicy, err)
+	}
+
+	maxAttempts, timeout, backoffMs := int32(4), false, int32(500)
+	jobDef.MaxTaskAttempts = &maxAttempts
+	jobDef.RetryOnTimeout = &timeout
+	jobDef.RetryOnExitCodes = []int32{75}
+	jobDef.RetryBackoffMs = &backoffMs
+	def, err = thriftJobToScoot(jobDef)
+	if err != nil {
+		t.Fatalf("unexpected error translating job: %v", err)
+	}
+	expected := &sched.RetryPolicy{
+		MaxAttempts:          4,
+		RetryOnWorkerFailure: true,
+		RetryOnExitCodes:     []int{75},
+		Backoff:              500 * time.Millisecond,
+	}
+	if !reflect.DeepEqual(def.RetryPolicy, expected) {
+		t.Errorf("expected retry policy %s, got %s", expected, def.RetryPolicy)
+	}
+}
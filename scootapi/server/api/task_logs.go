@@ -0,0 +1,141 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi"
+)
+
+// Serves the stdout or stderr of a task, identified by job and task id, at scootapi.TaskLogsPath<jobId>/<taskId>/<stdout|stderr>.
+// The output of a running task is proxied from the worker running it and streamed until the task finishes.
+// The output of a finished task is proxied from the uri recorded in its status, if it's available over http.
+type TaskLogsHandler struct {
+	scheduler scheduler.Scheduler
+	stat      stats.StatsReceiver
+	client    *http.Client
+}
+
+func NewTaskLogsHandler(scheduler scheduler.Scheduler, stat stats.StatsReceiver) *TaskLogsHandler {
+	return &TaskLogsHandler{scheduler: scheduler, stat: stat, client: scootapi.NewTaskLogsClient()}
+}
+
+func (h *TaskLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.stat.Counter(stats.SchedServerTaskLogsCounter).Inc(1)
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, scootapi.TaskLogsPath), "/"), "/")
+	if len(parts) != 3 || (parts[2] != "stdout" && parts[2] != "stderr") {
+		http.Error(w, fmt.Sprintf("Expected %s<jobId>/<taskId>/<stdout|stderr>", scootapi.TaskLogsPath), http.StatusBadRequest)
+		return
+	}
+	jobId, taskId, stdout := parts[0], parts[1], parts[2] == "stdout"
+
+	uri, follow, err := h.getOutputUri(jobId, taskId, stdout)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	u, err := url.Parse(uri)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		http.Error(w, fmt.Sprintf("Output of task %s isn't available over http: %s", taskId, uri), http.StatusNotFound)
+		return
+	}
+	q := u.Query()
+	q.Set("content", "true")
+	if follow {
+		q.Set("follow", "true")
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp, err := h.client.Do(req.WithContext(r.Context()))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Couldn't get output from %s: %v", uri, err), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		http.Error(w, fmt.Sprintf("Couldn't get output from %s: %s", uri, resp.Status), http.StatusBadGateway)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err := copyAndFlush(w, resp.Body); err != nil {
+		log.WithFields(
+			log.Fields{
+				"jobID":  jobId,
+				"taskID": taskId,
+				"uri":    uri,
+				"err":    err,
+			}).Info("Stopped proxying task output")
+	}
+}
+
+// Returns the uri of the task's output and whether it's still being written.
+func (h *TaskLogsHandler) getOutputUri(jobId, taskId string, stdout bool) (string, bool, error) {
+	if rs, ok := h.scheduler.GetTaskRunner(jobId, taskId); ok {
+		statuses, _, err := rs.StatusAll()
+		if err != nil {
+			return "", false, fmt.Errorf("Couldn't get status of task %s from its worker: %v", taskId, err)
+		}
+		for _, st := range statuses {
+			if st.JobID != jobId || st.TaskID != taskId || st.State.IsDone() {
+				continue
+			}
+			return outputRef(st, stdout), true, nil
+		}
+	}
+
+	status, err := GetJobStatus(jobId, h.scheduler.GetSagaCoord())
+	if err != nil {
+		return "", false, fmt.Errorf("Couldn't get status of job %s: %v", jobId, err)
+	}
+	rs, ok := status.TaskData[taskId]
+	if !ok {
+		return "", false, fmt.Errorf("No output for task %s of job %s", taskId, jobId)
+	}
+	if stdout {
+		return rs.GetOutUri(), false, nil
+	}
+	return rs.GetErrUri(), false, nil
+}
+
+func outputRef(st runner.RunStatus, stdout bool) string {
+	if stdout {
+		return st.StdoutRef
+	}
+	return st.StderrRef
+}
+
+// Copies src to w, flushing after every read so that streamed output is passed on as it arrives.
+func copyAndFlush(w http.ResponseWriter, src io.Reader) error {
+	flusher, _ := w.(http.Flusher)
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
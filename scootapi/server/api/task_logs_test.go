@@ -0,0 +1,72 @@
+package api
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/twitter/scoot/common/log/tags"
+	"github.com/twitter/scoot/common/stats"
+	"github.com/twitter/scoot/runner"
+	"github.com/twitter/scoot/sched/scheduler"
+	"github.com/twitter/scoot/scootapi"
+)
+
+// Only implements StatusAll, which is all the handler needs from a worker
+type fakeTaskRunner struct {
+	runner.Service
+	statuses []runner.RunStatus
+}
+
+func (r *fakeTaskRunner) StatusAll() ([]runner.RunStatus, runner.ServiceStatus, error) {
+	return r.statuses, runner.ServiceStatus{}, nil
+}
+
+func Test_TaskLogs(t *testing.T) {
+	var query string
+	worker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		fmt.Fprintf(w, "output of %s", r.URL.Path)
+	}))
+	defer worker.Close()
+
+	mockCtrl = gomock.NewController(t)
+	defer mockCtrl.Finish()
+	s := scheduler.NewMockScheduler(mockCtrl)
+	rs := &fakeTaskRunner{statuses: []runner.RunStatus{{
+		RunID:     "1",
+		State:     runner.RUNNING,
+		StdoutRef: worker.URL + "/output/stdout",
+		StderrRef: worker.URL + "/output/stderr",
+		LogTags:   tags.LogTags{JobID: "job", TaskID: "task"},
+	}}}
+	s.EXPECT().GetTaskRunner("job", "task").Return(rs, true).AnyTimes()
+
+	handler := NewTaskLogsHandler(s, stats.NilStatsReceiver())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		defer resp.Body.Close()
+		b, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+
+	if code, body := get(scootapi.TaskLogsPath + "job/task/stderr"); code != http.StatusOK || body != "output of /output/stderr" {
+		t.Fatalf("Expected stderr of the running task, got: %d %q", code, body)
+	}
+	if query != "content=true&follow=true" {
+		t.Fatalf("Expected output of a running task to be followed, got query: %q", query)
+	}
+	if code, _ := get(scootapi.TaskLogsPath + "job/task/other"); code != http.StatusBadRequest {
+		t.Fatalf("Expected bad request for an unknown stream, got: %d", code)
+	}
+}
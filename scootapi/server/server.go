@@ -64,3 +64,8 @@ func (h *Handler) OfflineWorker(req *scoot.OfflineWorkerReq) error {
 func (h *Handler) ReinstateWorker(req *scoot.ReinstateWorkerReq) error {
 	return api.ReinstateWorker(req, h.scheduler)
 }
+
+// Implements GetFlakyTasks Cloud Scoot API
+func (h *Handler) GetFlakyTasks(req *scoot.GetFlakyTasksReq) (*scoot.FlakyTasks, error) {
+	return api.GetFlakyTasks(req, h.scheduler)
+}
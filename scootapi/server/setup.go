@@ -2,6 +2,7 @@ package server
 
 import (
 	"net"
+	"net/http"
 	"time"
 
 	"github.com/apache/thrift/lib/go/thrift"
@@ -17,9 +18,11 @@ import (
 	"github.com/twitter/scoot/ice"
 	"github.com/twitter/scoot/runner"
 	"github.com/twitter/scoot/saga"
+	"github.com/twitter/scoot/saga/sagalogs"
 	"github.com/twitter/scoot/sched/scheduler"
 	"github.com/twitter/scoot/scootapi"
 	"github.com/twitter/scoot/scootapi/gen-go/scoot"
+	"github.com/twitter/scoot/scootapi/server/api"
 )
 
 type servers struct {
@@ -73,8 +76,16 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 			return MakeServer(h, t, tf, pf)
 		},
 
-		func(s stats.StatsReceiver) *endpoints.TwitterServer {
-			return endpoints.NewTwitterServer(endpoints.Addr(scootapi.DefaultSched_HTTP), s, nil)
+		func(s stats.StatsReceiver, handlers map[string]http.Handler) *endpoints.TwitterServer {
+			return endpoints.NewTwitterServer(endpoints.Addr(scootapi.DefaultSched_HTTP), s, handlers)
+		},
+
+		func(s scheduler.Scheduler, slog saga.SagaLog, stat stats.StatsReceiver) map[string]http.Handler {
+			handlers := map[string]http.Handler{scootapi.TaskLogsPath: api.NewTaskLogsHandler(s, stat)}
+			if history, ok := slog.(sagalogs.SagaHistory); ok {
+				handlers[api.JobHistoryPath] = api.NewJobHistoryHandler(history, stat)
+			}
+			return handlers
 		},
 
 		func(t thrift.TServer, h *endpoints.TwitterServer, g bazel.GRPCServer) servers {
@@ -100,9 +111,11 @@ func Defaults() (*ice.MagicBag, jsonconfig.Schema) {
 
 	schema := jsonconfig.Schema(map[string]jsonconfig.Implementations{
 		"SagaLog": {
-			"memory": &scootconfig.InMemorySagaLogConfig{},
-			"file":   &scootconfig.FileSagaLogConfig{},
-			"":       &scootconfig.InMemorySagaLogConfig{},
+			"memory":  &scootconfig.InMemorySagaLogConfig{},
+			"file":    &scootconfig.FileSagaLogConfig{},
+			"durable": &scootconfig.DurableSagaLogConfig{},
+			"sql":     &scootconfig.SQLSagaLogConfig{},
+			"":        &scootconfig.InMemorySagaLogConfig{},
 		},
 		"Cluster": {
 			"memory": &scootconfig.ClusterMemoryConfig{},
@@ -0,0 +1,27 @@
+package scootapi
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Http path under which the scheduler serves task output, ex: /logs/<jobId>/<taskId>/stdout
+const TaskLogsPath = "/logs/"
+
+// How long to wait for a worker or other output server to connect and start responding.
+// There's no limit on the whole request since the output of a running task is streamed until it finishes,
+// that's bounded by the caller's request instead.
+const TaskLogsResponseTimeout = 30 * time.Second
+
+// Returns an http client for requesting task output, see TaskLogsResponseTimeout.
+func NewTaskLogsClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:                 http.ProxyFromEnvironment,
+			DialContext:           (&net.Dialer{Timeout: TaskLogsResponseTimeout}).DialContext,
+			TLSHandshakeTimeout:   TaskLogsResponseTimeout,
+			ResponseHeaderTimeout: TaskLogsResponseTimeout,
+		},
+	}
+}
@@ -4,3 +4,6 @@ const (
 	// Store-related environment variables
 	BundlestoreDirEnvVar = "BUNDLESTORE_STORE_DIR"
 )
+
+// Path under which ActionResults are purged by ActionDigest via the HTTP API
+const actionCachePath = "/bundle/actioncache/"
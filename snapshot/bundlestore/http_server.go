@@ -9,6 +9,8 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/snapshot/store"
 )
@@ -111,3 +113,39 @@ func (s *httpServer) HandleDownload(w http.ResponseWriter, req *http.Request) {
 	}
 	s.storeConfig.Stat.Counter(stats.BundlestoreDownloadOkCounter).Inc(1)
 }
+
+// Admin API to purge a cached ActionResult, addressed by the [<digest function>/]<hash>/<size> of its ActionDigest.
+// Use when a cached result is known to be bad, i.e. produced by a broken or nondeterministic action.
+func (s *httpServer) HandlePurgeAction(w http.ResponseWriter, req *http.Request) {
+	log.Infof("Purging %v %v (from %v)", req.Host, req.URL, req.RemoteAddr)
+	s.storeConfig.Stat.Counter(stats.BundlestorePurgeActionCounter).Inc(1)
+	if !strings.HasPrefix(req.URL.Path, actionCachePath) {
+		log.Infof("Purge path err: %v --> StatusBadRequest (from %v)", req.URL.Path, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Only ActionResults can be purged, expected %s<hash>/<size>", actionCachePath), http.StatusBadRequest)
+		s.storeConfig.Stat.Counter(stats.BundlestorePurgeActionErrCounter).Inc(1)
+		return
+	}
+
+	// Digests computed by functions other than SHA256 are prefixed with the function name
+	digestStr := strings.TrimPrefix(req.URL.Path, actionCachePath)
+	fn := bazel.SHA256
+	if parts := strings.SplitN(digestStr, "/", 2); len(parts) == 2 {
+		if f, ok := bazel.DigestFunctionFromName(parts[0]); ok {
+			fn, digestStr = f, parts[1]
+		}
+	}
+	digest, err := bazel.DigestFromString(digestStr)
+	if err != nil {
+		log.Infof("Digest err: %v --> StatusBadRequest (from %v)", err, req.RemoteAddr)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		s.storeConfig.Stat.Counter(stats.BundlestorePurgeActionErrCounter).Inc(1)
+		return
+	}
+	if err := cas.PurgeActionResult(s.storeConfig, fn, digest); err != nil {
+		log.Infof("Purge err: %v --> StatusInternalServerError (from %v)", err, req.RemoteAddr)
+		http.Error(w, fmt.Sprintf("Error purging ActionResult: %s", err), http.StatusInternalServerError)
+		s.storeConfig.Stat.Counter(stats.BundlestorePurgeActionErrCounter).Inc(1)
+		return
+	}
+	fmt.Fprintf(w, "Successfully purged ActionResult for %s\n", bazel.DigestToStr(digest))
+}
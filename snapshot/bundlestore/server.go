@@ -29,7 +29,8 @@ type Server struct {
 // Make a new server that delegates to an underlying store.
 // TTL may be nil, in which case defaults are applied downstream.
 // TTL duration may be overriden by request headers, but we always pass this TTLKey to the store.
-func MakeServer(s store.Store, ttl *store.TTLConfig, stat stats.StatsReceiver, l bazel.GRPCListener) *Server {
+// ActionCacheConfig may be nil, in which case defaults are applied downstream.
+func MakeServer(s store.Store, ttl *store.TTLConfig, ac *cas.ActionCacheConfig, stat stats.StatsReceiver, l bazel.GRPCListener) *Server {
 	scopedStat := stat.Scope("bundlestoreServer")
 	go stats.StartUptimeReporting(scopedStat, stats.BundlestoreUptime_ms, stats.BundlestoreServerStartedGauge, stats.DefaultStartupGaugeSpikeLen)
 	cfg := &store.StoreConfig{Store: s, TTLCfg: ttl, Stat: scopedStat}
@@ -38,7 +39,7 @@ func MakeServer(s store.Store, ttl *store.TTLConfig, stat stats.StatsReceiver, l
 	return &Server{
 		storeConfig: cfg,
 		httpServer:  MakeHTTPServer(cfg),
-		casServer:   cas.MakeCASServer(l, cfg, stat),
+		casServer:   cas.MakeCASServer(l, cfg, ac, stat),
 	}
 }
 
@@ -52,19 +53,26 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		fallthrough
 	case "GET":
 		s.httpServer.HandleDownload(w, req)
+	case "DELETE":
+		s.httpServer.HandlePurgeAction(w, req)
 	default:
 		log.Infof("Request err: %v --> StatusMethodNotAllowed (from %v)", req.Method, req.RemoteAddr)
-		http.Error(w, "only support POST and GET", http.StatusMethodNotAllowed)
+		http.Error(w, "only support POST, GET and DELETE", http.StatusMethodNotAllowed)
 		return
 	}
 	s.storeConfig.Stat.Counter(stats.BundlestoreRequestOkCounter).Inc(1)
 }
 
 // Check for name enforcement for HTTP API
+// Besides bundles, workers store task logs, named by runners.LogStore.
 func checkBundleName(name string) error {
 	bundleRE := "^bs-[a-z0-9]{40}.bundle"
 	if ok, _ := regexp.MatchString(bundleRE, name); ok {
 		return nil
 	}
-	return fmt.Errorf("Error with bundleName, expected %q, got: %s", bundleRE, name)
+	logRE := `^log-[a-z0-9]{40}-(stdout|stderr|stdlog)\.txt$`
+	if ok, _ := regexp.MatchString(logRE, name); ok {
+		return nil
+	}
+	return fmt.Errorf("Error with bundleName, expected %q or %q, got: %s", bundleRE, logRE, name)
 }
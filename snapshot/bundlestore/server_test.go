@@ -6,10 +6,17 @@ import (
 	"io/ioutil"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
 
+	remoteexecution "github.com/twitter/scoot/bazel/remoteexecution"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/twitter/scoot/bazel"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/snapshot/store"
 )
@@ -29,7 +36,7 @@ func TestServer(t *testing.T) {
 	statsRegistry := stats.NewFinagleStatsRegistry()
 	statsReceiver, _ := stats.NewCustomStatsReceiver(func() stats.StatsRegistry { return statsRegistry }, 0)
 	stats.StatReportIntvl = 20 * time.Millisecond
-	server := MakeServer(fakeStore, nil, statsReceiver, nil)
+	server := MakeServer(fakeStore, nil, nil, statsReceiver, nil)
 	mux := http.NewServeMux()
 	mux.Handle("/bundle/", server)
 	go func() {
@@ -277,3 +284,55 @@ func TestRetry(t *testing.T) {
 		t.Fatalf("Expected 3 tries, got: %d", server.counter)
 	}
 }
+
+func TestPurgeAction(t *testing.T) {
+	fakeStore := &store.FakeStore{Files: map[string][]byte{}, TTL: nil}
+	server := MakeServer(fakeStore, nil, nil, stats.NilStatsReceiver(), nil)
+	acs := server.casServer.(remoteexecution.ActionCacheServer)
+
+	// Cache a result with no outputs
+	ad := &remoteexecution.Digest{Hash: "0000000000000000000000000000000000000000000000000000000000000001", SizeBytes: 1}
+	if _, err := acs.UpdateActionResult(context.Background(),
+		&remoteexecution.UpdateActionResultRequest{ActionDigest: ad, ActionResult: &remoteexecution.ActionResult{ExitCode: 1}}); err != nil {
+		t.Fatalf("Failed to UpdateActionResult: %v", err)
+	}
+
+	for _, c := range []struct {
+		path string
+		code int
+	}{
+		{"/bundle/bs-0000000000000000000000000000000000000001.bundle", http.StatusBadRequest},
+		{"/bundle/actioncache/invalid", http.StatusBadRequest},
+		{"/bundle/actioncache/" + bazel.DigestToStr(ad), http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		server.ServeHTTP(w, httptest.NewRequest("DELETE", c.path, nil))
+		if w.Code != c.code {
+			t.Fatalf("Expected status %d purging %s, got: %d %s", c.code, c.path, w.Code, w.Body)
+		}
+	}
+
+	_, err := acs.GetActionResult(context.Background(), &remoteexecution.GetActionResultRequest{ActionDigest: ad})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("Expected NotFound for purged ActionResult, got: %v", err)
+	}
+}
+
+func TestCheckBundleName(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		ok   bool
+	}{
+		{"bs-0000000000000000000000000000000000000001.bundle", true},
+		{"log-0000000000000000000000000000000000000001-stdout.txt", true},
+		{"log-0000000000000000000000000000000000000001-stdlog.txt", true},
+		{"log-0000000000000000000000000000000000000001-other.txt", false},
+		{"log-0000000000000000000000000000000000000001-stdoutXtxt", false},
+		{"log-0001-stdout.txt", false},
+		{"foo", false},
+	} {
+		if err := checkBundleName(c.name); (err == nil) != c.ok {
+			t.Fatalf("Expected %s to be valid: %v, got: %v", c.name, c.ok, err)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/twitter/scoot/bazel"
+	"github.com/twitter/scoot/bazel/cas"
 	"github.com/twitter/scoot/common/endpoints"
 	"github.com/twitter/scoot/config/jsonconfig"
 	"github.com/twitter/scoot/ice"
@@ -49,6 +50,7 @@ func (m module) Install(b *ice.MagicBag) {
 	b.Put(MakeFileStoreInEnvOrTemp)
 	b.Put(MakeServer)
 	b.Put(DefaultStore)
+	b.Put(cas.DefaultActionCacheConfig)
 }
 
 // Creates a MagicBag for a default bundlestore server and returns it
@@ -81,14 +81,17 @@ func (dba *dbAdapter) Checkout(id string) (Checkout, error) {
 	}
 }
 
+// Copies a checkout of id into dir, releasing the DB's checkout once it's copied.
 func (dba *dbAdapter) CheckoutAt(id string, dir string) (Checkout, error) {
-	if co, err := dba.Checkout(id); err != nil {
+	co, err := dba.Checkout(id)
+	if err != nil {
 		return nil, err
-	} else if err := exec.Command("cp", "-r", co.Path()+"/.", dir).Run(); err != nil {
+	}
+	defer co.Release()
+	if err := exec.Command("cp", "-r", co.Path()+"/.", dir).Run(); err != nil {
 		return nil, err
-	} else {
-		return &dbCheckout{db: dba.db, dir: dir, id: id}, nil
 	}
+	return &dbCheckout{db: dba.db, dir: dir, id: id}, nil
 }
 
 func (dba *dbAdapter) Ingest(path string) (id string, err error) {
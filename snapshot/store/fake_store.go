@@ -6,15 +6,20 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sync"
 )
 
 // Implements Store. FakeStore just keeps references to data that would be stored
+// and is safe for concurrent use.
 type FakeStore struct {
 	Files map[string][]byte
 	TTL   *TTLValue
+	mutex sync.RWMutex
 }
 
 func (f *FakeStore) Exists(name string) (bool, error) {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
 	if _, ok := f.Files[name]; !ok {
 		return false, nil
 	}
@@ -22,10 +27,13 @@ func (f *FakeStore) Exists(name string) (bool, error) {
 }
 
 func (f *FakeStore) OpenForRead(name string) (io.ReadCloser, error) {
-	if ok, _ := f.Exists(name); !ok {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	b, ok := f.Files[name]
+	if !ok {
 		return nil, errors.New("Doesn't exist :" + name)
 	}
-	return ioutil.NopCloser(bytes.NewBuffer(f.Files[name])), nil
+	return ioutil.NopCloser(bytes.NewBuffer(b)), nil
 }
 
 func (f *FakeStore) Root() string { return "" }
@@ -35,16 +43,17 @@ func (f *FakeStore) Write(name string, data io.Reader, ttl *TTLValue) error {
 		return fmt.Errorf("TTL mismatch: expected: %v, got: %v", f.TTL, ttl)
 	}
 
-	// Initialize map on first entry
-	if f.Files == nil {
-		f.Files = make(map[string][]byte)
-	}
-
 	b, err := ioutil.ReadAll(data)
 	if err != nil {
 		return err
-	} else {
-		f.Files[name] = b
-		return nil
 	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	// Initialize map on first entry
+	if f.Files == nil {
+		f.Files = make(map[string][]byte)
+	}
+	f.Files[name] = b
+	return nil
 }
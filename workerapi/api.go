@@ -20,6 +20,9 @@ type WorkerStatus struct {
 	Runs        []runner.RunStatus
 	Initialized bool
 	Error       string
+	Capacity    runner.Resources
+	Slots       int
+	FreeSlots   int
 }
 
 func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
@@ -27,7 +30,12 @@ func ThriftWorkerStatusToDomain(thrift *worker.WorkerStatus) WorkerStatus {
 	for _, r := range thrift.Runs {
 		runs = append(runs, ThriftRunStatusToDomain(r))
 	}
-	return WorkerStatus{runs, thrift.Initialized, thrift.Error}
+	capacity := runner.Resources{
+		Memory: thrift.GetCapacityMemoryBytes(),
+		CPUs:   thrift.GetCapacityCpus(),
+		Disk:   thrift.GetCapacityDiskBytes(),
+	}
+	return WorkerStatus{runs, thrift.Initialized, thrift.Error, capacity, int(thrift.GetSlots()), int(thrift.GetFreeSlots())}
 }
 
 func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
@@ -35,8 +43,15 @@ func DomainWorkerStatusToThrift(domain WorkerStatus) *worker.WorkerStatus {
 	thrift.Runs = make([]*worker.RunStatus, 0)
 	for _, r := range domain.Runs {
 		thrift.Runs = append(thrift.Runs, DomainRunStatusToThrift(r))
-		thrift.Initialized = domain.Initialized
-		thrift.Error = domain.Error
+	}
+	thrift.Initialized = domain.Initialized
+	thrift.Error = domain.Error
+	thrift.CapacityMemoryBytes, thrift.CapacityCpus, thrift.CapacityDiskBytes = ResourcesToThrift(domain.Capacity)
+	if domain.Slots != 0 {
+		slots := int32(domain.Slots)
+		freeSlots := int32(domain.FreeSlots)
+		thrift.Slots = &slots
+		thrift.FreeSlots = &freeSlots
 	}
 	return thrift
 }
@@ -46,6 +61,7 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 	env := make(map[string]string)
 	timeout := time.Duration(0)
 	snapshotID := ""
+	mergeSnapshotID := ""
 	jobID := ""
 	taskID := ""
 	tag := ""
@@ -61,6 +77,9 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 	if thrift.SnapshotId != nil {
 		snapshotID = *thrift.SnapshotId
 	}
+	if thrift.MergeSnapshotId != nil {
+		mergeSnapshotID = *thrift.MergeSnapshotId
+	}
 	if thrift.TaskId != nil {
 		taskID = *thrift.TaskId
 	}
@@ -71,17 +90,25 @@ func ThriftRunCommandToDomain(thrift *worker.RunCommand) *runner.Command {
 		tag = *thrift.Tag
 	}
 	er := bazelapi.MakeExecReqDomainFromThrift(thrift.BazelRequest)
+	resources := runner.Resources{
+		Memory: thrift.GetMemoryBytes(),
+		CPUs:   thrift.GetCpus(),
+		Disk:   thrift.GetDiskBytes(),
+	}
 	return &runner.Command{
-		Argv:       argv,
-		EnvVars:    env,
-		Timeout:    timeout,
-		SnapshotID: snapshotID,
+		Argv:            argv,
+		EnvVars:         env,
+		Timeout:         timeout,
+		SnapshotID:      snapshotID,
+		MergeSnapshotID: mergeSnapshotID,
 		LogTags: tags.LogTags{
 			JobID:  jobID,
 			TaskID: taskID,
 			Tag:    tag,
 		},
 		ExecuteRequest: er,
+		Resources:      resources,
+		AllowNetwork:   thrift.GetAllowNetwork(),
 	}
 }
 
@@ -93,6 +120,10 @@ func DomainRunCommandToThrift(domain *runner.Command) *worker.RunCommand {
 	thrift.Argv = domain.Argv
 	snapID := domain.SnapshotID
 	thrift.SnapshotId = &snapID
+	if domain.MergeSnapshotID != "" {
+		mergeSnapID := domain.MergeSnapshotID
+		thrift.MergeSnapshotId = &mergeSnapID
+	}
 	jobID := domain.JobID
 	thrift.JobId = &jobID
 	taskID := domain.TaskID
@@ -101,9 +132,28 @@ func DomainRunCommandToThrift(domain *runner.Command) *worker.RunCommand {
 	thrift.Tag = &tag
 	execReq := bazelapi.MakeExecReqThriftFromDomain(domain.ExecuteRequest)
 	thrift.BazelRequest = execReq
+	thrift.MemoryBytes, thrift.Cpus, thrift.DiskBytes = ResourcesToThrift(domain.Resources)
+	if domain.AllowNetwork {
+		allowNetwork := true
+		thrift.AllowNetwork = &allowNetwork
+	}
 	return thrift
 }
 
+// ResourcesToThrift returns pointers to the memory, cpus and disk of r, nil for zero values which are left unset
+func ResourcesToThrift(r runner.Resources) (memory *int64, cpus *float64, disk *int64) {
+	if r.Memory != 0 {
+		memory = &r.Memory
+	}
+	if r.CPUs != 0 {
+		cpus = &r.CPUs
+	}
+	if r.Disk != 0 {
+		disk = &r.Disk
+	}
+	return memory, cpus, disk
+}
+
 func ThriftRunStatusToDomain(thrift *worker.RunStatus) runner.RunStatus {
 	domain := runner.RunStatus{}
 	domain.RunID = runner.RunID(thrift.RunId)
@@ -149,6 +199,9 @@ func ThriftRunStatusToDomain(thrift *worker.RunStatus) runner.RunStatus {
 	if thrift.Tag != nil {
 		domain.Tag = *thrift.Tag
 	}
+	if thrift.FailureReason != nil {
+		domain.FailureReason = *thrift.FailureReason
+	}
 	domain.ActionResult = bazelapi.MakeActionResultDomainFromThrift(thrift.BazelResult_)
 	return domain
 }
@@ -183,6 +236,7 @@ func DomainRunStatusToThrift(domain runner.RunStatus) *worker.RunStatus {
 	thrift.JobId = helpers.CopyStringToPointer(domain.JobID)
 	thrift.TaskId = helpers.CopyStringToPointer(domain.TaskID)
 	thrift.Tag = helpers.CopyStringToPointer(domain.Tag)
+	thrift.FailureReason = helpers.CopyStringToPointer(domain.FailureReason)
 	thrift.BazelResult_ = bazelapi.MakeActionResultThriftFromDomain(domain.ActionResult)
 	return thrift
 }
@@ -199,3 +253,14 @@ func SerializeProcessStatus(processStatus runner.RunStatus) ([]byte, error) {
 
 	return asBytes, err
 }
+
+func DeserializeProcessStatus(asBytes []byte) (runner.RunStatus, error) {
+
+	runStatus := worker.NewRunStatus()
+
+	if err := thrifthelpers.JsonDeserialize(runStatus, asBytes); err != nil {
+		return runner.RunStatus{}, err
+	}
+
+	return ThriftRunStatusToDomain(runStatus), nil
+}
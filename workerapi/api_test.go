@@ -17,6 +17,11 @@ var nonzero = int32(12345)
 var emptystr = ""
 var nonemptystr = "abcdef"
 var deadbeefID = "snap-id-deadbeef"
+var someMemory = int64(1 << 30)
+var someCPUs = 1.5
+var someSlots = int32(4)
+var yes = true
+var someFreeSlots = int32(3)
 
 var cmdFromThrift = func(x interface{}) interface{} { return ThriftRunCommandToDomain(x.(*worker.RunCommand)) }
 var cmdToThrift = func(x interface{}) interface{} { return DomainRunCommandToThrift(x.(*runner.Command)) }
@@ -379,6 +384,87 @@ var tests = []struct {
 			},
 		},
 	},
+	{
+		16,
+		wsFromThrift,
+		wsToThrift,
+		&worker.WorkerStatus{
+			Runs:                []*worker.RunStatus{},
+			Initialized:         true,
+			CapacityMemoryBytes: &someMemory,
+			CapacityCpus:        &someCPUs,
+		},
+		WorkerStatus{
+			Runs:        []runner.RunStatus{},
+			Initialized: true,
+			Capacity:    runner.Resources{Memory: someMemory, CPUs: someCPUs},
+		},
+	},
+
+	//Cmd with resources and network access
+	{
+		17,
+		cmdFromThrift,
+		cmdToThrift,
+		&worker.RunCommand{
+			Argv:         someCmd,
+			Env:          map[string]string{},
+			SnapshotId:   &emptystr,
+			TimeoutMs:    &zero,
+			JobId:        &emptystr,
+			TaskId:       &emptystr,
+			Tag:          &emptystr,
+			MemoryBytes:  &someMemory,
+			Cpus:         &someCPUs,
+			AllowNetwork: &yes,
+		},
+		&runner.Command{
+			Argv:         someCmd,
+			EnvVars:      map[string]string{},
+			Timeout:      time.Duration(zero),
+			Resources:    runner.Resources{Memory: someMemory, CPUs: someCPUs},
+			AllowNetwork: true,
+		},
+	},
+
+	//WorkerStatus with slots
+	{
+		18,
+		wsFromThrift,
+		wsToThrift,
+		&worker.WorkerStatus{
+			Runs:        []*worker.RunStatus{},
+			Initialized: true,
+			Slots:       &someSlots,
+			FreeSlots:   &someFreeSlots,
+		},
+		WorkerStatus{
+			Runs:        []runner.RunStatus{},
+			Initialized: true,
+			Slots:       int(someSlots),
+			FreeSlots:   int(someFreeSlots),
+		},
+	},
+
+	//RunStatus with a FailureReason
+	{
+		19,
+		rsFromThrift,
+		rsToThrift,
+		&worker.RunStatus{
+			Status:        worker.Status_FAILED,
+			RunId:         "id",
+			Error:         &nonemptystr,
+			ExitCode:      &zero,
+			FailureReason: &nonemptystr,
+		},
+		runner.RunStatus{
+			RunID:         "id",
+			State:         runner.FAILED,
+			Error:         nonemptystr,
+			FailureReason: nonemptystr,
+		},
+	},
 }
 
 func TestTranslation(t *testing.T) {
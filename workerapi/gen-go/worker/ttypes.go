@@ -102,18 +102,20 @@ func (p *Status) UnmarshalText(text []byte) error {
 //  - TaskId
 //  - Tag
 //  - BazelResult_
+//  - FailureReason
 type RunStatus struct {
-	Status       Status               `thrift:"status,1,required" json:"status"`
-	RunId        string               `thrift:"runId,2,required" json:"runId"`
-	OutUri       *string              `thrift:"outUri,3" json:"outUri,omitempty"`
-	ErrUri       *string              `thrift:"errUri,4" json:"errUri,omitempty"`
-	Error        *string              `thrift:"error,5" json:"error,omitempty"`
-	ExitCode     *int32               `thrift:"exitCode,6" json:"exitCode,omitempty"`
-	SnapshotId   *string              `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
-	JobId        *string              `thrift:"jobId,8" json:"jobId,omitempty"`
-	TaskId       *string              `thrift:"taskId,9" json:"taskId,omitempty"`
-	Tag          *string              `thrift:"tag,10" json:"tag,omitempty"`
-	BazelResult_ *bazel.ActionResult_ `thrift:"bazelResult,11" json:"bazelResult,omitempty"`
+	Status        Status               `thrift:"status,1,required" json:"status"`
+	RunId         string               `thrift:"runId,2,required" json:"runId"`
+	OutUri        *string              `thrift:"outUri,3" json:"outUri,omitempty"`
+	ErrUri        *string              `thrift:"errUri,4" json:"errUri,omitempty"`
+	Error         *string              `thrift:"error,5" json:"error,omitempty"`
+	ExitCode      *int32               `thrift:"exitCode,6" json:"exitCode,omitempty"`
+	SnapshotId    *string              `thrift:"snapshotId,7" json:"snapshotId,omitempty"`
+	JobId         *string              `thrift:"jobId,8" json:"jobId,omitempty"`
+	TaskId        *string              `thrift:"taskId,9" json:"taskId,omitempty"`
+	Tag           *string              `thrift:"tag,10" json:"tag,omitempty"`
+	BazelResult_  *bazel.ActionResult_ `thrift:"bazelResult,11" json:"bazelResult,omitempty"`
+	FailureReason *string              `thrift:"failureReason,12" json:"failureReason,omitempty"`
 }
 
 func NewRunStatus() *RunStatus {
@@ -208,6 +210,15 @@ func (p *RunStatus) GetBazelResult_() *bazel.ActionResult_ {
 	}
 	return p.BazelResult_
 }
+
+var RunStatus_FailureReason_DEFAULT string
+
+func (p *RunStatus) GetFailureReason() string {
+	if !p.IsSetFailureReason() {
+		return RunStatus_FailureReason_DEFAULT
+	}
+	return *p.FailureReason
+}
 func (p *RunStatus) IsSetOutUri() bool {
 	return p.OutUri != nil
 }
@@ -244,6 +255,10 @@ func (p *RunStatus) IsSetBazelResult_() bool {
 	return p.BazelResult_ != nil
 }
 
+func (p *RunStatus) IsSetFailureReason() bool {
+	return p.FailureReason != nil
+}
+
 func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -307,6 +322,10 @@ func (p *RunStatus) Read(iprot thrift.TProtocol) error {
 			if err := p.readField11(iprot); err != nil {
 				return err
 			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -427,6 +446,15 @@ func (p *RunStatus) readField11(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RunStatus) readField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.FailureReason = &v
+	}
+	return nil
+}
+
 func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RunStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -464,6 +492,9 @@ func (p *RunStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField11(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -634,6 +665,21 @@ func (p *RunStatus) writeField11(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunStatus) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFailureReason() {
+		if err := oprot.WriteFieldBegin("failureReason", thrift.STRING, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:failureReason: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.FailureReason)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.failureReason (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:failureReason: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -645,10 +691,20 @@ func (p *RunStatus) String() string {
 //  - Runs
 //  - Initialized
 //  - Error
+//  - CapacityMemoryBytes
+//  - CapacityCpus
+//  - CapacityDiskBytes
+//  - Slots
+//  - FreeSlots
 type WorkerStatus struct {
-	Runs        []*RunStatus `thrift:"runs,1,required" json:"runs"`
-	Initialized bool         `thrift:"initialized,2,required" json:"initialized"`
-	Error       string       `thrift:"error,3,required" json:"error"`
+	Runs                []*RunStatus `thrift:"runs,1,required" json:"runs"`
+	Initialized         bool         `thrift:"initialized,2,required" json:"initialized"`
+	Error               string       `thrift:"error,3,required" json:"error"`
+	CapacityMemoryBytes *int64       `thrift:"capacityMemoryBytes,4" json:"capacityMemoryBytes,omitempty"`
+	CapacityCpus        *float64     `thrift:"capacityCpus,5" json:"capacityCpus,omitempty"`
+	CapacityDiskBytes   *int64       `thrift:"capacityDiskBytes,6" json:"capacityDiskBytes,omitempty"`
+	Slots               *int32       `thrift:"slots,7" json:"slots,omitempty"`
+	FreeSlots           *int32       `thrift:"freeSlots,8" json:"freeSlots,omitempty"`
 }
 
 func NewWorkerStatus() *WorkerStatus {
@@ -666,6 +722,71 @@ func (p *WorkerStatus) GetInitialized() bool {
 func (p *WorkerStatus) GetError() string {
 	return p.Error
 }
+
+var WorkerStatus_CapacityMemoryBytes_DEFAULT int64
+
+func (p *WorkerStatus) GetCapacityMemoryBytes() int64 {
+	if !p.IsSetCapacityMemoryBytes() {
+		return WorkerStatus_CapacityMemoryBytes_DEFAULT
+	}
+	return *p.CapacityMemoryBytes
+}
+
+var WorkerStatus_CapacityCpus_DEFAULT float64
+
+func (p *WorkerStatus) GetCapacityCpus() float64 {
+	if !p.IsSetCapacityCpus() {
+		return WorkerStatus_CapacityCpus_DEFAULT
+	}
+	return *p.CapacityCpus
+}
+
+var WorkerStatus_CapacityDiskBytes_DEFAULT int64
+
+func (p *WorkerStatus) GetCapacityDiskBytes() int64 {
+	if !p.IsSetCapacityDiskBytes() {
+		return WorkerStatus_CapacityDiskBytes_DEFAULT
+	}
+	return *p.CapacityDiskBytes
+}
+
+var WorkerStatus_Slots_DEFAULT int32
+
+func (p *WorkerStatus) GetSlots() int32 {
+	if !p.IsSetSlots() {
+		return WorkerStatus_Slots_DEFAULT
+	}
+	return *p.Slots
+}
+
+var WorkerStatus_FreeSlots_DEFAULT int32
+
+func (p *WorkerStatus) GetFreeSlots() int32 {
+	if !p.IsSetFreeSlots() {
+		return WorkerStatus_FreeSlots_DEFAULT
+	}
+	return *p.FreeSlots
+}
+func (p *WorkerStatus) IsSetCapacityMemoryBytes() bool {
+	return p.CapacityMemoryBytes != nil
+}
+
+func (p *WorkerStatus) IsSetCapacityCpus() bool {
+	return p.CapacityCpus != nil
+}
+
+func (p *WorkerStatus) IsSetCapacityDiskBytes() bool {
+	return p.CapacityDiskBytes != nil
+}
+
+func (p *WorkerStatus) IsSetSlots() bool {
+	return p.Slots != nil
+}
+
+func (p *WorkerStatus) IsSetFreeSlots() bool {
+	return p.FreeSlots != nil
+}
+
 func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -699,6 +820,26 @@ func (p *WorkerStatus) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetError = true
+		case 4:
+			if err := p.readField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.readField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.readField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.readField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.readField8(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -761,6 +902,51 @@ func (p *WorkerStatus) readField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *WorkerStatus) readField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.CapacityMemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.CapacityCpus = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.CapacityDiskBytes = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.Slots = &v
+	}
+	return nil
+}
+
+func (p *WorkerStatus) readField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.FreeSlots = &v
+	}
+	return nil
+}
+
 func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("WorkerStatus"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -774,6 +960,21 @@ func (p *WorkerStatus) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField3(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField4(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField5(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField6(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField7(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField8(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -830,6 +1031,81 @@ func (p *WorkerStatus) writeField3(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *WorkerStatus) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCapacityMemoryBytes() {
+		if err := oprot.WriteFieldBegin("capacityMemoryBytes", thrift.I64, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:capacityMemoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.CapacityMemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.capacityMemoryBytes (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:capacityMemoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCapacityCpus() {
+		if err := oprot.WriteFieldBegin("capacityCpus", thrift.DOUBLE, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:capacityCpus: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.CapacityCpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.capacityCpus (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:capacityCpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCapacityDiskBytes() {
+		if err := oprot.WriteFieldBegin("capacityDiskBytes", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:capacityDiskBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.CapacityDiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.capacityDiskBytes (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:capacityDiskBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSlots() {
+		if err := oprot.WriteFieldBegin("slots", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:slots: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.Slots)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.slots (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:slots: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *WorkerStatus) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetFreeSlots() {
+		if err := oprot.WriteFieldBegin("freeSlots", thrift.I32, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:freeSlots: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.FreeSlots)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.freeSlots (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:freeSlots: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *WorkerStatus) String() string {
 	if p == nil {
 		return "<nil>"
@@ -846,15 +1122,25 @@ func (p *WorkerStatus) String() string {
 //  - TaskId
 //  - Tag
 //  - BazelRequest
+//  - MergeSnapshotId
+//  - MemoryBytes
+//  - Cpus
+//  - DiskBytes
+//  - AllowNetwork
 type RunCommand struct {
-	Argv         []string              `thrift:"argv,1,required" json:"argv"`
-	Env          map[string]string     `thrift:"env,2" json:"env,omitempty"`
-	SnapshotId   *string               `thrift:"snapshotId,3" json:"snapshotId,omitempty"`
-	TimeoutMs    *int32                `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
-	JobId        *string               `thrift:"jobId,5" json:"jobId,omitempty"`
-	TaskId       *string               `thrift:"taskId,6" json:"taskId,omitempty"`
-	Tag          *string               `thrift:"tag,7" json:"tag,omitempty"`
-	BazelRequest *bazel.ExecuteRequest `thrift:"bazelRequest,8" json:"bazelRequest,omitempty"`
+	Argv            []string              `thrift:"argv,1,required" json:"argv"`
+	Env             map[string]string     `thrift:"env,2" json:"env,omitempty"`
+	SnapshotId      *string               `thrift:"snapshotId,3" json:"snapshotId,omitempty"`
+	TimeoutMs       *int32                `thrift:"timeoutMs,4" json:"timeoutMs,omitempty"`
+	JobId           *string               `thrift:"jobId,5" json:"jobId,omitempty"`
+	TaskId          *string               `thrift:"taskId,6" json:"taskId,omitempty"`
+	Tag             *string               `thrift:"tag,7" json:"tag,omitempty"`
+	BazelRequest    *bazel.ExecuteRequest `thrift:"bazelRequest,8" json:"bazelRequest,omitempty"`
+	MergeSnapshotId *string               `thrift:"mergeSnapshotId,9" json:"mergeSnapshotId,omitempty"`
+	MemoryBytes     *int64                `thrift:"memoryBytes,10" json:"memoryBytes,omitempty"`
+	Cpus            *float64              `thrift:"cpus,11" json:"cpus,omitempty"`
+	DiskBytes       *int64                `thrift:"diskBytes,12" json:"diskBytes,omitempty"`
+	AllowNetwork    *bool                 `thrift:"allowNetwork,13" json:"allowNetwork,omitempty"`
 }
 
 func NewRunCommand() *RunCommand {
@@ -924,6 +1210,51 @@ func (p *RunCommand) GetBazelRequest() *bazel.ExecuteRequest {
 	}
 	return p.BazelRequest
 }
+
+var RunCommand_MergeSnapshotId_DEFAULT string
+
+func (p *RunCommand) GetMergeSnapshotId() string {
+	if !p.IsSetMergeSnapshotId() {
+		return RunCommand_MergeSnapshotId_DEFAULT
+	}
+	return *p.MergeSnapshotId
+}
+
+var RunCommand_MemoryBytes_DEFAULT int64
+
+func (p *RunCommand) GetMemoryBytes() int64 {
+	if !p.IsSetMemoryBytes() {
+		return RunCommand_MemoryBytes_DEFAULT
+	}
+	return *p.MemoryBytes
+}
+
+var RunCommand_Cpus_DEFAULT float64
+
+func (p *RunCommand) GetCpus() float64 {
+	if !p.IsSetCpus() {
+		return RunCommand_Cpus_DEFAULT
+	}
+	return *p.Cpus
+}
+
+var RunCommand_DiskBytes_DEFAULT int64
+
+func (p *RunCommand) GetDiskBytes() int64 {
+	if !p.IsSetDiskBytes() {
+		return RunCommand_DiskBytes_DEFAULT
+	}
+	return *p.DiskBytes
+}
+
+var RunCommand_AllowNetwork_DEFAULT bool
+
+func (p *RunCommand) GetAllowNetwork() bool {
+	if !p.IsSetAllowNetwork() {
+		return RunCommand_AllowNetwork_DEFAULT
+	}
+	return *p.AllowNetwork
+}
 func (p *RunCommand) IsSetEnv() bool {
 	return p.Env != nil
 }
@@ -952,6 +1283,26 @@ func (p *RunCommand) IsSetBazelRequest() bool {
 	return p.BazelRequest != nil
 }
 
+func (p *RunCommand) IsSetMergeSnapshotId() bool {
+	return p.MergeSnapshotId != nil
+}
+
+func (p *RunCommand) IsSetMemoryBytes() bool {
+	return p.MemoryBytes != nil
+}
+
+func (p *RunCommand) IsSetCpus() bool {
+	return p.Cpus != nil
+}
+
+func (p *RunCommand) IsSetDiskBytes() bool {
+	return p.DiskBytes != nil
+}
+
+func (p *RunCommand) IsSetAllowNetwork() bool {
+	return p.AllowNetwork != nil
+}
+
 func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -1001,6 +1352,26 @@ func (p *RunCommand) Read(iprot thrift.TProtocol) error {
 			if err := p.readField8(iprot); err != nil {
 				return err
 			}
+		case 9:
+			if err := p.readField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.readField10(iprot); err != nil {
+				return err
+			}
+		case 11:
+			if err := p.readField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.readField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.readField13(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -1122,6 +1493,51 @@ func (p *RunCommand) readField8(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *RunCommand) readField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.MergeSnapshotId = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.MemoryBytes = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField11(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 11: ", err)
+	} else {
+		p.Cpus = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.DiskBytes = &v
+	}
+	return nil
+}
+
+func (p *RunCommand) readField13(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 13: ", err)
+	} else {
+		p.AllowNetwork = &v
+	}
+	return nil
+}
+
 func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("RunCommand"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -1150,6 +1566,21 @@ func (p *RunCommand) Write(oprot thrift.TProtocol) error {
 	if err := p.writeField8(oprot); err != nil {
 		return err
 	}
+	if err := p.writeField9(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField10(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField11(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField12(oprot); err != nil {
+		return err
+	}
+	if err := p.writeField13(oprot); err != nil {
+		return err
+	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
 	}
@@ -1296,6 +1727,81 @@ func (p *RunCommand) writeField8(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *RunCommand) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMergeSnapshotId() {
+		if err := oprot.WriteFieldBegin("mergeSnapshotId", thrift.STRING, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:mergeSnapshotId: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.MergeSnapshotId)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.mergeSnapshotId (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:mergeSnapshotId: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMemoryBytes() {
+		if err := oprot.WriteFieldBegin("memoryBytes", thrift.I64, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:memoryBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.MemoryBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.memoryBytes (10) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:memoryBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetCpus() {
+		if err := oprot.WriteFieldBegin("cpus", thrift.DOUBLE, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:cpus: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.Cpus)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.cpus (11) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:cpus: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDiskBytes() {
+		if err := oprot.WriteFieldBegin("diskBytes", thrift.I64, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:diskBytes: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.DiskBytes)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.diskBytes (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:diskBytes: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *RunCommand) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAllowNetwork() {
+		if err := oprot.WriteFieldBegin("allowNetwork", thrift.BOOL, 13); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:allowNetwork: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.AllowNetwork)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.allowNetwork (13) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 13:allowNetwork: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *RunCommand) String() string {
 	if p == nil {
 		return "<nil>"
@@ -13,7 +13,6 @@ import (
 	"github.com/twitter/scoot/common/log/helpers"
 	"github.com/twitter/scoot/common/stats"
 	"github.com/twitter/scoot/runner"
-	"github.com/twitter/scoot/runner/runners"
 	domain "github.com/twitter/scoot/workerapi"
 	"github.com/twitter/scoot/workerapi/gen-go/worker"
 )
@@ -40,14 +39,21 @@ type handler struct {
 	run          runner.Service
 	timeLastRpc  time.Time
 	mu           sync.RWMutex
-	currentCmd   *runner.Command
-	currentRunID runner.RunID
+	activeCmds   map[runner.RunID]*runner.Command // Commands started by this handler that may still be running
+	capacity     runner.Resources
 }
 
-// Creates a new Handler which combines a runner.Service to do work and a StatsReceiver
-func NewHandler(stat stats.StatsReceiver, run runner.Service) worker.Worker {
+// Creates a new Handler which combines a runner.Service to do work and a StatsReceiver.
+// The capacity is advertised to the scheduler, which won't assign this worker more than it can hold.
+func NewHandler(stat stats.StatsReceiver, run runner.Service, capacity runner.Resources) worker.Worker {
 	scopedStat := stat.Scope("handler")
-	h := &handler{stat: scopedStat, run: run, timeLastRpc: time.Now()}
+	h := &handler{
+		stat:        scopedStat,
+		run:         run,
+		timeLastRpc: time.Now(),
+		activeCmds:  make(map[runner.RunID]*runner.Command),
+		capacity:    capacity,
+	}
 	stats.ReportServerRestart(scopedStat, stats.WorkerServerStartedGauge, stats.DefaultStartupGaugeSpikeLen)
 	go h.stats()
 	return h
@@ -128,8 +134,13 @@ func (h *handler) QueryWorker() (*worker.WorkerStatus, error) {
 		ws.Error = err.Error()
 	}
 	ws.Initialized = svc.Initialized
+	ws.CapacityMemoryBytes, ws.CapacityCpus, ws.CapacityDiskBytes = domain.ResourcesToThrift(h.capacity)
 
+	freeSlots := svc.Slots
 	for _, status := range st {
+		if !status.State.IsDone() {
+			freeSlots--
+		}
 		if status.State.IsDone() {
 			// Note: TravisCI fails when output is too long so we set full status to Debug and disable it when running in that env.
 			if log.GetLevel() == log.DebugLevel {
@@ -140,9 +151,22 @@ func (h *handler) QueryWorker() (*worker.WorkerStatus, error) {
 		}
 		ws.Runs = append(ws.Runs, domain.DomainRunStatusToThrift(status))
 	}
+	if svc.Slots > 0 {
+		slots := int32(svc.Slots)
+		free := int32(maxInt(0, freeSlots))
+		ws.Slots = &slots
+		ws.FreeSlots = &free
+	}
 	return ws, nil
 }
 
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 // Implements worker.thrift Worker.Run interface
 func (h *handler) Run(cmd *worker.RunCommand) (*worker.RunStatus, error) {
 	defer h.stat.Latency(stats.WorkerServerStartRunLatency_ms).Time().Stop()
@@ -160,21 +184,25 @@ func (h *handler) Run(cmd *worker.RunCommand) (*worker.RunStatus, error) {
 
 	h.updateTimeLastRpc()
 	c := domain.ThriftRunCommandToDomain(cmd)
-	status, err := h.run.Run(c)
+
+	h.mu.Lock()
 	//Check if this is a dup retry for an already running command and if so get its status.
 	//TODO(jschiller): accept a cmd.Nonce field so we can be precise about hiccups with dup cmd resends?
-	if err != nil && err.Error() == runners.QueueFullMsg && reflect.DeepEqual(c, h.currentCmd) {
-		log.Infof("Worker received dup request, recovering runID: %v", h.currentRunID)
-		status, _, err = h.run.Status(h.currentRunID)
+	var err error
+	status, dup := h.findActiveCmd(c)
+	if dup {
+		log.Infof("Worker received dup request, recovering runID: %v", status.RunID)
+	} else {
+		status, err = h.run.Run(c)
 	}
 	if err != nil {
 		// Set invalid status and nil err to indicate handleable internal err.
 		status.Error = err.Error()
 		status.State = runner.BADREQUEST
 	} else {
-		h.currentCmd = c
-		h.currentRunID = status.RunID
+		h.activeCmds[status.RunID] = c
 	}
+	h.mu.Unlock()
 	// status's stdout, stderr, taskID, jobID, and tag might not be populated yet.
 	// h.run.Run(c) calls *runner.Invoker#run in a goroutine, and these fields are set on the fly
 	log.WithFields(
@@ -192,6 +220,22 @@ func (h *handler) Run(cmd *worker.RunCommand) (*worker.RunStatus, error) {
 	return domain.DomainRunStatusToThrift(status), nil
 }
 
+// Returns the status of an active run of a command identical to c, if there is one.
+// Forgets about commands that have finished. Must be called with h.mu held.
+func (h *handler) findActiveCmd(c *runner.Command) (runner.RunStatus, bool) {
+	for id, active := range h.activeCmds {
+		st, _, err := h.run.Status(id)
+		if err != nil || st.State.IsDone() {
+			delete(h.activeCmds, id)
+			continue
+		}
+		if reflect.DeepEqual(c, active) {
+			return st, true
+		}
+	}
+	return runner.RunStatus{}, false
+}
+
 // Implements worker.thrift Worker.Abort interface
 func (h *handler) Abort(runId string) (*worker.RunStatus, error) {
 	h.stat.Counter(stats.WorkerServerAborts).Inc(1)
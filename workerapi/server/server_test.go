@@ -171,7 +171,7 @@ func setupTestEnv(useErrorExec bool) (h *handler, initDoneCh chan error, statsRe
 			return statsRec
 		},
 		func(stat stats.StatsReceiver, run runner.Service) worker.Worker {
-			return NewHandler(stat, run)
+			return NewHandler(stat, run, runner.Resources{})
 		},
 	)
 	if useErrorExec {
@@ -46,8 +46,11 @@ func (m module) Install(b *ice.MagicBag) {
 		func(m execer.Memory, s stats.StatsReceiver) execer.Execer {
 			return execers.MakeSimExecerInterceptor(execers.NewSimExecer(), osexec.NewBoundedExecer(m, s))
 		},
-		func(stat stats.StatsReceiver, r runner.Service) worker.Worker {
-			return NewHandler(stat, r)
+		func() runner.Resources {
+			return runner.Resources{}
+		},
+		func(stat stats.StatsReceiver, r runner.Service, capacity runner.Resources) worker.Worker {
+			return NewHandler(stat, r, capacity)
 		},
 		func(
 			handler worker.Worker,
@@ -34,6 +34,7 @@ struct RunStatus {
   9: optional string taskId
   10: optional string tag
   11: optional bazel.ActionResult bazelResult
+  12: optional string failureReason  # Why the worker failed the run, ex: "OOMKilled". Unset if the run failed on its own.
 }
 
 // TODO: add useful load information when it comes time to have multiple runs.
@@ -41,6 +42,11 @@ struct WorkerStatus {
   1: required list<RunStatus> runs  # All runs excepting what's been Erase()'d
   2: required bool initialized      # True if the worker has finished with any long-running init tasks.
   3: required string error          # Set when a general worker error unrelated to a specific run has occurred.
+  4: optional i64 capacityMemoryBytes # Resources the worker offers to tasks, unset means unlimited.
+  5: optional double capacityCpus
+  6: optional i64 capacityDiskBytes
+  7: optional i32 slots               # Number of runs the worker executes concurrently, unset means one.
+  8: optional i32 freeSlots           # Number of slots not occupied by an active run.
 }
 
 struct RunCommand {
@@ -52,6 +58,11 @@ struct RunCommand {
   6: optional string taskId
   7: optional string tag
   8: optional bazel.ExecuteRequest bazelRequest
+  9: optional string mergeSnapshotId  # Contents are copied over the snapshotId checkout before running.
+  10: optional i64 memoryBytes        # Requested resources, memory and cpus are enforced as limits on the run.
+  11: optional double cpus
+  12: optional i64 diskBytes
+  13: optional bool allowNetwork     # Give the run network access if the worker runs commands in a sandbox.
 }
 
 //TODO: add a method to kill the worker if we can articulate unrecoverable issues.